@@ -6,6 +6,7 @@ package zip
 
 import (
 	"compress/flate"
+	"compress/zstd"
 	"errors"
 	"io"
 	"sync"
@@ -101,6 +102,38 @@ func (r *pooledFlateReader) Close() error {
 	return err
 }
 
+// zstdReader defers creating the zstd.Reader until the first Read,
+// so that errors in the frame header are reported by Read.
+type zstdReader struct {
+	r   io.Reader
+	zr  *zstd.Reader
+	err error
+}
+
+func newZstdReader(r io.Reader) io.ReadCloser {
+	return &zstdReader{r: r}
+}
+
+func (r *zstdReader) Read(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.zr == nil {
+		r.zr, r.err = zstd.NewReader(r.r)
+		if r.err != nil {
+			return 0, r.err
+		}
+	}
+	return r.zr.Read(p)
+}
+
+func (r *zstdReader) Close() error {
+	if r.zr == nil {
+		return nil
+	}
+	return r.zr.Close()
+}
+
 var (
 	compressors   sync.Map // map[uint16]Compressor
 	decompressors sync.Map // map[uint16]Decompressor
@@ -109,13 +142,15 @@ var (
 func init() {
 	compressors.Store(Store, Compressor(func(w io.Writer) (io.WriteCloser, error) { return &nopCloser{w}, nil }))
 	compressors.Store(Deflate, Compressor(func(w io.Writer) (io.WriteCloser, error) { return newFlateWriter(w), nil }))
+	compressors.Store(Zstd, Compressor(func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w), nil }))
 
 	decompressors.Store(Store, Decompressor(io.NopCloser))
 	decompressors.Store(Deflate, Decompressor(newFlateReader))
+	decompressors.Store(Zstd, Decompressor(newZstdReader))
 }
 
 // RegisterDecompressor allows custom decompressors for a specified method ID.
-// The common methods Store and Deflate are built in.
+// The common methods Store, Deflate and Zstd are built in.
 func RegisterDecompressor(method uint16, dcomp Decompressor) {
 	if _, dup := decompressors.LoadOrStore(method, dcomp); dup {
 		panic("decompressor already registered")
@@ -123,7 +158,7 @@ func RegisterDecompressor(method uint16, dcomp Decompressor) {
 }
 
 // RegisterCompressor registers custom compressors for a specified method ID.
-// The common methods Store and Deflate are built in.
+// The common methods Store, Deflate and Zstd are built in.
 func RegisterCompressor(method uint16, comp Compressor) {
 	if _, dup := compressors.LoadOrStore(method, comp); dup {
 		panic("compressor already registered")
@@ -71,6 +71,12 @@ var writeTests = []WriteTest{
 		Method: Deflate,
 		Mode:   0755 | fs.ModeDevice | fs.ModeCharDevice,
 	},
+	{
+		Name:   "zstd",
+		Data:   []byte("Rabbits, guinea pigs, gophers, marsupial rats, and quolls. Rabbits, guinea pigs, gophers."),
+		Method: Zstd,
+		Mode:   0644,
+	},
 }
 
 func TestWriter(t *testing.T) {
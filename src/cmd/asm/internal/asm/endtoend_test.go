@@ -442,6 +442,10 @@ func TestAMD64DynLinkErrors(t *testing.T) {
 	testErrors(t, "amd64", "amd64dynlinkerror", "dynlink")
 }
 
+func TestLoong64Encoder(t *testing.T) {
+	testEndToEnd(t, "loong64", "loong64enc1")
+}
+
 func TestMIPSEndToEnd(t *testing.T) {
 	testEndToEnd(t, "mips", "mips")
 	testEndToEnd(t, "mips64", "mips64")
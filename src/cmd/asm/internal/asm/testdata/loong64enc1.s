@@ -0,0 +1,150 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "../../../../../runtime/textflag.h"
+
+TEXT asmtest(SB),DUPOK|NOSPLIT,$0
+lable1:
+	BFPT	1(PC)	// 00050048
+	BFPT	lable1	// BFPT	2	// 1ffdff4b
+	BFPF	1(PC)	// 00040048
+	BFPF	lable1	// BFPF	2	// 1ff4ff4b
+lable2:
+	BEQ	R4, R5, 1(PC)	// 85040058
+	BEQ	R4, R5, lable2	// BEQ	R4, R5, 6	// 85fcff5b
+	BNE	R4, R5, 1(PC)	// 8504005c
+	BNE	R4, R5, lable2	// BNE	R4, R5, 6	// 85f4ff5f
+	BEQ	R4, 1(PC)	// 80040040
+	BNE	R4, 1(PC)	// 80040044
+	BLTZ	R4, 1(PC)	// 80040060
+	BGEZ	R4, 1(PC)	// 80040064
+	BLEZ	R4, 1(PC)	// 04040064
+	BGTZ	R4, 1(PC)	// 04040060
+	BLT	R4, R5, 1(PC)	// 85040060
+	BGE	R4, R5, 1(PC)	// 85040064
+	BLTU	R4, R5, 1(PC)	// 85040068
+	BGEU	R4, R5, 1(PC)	// 8508006c
+	JMP	1(PC)	// 00040050
+	JMP	(R4)	// 8000004c
+	JAL	(R4)	// CALL	(R4)	// 8100004c
+	CALL	(R4)	// 8100004c
+
+	MOVW	R4, R5	// 85804000
+	MOVV	R4, R5	// 85001500
+	MOVBU	R4, R5	// 85fc4303
+	MOVHU	R4, R5	// 8500cf00
+	MOVWU	R4, R5	// 8500df00
+	MOVB	R4, R5	// 855c0000
+	MOVH	R4, R5	// 85580000
+
+	MOVW	$1, R4	// 0404c002
+	MOVV	$-1, R4	// 04fcff02
+	MOVW	$4095, R4	// 04fcbf03
+	MOVW	$65536, R4	// 04020014
+	MOVV	$4096, R4	// 24000014
+	MOVW	$74565, R4	// 4402001484148d03
+	MOVW	$-74565, R4	// a4fdff1584ecb203
+
+	MOVW	R4, 1(R5)	// a4048029
+	MOVWU	R4, 1(R5)	// a4048029
+	MOVV	R4, 8(R5)	// a420c029
+	MOVB	R4, -1(R5)	// a4fc3f29
+	MOVBU	R4, (R5)	// a4000029
+	MOVH	R4, 2(R5)	// a4084029
+	MOVHU	R4, 2(R5)	// a4084029
+	MOVW	1(R5), R4	// a4048028
+	MOVWU	1(R5), R4	// a404802a
+	MOVV	8(R5), R4	// a420c028
+	MOVB	-1(R5), R4	// a4fc3f28
+	MOVBU	(R5), R4	// a400002a
+	MOVH	2(R5), R4	// a4084028
+	MOVHU	2(R5), R4	// a408402a
+	MOVV	R4, 65536(R5)	// 1e020014de971000c403c029
+	MOVV	65540(R5), R4	// 1e020014de971000c413c028
+
+	ADD	R4, R5, R6	// a6101000
+	ADD	R4, R5	// a5101000
+	ADDU	R4, R5, R6	// a6101000
+	ADDV	R4, R5, R6	// a6901000
+	ADDV	R4, R5	// a5901000
+	ADDVU	R4, R5, R6	// a6901000
+	ADD	$1, R4, R5	// 85048002
+	ADDV	$-1, R4	// 84fcff02
+	ADDV	$65536, R4, R5	// 1e02001485f81000
+	SUB	R4, R5, R6	// a6101100
+	SUBV	R4, R5	// a5901100
+	SUBV	$1, R4, R5	// 85fcff02
+	AND	R4, R5, R6	// a6901400
+	AND	$4095, R4, R5	// 85fc7f03
+	OR	R4, R5	// a5101500
+	OR	$1, R4, R5	// 85048003
+	XOR	R4, R5, R6	// a6901500
+	XOR	$1, R4	// 8404c003
+	NOR	R4, R5, R6	// a6101400
+	NEGW	R4, R5	// 05101100
+	NEGV	R4, R5	// 05901100
+	SGT	R4, R5, R6	// a6101200
+	SGTU	R4, R5, R6	// a6901200
+	SGT	$1, R4, R5	// 85040002
+	SGTU	$1, R4, R5	// 85044002
+	MASKEQZ	R4, R5, R6	// a6101300
+	MASKNEZ	R4, R5, R6	// a6901300
+
+	SLL	R4, R5, R6	// a6101700
+	SRL	R4, R5	// a5901700
+	SRA	$31, R4	// 84fc4800
+	ROTR	$1, R4, R5	// 85844c00
+	SLLV	$3, R4, R5	// 850c4100
+	SRLV	R4, R5	// a5101900
+	SRAV	$63, R4	// 84fc4900
+	ROTRV	R4, R5, R6	// a6901b00
+
+	MUL	R4, R5, R6	// a6101c00
+	MULV	R4, R5, R6	// a6901d00
+	MULH	R4, R5, R6	// a6901c00
+	MULHVU	R4, R5, R6	// a6901e00
+	DIV	R4, R5, R6	// a6102000
+	DIVVU	R4, R5, R6	// a6102300
+	REM	R4, R5	// a5902000
+	REMV	R4, R5	// a5902200
+
+	MOVF	4(R5), F1	// a110002b
+	MOVD	8(R5), F1	// a120802b
+	MOVF	F1, 4(R5)	// a110402b
+	MOVD	F1, 8(R5)	// a120c02b
+	MOVF	F1, F2	// 22941401
+	MOVD	F1, F2	// 22981401
+	ADDF	F1, F2, F3	// 43840001
+	ADDD	F1, F2	// 42040101
+	SUBD	F1, F2, F3	// 43040301
+	MULF	F1, F2	// 42840401
+	DIVD	F1, F2, F3	// 43040701
+	ABSD	F1, F2	// 22081401
+	NEGF	F1, F2	// 22141401
+	SQRTD	F1, F2	// 22481401
+	CMPEQF	F1, F2	// 4004120c
+	CMPGTD	F1, F2, FCC0	// 4004210c
+	CMPGEF	F1, F2	// 4004130c
+	MOVFD	F1, F2	// 22241901
+	MOVDF	F1, F2	// 22181901
+	MOVWF	F1, F2	// 22101d01
+	MOVVD	F1, F2	// 22281d01
+	TRUNCFW	F1, F2	// 22841a01
+	TRUNCDV	F1, F2	// 22a81a01
+	MOVW	R4, F1	// 81a41401
+	MOVW	F1, R4	// 24b41401
+	MOVV	R4, F1	// 81a81401
+	MOVV	F1, R4	// 24b81401
+
+	LL	(R4), R5	// 85000020
+	LLV	(R4), R5	// 85000022
+	SC	R5, (R4)	// 85000021
+	SCV	R5, (R4)	// 85000023
+	DBAR	// 00007238
+	SYSCALL	// 00002b00
+	NOOP	// 00004003
+	BREAK	// 00002a00
+	RDTIMED	R0, R4	// 04680000
+	WORD	$0x12345678	// WORD	$305419896	// 78563412
+	RET
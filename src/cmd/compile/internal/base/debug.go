@@ -41,6 +41,9 @@ type DebugFlags struct {
 	Nil                  int    `help:"print information about nil checks"`
 	NoOpenDefer          int    `help:"disable open-coded defers"`
 	PCTab                string `help:"print named pc-value table"`
+	PGODebug             int    `help:"print information about profile-guided optimizations"`
+	PGOInlineBudget      int    `help:"inline budget for hot functions (default 2000)"`
+	PGOInlineCDF         int    `help:"cumulative weight percentage of call edges considered hot (default 99)"`
 	Panic                int    `help:"show all compiler panics"`
 	Slice                int    `help:"print information about slice compilation"`
 	SoftFloat            int    `help:"force compiler to emit soft-float code"`
@@ -0,0 +1,203 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package devirtualize
+
+import (
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/pgo"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+)
+
+// ProfileGuided performs call-site specific devirtualization of
+// interface method calls in fn, based on the concrete callees observed
+// in the profile p.
+//
+// For a hot call i.M() whose hottest callee in the profile is the
+// method M of concrete type T, the call is rewritten to
+//
+//	if t, ok := i.(T); ok {
+//		t.M()
+//	} else {
+//		i.M()
+//	}
+//
+// The direct call in the first branch may then be inlined.
+func ProfileGuided(fn *ir.Func, p *pgo.Profile) {
+	ir.CurFunc = fn
+
+	var edit func(n ir.Node) ir.Node
+	edit = func(n ir.Node) ir.Node {
+		if n == nil {
+			return n
+		}
+		switch n.Op() {
+		case ir.ODEFER, ir.OGO, ir.OCLOSURE:
+			// The call in a go or defer statement must stay a
+			// call, and closures are handled as functions of
+			// their own.
+			return n
+		}
+
+		ir.EditChildren(n, edit)
+
+		call, ok := n.(*ir.CallExpr)
+		if !ok || call.Op() != ir.OCALLINTER || call.Use == ir.CallUseList {
+			return n
+		}
+		typ := hotConcreteType(fn, call, p)
+		if typ == nil {
+			return n
+		}
+		if base.Flag.LowerM != 0 || base.Debug.PGODebug > 0 {
+			base.WarnfAt(call.Pos(), "PGO devirtualizing %v to %v", call.X, typ)
+		}
+		return rewriteCondCall(fn, call, typ)
+	}
+	ir.EditChildren(fn, edit)
+}
+
+// hotConcreteType returns the concrete type of the receiver of the
+// hottest callee of call according to p, or nil if there is no
+// suitable hot callee.
+func hotConcreteType(fn *ir.Func, call *ir.CallExpr, p *pgo.Profile) *types.Type {
+	sel := call.X.(*ir.SelectorExpr)
+	iface := sel.X.Type()
+	suffix := "." + sel.Sel.Name
+	for _, c := range p.HotCallees(fn, call.Pos()) {
+		if !strings.HasSuffix(c.Name, suffix) {
+			continue
+		}
+		typ := lookupMethodType(strings.TrimSuffix(c.Name, suffix))
+		if typ == nil || typ.IsInterface() || !typecheck.Implements(typ, iface) {
+			continue
+		}
+		return typ
+	}
+	return nil
+}
+
+// lookupMethodType returns the receiver type named by recv, which is
+// the receiver part of a method's symbol name (for example,
+// "bytes.(*Buffer)" or "time.Time"), or nil if the type is not known
+// to this compilation.
+func lookupMethodType(recv string) *types.Type {
+	ptr := false
+	var path, name string
+	if strings.HasSuffix(recv, ")") {
+		i := strings.LastIndex(recv, ".(*")
+		if i < 0 {
+			return nil
+		}
+		ptr = true
+		path, name = recv[:i], recv[i+len(".(*"):len(recv)-len(")")]
+	} else {
+		i := strings.LastIndex(recv, ".")
+		if i < 0 {
+			return nil
+		}
+		path, name = recv[:i], recv[i+len("."):]
+	}
+	if strings.ContainsAny(name, "[]") {
+		// Instantiated generic types are not supported.
+		return nil
+	}
+
+	var pkg *types.Pkg
+	if path == base.Ctxt.Pkgpath {
+		pkg = types.LocalPkg
+	} else {
+		for _, p := range types.ImportedPkgList() {
+			if p.Path == path {
+				pkg = p
+				break
+			}
+		}
+	}
+	if pkg == nil {
+		return nil
+	}
+	sym := pkg.Syms[name]
+	if sym == nil {
+		return nil
+	}
+	n := typecheck.Resolve(ir.NewIdent(base.Pos, sym))
+	if n.Op() != ir.OTYPE || n.Type() == nil {
+		return nil
+	}
+	typ := n.Type()
+	if ptr {
+		typ = types.NewPtr(typ)
+	}
+	return typ
+}
+
+// rewriteCondCall rewrites the interface method call call in fn into a
+// type switch between a direct call of the method of typ and the
+// original call, and returns the node to use in place of call.
+func rewriteCondCall(fn *ir.Func, call *ir.CallExpr, typ *types.Type) ir.Node {
+	pos := call.Pos()
+	sel := call.X.(*ir.SelectorExpr)
+	sig := call.X.Type()
+
+	// The receiver and arguments are shared between both calls, so
+	// evaluate them once, in order, up front.
+	var init ir.Nodes
+	init.Append(call.Init()...)
+	call.SetInit(nil)
+
+	recv := typecheck.TempAt(pos, fn, sel.X.Type())
+	init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, recv, sel.X)))
+	args := make([]ir.Node, len(call.Args))
+	for i, arg := range call.Args {
+		v := typecheck.TempAt(pos, fn, arg.Type())
+		init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, v, arg)))
+		args[i] = v
+	}
+
+	retvars := make([]ir.Node, sig.NumResults())
+	for i, res := range sig.Results().FieldSlice() {
+		retvars[i] = typecheck.TempAt(pos, fn, res.Type)
+	}
+
+	// t, ok := recv.(T)
+	concrete := typecheck.TempAt(pos, fn, typ)
+	ok := typecheck.TempAt(pos, fn, types.Types[types.TBOOL])
+	assert := ir.NewTypeAssertExpr(pos, recv, ir.TypeNode(typ))
+	init.Append(typecheck.Stmt(ir.NewAssignListStmt(pos, ir.OAS2, []ir.Node{concrete, ok}, []ir.Node{assert})))
+
+	concreteCall := ir.NewCallExpr(pos, ir.OCALL, ir.NewSelectorExpr(pos, ir.OXDOT, concrete, sel.Sel), args)
+	concreteCall.IsDDD = call.IsDDD
+	ifaceCall := ir.NewCallExpr(pos, ir.OCALL, ir.NewSelectorExpr(pos, ir.OXDOT, recv, sel.Sel), args)
+	ifaceCall.IsDDD = call.IsDDD
+
+	nif := ir.NewIfStmt(pos, ok, []ir.Node{assignResults(pos, retvars, concreteCall)}, []ir.Node{assignResults(pos, retvars, ifaceCall)})
+	nif.Likely = true
+	init.Append(typecheck.Stmt(nif))
+
+	if call.Use == ir.CallUseStmt {
+		return ir.NewBlockStmt(pos, init)
+	}
+	if len(retvars) != 1 {
+		base.FatalfAt(pos, "unexpected result count %d for %v", len(retvars), call)
+	}
+	return ir.InitExpr(init, retvars[0])
+}
+
+// assignResults returns a statement that makes call and assigns its
+// results to retvars.
+func assignResults(pos src.XPos, retvars []ir.Node, call *ir.CallExpr) ir.Node {
+	switch len(retvars) {
+	case 0:
+		return call
+	case 1:
+		return ir.NewAssignStmt(pos, retvars[0], call)
+	}
+	return ir.NewAssignListStmt(pos, ir.OAS2, retvars, []ir.Node{call})
+}
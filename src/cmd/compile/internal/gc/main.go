@@ -16,6 +16,7 @@ import (
 	"cmd/compile/internal/ir"
 	"cmd/compile/internal/logopt"
 	"cmd/compile/internal/noder"
+	"cmd/compile/internal/pgo"
 	"cmd/compile/internal/pkginit"
 	"cmd/compile/internal/reflectdata"
 	"cmd/compile/internal/ssa"
@@ -223,10 +224,34 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 		typecheck.AllImportedBodies()
 	}
 
+	// Read the profile for profile-guided optimization, if any.
+	var profile *pgo.Profile
+	if base.Flag.PgoProfile != "" {
+		base.Timer.Start("fe", "pgoprofile")
+		var err error
+		profile, err = pgo.New(base.Flag.PgoProfile)
+		if err != nil {
+			log.Fatalf("%s: PGO error: %v", base.Flag.PgoProfile, err)
+		}
+	}
+
+	// Devirtualize hot interface calls using the profile. This
+	// happens before inlining so that the new direct calls can be
+	// inlined.
+	if profile != nil {
+		base.Timer.Start("fe", "pgo-devirtualization")
+		for _, n := range typecheck.Target.Decls {
+			if n.Op() == ir.ODCLFUNC {
+				devirtualize.ProfileGuided(n.(*ir.Func), profile)
+			}
+		}
+		ir.CurFunc = nil
+	}
+
 	// Inlining
 	base.Timer.Start("fe", "inlining")
 	if base.Flag.LowerL != 0 {
-		inline.InlinePackage()
+		inline.InlinePackage(profile)
 	}
 
 	// Devirtualize.
@@ -0,0 +1,248 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pgo implements the compiler's support for profile-guided
+// optimization (PGO).
+//
+// The compiler is given a CPU profile in pprof format (-pgoprofile),
+// which it summarizes as a set of weighted call edges. Each edge
+// records a caller, a callee, and the position of the call within the
+// caller. Edges that together account for most of the profile's weight
+// are "hot": the inliner gives hot callees a larger budget and inlines
+// them at hot call sites, and the devirtualizer specializes hot
+// interface method calls for the concrete callee observed in the profile.
+//
+// Functions are identified by their fully qualified symbol names, as
+// they appear in the profile (for example, "bytes.(*Buffer).Write").
+// Call sites are identified by their line offset from the start of
+// the calling function when the profile records function start lines,
+// which keeps the profile useful as unrelated code moves around, and
+// by absolute line number otherwise.
+package pgo
+
+import (
+	"fmt"
+	"internal/profile"
+	"os"
+	"sort"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/internal/src"
+)
+
+// defaultCDFThreshold is the default value for
+// base.Debug.PGOInlineCDF: hot call edges are those that, in
+// decreasing order of weight, make up this percentage of the total
+// edge weight.
+const defaultCDFThreshold = 99
+
+// An edgeKey identifies a call edge.
+type edgeKey struct {
+	caller, callee string
+	offset         int
+}
+
+// A siteKey identifies a call site.
+type siteKey struct {
+	caller string
+	offset int
+}
+
+// A Callee is a function called from a call site, along with the
+// weight of the call edge.
+type Callee struct {
+	Name   string
+	Weight int64
+}
+
+// Profile is a summary of a CPU profile for use by the compiler.
+type Profile struct {
+	// TotalWeight is the sum of the weights of all call edges.
+	TotalWeight int64
+
+	// edges maps each call edge to its weight.
+	edges map[edgeKey]int64
+
+	// hot is the set of hot call edges.
+	hot map[edgeKey]bool
+
+	// hotCallees is the set of functions that are the callee of at
+	// least one hot call edge.
+	hotCallees map[string]bool
+
+	// hotSites maps each call site with a hot edge to its hot
+	// callees, in decreasing order of weight.
+	hotSites map[siteKey][]Callee
+
+	// startLines records the start line of each function in the
+	// profile that has one.
+	startLines map[string]int64
+}
+
+// New reads the pprof-format CPU profile in the named file and returns
+// its summary.
+func New(name string) (*Profile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	prof, err := profile.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+	return newProfile(prof)
+}
+
+func newProfile(prof *profile.Profile) (*Profile, error) {
+	valueIndex := -1
+	for i, st := range prof.SampleType {
+		if (st.Type == "samples" && st.Unit == "count") || (st.Type == "cpu" && st.Unit == "nanoseconds") {
+			valueIndex = i
+			break
+		}
+	}
+	if valueIndex < 0 {
+		return nil, fmt.Errorf("profile does not contain a sample index with value/type samples/count or cpu/nanoseconds")
+	}
+
+	p := &Profile{
+		edges:      make(map[edgeKey]int64),
+		hot:        make(map[edgeKey]bool),
+		hotCallees: make(map[string]bool),
+		hotSites:   make(map[siteKey][]Callee),
+		startLines: make(map[string]int64),
+	}
+
+	type frame struct {
+		name   string
+		offset int
+	}
+	var frames []frame
+	seen := make(map[edgeKey]bool)
+	for _, s := range prof.Sample {
+		weight := s.Value[valueIndex]
+		if weight <= 0 {
+			continue
+		}
+
+		// Flatten the stack, including inlined frames, from the
+		// leaf outward.
+		frames = frames[:0]
+		for _, loc := range s.Location {
+			for _, line := range loc.Line {
+				if line.Function == nil {
+					continue
+				}
+				fn := line.Function
+				offset := int(line.Line)
+				if fn.StartLine != 0 {
+					p.startLines[fn.Name] = fn.StartLine
+					offset -= int(fn.StartLine)
+				}
+				frames = append(frames, frame{fn.Name, offset})
+			}
+		}
+
+		// Attribute the sample to each call edge on the stack, once
+		// per edge so that recursion doesn't inflate its weight.
+		for k := range seen {
+			delete(seen, k)
+		}
+		for i := 0; i+1 < len(frames); i++ {
+			callee, caller := frames[i], frames[i+1]
+			k := edgeKey{caller: caller.name, callee: callee.name, offset: caller.offset}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			p.edges[k] += weight
+			p.TotalWeight += weight
+		}
+	}
+
+	p.computeHot()
+	return p, nil
+}
+
+// computeHot determines the hot edges, which are the heaviest edges
+// that make up the base.Debug.PGOInlineCDF percentage of
+// the total edge weight.
+func (p *Profile) computeHot() {
+	threshold := base.Debug.PGOInlineCDF
+	if threshold <= 0 || threshold > 100 {
+		threshold = defaultCDFThreshold
+	}
+
+	keys := make([]edgeKey, 0, len(p.edges))
+	for k := range p.edges {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ki, kj := keys[i], keys[j]
+		if wi, wj := p.edges[ki], p.edges[kj]; wi != wj {
+			return wi > wj
+		}
+		// Break ties deterministically.
+		if ki.caller != kj.caller {
+			return ki.caller < kj.caller
+		}
+		if ki.callee != kj.callee {
+			return ki.callee < kj.callee
+		}
+		return ki.offset < kj.offset
+	})
+
+	var cum int64
+	for _, k := range keys {
+		if float64(cum) >= float64(p.TotalWeight)*float64(threshold)/100 {
+			break
+		}
+		w := p.edges[k]
+		cum += w
+		p.hot[k] = true
+		p.hotCallees[k.callee] = true
+		site := siteKey{caller: k.caller, offset: k.offset}
+		p.hotSites[site] = append(p.hotSites[site], Callee{Name: k.callee, Weight: w})
+	}
+
+	if base.Debug.PGODebug > 0 {
+		fmt.Printf("pgo: %d call edges, total weight %d, %d hot at %d%% threshold\n", len(p.edges), p.TotalWeight, len(p.hot), threshold)
+	}
+}
+
+// siteOffset returns the offset identifying the call site at pos
+// within caller, which is named name in the profile.
+func (p *Profile) siteOffset(name string, caller *ir.Func, pos src.XPos) int {
+	offset := int(base.Ctxt.InnermostPos(pos).RelLine())
+	if _, ok := p.startLines[name]; ok {
+		offset -= int(base.Ctxt.InnermostPos(caller.Pos()).RelLine())
+	}
+	return offset
+}
+
+// HotCallee reports whether fn is the callee of any hot call edge.
+func (p *Profile) HotCallee(fn *ir.Func) bool {
+	return p.hotCallees[ir.PkgFuncName(fn)]
+}
+
+// HotCallSite reports whether the call from caller at pos to callee
+// is a hot call edge.
+func (p *Profile) HotCallSite(caller *ir.Func, pos src.XPos, callee *ir.Func) bool {
+	name := ir.PkgFuncName(caller)
+	k := edgeKey{
+		caller: name,
+		callee: ir.PkgFuncName(callee),
+		offset: p.siteOffset(name, caller, pos),
+	}
+	return p.hot[k]
+}
+
+// HotCallees returns the callees of the hot call edges from caller at
+// pos, in decreasing order of weight.
+func (p *Profile) HotCallees(caller *ir.Func, pos src.XPos) []Callee {
+	name := ir.PkgFuncName(caller)
+	return p.hotSites[siteKey{caller: name, offset: p.siteOffset(name, caller, pos)}]
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pgo
+
+import (
+	"internal/profile"
+	"reflect"
+	"testing"
+)
+
+func TestNewProfile(t *testing.T) {
+	main := &profile.Function{ID: 1, Name: "main.main", StartLine: 10}
+	hot := &profile.Function{ID: 2, Name: "main.hot", StartLine: 20}
+	cold := &profile.Function{ID: 3, Name: "main.cold", StartLine: 30}
+	leaf := &profile.Function{ID: 4, Name: "main.leaf"}
+
+	loc := func(id uint64, fn *profile.Function, line int64) *profile.Location {
+		return &profile.Location{ID: id, Line: []profile.Line{{Function: fn, Line: line}}}
+	}
+	mainHot := loc(1, main, 12)   // main.main calls main.hot at offset 2.
+	mainCold := loc(2, main, 15)  // main.main calls main.cold at offset 5.
+	hotLeaf := loc(3, hot, 21)    // main.hot calls main.leaf at offset 1.
+	hotSelf := loc(4, hot, 22)    // main.hot at offset 2.
+	coldSelf := loc(5, cold, 31)  // main.cold at offset 1.
+	leafSelf := loc(6, leaf, 100) // main.leaf at line 100 (no start line).
+
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "cpu", Unit: "nanoseconds"},
+		},
+		Sample: []*profile.Sample{
+			{Location: []*profile.Location{hotSelf, mainHot}, Value: []int64{900, 0}},
+			{Location: []*profile.Location{leafSelf, hotLeaf, mainHot}, Value: []int64{99, 0}},
+			{Location: []*profile.Location{coldSelf, mainCold}, Value: []int64{1, 0}},
+		},
+	}
+
+	p, err := newProfile(prof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantEdges := map[edgeKey]int64{
+		{caller: "main.main", callee: "main.hot", offset: 2}:  999,
+		{caller: "main.hot", callee: "main.leaf", offset: 1}:  99,
+		{caller: "main.main", callee: "main.cold", offset: 5}: 1,
+	}
+	if !reflect.DeepEqual(p.edges, wantEdges) {
+		t.Errorf("edges = %v, want %v", p.edges, wantEdges)
+	}
+	if p.TotalWeight != 1099 {
+		t.Errorf("TotalWeight = %d, want 1099", p.TotalWeight)
+	}
+
+	// The two heaviest edges make up 99% of the total weight.
+	for k := range wantEdges {
+		want := k.callee != "main.cold"
+		if got := p.hot[k]; got != want {
+			t.Errorf("hot[%v] = %v, want %v", k, got, want)
+		}
+		if got := p.hotCallees[k.callee]; got != want {
+			t.Errorf("hotCallees[%q] = %v, want %v", k.callee, got, want)
+		}
+	}
+	wantSite := []Callee{{Name: "main.hot", Weight: 999}}
+	if got := p.hotSites[siteKey{caller: "main.main", offset: 2}]; !reflect.DeepEqual(got, wantSite) {
+		t.Errorf("hotSites[main.main+2] = %v, want %v", got, wantSite)
+	}
+}
+
+func TestNewProfileBadSampleType(t *testing.T) {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "alloc_space", Unit: "bytes"}},
+	}
+	if _, err := newProfile(prof); err == nil {
+		t.Errorf("newProfile succeeded on a heap profile, want error")
+	}
+}
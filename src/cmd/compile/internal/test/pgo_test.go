@@ -0,0 +1,145 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"internal/testenv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// buildPGOPkg copies the package in testdata/pgo/name to a temporary
+// module named test/pgo/name, so that its symbols match the ones in the
+// checked-in profile, and compiles its test binary with -m and
+// -d=pgodebug=1. If profile is not empty, it is passed to the compiler
+// with -pgoprofile. buildPGOPkg returns the compiler's output.
+func buildPGOPkg(t *testing.T, name, profile string) []byte {
+	t.Helper()
+
+	src := filepath.Join("testdata", "pgo", name)
+	dir := t.TempDir()
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".go") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(src, f.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, f.Name()), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gomod := fmt.Sprintf("module test/pgo/%s\n\ngo 1.17\n", name)
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gcflags := "-gcflags=-m -d=pgodebug=1"
+	if profile != "" {
+		abs, err := filepath.Abs(filepath.Join(src, profile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		gcflags += " -pgoprofile=" + abs
+	}
+	cmd := exec.Command(testenv.GoToolPath(t), "test", "-c", "-o", filepath.Join(dir, "test.exe"), gcflags, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s failed: %v\n%s", cmd, err, out)
+	}
+	return out
+}
+
+// checkPGOOutput checks that out has a line matching each of the
+// regular expressions in want, and none matching those in notWant.
+func checkPGOOutput(t *testing.T, out []byte, want, notWant []string) {
+	t.Helper()
+	found := make([]bool, len(want))
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		for i, re := range want {
+			if regexp.MustCompile(re).MatchString(line) {
+				found[i] = true
+			}
+		}
+		for _, re := range notWant {
+			if regexp.MustCompile(re).MatchString(line) {
+				t.Errorf("unexpected line: %s", line)
+			}
+		}
+	}
+	for i, re := range want {
+		if !found[i] {
+			t.Errorf("no line matching %#q", re)
+		}
+	}
+	if t.Failed() {
+		t.Logf("output:\n%s", out)
+	}
+}
+
+// TestPGOInline checks that checksum, which is over the default inlining
+// budget, gets a bigger budget and is inlined at its hot call site in Sum
+// when compiling with testdata/pgo/inline/inline_hot.pprof.
+func TestPGOInline(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	// Without the profile, checksum is too big to inline.
+	out := buildPGOPkg(t, "inline", "")
+	checkPGOOutput(t, out, nil, []string{
+		`can inline checksum`,
+		`inlining call to checksum`,
+		`pgo:`,
+	})
+
+	out = buildPGOPkg(t, "inline", "inline_hot.pprof")
+	checkPGOOutput(t, out, []string{
+		`^pgo: \d+ call edges, total weight \d+, \d+ hot at 99% threshold$`,
+		`inline_hot.go:17:6: hot function checksum gets inline budget 2000$`,
+		`inline_hot.go:17:6: can inline checksum$`,
+		`inline_hot.go:47:16: inlining hot call to checksum with cost \d+ into Sum$`,
+		`inline_hot.go:47:16: inlining call to checksum$`,
+	}, nil)
+}
+
+// TestPGODevirtualize checks that the interface call in Exercise is
+// devirtualized to the hot concrete callee in
+// testdata/pgo/devirtualize/devirt.pprof, which can then be inlined.
+func TestPGODevirtualize(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	// Without the profile, the call stays an interface call.
+	out := buildPGOPkg(t, "devirtualize", "")
+	checkPGOOutput(t, out, nil, []string{
+		`PGO devirtualizing`,
+		`inlining call to \(\*Add\)\.Add`,
+	})
+
+	out = buildPGOPkg(t, "devirtualize", "devirt.pprof")
+	checkPGOOutput(t, out, []string{
+		`devirt.go:43:12: PGO devirtualizing a.Add to \*Add$`,
+		`devirt.go:43:12: inlining call to \(\*Add\)\.Add$`,
+	}, []string{
+		`PGO devirtualizing a.Add to \*Sub`,
+	})
+}
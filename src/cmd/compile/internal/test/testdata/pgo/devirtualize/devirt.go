@@ -0,0 +1,46 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// WARNING: Please avoid updating this file. If this file needs to be
+// updated, then a new devirt.pprof file should be generated:
+//
+//	$ cd $GOROOT/src/cmd/compile/internal/test/testdata/pgo/devirtualize/
+//	$ go mod init test/pgo/devirtualize
+//	$ go test -bench=. -cpuprofile ./devirt.pprof
+//	$ rm go.mod
+
+package devirtualize
+
+type Adder interface {
+	Add(a, b int) int
+}
+
+type Add struct{}
+
+func (*Add) Add(a, b int) int {
+	for i := 0; i < 100; i++ {
+		a = a*31 + b
+	}
+	return a
+}
+
+type Sub struct{}
+
+func (*Sub) Add(a, b int) int {
+	return a - b
+}
+
+// Exercise calls a1 nine times out of ten and a2 otherwise, so the
+// profile shows that the call in its loop mostly goes to a1.
+func Exercise(iter int, a1, a2 Adder) int {
+	var s int
+	for i := 0; i < iter; i++ {
+		a := a1
+		if i%10 == 0 {
+			a = a2
+		}
+		s = a.Add(s, i)
+	}
+	return s
+}
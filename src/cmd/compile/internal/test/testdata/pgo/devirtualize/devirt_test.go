@@ -0,0 +1,11 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package devirtualize
+
+import "testing"
+
+func BenchmarkDevirt(b *testing.B) {
+	Exercise(b.N, &Add{}, &Sub{})
+}
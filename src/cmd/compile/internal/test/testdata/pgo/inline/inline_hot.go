@@ -0,0 +1,50 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// WARNING: Please avoid updating this file. If this file needs to be
+// updated, then a new inline_hot.pprof file should be generated:
+//
+//	$ cd $GOROOT/src/cmd/compile/internal/test/testdata/pgo/inline/
+//	$ go mod init test/pgo/inline
+//	$ go test -bench=. -cpuprofile ./inline_hot.pprof
+//	$ rm go.mod
+
+package inline
+
+// checksum is too big to inline with the default budget, but the
+// profile shows that its call in Sum is hot.
+func checksum(b []byte) uint32 {
+	a, c := uint32(1), uint32(0)
+	for i := 0; i < len(b); i++ {
+		a += uint32(b[i])
+		if a >= 65521 {
+			a -= 65521
+		}
+		c += a
+		if c >= 65521 {
+			c -= 65521
+		}
+		if b[i] == 0 {
+			a ^= c << 3
+			c ^= a >> 5
+		}
+	}
+	// Mix the result, as the hash finalizers of MurmurHash3 do.
+	h := c<<16 | a
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// Sum returns the sum of the checksums of the blocks in data.
+func Sum(data [][]byte) uint32 {
+	var s uint32
+	for i := 0; i < len(data); i++ {
+		s += checksum(data[i])
+	}
+	return s
+}
@@ -0,0 +1,21 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import "testing"
+
+func BenchmarkSum(b *testing.B) {
+	data := make([][]byte, 64)
+	for i := range data {
+		data[i] = make([]byte, 16+i)
+		for j := range data[i] {
+			data[i][j] = byte(i * j)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sum(data)
+	}
+}
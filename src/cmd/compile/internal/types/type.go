@@ -1959,6 +1959,15 @@ func IsReflectPkg(p *Pkg) bool {
 	return p.Path == "reflect"
 }
 
+// isAtomicStdPkg reports whether p is package sync/atomic or
+// runtime/internal/atomic.
+func isAtomicStdPkg(p *Pkg) bool {
+	if p == LocalPkg {
+		return base.Ctxt.Pkgpath == "sync/atomic" || base.Ctxt.Pkgpath == "runtime/internal/atomic"
+	}
+	return p.Path == "sync/atomic" || p.Path == "runtime/internal/atomic"
+}
+
 // ReceiverBaseType returns the underlying type, if any,
 // that owns methods with receiver parameter t.
 // The result is either a named type or an anonymous struct.
@@ -105,3 +105,47 @@ const _ = unsafe.Offsetof(struct{ x int64 }{}.x)
 		_ = conf.Sizes.Alignof(tv.Type)
 	}
 }
+
+func TestAtomicAlign(t *testing.T) {
+	const src = `
+package main
+
+import "sync/atomic"
+
+var s struct {
+	x int32
+	y atomic.Int64
+	z int64
+}
+`
+
+	want := []int64{0, 8, 16}
+	for _, arch := range []string{"386", "amd64"} {
+		f, err := parseSrc("x.go", src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := types2.Info{Types: make(map[syntax.Expr]types2.TypeAndValue)}
+		conf := types2.Config{
+			Importer: defaultImporter(),
+			Sizes:    types2.SizesFor("gc", arch),
+		}
+		pkg, err := conf.Check("x", []*syntax.File{f}, &info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj := pkg.Scope().Lookup("s")
+		if obj == nil {
+			t.Fatal("no var s")
+		}
+		ts := obj.Type().(*types2.Struct)
+		var fields []*types2.Var
+		for i := 0; i < ts.NumFields(); i++ {
+			fields = append(fields, ts.Field(i))
+		}
+		offsets := conf.Sizes.Offsetsof(fields)
+		if offsets[0] != want[0] || offsets[1] != want[1] || offsets[2] != want[2] {
+			t.Errorf("GOARCH=%s: Offsetsof(%v) = %v want %v", arch, ts, offsets, want)
+		}
+	}
+}
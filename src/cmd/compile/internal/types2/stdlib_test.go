@@ -206,7 +206,7 @@ var excluded = map[string]bool{
 	"builtin": true,
 
 	// See #46027: some imports are missing for this submodule.
-	"crypto/ed25519/internal/edwards25519/field/_asm": true,
+	"crypto/internal/edwards25519/field/_asm": true,
 }
 
 // typecheck typechecks the given package files.
@@ -0,0 +1,303 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"internal/coverage"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"cmd/internal/objabi"
+)
+
+const usageMessage = "" +
+	`Usage of 'go tool covdata':
+Convert the coverage data in one or more directories to a textual profile:
+	go tool covdata textfmt -i=dir1,dir2 -o=profile.txt
+
+Merge the coverage data in one or more directories:
+	go tool covdata merge -i=dir1,dir2 -o=outdir
+
+Subtract the coverage data in one directory from that in another:
+	go tool covdata subtract -i=dir1,dir2 -o=outdir
+`
+
+func usage() {
+	fmt.Fprint(os.Stderr, usageMessage)
+	fmt.Fprintln(os.Stderr, "\nFlags:")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+var (
+	inputs = flag.String("i", "", "comma-separated list of input directories")
+	output = flag.String("o", "", "output file (textfmt) or directory (merge, subtract)")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("covdata: ")
+
+	objabi.AddVersionFlag()
+	flag.Usage = usage
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") {
+		flag.Parse() // for -V and -help
+		usage()
+	}
+	mode := os.Args[1]
+	flag.CommandLine.Parse(os.Args[2:])
+	if flag.NArg() != 0 {
+		usage()
+	}
+	if *inputs == "" {
+		log.Fatalf("missing -i flag")
+	}
+	if *output == "" {
+		log.Fatalf("missing -o flag")
+	}
+	dirs := strings.Split(*inputs, ",")
+
+	switch mode {
+	case "textfmt":
+		textfmt(readDirs(dirs), *output)
+	case "merge":
+		writeDir(readDirs(dirs), *output)
+	case "subtract":
+		if len(dirs) != 2 {
+			log.Fatalf("subtract requires exactly two input directories")
+		}
+		writeDir(subtract(readDirs(dirs[:1]), readDirs(dirs[1:])), *output)
+	default:
+		fmt.Fprintf(os.Stderr, "covdata: unknown mode %q\n", mode)
+		usage()
+	}
+}
+
+// A program holds the meta-data of an instrumented program
+// and the combined counters of all the runs read for it.
+type program struct {
+	metaData []byte
+	meta     *coverage.MetaData
+	counters []uint32
+}
+
+// A dataset is the coverage data read from a set of directories,
+// keyed by meta-data hash.
+type dataset struct {
+	mode     string
+	programs map[coverage.Hash]*program
+}
+
+// hashes returns the meta-data hashes of the programs in d, sorted.
+func (d *dataset) hashes() []coverage.Hash {
+	var hs []coverage.Hash
+	for h := range d.programs {
+		hs = append(hs, h)
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i].String() < hs[j].String() })
+	return hs
+}
+
+// readDirs reads the coverage data files in dirs.
+func readDirs(dirs []string) *dataset {
+	d := &dataset{programs: make(map[coverage.Hash]*program)}
+	var counterFiles []string
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, e := range entries {
+			name := filepath.Join(dir, e.Name())
+			if _, ok := coverage.ParseCounterFileName(e.Name()); ok {
+				counterFiles = append(counterFiles, name)
+				continue
+			}
+			h, ok := coverage.ParseMetaFileName(e.Name())
+			if !ok || d.programs[h] != nil {
+				continue
+			}
+			data, err := os.ReadFile(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if coverage.HashMetaData(data) != h {
+				log.Fatalf("%s: contents do not match file name", name)
+			}
+			m, err := coverage.UnmarshalMetaData(data)
+			if err != nil {
+				log.Fatalf("%s: %v", name, err)
+			}
+			if d.mode == "" {
+				d.mode = m.Mode
+			} else if m.Mode != d.mode {
+				log.Fatalf("%s: coverage mode %s does not match mode %s of other programs", name, m.Mode, d.mode)
+			}
+			d.programs[h] = &program{
+				metaData: data,
+				meta:     m,
+				counters: make([]uint32, m.NumBlocks()),
+			}
+		}
+	}
+
+	for _, name := range counterFiles {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		c, err := coverage.UnmarshalCounterData(data)
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		p := d.programs[c.MetaHash]
+		if p == nil {
+			log.Fatalf("%s: no meta-data file %s", name, coverage.MetaFileName(c.MetaHash))
+		}
+		if len(c.Counters) != len(p.counters) {
+			log.Fatalf("%s: found %d counters, but meta-data describes %d blocks", name, len(c.Counters), len(p.counters))
+		}
+		for i, v := range c.Counters {
+			p.counters[i] = d.combine(p.counters[i], v)
+		}
+	}
+	return d
+}
+
+// combine returns the combination of counter values x and y
+// recorded by different runs of a program.
+func (d *dataset) combine(x, y uint32) uint32 {
+	if d.mode == "set" {
+		return x | y
+	}
+	if x > math.MaxUint32-y {
+		return math.MaxUint32
+	}
+	return x + y
+}
+
+// A blockKey identifies a block across programs.
+type blockKey struct {
+	file  string
+	block coverage.Block
+}
+
+// textfmt writes the data in d to the file named out
+// in the textual profile format.
+func textfmt(d *dataset, out string) {
+	if len(d.programs) == 0 {
+		log.Fatalf("no coverage data files found")
+	}
+
+	// Several programs may include the same source files;
+	// combine the counters of their blocks.
+	counts := make(map[blockKey]uint32)
+	for _, p := range d.programs {
+		i := 0
+		for _, f := range p.meta.Files {
+			for _, b := range f.Blocks {
+				k := blockKey{f.Name, b}
+				counts[k] = d.combine(counts[k], p.counters[i])
+				i++
+			}
+		}
+	}
+	keys := make([]blockKey, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ki, kj := keys[i], keys[j]
+		if ki.file != kj.file {
+			return ki.file < kj.file
+		}
+		bi, bj := ki.block, kj.block
+		if bi.StartLine != bj.StartLine {
+			return bi.StartLine < bj.StartLine
+		}
+		if bi.StartCol != bj.StartCol {
+			return bi.StartCol < bj.StartCol
+		}
+		if bi.EndLine != bj.EndLine {
+			return bi.EndLine < bj.EndLine
+		}
+		return bi.EndCol < bj.EndCol
+	})
+
+	f, err := os.Create(out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := bufio.NewWriter(f)
+	fmt.Fprintf(w, "mode: %s\n", d.mode)
+	for _, k := range keys {
+		b := k.block
+		fmt.Fprintf(w, "%s:%d.%d,%d.%d %d %d\n", k.file, b.StartLine, b.StartCol, b.EndLine, b.EndCol, b.NumStmt, counts[k])
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// subtract returns the data in d with the counters of the blocks
+// executed according to the data in sub set to zero.
+func subtract(d, sub *dataset) *dataset {
+	executed := make(map[blockKey]bool)
+	for _, p := range sub.programs {
+		i := 0
+		for _, f := range p.meta.Files {
+			for _, b := range f.Blocks {
+				if p.counters[i] != 0 {
+					executed[blockKey{f.Name, b}] = true
+				}
+				i++
+			}
+		}
+	}
+	for _, p := range d.programs {
+		i := 0
+		for _, f := range p.meta.Files {
+			for _, b := range f.Blocks {
+				if executed[blockKey{f.Name, b}] {
+					p.counters[i] = 0
+				}
+				i++
+			}
+		}
+	}
+	return d
+}
+
+// writeDir writes the data in d to the directory dir,
+// as a meta-data file and a counter data file for each program.
+func writeDir(d *dataset, dir string) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		log.Fatal(err)
+	}
+	pid := os.Getpid()
+	for _, h := range d.hashes() {
+		p := d.programs[h]
+		if err := os.WriteFile(filepath.Join(dir, coverage.MetaFileName(h)), p.metaData, 0666); err != nil {
+			log.Fatal(err)
+		}
+		c := &coverage.CounterData{MetaHash: h, Counters: p.counters}
+		name := coverage.CounterFileName(h, pid, time.Now().UnixNano())
+		if err := os.WriteFile(filepath.Join(dir, name), c.Marshal(), 0666); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
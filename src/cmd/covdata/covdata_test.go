@@ -0,0 +1,273 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"internal/coverage"
+	"internal/testenv"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	metaA = &coverage.MetaData{
+		Mode: "count",
+		Files: []coverage.File{
+			{
+				Name: "example.com/m/a.go",
+				Blocks: []coverage.Block{
+					{StartLine: 1, StartCol: 5, EndLine: 2, EndCol: 10, NumStmt: 2},
+					{StartLine: 4, StartCol: 2, EndLine: 6, EndCol: 1, NumStmt: 1},
+				},
+			},
+			{
+				Name: "example.com/m/lib.go",
+				Blocks: []coverage.Block{
+					{StartLine: 3, StartCol: 1, EndLine: 3, EndCol: 20, NumStmt: 1},
+				},
+			},
+		},
+	}
+
+	// metaB is a second program that shares lib.go with metaA.
+	metaB = &coverage.MetaData{
+		Mode: "count",
+		Files: []coverage.File{
+			{
+				Name: "example.com/m/b.go",
+				Blocks: []coverage.Block{
+					{StartLine: 8, StartCol: 1, EndLine: 9, EndCol: 2, NumStmt: 3},
+				},
+			},
+			{
+				Name: "example.com/m/lib.go",
+				Blocks: []coverage.Block{
+					{StartLine: 3, StartCol: 1, EndLine: 3, EndCol: 20, NumStmt: 1},
+				},
+			},
+		},
+	}
+)
+
+// writeRun writes the meta-data of m and the counters of one run of
+// the program to dir, as the program itself would. Each run has a
+// distinct pid, so that the counter files do not collide.
+func writeRun(t *testing.T, dir string, m *coverage.MetaData, pid int, counters ...uint32) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	data := m.Marshal()
+	h := coverage.HashMetaData(data)
+	if err := os.WriteFile(filepath.Join(dir, coverage.MetaFileName(h)), data, 0666); err != nil {
+		t.Fatal(err)
+	}
+	c := &coverage.CounterData{MetaHash: h, Counters: counters}
+	if err := os.WriteFile(filepath.Join(dir, coverage.CounterFileName(h, pid, 1)), c.Marshal(), 0666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// counters returns the combined counters read for the program m.
+func counters(t *testing.T, d *dataset, m *coverage.MetaData) []uint32 {
+	t.Helper()
+	p := d.programs[coverage.HashMetaData(m.Marshal())]
+	if p == nil {
+		t.Fatalf("no data read for program with files %v", m.Files)
+	}
+	return p.counters
+}
+
+func TestReadDirs(t *testing.T) {
+	dir1 := filepath.Join(t.TempDir(), "1")
+	dir2 := filepath.Join(t.TempDir(), "2")
+	writeRun(t, dir1, metaA, 1, 1, 0, 2)
+	writeRun(t, dir1, metaA, 2, 3, 0, math.MaxUint32)
+	writeRun(t, dir2, metaA, 3, 1, 0, 0)
+	writeRun(t, dir2, metaB, 4, 5, 0)
+
+	d := readDirs([]string{dir1, dir2})
+	if d.mode != "count" {
+		t.Errorf("mode = %q, want count", d.mode)
+	}
+	if len(d.programs) != 2 {
+		t.Fatalf("read %d programs, want 2", len(d.programs))
+	}
+	// Counters from all runs are added, saturating at the maximum.
+	if got, want := counters(t, d, metaA), []uint32{5, 0, math.MaxUint32}; !reflect.DeepEqual(got, want) {
+		t.Errorf("counters of program A = %v, want %v", got, want)
+	}
+	if got, want := counters(t, d, metaB), []uint32{5, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("counters of program B = %v, want %v", got, want)
+	}
+}
+
+func TestReadDirsSetMode(t *testing.T) {
+	m := &coverage.MetaData{Mode: "set", Files: metaA.Files}
+	dir := t.TempDir()
+	writeRun(t, dir, m, 1, 1, 0, 0)
+	writeRun(t, dir, m, 2, 1, 0, 1)
+
+	d := readDirs([]string{dir})
+	if got, want := counters(t, d, m), []uint32{1, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("counters = %v, want %v", got, want)
+	}
+}
+
+func TestTextfmt(t *testing.T) {
+	dir := t.TempDir()
+	writeRun(t, dir, metaA, 1, 1, 0, 2)
+	writeRun(t, dir, metaB, 2, 4, 3)
+
+	out := filepath.Join(t.TempDir(), "profile.txt")
+	textfmt(readDirs([]string{dir}), out)
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The counters of lib.go, which is in both programs, are combined.
+	want := `mode: count
+example.com/m/a.go:1.5,2.10 2 1
+example.com/m/a.go:4.2,6.1 1 0
+example.com/m/b.go:8.1,9.2 3 4
+example.com/m/lib.go:3.1,3.20 1 5
+`
+	if string(got) != want {
+		t.Errorf("textfmt wrote:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	writeRun(t, dir1, metaA, 1, 1, 0, 2)
+	writeRun(t, dir2, metaA, 2, 0, 7, 1)
+	writeRun(t, dir2, metaB, 3, 1, 1)
+
+	out := filepath.Join(t.TempDir(), "out")
+	writeDir(readDirs([]string{dir1, dir2}), out)
+
+	// The output has one meta-data file and one counter data file
+	// for each program.
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metas, counterFiles int
+	for _, e := range entries {
+		if _, ok := coverage.ParseMetaFileName(e.Name()); ok {
+			metas++
+		} else if _, ok := coverage.ParseCounterFileName(e.Name()); ok {
+			counterFiles++
+		}
+	}
+	if metas != 2 || counterFiles != 2 {
+		t.Errorf("merge wrote %d meta-data and %d counter files, want 2 and 2", metas, counterFiles)
+	}
+
+	d := readDirs([]string{out})
+	if got, want := counters(t, d, metaA), []uint32{1, 7, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("merged counters of program A = %v, want %v", got, want)
+	}
+	if got, want := counters(t, d, metaB), []uint32{1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("merged counters of program B = %v, want %v", got, want)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	writeRun(t, dir1, metaA, 1, 1, 4, 2)
+	// lib.go's block is executed by program B, so it is subtracted
+	// from program A as well.
+	writeRun(t, dir2, metaB, 2, 0, 1)
+	writeRun(t, dir2, metaA, 3, 3, 0, 0)
+
+	d := subtract(readDirs([]string{dir1}), readDirs([]string{dir2}))
+	if got, want := counters(t, d, metaA), []uint32{0, 4, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("counters after subtract = %v, want %v", got, want)
+	}
+	if len(d.programs) != 1 {
+		t.Errorf("subtract result has %d programs, want 1", len(d.programs))
+	}
+}
+
+// TestCommands runs the covdata binary to check its command line
+// handling, including the errors reported for bad input.
+func TestCommands(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+
+	tmp := t.TempDir()
+	exe := filepath.Join(tmp, "covdata.exe")
+	out, err := exec.Command(testenv.GoToolPath(t), "build", "-o", exe, ".").CombinedOutput()
+	if err != nil {
+		t.Fatalf("building covdata: %v\n%s", err, out)
+	}
+
+	in := filepath.Join(tmp, "in")
+	writeRun(t, in, metaA, 1, 1, 0, 2)
+	merged := filepath.Join(tmp, "merged")
+	profile := filepath.Join(tmp, "profile.txt")
+	for _, args := range [][]string{
+		{"merge", "-i=" + in, "-o=" + merged},
+		{"textfmt", "-i=" + merged, "-o=" + profile},
+	} {
+		if out, err := exec.Command(exe, args...).CombinedOutput(); err != nil {
+			t.Fatalf("covdata %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	data, err := os.ReadFile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "mode: count\nexample.com/m/a.go:1.5,2.10 2 1\n") {
+		t.Errorf("profile after merge and textfmt:\n%s", data)
+	}
+
+	// A counter file whose meta-data file is missing.
+	orphan := filepath.Join(tmp, "orphan")
+	writeRun(t, orphan, metaB, 2, 1, 1)
+	for _, e := range mustReadDir(t, orphan) {
+		if _, ok := coverage.ParseMetaFileName(e.Name()); ok {
+			os.Remove(filepath.Join(orphan, e.Name()))
+		}
+	}
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{}, "Usage of 'go tool covdata'"},
+		{[]string{"bogus", "-i=" + in, "-o=" + profile}, `unknown mode "bogus"`},
+		{[]string{"textfmt", "-o=" + profile}, "missing -i flag"},
+		{[]string{"merge", "-i=" + in}, "missing -o flag"},
+		{[]string{"subtract", "-i=" + in, "-o=" + merged}, "subtract requires exactly two input directories"},
+		{[]string{"textfmt", "-i=" + t.TempDir(), "-o=" + profile}, "no coverage data files found"},
+		{[]string{"textfmt", "-i=" + orphan, "-o=" + profile}, "no meta-data file"},
+	} {
+		out, err := exec.Command(exe, tt.args...).CombinedOutput()
+		if err == nil {
+			t.Errorf("covdata %s succeeded, want error", strings.Join(tt.args, " "))
+			continue
+		}
+		if !strings.Contains(string(out), tt.want) {
+			t.Errorf("covdata %s: output does not contain %q:\n%s", strings.Join(tt.args, " "), tt.want, out)
+		}
+	}
+}
+
+func mustReadDir(t *testing.T, dir string) []os.DirEntry {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
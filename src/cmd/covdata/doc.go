@@ -0,0 +1,34 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Covdata is a program for manipulating the coverage data files written
+by programs built with 'go build -cover', and for converting them to
+the textual profile format read by 'go tool cover'.
+
+An instrumented program writes a meta-data file and a counter data file
+to the directory named by the GOCOVERDIR environment variable when it
+exits. Each invocation of covdata reads all the coverage data files in
+one or more such directories, given by the -i flag, combining the
+counters of all the runs of each program.
+
+Usage:
+
+	go tool covdata textfmt -i=dir1,dir2,... -o=profile.txt
+	go tool covdata merge -i=dir1,dir2,... -o=outdir
+	go tool covdata subtract -i=dir1,dir2 -o=outdir
+
+The textfmt mode writes the combined coverage data as a textual
+profile, as written by 'go test -coverprofile', which can be passed to
+'go tool cover -html' or 'go tool cover -func'.
+
+The merge mode writes the combined coverage data to the directory outdir,
+as a single pair of meta-data and counter data files for each program.
+
+The subtract mode writes to outdir the coverage data of dir1, except
+that the counters of blocks executed according to the data in dir2 are
+set to zero, leaving only the code that the runs recorded in dir1 reach
+and the runs recorded in dir2 do not.
+*/
+package main
@@ -40,7 +40,7 @@ Finally, to generate modified source code with coverage annotations
 `
 
 func usage() {
-	fmt.Fprintln(os.Stderr, usageMessage)
+	fmt.Fprint(os.Stderr, usageMessage)
 	fmt.Fprintln(os.Stderr, "Flags:")
 	flag.PrintDefaults()
 	fmt.Fprintln(os.Stderr, "\n  Only one of -html, -func, or -mode may be set.")
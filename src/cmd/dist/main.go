@@ -115,6 +115,8 @@ func main() {
 			gohostarch = "ppc64le"
 		case strings.Contains(out, "ppc64"):
 			gohostarch = "ppc64"
+		case strings.Contains(out, "loongarch64"):
+			gohostarch = "loong64"
 		case strings.Contains(out, "mips64"):
 			gohostarch = "mips64"
 			if elfIsLittleEndian(os.Args[0]) {
@@ -30,6 +30,7 @@
 // 	tool        run specified go tool
 // 	version     print Go version
 // 	vet         report likely mistakes in packages
+// 	work        workspace maintenance
 //
 // Use "go help <command>" for more information about a command.
 //
@@ -101,6 +102,18 @@
 // The -i flag installs the packages that are dependencies of the target.
 // The -i flag is deprecated. Compiled packages are cached automatically.
 //
+// The -cover flag builds the main packages into executables instrumented
+// for coverage analysis. When an instrumented program exits, by returning
+// from main.main or by calling os.Exit, it writes coverage data files to the
+// directory named by the GOCOVERDIR environment variable. The command
+// 'go tool covdata' merges and subtracts such files, and converts them
+// to the profile format read by 'go tool cover'. By default, the packages
+// instrumented are those in the main module, or, in GOPATH mode, those named
+// on the command line. The -coverpkg flag instead instruments the packages
+// matching a comma-separated list of patterns, and the -covermode flag sets
+// the mode of coverage analysis (set, count, or atomic) as described in
+// 'go help testflag'. Both imply -cover. Install and run accept the same flags.
+//
 // The build flags are shared by the build, clean, get, install, list, run,
 // and test commands:
 //
@@ -178,6 +191,11 @@
 // 		include path must be in the same directory as the Go package they are
 // 		included from, and overlays will not appear when binaries and tests are
 // 		run through go run and go test respectively.
+// 	-pgo file
+// 		specify the file path of a CPU profile in pprof format to use for
+// 		profile-guided optimization (PGO). The compiler uses the profile to
+// 		inline and devirtualize calls on hot paths more aggressively.
+// 		The special name "off" turns off PGO, which is also the default.
 // 	-pkgdir dir
 // 		install and load all packages from dir instead of the usual locations.
 // 		For example, when building with a non-standard configuration,
@@ -1568,6 +1586,216 @@
 // See also: go fmt, go fix.
 //
 //
+// Workspace maintenance
+//
+// Go workspace provides access to operations on workspaces.
+//
+// Note that support for workspaces is built into many other commands, not
+// just 'go work'.
+//
+// See 'go help modules' for information about Go's module system of which
+// workspaces are a part.
+//
+// A workspace is specified by a go.work file that specifies a set of
+// module directories with the "use" directive. These modules are used as
+// root modules by the go command for builds and related operations. A
+// workspace that does not specify modules to be used cannot be used to do
+// builds from local modules.
+//
+// go.work files are line-oriented. Each line holds a single directive,
+// made up of a keyword followed by arguments. For example:
+//
+// 	go 1.17
+//
+// 	use ../foo/bar
+// 	use ./baz
+//
+// 	replace example.com/foo v1.2.3 => example.com/bar v1.4.5
+//
+// The leading keyword can be factored out of adjacent lines to create a block,
+// like in Go imports.
+//
+// 	use (
+// 	  ../foo/bar
+// 	  ./baz
+// 	)
+//
+// The use directive specifies a module to be included in the workspace's
+// set of main modules. The argument to the use directive is the directory
+// containing the module's go.mod file.
+//
+// The go directive specifies the version of Go the file was written at. It
+// is possible there may be future changes in the semantics of workspaces
+// that could be controlled by this version, but for now the version
+// specified has no effect.
+//
+// The replace directive has the same syntax as the replace directive in a
+// go.mod file and takes precedence over replaces in go.mod files. It is
+// primarily intended to override conflicting replaces in different workspace
+// modules.
+//
+// In workspace mode, the go command finds the main module as usual, from
+// the current directory, among the modules listed in go.work, or uses the
+// first one listed. Each of the other modules is loaded from its directory,
+// as if replaced there, and required by the main module. Packages from all
+// of them can be built, listed, and tested together. The go command reads,
+// but never writes, the go.mod and go.sum files of the modules in a
+// workspace; checksums that none of their go.sum files record are written
+// to a go.work.sum file next to go.work.
+//
+// To determine whether the go command is operating in workspace mode, use
+// the "go env GOWORK" command. This will specify the workspace file being
+// used.
+//
+// Usage:
+//
+// 	go work <command> [arguments]
+//
+// The commands are:
+//
+// 	edit        edit go.work from tools or scripts
+// 	init        initialize workspace file
+// 	sync        sync workspace build list to modules
+// 	use         add modules to workspace file
+//
+// Use "go help work <command>" for more information about a command.
+//
+// Edit go.work from tools or scripts
+//
+// Usage:
+//
+// 	go work edit [editing flags] [go.work]
+//
+// Edit provides a command-line interface for editing go.work,
+// for use primarily by tools or scripts. It only reads go.work;
+// it does not look up information about the modules involved.
+// If no file is specified, Edit looks for a go.work file in the current
+// directory and its parent directories.
+//
+// The editing flags specify a sequence of editing operations.
+//
+// The -fmt flag reformats the go.work file without making other changes.
+// This reformatting is also implied by any other modifications that use or
+// rewrite the go.work file. The only time this flag is needed is if no other
+// flags are specified, as in 'go work edit -fmt'.
+//
+// The -use=path and -dropuse=path flags
+// add and drop a use directive from the go.work file's set of module directories.
+//
+// The -replace=old[@v]=new[@v] flag adds a replacement of the given
+// module path and version pair. If the @v in old@v is omitted, a
+// replacement without a version on the left side is added, which applies
+// to all versions of the old module path. If the @v in new@v is omitted,
+// the new path should be a local module root directory, not a module
+// path. Note that -replace overrides any redundant replacements for old[@v],
+// so omitting @v will drop existing replacements for specific versions.
+//
+// The -dropreplace=old[@v] flag drops a replacement of the given
+// module path and version pair. If the @v is omitted, a replacement without
+// a version on the left side is dropped.
+//
+// The -use, -dropuse, -replace, and -dropreplace
+// editing flags may be repeated, and the changes are applied in the order given.
+//
+// The -go=version flag sets the expected Go language version.
+//
+// The -print flag prints the final go.work in its text format instead of
+// writing it back to go.work.
+//
+// The -json flag prints the final go.work file in JSON format instead of
+// writing it back to go.work. The JSON output corresponds to these Go types:
+//
+// 	type Module struct {
+// 		Path    string
+// 		Version string
+// 	}
+//
+// 	type GoWork struct {
+// 		Go      string
+// 		Use     []Use
+// 		Replace []Replace
+// 	}
+//
+// 	type Use struct {
+// 		DiskPath string
+// 	}
+//
+// 	type Replace struct {
+// 		Old Module
+// 		New Module
+// 	}
+//
+// See the workspaces design proposal at
+// https://go.googlesource.com/proposal/+/master/design/45713-workspace.md for
+// more information.
+//
+//
+// Initialize workspace file
+//
+// Usage:
+//
+// 	go work init [moddirs]
+//
+// Init initializes and writes a new go.work file in the
+// current directory, in effect creating a new workspace at the current
+// directory.
+//
+// go work init optionally accepts paths to the workspace modules as
+// arguments. If the argument is omitted, an empty workspace with no
+// modules will be created.
+//
+// Each argument path is added to a use directive in the go.work file. The
+// current go version will also be listed in the go.work file.
+//
+// See the workspaces design proposal at
+// https://go.googlesource.com/proposal/+/master/design/45713-workspace.md for
+// more information.
+//
+//
+// Sync workspace build list to modules
+//
+// Usage:
+//
+// 	go work sync
+//
+// Sync syncs the workspace's build list back to the
+// workspace's modules.
+//
+// The workspace's build list is the set of versions of all the
+// (transitive) dependency modules used to do builds in the workspace. go
+// work sync generates that build list using the Minimal Version Selection
+// algorithm, and then syncs those versions back to each of modules
+// specified in the workspace (with use directives).
+//
+// The syncing is done by sequentially upgrading each of the dependency
+// modules specified in a workspace module to the version in the build list
+// if the dependency module's version is not already the same as the build
+// list's version. Note that Minimal Version Selection guarantees that the
+// build list's version of each module is always the same or higher than
+// that in each workspace module. The checksums the workspace knows for the
+// modules in its build list are added to each updated module's go.sum file.
+//
+//
+// Add modules to workspace file
+//
+// Usage:
+//
+// 	go work use [-r] moddirs
+//
+// Use provides a command-line interface for adding
+// directories, optionally recursively, to a go.work file.
+//
+// A use directive will be added to the go.work file for each argument
+// directory listed on the command line, if it contains a go.mod file,
+// or removed from the go.work file if it does not.
+//
+// The -r flag searches recursively for modules in the argument
+// directories, and the use command operates as if each of the directories
+// were specified as arguments: namely, use directives will be added for
+// directories that contain a module, and removed for directories that
+// no longer do.
+//
+//
 // Build constraints
 //
 // A build constraint, also known as a build tag, is a line comment that begins
@@ -1791,6 +2019,9 @@
 // 	GOCACHE
 // 		The directory where the go command will store cached
 // 		information for reuse in future builds.
+// 	GOCOVERDIR
+// 		The directory into which programs built with 'go build -cover'
+// 		write their coverage data files. Not used by the go command itself.
 // 	GOMODCACHE
 // 		The directory where the go command will store downloaded modules.
 // 	GODEBUG
@@ -1836,6 +2067,14 @@
 // 	GOVCS
 // 		Lists version control commands that may be used with matching servers.
 // 		See 'go help vcs'.
+// 	GOWORK
+// 		In module aware mode, use the given go.work file as a workspace file.
+// 		By default or when GOWORK is "auto", the go command searches for a
+// 		file named go.work in the current directory and then containing directories
+// 		until one is found. If a valid go.work file is found, the modules
+// 		it lists are used together, each from its own directory. If GOWORK
+// 		is "off", or a go.work file is not found in "auto" mode, workspace
+// 		mode is disabled. See 'go help work'.
 //
 // Environment variables for use with cgo:
 //
@@ -2658,9 +2897,32 @@
 // 	-failfast
 // 	    Do not start new tests after the first test failure.
 //
+// 	-fuzz regexp
+// 	    Run the fuzz target matching the regular expression. When specified,
+// 	    the command line argument must match exactly one package, and regexp
+// 	    must match exactly one fuzz target within that package. After tests,
+// 	    benchmarks, seed corpora of other fuzz targets, and examples have
+// 	    completed, the matching target will be fuzzed. See the Fuzzing
+// 	    section of the testing package documentation for details.
+//
+// 	-fuzztime t
+// 	    Run enough iterations of the fuzz target to take t, specified as a
+// 	    time.Duration (for example, -fuzztime 1h30s). The default is to run
+// 	    forever.
+// 	    The special syntax Nx means to run the fuzz target N times
+// 	    (for example, -fuzztime 100x).
+//
+// 	-fuzzminimizetime t
+// 	    Spend at most t trying to minimize an input that causes the fuzz
+// 	    target to fail, specified as a time.Duration (for example,
+// 	    -fuzzminimizetime 30s). The default is 60s.
+// 	    The special syntax Nx means to try at most N minimization
+// 	    candidates (for example, -fuzzminimizetime 100x).
+//
 // 	-list regexp
-// 	    List tests, benchmarks, or examples matching the regular expression.
-// 	    No tests, benchmarks or examples will be run. This will only
+// 	    List tests, benchmarks, fuzz targets, or examples matching the
+// 	    regular expression. No tests, benchmarks, fuzz targets, or examples
+// 	    will be run. This will only
 // 	    list top-level tests. No subtest or subbenchmarks will be shown.
 //
 // 	-parallel n
@@ -2831,8 +3093,8 @@
 //
 // Testing functions
 //
-// The 'go test' command expects to find test, benchmark, and example functions
-// in the "*_test.go" files corresponding to the package under test.
+// The 'go test' command expects to find test, benchmark, fuzz, and example
+// functions in the "*_test.go" files corresponding to the package under test.
 //
 // A test function is one named TestXxx (where Xxx does not start with a
 // lower case letter) and should have the signature,
@@ -2843,6 +3105,10 @@
 //
 // 	func BenchmarkXxx(b *testing.B) { ... }
 //
+// A fuzz target is one named FuzzXxx and should have the signature,
+//
+// 	func FuzzXxx(f *testing.F) { ... }
+//
 // An example function is similar to a test function but, instead of using
 // *testing.T to report success or failure, prints output to os.Stdout.
 // If the last comment in the function starts with "Output:" then the output
@@ -2882,7 +3148,7 @@
 //
 // The entire test file is presented as the example when it contains a single
 // example function, at least one other function, type, variable, or constant
-// declaration, and no test or benchmark functions.
+// declaration, and no test, benchmark, or fuzz functions.
 //
 // See the documentation of the testing package for more information.
 //
@@ -144,14 +144,21 @@ func findEnv(env []cfg.EnvVar, name string) string {
 
 // ExtraEnvVars returns environment variables that should not leak into child processes.
 func ExtraEnvVars() []cfg.EnvVar {
+	modload.InitWorkfile()
 	gomod := ""
 	if modload.HasModRoot() {
 		gomod = filepath.Join(modload.ModRoot(), "go.mod")
 	} else if modload.Enabled() {
 		gomod = os.DevNull
 	}
+	gowork := modload.WorkFilePath()
+	// As a special case, report GOWORK=off if the user set it explicitly.
+	if cfg.Getenv("GOWORK") == "off" {
+		gowork = "off"
+	}
 	return []cfg.EnvVar{
 		{Name: "GOMOD", Value: gomod},
+		{Name: "GOWORK", Value: gowork},
 	}
 }
 
@@ -498,6 +498,9 @@ General-purpose environment variables:
 	GOCACHE
 		The directory where the go command will store cached
 		information for reuse in future builds.
+	GOCOVERDIR
+		The directory into which programs built with 'go build -cover'
+		write their coverage data files. Not used by the go command itself.
 	GOMODCACHE
 		The directory where the go command will store downloaded modules.
 	GODEBUG
@@ -543,6 +546,14 @@ General-purpose environment variables:
 	GOVCS
 		Lists version control commands that may be used with matching servers.
 		See 'go help vcs'.
+	GOWORK
+		In module aware mode, use the given go.work file as a workspace file.
+		By default or when GOWORK is "auto", the go command searches for a
+		file named go.work in the current directory and then containing directories
+		until one is found. If a valid go.work file is found, the modules
+		it lists are used together, each from its own directory. If GOWORK
+		is "off", or a go.work file is not found in "auto" mode, workspace
+		mode is disabled. See 'go help work'.
 
 Environment variables for use with cgo:
 
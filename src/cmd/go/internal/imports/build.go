@@ -234,6 +234,7 @@ var KnownArch = map[string]bool{
 	"arm64be":     true,
 	"ppc64":       true,
 	"ppc64le":     true,
+	"loong64":     true,
 	"mips":        true,
 	"mipsle":      true,
 	"mips64":      true,
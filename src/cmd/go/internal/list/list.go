@@ -340,6 +340,7 @@ func runList(ctx context.Context, cmd *base.Command, args []string) {
 		base.Fatalf("go list -f cannot be used with -json")
 	}
 
+	modload.InitWorkfile()
 	work.BuildInit()
 	out := newTrackingWriter(os.Stdout)
 	defer out.w.Flush()
@@ -386,6 +386,12 @@ func RemoveAll(dir string) error {
 
 var GoSumFile string // path to go.sum; set by package modload
 
+// WorkspaceGoSumFiles are the paths to the go.sum files of the modules in
+// the workspace, in workspace mode; set by package modload. Checksums are
+// read from them but never written to them: GoSumFile records any checksums
+// they lack.
+var WorkspaceGoSumFiles []string
+
 type modSum struct {
 	mod module.Version
 	sum string
@@ -395,6 +401,7 @@ var goSum struct {
 	mu        sync.Mutex
 	m         map[module.Version][]string // content of go.sum file
 	status    map[modSum]modSumStatus     // state of sums in m
+	w         map[modSum]bool             // sums in m read from WorkspaceGoSumFiles
 	overwrite bool                        // if true, overwrite go.sum without incorporating its contents
 	enabled   bool                        // whether to use go.sum at all
 }
@@ -435,9 +442,41 @@ func initGoSum() (bool, error) {
 	goSum.enabled = true
 	readGoSum(goSum.m, GoSumFile, data)
 
+	goSum.w = make(map[modSum]bool)
+	for _, f := range WorkspaceGoSumFiles {
+		data, err := lockedfile.Read(f)
+		if err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		sums := make(map[module.Version][]string)
+		if err := readGoSum(sums, f, data); err != nil {
+			return false, err
+		}
+		for m, hs := range sums {
+			for _, h := range hs {
+				goSum.w[modSum{m, h}] = true
+			}
+		}
+	}
+	addWorkspaceSumsLocked()
+
 	return true, nil
 }
 
+// addWorkspaceSumsLocked adds the sums read from WorkspaceGoSumFiles to
+// goSum.m. The goSum lock must be held.
+func addWorkspaceSumsLocked() {
+Outer:
+	for ms := range goSum.w {
+		for _, h := range goSum.m[ms.mod] {
+			if h == ms.sum {
+				continue Outer
+			}
+		}
+		goSum.m[ms.mod] = append(goSum.m[ms.mod], ms.sum)
+	}
+}
+
 // emptyGoModHash is the hash of a 1-file tree containing a 0-length go.mod.
 // A bug caused us to write these into go.sum files for non-modules.
 // We detect and remove them.
@@ -746,6 +785,7 @@ Outer:
 			// them without good reason.
 			goSum.m = make(map[module.Version][]string, len(goSum.m))
 			readGoSum(goSum.m, GoSumFile, data)
+			addWorkspaceSumsLocked()
 			for ms, st := range goSum.status {
 				if st.used {
 					addModSumLocked(ms.mod, ms.sum)
@@ -764,6 +804,10 @@ Outer:
 			list := goSum.m[m]
 			sort.Strings(list)
 			for _, h := range list {
+				if goSum.w[modSum{m, h}] {
+					// Already recorded in the go.sum file of a workspace module.
+					continue
+				}
 				st := goSum.status[modSum{m, h}]
 				if !st.dirty || (st.used && keep[m]) {
 					fmt.Fprintf(&buf, "%s %s %s\n", m.Path, m.Version, h)
@@ -781,6 +825,65 @@ Outer:
 	goSum.overwrite = false
 }
 
+// AddGoSums adds to the go.sum file at path, which is not GoSumFile, the
+// known checksums for the modules in keep. As for WriteGoSum, keep should
+// have entries for both module content sums and go.mod sums.
+//
+// 'go work sync' uses AddGoSums to record in the go.sum file of each module
+// in a workspace the checksums for the requirements it updates.
+func AddGoSums(path string, keep map[module.Version]bool) error {
+	goSum.mu.Lock()
+	defer goSum.mu.Unlock()
+	if _, err := initGoSum(); err != nil {
+		return err
+	}
+
+	return lockedfile.Transform(path, func(data []byte) ([]byte, error) {
+		sums := make(map[module.Version][]string)
+		if err := readGoSum(sums, path, data); err != nil {
+			return nil, err
+		}
+		changed := false
+		for m, hs := range goSum.m {
+			if !keep[m] {
+				continue
+			}
+		Hashes:
+			for _, h := range hs {
+				if goSum.status[modSum{m, h}].dirty && !goSum.status[modSum{m, h}].used {
+					continue
+				}
+				for _, old := range sums[m] {
+					if old == h {
+						continue Hashes
+					}
+				}
+				sums[m] = append(sums[m], h)
+				changed = true
+			}
+		}
+		if !changed {
+			return data, nil
+		}
+
+		var mods []module.Version
+		for m := range sums {
+			mods = append(mods, m)
+		}
+		module.Sort(mods)
+
+		var buf bytes.Buffer
+		for _, m := range mods {
+			list := sums[m]
+			sort.Strings(list)
+			for _, h := range list {
+				fmt.Fprintf(&buf, "%s %s %s\n", m.Path, m.Version, h)
+			}
+		}
+		return buf.Bytes(), nil
+	})
+}
+
 // TrimGoSum trims go.sum to contain only the modules needed for reproducible
 // builds.
 //
@@ -540,6 +540,11 @@ func tidyRoots(ctx context.Context, rs *Requirements, pkgs []*loadPkg) (*Require
 }
 
 func updateRoots(ctx context.Context, direct map[string]bool, rs *Requirements, pkgs []*loadPkg, add []module.Version, rootsImported bool) (*Requirements, error) {
+	if inWorkspaceMode() && len(add) == 0 {
+		// The roots of a workspace are implied by the go.mod files of its
+		// modules, which are never updated in workspace mode.
+		return rs, nil
+	}
 	if rs.depth == eager {
 		return updateEagerRoots(ctx, direct, rs, add)
 	}
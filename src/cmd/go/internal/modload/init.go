@@ -52,6 +52,24 @@ var (
 	gopath      string
 )
 
+// Variables set in InitWorkfile and Init for workspace mode.
+var (
+	// workFilePath is the path to the go.work file, or the empty string if
+	// the go command is not in workspace mode.
+	workFilePath string
+
+	// workModules are the modules used by the go.work file, in order.
+	// The main module is one of them.
+	workModules []workModule
+)
+
+// A workModule is a module used by a go.work file.
+type workModule struct {
+	path    string             // module path, from its go.mod file
+	dir     string             // absolute path of the module root directory
+	replace []*modfile.Replace // replace directives of its go.mod file
+}
+
 // Variables set in initTarget (during {Load,Create}ModFile).
 var (
 	Target module.Version
@@ -132,6 +150,7 @@ func Init() {
 			base.Fatalf("go: modules disabled by GO111MODULE=off; see 'go help modules'")
 		}
 		mustUseModules = false
+		workFilePath = ""
 		return
 	}
 
@@ -173,11 +192,23 @@ func Init() {
 	if modRoot != "" {
 		// modRoot set before Init was called ("go mod init" does this).
 		// No need to search for go.mod.
+		workFilePath = ""
 	} else if RootMode == NoRoot {
 		if cfg.ModFile != "" && !base.InGOFLAGS("-modfile") {
 			base.Fatalf("go: -modfile cannot be used with commands that ignore the current module")
 		}
 		modRoot = ""
+		workFilePath = ""
+	} else if workFilePath != "" {
+		if cfg.ModFile != "" {
+			base.Fatalf("go: -modfile cannot be used in workspace mode")
+		}
+		if cfg.BuildModExplicit && cfg.BuildMod != "readonly" {
+			base.Fatalf("go: -mod may only be set to readonly in workspace mode, but it is set to %q"+
+				"\n\tRemove the -mod flag to use the default readonly value,"+
+				"\n\tor set GOWORK=off to disable workspace mode.", cfg.BuildMod)
+		}
+		modRoot = loadWorkspace()
 	} else {
 		modRoot = findModuleRoot(base.Cwd())
 		if modRoot == "" {
@@ -239,12 +270,98 @@ func Init() {
 		// For example, 'go get' does this, since it is expected to resolve paths.
 		//
 		// See golang.org/issue/32027.
+	} else if inWorkspaceMode() {
+		// Checksums missing from the go.sum files of the modules in the
+		// workspace are recorded in a go.work.sum file next to go.work.
+		modfetch.GoSumFile = workFilePath + ".sum"
+		for _, m := range workModules {
+			modfetch.WorkspaceGoSumFiles = append(modfetch.WorkspaceGoSumFiles, filepath.Join(m.dir, "go.sum"))
+		}
+		search.SetModRoot(modRoot)
 	} else {
 		modfetch.GoSumFile = strings.TrimSuffix(ModFilePath(), ".mod") + ".sum"
 		search.SetModRoot(modRoot)
 	}
 }
 
+// inWorkspaceMode reports whether the go command is using a go.work file
+// to combine several main modules.
+func inWorkspaceMode() bool {
+	if !initialized {
+		panic("inWorkspaceMode called before modload.Init called")
+	}
+	return workFilePath != ""
+}
+
+// loadWorkspace reads the go.work file and the module paths of the modules
+// it uses. It returns the root directory of the main module: the innermost
+// used module containing the current directory, or else the first one
+// listed in go.work.
+func loadWorkspace() (root string) {
+	wf, err := ReadWorkFile(workFilePath)
+	if err != nil {
+		base.Fatalf("go: %v", err)
+	}
+	workDir := filepath.Dir(workFilePath)
+	dirs := make(map[string]string) // module path → directory
+	for _, u := range wf.Use {
+		dir := filepath.FromSlash(u.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workDir, dir)
+		}
+		dir = filepath.Clean(dir)
+		m, err := readWorkModule(dir)
+		if os.IsNotExist(err) {
+			base.Fatalf("go: directory %s listed in %s does not contain a go.mod file", base.ShortPath(dir), base.ShortPath(workFilePath))
+		} else if err != nil {
+			base.Fatalf("go: %v", err)
+		}
+		if prev, ok := dirs[m.path]; ok {
+			base.Fatalf("go: module %s appears multiple times in workspace:\n\t%s\n\t%s", m.path, base.ShortPath(prev), base.ShortPath(dir))
+		}
+		dirs[m.path] = dir
+		workModules = append(workModules, m)
+
+		if search.InDir(base.Cwd(), dir) != "" && len(dir) > len(root) {
+			root = dir
+		}
+	}
+	if len(workModules) == 0 {
+		base.Fatalf("go: no modules listed in %s; to add one:\n\tgo work use DIR", base.ShortPath(workFilePath))
+	}
+	if root == "" {
+		root = workModules[0].dir
+	}
+	workReplace = wf.Replace
+	return root
+}
+
+// workReplace holds the replace directives of the go.work file.
+var workReplace []*modfile.Replace
+
+// readWorkModule reads the go.mod file of the module in dir.
+func readWorkModule(dir string) (workModule, error) {
+	gomod := filepath.Join(dir, "go.mod")
+	var data []byte
+	var err error
+	if gomodActual, ok := fsys.OverlayPath(gomod); ok {
+		data, err = os.ReadFile(gomodActual)
+	} else {
+		data, err = lockedfile.Read(gomodActual)
+	}
+	if err != nil {
+		return workModule{}, err
+	}
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return workModule{}, fmt.Errorf("errors parsing %s:\n%v", base.ShortPath(gomod), err)
+	}
+	if f.Module == nil {
+		return workModule{}, fmt.Errorf("no module declaration in %s", base.ShortPath(gomod))
+	}
+	return workModule{path: f.Module.Mod.Path, dir: dir, replace: f.Replace}, nil
+}
+
 // WillBeEnabled checks whether modules should be enabled but does not
 // initialize modules by installing hooks. If Init has already been called,
 // WillBeEnabled returns the same result as Enabled.
@@ -440,6 +557,9 @@ func loadModFile(ctx context.Context) (rs *Requirements, needCommit bool) {
 	modFile = f
 	initTarget(f.Module.Mod)
 	index = indexModFile(data, f, fixed)
+	if inWorkspaceMode() {
+		index.addWorkspaceReplacements()
+	}
 
 	if err := module.CheckImportPath(f.Module.Mod.Path); err != nil {
 		if pathErr, ok := err.(*module.InvalidPathError); ok {
@@ -642,7 +762,7 @@ func initTarget(m module.Version) {
 // requirementsFromModFile returns the set of non-excluded requirements from
 // the global modFile.
 func requirementsFromModFile(ctx context.Context) *Requirements {
-	roots := make([]module.Version, 0, len(modFile.Require))
+	roots := make([]module.Version, 0, len(modFile.Require)+len(workModules))
 	mPathCount := map[string]int{Target.Path: 1}
 	direct := map[string]bool{}
 	for _, r := range modFile.Require {
@@ -661,8 +781,31 @@ func requirementsFromModFile(ctx context.Context) *Requirements {
 			direct[r.Mod.Path] = true
 		}
 	}
+	depth := modDepthFromGoVersion(modFileGoVersion())
+	if inWorkspaceMode() {
+		// Every module in the workspace is a root, replaced by its directory.
+		// Requiring a zero pseudo-version lets any other requirement on the
+		// module select the version, which is immaterial since the
+		// replacement applies to all versions.
+		for _, m := range workModules {
+			if mPathCount[m.path] > 0 {
+				continue
+			}
+			mPathCount[m.path]++
+			direct[m.path] = true
+			if _, pathMajor, ok := module.SplitPathVersion(m.path); ok && len(pathMajor) > 0 {
+				roots = append(roots, module.Version{Path: m.path, Version: module.ZeroPseudoVersion(pathMajor[1:])})
+			} else {
+				roots = append(roots, module.Version{Path: m.path, Version: module.ZeroPseudoVersion("v0")})
+			}
+		}
+		// The go.mod files of the modules in a workspace need not be tidy
+		// with respect to each other, so the lazy-loading invariants can't be
+		// relied upon: load the complete module graph instead.
+		depth = eager
+	}
 	module.Sort(roots)
-	rs := newRequirements(modDepthFromGoVersion(modFileGoVersion()), roots, direct)
+	rs := newRequirements(depth, roots, direct)
 
 	// If any module path appears more than once in the roots, we know that the
 	// go.mod file needs to be updated even though we have not yet loaded any
@@ -689,6 +832,17 @@ func setDefaultBuildMod() {
 		return
 	}
 
+	if inWorkspaceMode() {
+		// Workspace mode never updates go.mod files, but 'go mod' commands
+		// and 'go work sync' may still resolve missing modules.
+		if strings.HasPrefix(cfg.CmdName, "mod ") || cfg.CmdName == "work sync" {
+			cfg.BuildMod = "mod"
+		} else {
+			cfg.BuildMod = "readonly"
+		}
+		return
+	}
+
 	if cfg.CmdName == "get" || strings.HasPrefix(cfg.CmdName, "mod ") {
 		// 'get' and 'go mod' commands may update go.mod automatically.
 		// TODO(jayconrod): should this narrower? Should 'go mod download' or
@@ -1001,6 +1155,14 @@ func commitRequirements(ctx context.Context, goVersion string, rs *Requirements)
 		return
 	}
 
+	if inWorkspaceMode() {
+		// The requirements of a workspace are derived from the go.mod files
+		// of its modules, which the go command never updates in workspace
+		// mode. Only new checksums are recorded, in go.work.sum.
+		modfetch.WriteGoSum(keepSums(ctx, loaded, rs, addBuildListZipSums))
+		return
+	}
+
 	var list []*modfile.Require
 	for _, m := range rs.rootModules {
 		list = append(list, &modfile.Require{
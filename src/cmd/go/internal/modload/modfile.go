@@ -382,6 +382,67 @@ func indexModFile(data []byte, modFile *modfile.File, needsFix bool) *modFileInd
 	return i
 }
 
+// addWorkspaceReplacements adds the replacements that apply in workspace
+// mode to i, which indexes the go.mod file of the main module.
+//
+// Each module in the workspace is replaced by its directory. Otherwise,
+// the replace directives of go.work take precedence over those of the main
+// module, which take precedence over those of the other modules in the
+// workspace. Relative directory paths are resolved against the directory
+// of the file in which they appear.
+func (i *modFileIndex) addWorkspaceReplacements() {
+	replaced := make(map[string]bool)
+	for old := range i.replace {
+		replaced[old.Path] = true
+	}
+	set := func(old, new module.Version, dir string) {
+		if new.Version == "" && !filepath.IsAbs(new.Path) {
+			new.Path = filepath.Join(dir, filepath.FromSlash(new.Path))
+		}
+		if old.Version == "" {
+			// A wildcard replacement overrides all the others for its path.
+			for r := range i.replace {
+				if r.Path == old.Path {
+					delete(i.replace, r)
+				}
+			}
+		}
+		i.replace[old] = new
+		replaced[old.Path] = true
+	}
+
+	mainReplaced := make(map[string]bool, len(replaced))
+	for path := range replaced {
+		mainReplaced[path] = true
+	}
+	for _, m := range workModules {
+		if m.dir == modRoot {
+			continue
+		}
+		for _, r := range m.replace {
+			if !mainReplaced[r.Old.Path] {
+				set(r.Old, r.New, m.dir)
+			}
+		}
+	}
+	for _, r := range workReplace {
+		set(r.Old, r.New, filepath.Dir(workFilePath))
+	}
+	for _, m := range workModules {
+		if m.dir != modRoot {
+			set(module.Version{Path: m.path}, module.Version{Path: m.dir}, "")
+		}
+	}
+
+	i.highestReplaced = make(map[string]string)
+	for old := range i.replace {
+		v, ok := i.highestReplaced[old.Path]
+		if !ok || semver.Compare(old.Version, v) > 0 {
+			i.highestReplaced[old.Path] = old.Version
+		}
+	}
+}
+
 // modFileIsDirty reports whether the go.mod file differs meaningfully
 // from what was indexed.
 // If modFile has been changed (even cosmetically) since it was first read,
@@ -0,0 +1,453 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package modload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/cfg"
+	"cmd/go/internal/fsys"
+	"cmd/go/internal/lockedfile"
+	"cmd/go/internal/modfetch"
+	"cmd/go/internal/search"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// A WorkFile is the parsed, interpreted form of a go.work file.
+//
+// A go.work file has the same syntax as a go.mod file, but it may only
+// contain go, use, and replace directives.
+type WorkFile struct {
+	Go      *modfile.Go
+	Use     []*Use
+	Replace []*modfile.Replace
+
+	Syntax *modfile.FileSyntax
+}
+
+// A Use is a single directory statement in a go.work file.
+type Use struct {
+	Path   string // directory of the module, relative to the go.work file or absolute
+	Syntax *modfile.Line
+}
+
+// ReadWorkFile reads and parses the go.work file at path.
+func ReadWorkFile(path string) (*WorkFile, error) {
+	var data []byte
+	var err error
+	if actual, ok := fsys.OverlayPath(path); ok {
+		// Don't lock go.work if it's part of the overlay.
+		data, err = os.ReadFile(actual)
+	} else {
+		data, err = lockedfile.Read(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseWorkFile(path, data)
+}
+
+// WriteWorkFile formats f and writes it to the go.work file at path.
+func WriteWorkFile(path string, f *WorkFile) error {
+	f.Cleanup()
+	out := f.Format()
+
+	// Make a best-effort attempt to acquire the side lock, only to exclude
+	// previous versions of the 'go' command from making simultaneous edits.
+	if unlock, err := modfetch.SideLock(); err == nil {
+		defer unlock()
+	}
+	return lockedfile.Write(path, bytes.NewReader(out), 0666)
+}
+
+// ParseWorkFile parses the content data of the go.work file named file.
+func ParseWorkFile(file string, data []byte) (*WorkFile, error) {
+	// The go.work syntax is the go.mod syntax, so reuse the go.mod parser
+	// for the syntax tree. ParseLax ignores the directives that it doesn't
+	// interpret, so we interpret all of them ourselves.
+	mf, err := modfile.ParseLax(file, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	f := &WorkFile{Syntax: mf.Syntax}
+
+	var errs modfile.ErrorList
+	for _, x := range f.Syntax.Stmt {
+		switch x := x.(type) {
+		case *modfile.Line:
+			f.add(&errs, x, x.Token[0], x.Token[1:])
+
+		case *modfile.LineBlock:
+			if len(x.Token) > 1 || (x.Token[0] != "use" && x.Token[0] != "replace") {
+				errs = append(errs, modfile.Error{
+					Filename: file,
+					Pos:      x.Start,
+					Err:      fmt.Errorf("unknown block type: %s", strings.Join(x.Token, " ")),
+				})
+				continue
+			}
+			for _, l := range x.Line {
+				f.add(&errs, l, x.Token[0], l.Token)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return f, nil
+}
+
+func (f *WorkFile) add(errs *modfile.ErrorList, line *modfile.Line, verb string, args []string) {
+	errorf := func(format string, args ...interface{}) {
+		*errs = append(*errs, modfile.Error{
+			Filename: f.Syntax.Name,
+			Pos:      line.Start,
+			Verb:     verb,
+			Err:      fmt.Errorf(format, args...),
+		})
+	}
+
+	switch verb {
+	default:
+		errorf("unknown directive: %s", verb)
+
+	case "go":
+		if f.Go != nil {
+			errorf("repeated go statement")
+			return
+		}
+		if len(args) != 1 {
+			errorf("go directive expects exactly one argument")
+			return
+		}
+		if !modfile.GoVersionRE.MatchString(args[0]) {
+			errorf("invalid go version '%s': must match format 1.23", args[0])
+			return
+		}
+		f.Go = &modfile.Go{Version: args[0], Syntax: line}
+
+	case "use":
+		if len(args) != 1 {
+			errorf("usage: use local/dir")
+			return
+		}
+		s, err := parseWorkString(args[0])
+		if err != nil {
+			errorf("invalid quoted string: %v", err)
+			return
+		}
+		f.Use = append(f.Use, &Use{Path: s, Syntax: line})
+
+	case "replace":
+		arrow := 2
+		if len(args) >= 2 && args[1] == "=>" {
+			arrow = 1
+		}
+		if len(args) < arrow+2 || len(args) > arrow+3 || args[arrow] != "=>" {
+			errorf("usage: replace module/path [v1.2.3] => other/module v1.4\n\t or replace module/path [v1.2.3] => ../local/directory")
+			return
+		}
+		var old, new module.Version
+		var err error
+		if old.Path, err = parseWorkString(args[0]); err != nil {
+			errorf("invalid quoted string: %v", err)
+			return
+		}
+		if err := module.CheckImportPath(old.Path); err != nil {
+			errorf("%v", err)
+			return
+		}
+		if arrow == 2 {
+			if old.Version, err = parseWorkVersion(old.Path, args[1]); err != nil {
+				errorf("%v", err)
+				return
+			}
+		}
+		if new.Path, err = parseWorkString(args[arrow+1]); err != nil {
+			errorf("invalid quoted string: %v", err)
+			return
+		}
+		if len(args) == arrow+2 {
+			if !modfile.IsDirectoryPath(new.Path) {
+				errorf("replacement module without version must be directory path (rooted or starting with ./ or ../)")
+				return
+			}
+		} else {
+			if modfile.IsDirectoryPath(new.Path) {
+				errorf("replacement module directory path %q cannot have version", new.Path)
+				return
+			}
+			if new.Version, err = parseWorkVersion(new.Path, args[arrow+2]); err != nil {
+				errorf("%v", err)
+				return
+			}
+		}
+		f.Replace = append(f.Replace, &modfile.Replace{Old: old, New: new, Syntax: line})
+	}
+}
+
+// parseWorkString returns the unquoted form of the token s.
+func parseWorkString(s string) (string, error) {
+	if strings.HasPrefix(s, `"`) {
+		return strconv.Unquote(s)
+	}
+	if strings.ContainsAny(s, "\"'`") {
+		// Other quotes are reserved for future use.
+		return "", fmt.Errorf("unquoted string cannot contain quote")
+	}
+	return s, nil
+}
+
+// parseWorkVersion parses the version token s for module path. Unlike in
+// a go.mod file, the version must already be canonical: the go command
+// never rewrites a go.work file to resolve queries.
+func parseWorkVersion(path, s string) (string, error) {
+	v, err := parseWorkString(s)
+	if err != nil {
+		return "", err
+	}
+	if module.CanonicalVersion(v) != v {
+		return "", &module.ModuleError{
+			Path:    path,
+			Version: v,
+			Err:     &module.InvalidVersionError{Version: v, Err: errors.New("must be of the form v1.2.3")},
+		}
+	}
+	return v, nil
+}
+
+// AddGoStmt sets the go directive of f to version.
+func (f *WorkFile) AddGoStmt(version string) error {
+	if !modfile.GoVersionRE.MatchString(version) {
+		return fmt.Errorf("invalid language version string %q", version)
+	}
+	if f.Go != nil {
+		f.Go.Version = version
+		setLine(f.Go.Syntax, "go", version)
+		return nil
+	}
+	// The go directive conventionally comes first.
+	line := &modfile.Line{Token: []string{"go", version}}
+	f.Syntax.Stmt = append([]modfile.Expr{line}, f.Syntax.Stmt...)
+	f.Go = &modfile.Go{Version: version, Syntax: line}
+	return nil
+}
+
+// AddUse adds a use directive for the directory path, unless there is one
+// already.
+func (f *WorkFile) AddUse(path string) {
+	for _, u := range f.Use {
+		if u.Path == path {
+			return
+		}
+	}
+	f.Use = append(f.Use, &Use{Path: path, Syntax: f.addLine("use", modfile.AutoQuote(path))})
+}
+
+// DropUse removes the use directives for the directory path.
+func (f *WorkFile) DropUse(path string) {
+	for _, u := range f.Use {
+		if u.Path == path {
+			dropLine(u.Syntax)
+			*u = Use{}
+		}
+	}
+}
+
+// AddReplace adds a replacement of oldPath@oldVers by newPath@newVers,
+// overriding any existing replacement for oldPath@oldVers. As in go.mod,
+// an empty oldVers replaces all versions of oldPath.
+func (f *WorkFile) AddReplace(oldPath, oldVers, newPath, newVers string) {
+	tokens := []string{"replace", modfile.AutoQuote(oldPath)}
+	if oldVers != "" {
+		tokens = append(tokens, oldVers)
+	}
+	tokens = append(tokens, "=>", modfile.AutoQuote(newPath))
+	if newVers != "" {
+		tokens = append(tokens, newVers)
+	}
+	new := module.Version{Path: newPath, Version: newVers}
+
+	need := true
+	for _, r := range f.Replace {
+		if r.Old.Path == oldPath && (oldVers == "" || r.Old.Version == oldVers) {
+			if need {
+				r.Old.Version = oldVers
+				r.New = new
+				setLine(r.Syntax, tokens...)
+				need = false
+				continue
+			}
+			dropLine(r.Syntax)
+			*r = modfile.Replace{}
+		}
+	}
+	if need {
+		f.Replace = append(f.Replace, &modfile.Replace{
+			Old:    module.Version{Path: oldPath, Version: oldVers},
+			New:    new,
+			Syntax: f.addLine(tokens...),
+		})
+	}
+}
+
+// DropReplace removes the replacement of oldPath@oldVers.
+func (f *WorkFile) DropReplace(oldPath, oldVers string) {
+	for _, r := range f.Replace {
+		if r.Old.Path == oldPath && r.Old.Version == oldVers {
+			dropLine(r.Syntax)
+			*r = modfile.Replace{}
+		}
+	}
+}
+
+// Cleanup removes the entries dropped by edits from f.
+// It must be called after all edits, before Format.
+func (f *WorkFile) Cleanup() {
+	w := 0
+	for _, u := range f.Use {
+		if u.Path != "" {
+			f.Use[w] = u
+			w++
+		}
+	}
+	f.Use = f.Use[:w]
+
+	w = 0
+	for _, r := range f.Replace {
+		if r.Old.Path != "" {
+			f.Replace[w] = r
+			w++
+		}
+	}
+	f.Replace = f.Replace[:w]
+
+	f.Syntax.Cleanup()
+}
+
+// Format returns the text of f.
+func (f *WorkFile) Format() []byte {
+	return modfile.Format(f.Syntax)
+}
+
+// addLine adds a new line with the given tokens after the last statement
+// with the same verb, merging the two into a block.
+func (f *WorkFile) addLine(tokens ...string) *modfile.Line {
+	for i := len(f.Syntax.Stmt) - 1; i >= 0; i-- {
+		switch x := f.Syntax.Stmt[i].(type) {
+		case *modfile.Line:
+			if len(x.Token) == 0 || x.Token[0] != tokens[0] {
+				continue
+			}
+			x.InBlock = true
+			block := &modfile.LineBlock{Token: x.Token[:1], Line: []*modfile.Line{x}}
+			x.Token = x.Token[1:]
+			f.Syntax.Stmt[i] = block
+			line := &modfile.Line{Token: tokens[1:], InBlock: true}
+			block.Line = append(block.Line, line)
+			return line
+
+		case *modfile.LineBlock:
+			if x.Token[0] != tokens[0] {
+				continue
+			}
+			line := &modfile.Line{Token: tokens[1:], InBlock: true}
+			x.Line = append(x.Line, line)
+			return line
+		}
+	}
+	line := &modfile.Line{Token: tokens}
+	f.Syntax.Stmt = append(f.Syntax.Stmt, line)
+	return line
+}
+
+// setLine replaces the tokens of line, which has the given verb.
+func setLine(line *modfile.Line, tokens ...string) {
+	if line.InBlock {
+		tokens = tokens[1:]
+	}
+	line.Token = tokens
+}
+
+// dropLine marks line as removed; Cleanup deletes it from the syntax tree.
+func dropLine(line *modfile.Line) {
+	line.Token = nil
+	line.Comments.Suffix = nil
+}
+
+// InitWorkfile determines whether the current command runs in workspace
+// mode, and if so locates its go.work file. Commands that support
+// workspaces must call it before Init.
+//
+// The go.work file is the one named by $GOWORK, or else the first go.work
+// file found in the current directory or its parents. Setting GOWORK=off
+// disables workspace mode.
+func InitWorkfile() {
+	switch gowork := cfg.Getenv("GOWORK"); gowork {
+	case "off":
+		workFilePath = ""
+	case "", "auto":
+		workFilePath = findWorkspaceFile(base.Cwd())
+	default:
+		if !filepath.IsAbs(gowork) {
+			base.Fatalf("go: invalid GOWORK: not an absolute path")
+		}
+		workFilePath = gowork
+	}
+}
+
+// WorkFilePath returns the path of the go.work file in use, or the empty
+// string if the go command is not in workspace mode.
+func WorkFilePath() string {
+	return workFilePath
+}
+
+// WorkspaceModules returns the module paths and root directories of the
+// modules used by the go.work file, in the order it lists them, or nil
+// slices if the go command is not in workspace mode.
+func WorkspaceModules() (paths, dirs []string) {
+	Init()
+	for _, m := range workModules {
+		paths = append(paths, m.path)
+		dirs = append(dirs, m.dir)
+	}
+	return paths, dirs
+}
+
+// findWorkspaceFile returns the path of the first go.work file in dir or
+// one of its parents, or "" if there is none.
+func findWorkspaceFile(dir string) string {
+	if dir == "" {
+		panic("dir not set")
+	}
+	dir = filepath.Clean(dir)
+
+	for {
+		f := filepath.Join(dir, "go.work")
+		if fi, err := fsys.Stat(f); err == nil && !fi.IsDir() {
+			if search.InDir(dir, os.TempDir()) == "." {
+				// As for go.mod, ignore a go.work file in the system temp
+				// root (see golang.org/issue/26708).
+				return ""
+			}
+			return f
+		}
+		d := filepath.Dir(dir)
+		if d == dir {
+			break
+		}
+		dir = d
+	}
+	return ""
+}
@@ -7,7 +7,6 @@ package test
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"errors"
 	"fmt"
 	"go/build"
@@ -15,7 +14,6 @@ import (
 	"io"
 	"io/fs"
 	"os"
-	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -29,10 +27,12 @@ import (
 	"cmd/go/internal/cfg"
 	"cmd/go/internal/load"
 	"cmd/go/internal/lockedfile"
+	"cmd/go/internal/modload"
 	"cmd/go/internal/search"
 	"cmd/go/internal/str"
 	"cmd/go/internal/trace"
 	"cmd/go/internal/work"
+	"cmd/internal/sys"
 	"cmd/internal/test2json"
 )
 
@@ -242,9 +242,32 @@ control the execution of any test:
 	-failfast
 	    Do not start new tests after the first test failure.
 
+	-fuzz regexp
+	    Run the fuzz target matching the regular expression. When specified,
+	    the command line argument must match exactly one package, and regexp
+	    must match exactly one fuzz target within that package. After tests,
+	    benchmarks, seed corpora of other fuzz targets, and examples have
+	    completed, the matching target will be fuzzed. See the Fuzzing
+	    section of the testing package documentation for details.
+
+	-fuzztime t
+	    Run enough iterations of the fuzz target to take t, specified as a
+	    time.Duration (for example, -fuzztime 1h30s). The default is to run
+	    forever.
+	    The special syntax Nx means to run the fuzz target N times
+	    (for example, -fuzztime 100x).
+
+	-fuzzminimizetime t
+	    Spend at most t trying to minimize an input that causes the fuzz
+	    target to fail, specified as a time.Duration (for example,
+	    -fuzzminimizetime 30s). The default is 60s.
+	    The special syntax Nx means to try at most N minimization
+	    candidates (for example, -fuzzminimizetime 100x).
+
 	-list regexp
-	    List tests, benchmarks, or examples matching the regular expression.
-	    No tests, benchmarks or examples will be run. This will only
+	    List tests, benchmarks, fuzz targets, or examples matching the
+	    regular expression. No tests, benchmarks, fuzz targets, or examples
+	    will be run. This will only
 	    list top-level tests. No subtest or subbenchmarks will be shown.
 
 	-parallel n
@@ -418,8 +441,8 @@ var HelpTestfunc = &base.Command{
 	UsageLine: "testfunc",
 	Short:     "testing functions",
 	Long: `
-The 'go test' command expects to find test, benchmark, and example functions
-in the "*_test.go" files corresponding to the package under test.
+The 'go test' command expects to find test, benchmark, fuzz, and example
+functions in the "*_test.go" files corresponding to the package under test.
 
 A test function is one named TestXxx (where Xxx does not start with a
 lower case letter) and should have the signature,
@@ -430,6 +453,10 @@ A benchmark function is one named BenchmarkXxx and should have the signature,
 
 	func BenchmarkXxx(b *testing.B) { ... }
 
+A fuzz target is one named FuzzXxx and should have the signature,
+
+	func FuzzXxx(f *testing.F) { ... }
+
 An example function is similar to a test function but, instead of using
 *testing.T to report success or failure, prints output to os.Stdout.
 If the last comment in the function starts with "Output:" then the output
@@ -469,7 +496,7 @@ Here is another example where the ordering of the output is ignored:
 
 The entire test file is presented as the example when it contains a single
 example function, at least one other function, type, variable, or constant
-declaration, and no test or benchmark functions.
+declaration, and no test, benchmark, or fuzz functions.
 
 See the documentation of the testing package for more information.
 `,
@@ -483,6 +510,7 @@ var (
 	testCoverPaths   []string                          // -coverpkg flag
 	testCoverPkgs    []*load.Package                   // -coverpkg flag
 	testCoverProfile string                            // -coverprofile flag
+	testFuzz         string                            // -fuzz flag
 	testJSON         bool                              // -json flag
 	testList         string                            // -list flag
 	testO            string                            // -o flag
@@ -506,6 +534,20 @@ var (
 	testBlockProfile, testCPUProfile, testMemProfile, testMutexProfile, testTrace string // profiling flag that limits test to one package
 )
 
+// skipFuzzInstrumentation lists packages that are never instrumented for
+// coverage-guided fuzzing, even when they are the package under test.
+var skipFuzzInstrumentation = map[string]bool{
+	"context":       true,
+	"internal/fuzz": true,
+	"reflect":       true,
+	"runtime":       true,
+	"sync":          true,
+	"sync/atomic":   true,
+	"syscall":       true,
+	"testing":       true,
+	"time":          true,
+}
+
 // testProfile returns the name of an arbitrary single-package profiling flag
 // that is set, if any.
 func testProfile() string {
@@ -578,6 +620,7 @@ var defaultVetFlags = []string{
 
 func runTest(ctx context.Context, cmd *base.Command, args []string) {
 	pkgArgs, testArgs = testFlags(args)
+	modload.InitWorkfile() // The test command does custom flag processing; initialize workspaces after that.
 
 	if cfg.DebugTrace != "" {
 		var close func() error
@@ -618,6 +661,19 @@ func runTest(ctx context.Context, cmd *base.Command, args []string) {
 	if testProfile() != "" && len(pkgs) != 1 {
 		base.Fatalf("cannot use %s flag with multiple packages", testProfile())
 	}
+	if testFuzz != "" {
+		if len(pkgs) != 1 {
+			base.Fatalf("cannot use -fuzz flag with multiple packages")
+		}
+
+		// Instrument the package under test so that the fuzzing engine can
+		// observe which edges each input reaches. Packages that the testing
+		// and fuzzing machinery itself depends on are left alone, since
+		// their coverage would only add noise.
+		if p := pkgs[0]; sys.FuzzInstrumented(cfg.Goos, cfg.Goarch) && !skipFuzzInstrumentation[p.ImportPath] {
+			p.Internal.Gcflags = append(p.Internal.Gcflags, "-d=libfuzzer")
+		}
+	}
 	initCoverProfile()
 	defer closeCoverProfile()
 
@@ -625,7 +681,9 @@ func runTest(ctx context.Context, cmd *base.Command, args []string) {
 	// to that timeout plus one minute. This is a backup alarm in case
 	// the test wedges with a goroutine spinning and its background
 	// timer does not get a chance to fire.
-	if testTimeout > 0 {
+	// The test binary turns off its own alarm before it starts fuzzing,
+	// so don't impose one here either.
+	if testTimeout > 0 && testFuzz == "" {
 		testKillTimeout = testTimeout + 1*time.Minute
 	}
 
@@ -768,7 +826,7 @@ func runTest(ctx context.Context, cmd *base.Command, args []string) {
 			coverFiles = append(coverFiles, p.GoFiles...)
 			coverFiles = append(coverFiles, p.CgoFiles...)
 			coverFiles = append(coverFiles, p.TestGoFiles...)
-			p.Internal.CoverVars = declareCoverVars(p, coverFiles...)
+			p.Internal.CoverVars = load.DeclareCoverVars(p, coverFiles...)
 			if testCover && testCoverMode == "atomic" {
 				ensureImport(p, "sync/atomic")
 			}
@@ -869,7 +927,7 @@ func builderTest(b *work.Builder, ctx context.Context, pkgOpts load.PackageOpts,
 			Local:    testCover && testCoverPaths == nil,
 			Pkgs:     testCoverPkgs,
 			Paths:    testCoverPaths,
-			DeclVars: declareCoverVars,
+			DeclVars: load.DeclareCoverVars,
 		}
 	}
 	pmain, ptest, pxtest, err := load.TestPackagesFor(ctx, pkgOpts, p, cover)
@@ -1035,50 +1093,6 @@ func addTestVet(b *work.Builder, p *load.Package, runAction, installAction *work
 	}
 }
 
-// isTestFile reports whether the source file is a set of tests and should therefore
-// be excluded from coverage analysis.
-func isTestFile(file string) bool {
-	// We don't cover tests, only the code they test.
-	return strings.HasSuffix(file, "_test.go")
-}
-
-// declareCoverVars attaches the required cover variables names
-// to the files, to be used when annotating the files.
-func declareCoverVars(p *load.Package, files ...string) map[string]*load.CoverVar {
-	coverVars := make(map[string]*load.CoverVar)
-	coverIndex := 0
-	// We create the cover counters as new top-level variables in the package.
-	// We need to avoid collisions with user variables (GoCover_0 is unlikely but still)
-	// and more importantly with dot imports of other covered packages,
-	// so we append 12 hex digits from the SHA-256 of the import path.
-	// The point is only to avoid accidents, not to defeat users determined to
-	// break things.
-	sum := sha256.Sum256([]byte(p.ImportPath))
-	h := fmt.Sprintf("%x", sum[:6])
-	for _, file := range files {
-		if isTestFile(file) {
-			continue
-		}
-		// For a package that is "local" (imported via ./ import or command line, outside GOPATH),
-		// we record the full path to the file name.
-		// Otherwise we record the import path, then a forward slash, then the file name.
-		// This makes profiles within GOPATH file system-independent.
-		// These names appear in the cmd/cover HTML interface.
-		var longFile string
-		if p.Internal.Local {
-			longFile = filepath.Join(p.Dir, file)
-		} else {
-			longFile = path.Join(p.ImportPath, file)
-		}
-		coverVars[file] = &load.CoverVar{
-			File: longFile,
-			Var:  fmt.Sprintf("GoCover_%d_%x", coverIndex, h),
-		}
-		coverIndex++
-	}
-	return coverVars
-}
-
 var noTestsToRun = []byte("\ntesting: warning: no tests to run\n")
 
 type runCache struct {
@@ -1183,7 +1197,13 @@ func (c *runCache) builderRunTest(b *work.Builder, ctx context.Context, a *work.
 		testlogArg = []string{"-test.testlogfile=" + a.Objdir + "testlog.txt"}
 	}
 	panicArg := "-test.paniconexit0"
-	args := str.StringList(execCmd, a.Deps[0].BuiltTarget(), testlogArg, panicArg, testArgs)
+	fuzzArg := []string{}
+	if testFuzz != "" {
+		if dir := cache.DefaultDir(); dir != "off" {
+			fuzzArg = []string{"-test.fuzzcachedir=" + filepath.Join(dir, "fuzz", a.Package.ImportPath)}
+		}
+	}
+	args := str.StringList(execCmd, a.Deps[0].BuiltTarget(), testlogArg, panicArg, fuzzArg, testArgs)
 
 	if testCoverProfile != "" {
 		// Write coverage to temporary profile, for merging later.
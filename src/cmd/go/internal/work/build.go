@@ -55,6 +55,18 @@ will be written to that directory.
 The -i flag installs the packages that are dependencies of the target.
 The -i flag is deprecated. Compiled packages are cached automatically.
 
+The -cover flag builds the main packages into executables instrumented
+for coverage analysis. When an instrumented program exits, by returning
+from main.main or by calling os.Exit, it writes coverage data files to the
+directory named by the GOCOVERDIR environment variable. The command
+'go tool covdata' merges and subtracts such files, and converts them
+to the profile format read by 'go tool cover'. By default, the packages
+instrumented are those in the main module, or, in GOPATH mode, those named
+on the command line. The -coverpkg flag instead instruments the packages
+matching a comma-separated list of patterns, and the -covermode flag sets
+the mode of coverage analysis (set, count, or atomic) as described in
+'go help testflag'. Both imply -cover. Install and run accept the same flags.
+
 The build flags are shared by the build, clean, get, install, list, run,
 and test commands:
 
@@ -132,6 +144,11 @@ and test commands:
 		include path must be in the same directory as the Go package they are
 		included from, and overlays will not appear when binaries and tests are
 		run through go run and go test respectively.
+	-pgo file
+		specify the file path of a CPU profile in pprof format to use for
+		profile-guided optimization (PGO). The compiler uses the profile to
+		inline and devirtualize calls on hot paths more aggressively.
+		The special name "off" turns off PGO, which is also the default.
 	-pkgdir dir
 		install and load all packages from dir instead of the usual locations.
 		For example, when building with a non-standard configuration,
@@ -201,6 +218,8 @@ func init() {
 
 	AddBuildFlags(CmdBuild, DefaultBuildFlags)
 	AddBuildFlags(CmdInstall, DefaultBuildFlags)
+	AddCoverFlags(CmdBuild)
+	AddCoverFlags(CmdInstall)
 }
 
 // Note that flags consulted by other parts of the code
@@ -286,6 +305,7 @@ func AddBuildFlags(cmd *base.Command, mask BuildFlagMask) {
 	cmd.Flag.StringVar(&cfg.BuildContext.InstallSuffix, "installsuffix", "", "")
 	cmd.Flag.Var(&load.BuildLdflags, "ldflags", "")
 	cmd.Flag.BoolVar(&cfg.BuildLinkshared, "linkshared", false, "")
+	cmd.Flag.StringVar(&cfg.BuildPGO, "pgo", "", "")
 	cmd.Flag.StringVar(&cfg.BuildPkgdir, "pkgdir", "", "")
 	cmd.Flag.BoolVar(&cfg.BuildRace, "race", false, "")
 	cmd.Flag.BoolVar(&cfg.BuildMSan, "msan", false, "")
@@ -364,6 +384,7 @@ var pkgsFilter = func(pkgs []*load.Package) []*load.Package { return pkgs }
 var runtimeVersion = runtime.Version()
 
 func runBuild(ctx context.Context, cmd *base.Command, args []string) {
+	modload.InitWorkfile()
 	BuildInit()
 	var b Builder
 	b.Init()
@@ -400,6 +421,7 @@ func runBuild(ctx context.Context, cmd *base.Command, args []string) {
 	}
 
 	pkgs = omitTestOnly(pkgsFilter(pkgs))
+	PrepareCoverageBuild(pkgs)
 
 	// Special case -o /dev/null by not writing at all.
 	if cfg.BuildO == os.DevNull {
@@ -587,6 +609,7 @@ func runInstall(ctx context.Context, cmd *base.Command, args []string) {
 		}
 	}
 
+	modload.InitWorkfile()
 	BuildInit()
 	pkgs := load.PackagesAndErrors(ctx, load.PackageOpts{}, args)
 	if cfg.ModulesEnabled && !modload.HasModRoot() {
@@ -679,6 +702,7 @@ func InstallPackages(ctx context.Context, patterns []string, pkgs []*load.Packag
 		}
 	}
 	base.ExitIfErrors()
+	PrepareCoverageBuild(pkgs)
 
 	var b Builder
 	b.Init()
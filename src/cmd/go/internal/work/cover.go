@@ -0,0 +1,269 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Coverage instrumentation of programs built with 'go build -cover'.
+
+package work
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/cfg"
+	"cmd/go/internal/load"
+	"cmd/go/internal/modload"
+	"cmd/go/internal/str"
+)
+
+// AddCoverFlags adds the -cover, -covermode, and -coverpkg flags
+// accepted by the build, install, and run commands.
+// ('go test' has its own flags with the same names.)
+func AddCoverFlags(cmd *base.Command) {
+	cmd.Flag.BoolVar(&cfg.BuildCover, "cover", false, "")
+	cmd.Flag.Var(coverFlag{(*coverModeFlag)(&cfg.BuildCoverMode)}, "covermode", "")
+	cmd.Flag.Var(coverFlag{commaListFlag{&cfg.BuildCoverPkg}}, "coverpkg", "")
+}
+
+// A coverFlag is a flag.Value that also implies -cover.
+type coverFlag struct{ v flag.Value }
+
+func (f coverFlag) String() string { return f.v.String() }
+
+func (f coverFlag) Set(value string) error {
+	if err := f.v.Set(value); err != nil {
+		return err
+	}
+	cfg.BuildCover = true
+	return nil
+}
+
+type coverModeFlag string
+
+func (f *coverModeFlag) String() string { return string(*f) }
+func (f *coverModeFlag) Set(value string) error {
+	switch value {
+	case "", "set", "count", "atomic":
+		*f = coverModeFlag(value)
+		return nil
+	default:
+		return errors.New(`valid modes are "set", "count", or "atomic"`)
+	}
+}
+
+// A commaListFlag is a flag.Value representing a comma-separated list.
+type commaListFlag struct{ vals *[]string }
+
+func (f commaListFlag) String() string { return strings.Join(*f.vals, ",") }
+
+func (f commaListFlag) Set(value string) error {
+	if value == "" {
+		*f.vals = nil
+	} else {
+		*f.vals = strings.Split(value, ",")
+	}
+	return nil
+}
+
+// coverInit sets the default coverage mode and checks that
+// it is compatible with the other build flags.
+func coverInit() {
+	if !cfg.BuildCover {
+		return
+	}
+	if cfg.BuildCoverMode == "" {
+		cfg.BuildCoverMode = "set"
+		if cfg.BuildRace {
+			// Default coverage mode is atomic when -race is set.
+			cfg.BuildCoverMode = "atomic"
+		}
+	}
+	if cfg.BuildRace && cfg.BuildCoverMode != "atomic" {
+		base.Fatalf(`-covermode must be "atomic", not %q, when -race is enabled`, cfg.BuildCoverMode)
+	}
+	if cfg.BuildToolchainName == "gccgo" {
+		base.Fatalf("go %s: -cover is not supported with -compiler=gccgo", cfg.CmdName)
+	}
+}
+
+// PrepareCoverageBuild marks the packages selected for coverage
+// analysis among pkgs and their dependencies to be instrumented, and
+// arranges for the main packages among pkgs to register the counters
+// of the instrumented packages they link with runtime/coverage, which
+// writes them out when the program exits.
+//
+// By default, the packages selected are those in the main module (or
+// the workspace modules), or in GOPATH mode those named on the command
+// line; if -coverpkg is set, they are the packages matching its patterns.
+func PrepareCoverageBuild(pkgs []*load.Package) {
+	if !cfg.BuildCover {
+		return
+	}
+
+	var match []func(*load.Package) bool
+	if cfg.BuildCoverPkg != nil {
+		for _, pattern := range cfg.BuildCoverPkg {
+			match = append(match, load.MatchPackage(pattern, base.Cwd()))
+		}
+	} else {
+		match = []func(*load.Package) bool{defaultCoverMatch()}
+	}
+	matched := make([]bool, len(match))
+
+	for _, p := range load.PackageList(pkgs) {
+		haveMatch := false
+		for i := range match {
+			if match[i](p) {
+				matched[i] = true
+				haveMatch = true
+			}
+		}
+		if !haveMatch || !coverable(p) {
+			continue
+		}
+		p.Internal.CoverMode = cfg.BuildCoverMode
+		p.Internal.CoverVars = load.DeclareCoverVars(p, str.StringList(p.GoFiles, p.CgoFiles)...)
+		if cfg.BuildCoverMode == "atomic" {
+			// sync/atomic import is inserted by the cover tool. See #18486
+			ensureImport(p, "sync/atomic")
+		}
+	}
+
+	// Warn about -coverpkg arguments that are not actually used.
+	for i, pattern := range cfg.BuildCoverPkg {
+		if !matched[i] {
+			fmt.Fprintf(os.Stderr, "warning: no packages being built depend on matches for pattern %s\n", pattern)
+		}
+	}
+
+	// Each main package registers the covered packages it links,
+	// including itself, which it refers to without an import.
+	for _, pmain := range pkgs {
+		if pmain.Name != "main" {
+			continue
+		}
+		for _, p := range load.PackageList([]*load.Package{pmain}) {
+			if len(p.Internal.CoverVars) > 0 {
+				pmain.Internal.CoverPkgs = append(pmain.Internal.CoverPkgs, p)
+			}
+		}
+		if len(pmain.Internal.CoverPkgs) == 0 {
+			continue
+		}
+		ensureImport(pmain, "runtime/coverage")
+		// The covered packages are already loaded as dependencies of
+		// pmain, so add them directly: importing them by path from
+		// pmain's directory would not be allowed for internal packages.
+		imported := make(map[*load.Package]bool)
+		for _, p := range pmain.Internal.Imports {
+			imported[p] = true
+		}
+		for _, p := range pmain.Internal.CoverPkgs {
+			if p != pmain && !imported[p] {
+				pmain.Internal.Imports = append(pmain.Internal.Imports, p)
+			}
+		}
+	}
+}
+
+// defaultCoverMatch returns a function reporting whether a package is
+// covered when -coverpkg is not set.
+func defaultCoverMatch() func(*load.Package) bool {
+	if !cfg.ModulesEnabled {
+		return func(p *load.Package) bool { return p.Internal.CmdlinePkg }
+	}
+	inWorkspace := make(map[string]bool)
+	paths, _ := modload.WorkspaceModules()
+	for _, path := range paths {
+		inWorkspace[path] = true
+	}
+	return func(p *load.Package) bool {
+		return p.Module != nil && (p.Module.Main || inWorkspace[p.Module.Path])
+	}
+}
+
+// coverable reports whether p can be instrumented for coverage.
+func coverable(p *load.Package) bool {
+	// There is nothing to cover in package unsafe; it comes from the compiler.
+	if p.ImportPath == "unsafe" {
+		return false
+	}
+	// A package which only has test files can't be imported
+	// as a dependency, nor can it be instrumented for coverage.
+	if len(p.GoFiles)+len(p.CgoFiles) == 0 {
+		return false
+	}
+	if p.Standard {
+		switch {
+		case p.ImportPath == "runtime/coverage" || p.ImportPath == "internal/coverage":
+			// These packages record the counters of the others.
+			return false
+		case cfg.BuildCoverMode == "atomic" && p.ImportPath == "sync/atomic":
+			// Atomic coverage mode uses sync/atomic, so
+			// we can't also do coverage on it.
+			return false
+		case cfg.BuildRace && (p.ImportPath == "runtime" || strings.HasPrefix(p.ImportPath, "runtime/internal")):
+			// Instrumenting the runtime packages causes the race
+			// detector to be invoked before it has been initialized.
+			return false
+		}
+	}
+	return true
+}
+
+// ensureImport adds the package with the given import path
+// to the direct imports of p, if it is not already there.
+func ensureImport(p *load.Package, pkg string) {
+	for _, d := range p.Internal.Imports {
+		if d.ImportPath == pkg {
+			return
+		}
+	}
+
+	p1 := load.LoadImportWithFlags(pkg, p.Dir, p, &load.ImportStack{}, nil, 0)
+	if p1.Error != nil {
+		base.Fatalf("load %s: %v", pkg, p1.Error)
+	}
+
+	p.Internal.Imports = append(p.Internal.Imports, p1)
+}
+
+// coverMainGo returns the source of the file that 'go build -cover'
+// adds to the main package p to register the coverage counters of the
+// instrumented packages it links.
+func coverMainGo(p *load.Package) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by 'go build -cover'. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package main\n\nimport (\n")
+	fmt.Fprintf(&buf, "\t_coverage %q\n", "runtime/coverage")
+	for i, p1 := range p.Internal.CoverPkgs {
+		if p1 != p {
+			fmt.Fprintf(&buf, "\t_cover%d %q\n", i, p1.ImportPath)
+		}
+	}
+	fmt.Fprintf(&buf, ")\n\nfunc init() {\n")
+	for i, p1 := range p.Internal.CoverPkgs {
+		qual := ""
+		if p1 != p {
+			qual = fmt.Sprintf("_cover%d.", i)
+		}
+		files := make([]string, 0, len(p1.Internal.CoverVars))
+		for file := range p1.Internal.CoverVars {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			cv := p1.Internal.CoverVars[file]
+			v := qual + cv.Var
+			fmt.Fprintf(&buf, "\t_coverage.RegisterFile(%q, %q, %s.Count[:], %s.Pos[:], %s.NumStmt[:])\n", p1.Internal.CoverMode, cv.File, v, v, v)
+		}
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return buf.Bytes()
+}
@@ -281,6 +281,9 @@ func (b *Builder) buildActionID(a *Action) cache.ActionID {
 	if p.Internal.CoverMode != "" {
 		fmt.Fprintf(h, "cover %q %q\n", p.Internal.CoverMode, b.toolID("cover"))
 	}
+	for _, p1 := range p.Internal.CoverPkgs {
+		fmt.Fprintf(h, "coverpkg %q %q\n", p1.ImportPath, p1.Internal.CoverMode)
+	}
 	fmt.Fprintf(h, "modinfo %q\n", p.Internal.BuildInfo)
 
 	// Configuration specific to compiler toolchain.
@@ -289,6 +292,11 @@ func (b *Builder) buildActionID(a *Action) cache.ActionID {
 		base.Fatalf("buildActionID: unknown build toolchain %q", cfg.BuildToolchainName)
 	case "gc":
 		fmt.Fprintf(h, "compile %s %q %q\n", b.toolID("compile"), forcedGcflags, p.Internal.Gcflags)
+		if cfg.BuildPGO != "" {
+			// The profile is read by the compiler, so its contents
+			// (rather than its path) are what matter to the output.
+			fmt.Fprintf(h, "pgofile %s\n", b.fileHash(cfg.BuildPGO))
+		}
 		if len(p.SFiles) > 0 {
 			fmt.Fprintf(h, "asm %q %q %q\n", b.toolID("asm"), forcedAsmflags, p.Internal.Asmflags)
 		}
@@ -779,6 +787,13 @@ OverlayLoop:
 		gofiles = append(gofiles, objdir+"_gomod_.go")
 	}
 
+	if len(p.Internal.CoverPkgs) > 0 {
+		if err := b.writeFile(objdir+"_covermain_.go", coverMainGo(p)); err != nil {
+			return err
+		}
+		gofiles = append(gofiles, objdir+"_covermain_.go")
+	}
+
 	// Compile Go.
 	objpkg := objdir + "_pkg_.a"
 	ofile, out, err := BuildToolchain.gc(b, a, objpkg, icfg.Bytes(), embedcfg, symabis, len(sfiles) > 0, gofiles)
@@ -23,6 +23,7 @@ func BuildInit() {
 	modload.Init()
 	instrumentInit()
 	buildModeInit()
+	coverInit()
 	if err := fsys.Init(base.Cwd()); err != nil {
 		base.Fatalf("go: %v", err)
 	}
@@ -39,6 +40,25 @@ func BuildInit() {
 		cfg.BuildPkgdir = p
 	}
 
+	// Make sure -pgo is absolute too, and that it exists, so that
+	// a mistyped profile path is reported once instead of by every
+	// compiler invocation.
+	if cfg.BuildPGO == "off" {
+		cfg.BuildPGO = ""
+	}
+	if cfg.BuildPGO != "" {
+		p, err := filepath.Abs(cfg.BuildPGO)
+		if err == nil {
+			_, err = fsys.Stat(p)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "go %s: evaluating -pgo: %v\n", flag.Args()[0], err)
+			base.SetExitStatus(2)
+			base.Exit()
+		}
+		cfg.BuildPGO = p
+	}
+
 	// Make sure CC and CXX are absolute paths
 	for _, key := range []string{"CC", "CXX"} {
 		if path := cfg.Getenv(key); !filepath.IsAbs(path) && path != "" && path != filepath.Base(path) {
@@ -0,0 +1,72 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go work init
+
+package workcmd
+
+import (
+	"context"
+	"path/filepath"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/fsys"
+	"cmd/go/internal/modload"
+)
+
+var cmdInit = &base.Command{
+	UsageLine: "go work init [moddirs]",
+	Short:     "initialize workspace file",
+	Long: `
+Init initializes and writes a new go.work file in the
+current directory, in effect creating a new workspace at the current
+directory.
+
+go work init optionally accepts paths to the workspace modules as
+arguments. If the argument is omitted, an empty workspace with no
+modules will be created.
+
+Each argument path is added to a use directive in the go.work file. The
+current go version will also be listed in the go.work file.
+
+See the workspaces design proposal at
+https://go.googlesource.com/proposal/+/master/design/45713-workspace.md for
+more information.
+`,
+	Run: runInit,
+}
+
+func init() {
+	base.AddModCommonFlags(&cmdInit.Flag)
+}
+
+func runInit(ctx context.Context, cmd *base.Command, args []string) {
+	modload.ForceUseModules = true
+
+	gowork := filepath.Join(base.Cwd(), "go.work")
+	if _, err := fsys.Stat(gowork); err == nil {
+		base.Fatalf("go: %s already exists", base.ShortPath(gowork))
+	}
+
+	wf, err := modload.ParseWorkFile(gowork, nil)
+	if err != nil {
+		base.Fatalf("go: internal error: %v", err)
+	}
+	if err := wf.AddGoStmt(modload.LatestGoVersion()); err != nil {
+		base.Fatalf("go: internal error: %v", err)
+	}
+	workDir := filepath.Dir(gowork)
+	for _, dir := range args {
+		if fi, err := fsys.Stat(filepath.Join(dir, "go.mod")); err != nil || fi.IsDir() {
+			base.Errorf("go: directory %s does not contain a go.mod file", dir)
+			continue
+		}
+		wf.AddUse(usePath(workDir, dir))
+	}
+	base.ExitIfErrors()
+
+	if err := modload.WriteWorkFile(gowork, wf); err != nil {
+		base.Fatalf("go: %v", err)
+	}
+}
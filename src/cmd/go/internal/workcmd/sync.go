@@ -0,0 +1,118 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go work sync
+
+package workcmd
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/lockedfile"
+	"cmd/go/internal/modfetch"
+	"cmd/go/internal/modload"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+var cmdSync = &base.Command{
+	UsageLine: "go work sync",
+	Short:     "sync workspace build list to modules",
+	Long: `Sync syncs the workspace's build list back to the
+workspace's modules.
+
+The workspace's build list is the set of versions of all the
+(transitive) dependency modules used to do builds in the workspace. go
+work sync generates that build list using the Minimal Version Selection
+algorithm, and then syncs those versions back to each of modules
+specified in the workspace (with use directives).
+
+The syncing is done by sequentially upgrading each of the dependency
+modules specified in a workspace module to the version in the build list
+if the dependency module's version is not already the same as the build
+list's version. Note that Minimal Version Selection guarantees that the
+build list's version of each module is always the same or higher than
+that in each workspace module. The checksums the workspace knows for the
+modules in its build list are added to each updated module's go.sum file.
+`,
+	Run: runSync,
+}
+
+func init() {
+	base.AddModCommonFlags(&cmdSync.Flag)
+}
+
+func runSync(ctx context.Context, cmd *base.Command, args []string) {
+	if len(args) != 0 {
+		base.Fatalf("go work sync: sync takes no arguments")
+	}
+	workFilePath() // ensure that we are in workspace mode
+	modload.ForceUseModules = true
+	modload.RootMode = modload.NeedRoot
+
+	const defaultGoVersion = ""
+	mg := modload.LoadModGraph(ctx, defaultGoVersion)
+
+	paths, dirs := modload.WorkspaceModules()
+	inWorkspace := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		inWorkspace[path] = true
+	}
+
+	// Every module in the build list is a candidate for the go.sum files of
+	// the workspace modules: their own builds need a subset of them.
+	keep := make(map[module.Version]bool)
+	for _, m := range mg.BuildList() {
+		if m.Version != "" && !inWorkspace[m.Path] {
+			keep[m] = true
+			keep[module.Version{Path: m.Path, Version: m.Version + "/go.mod"}] = true
+		}
+	}
+
+	errNoChange := errors.New("no update needed")
+	for _, dir := range dirs {
+		gomod := filepath.Join(dir, "go.mod")
+		err := lockedfile.Transform(gomod, func(data []byte) ([]byte, error) {
+			f, err := modfile.Parse(gomod, data, nil)
+			if err != nil {
+				return nil, err
+			}
+			var upgrades []module.Version
+			for _, r := range f.Require {
+				if inWorkspace[r.Mod.Path] {
+					// The workspace module is used from its directory,
+					// whatever version the go.mod file requires.
+					continue
+				}
+				if v := mg.Selected(r.Mod.Path); semver.Compare(v, r.Mod.Version) > 0 {
+					upgrades = append(upgrades, module.Version{Path: r.Mod.Path, Version: v})
+				}
+			}
+			if len(upgrades) == 0 {
+				return nil, errNoChange
+			}
+			for _, m := range upgrades {
+				if err := f.AddRequire(m.Path, m.Version); err != nil {
+					return nil, err
+				}
+			}
+			f.Cleanup()
+			return f.Format()
+		})
+		if err == errNoChange {
+			continue
+		}
+		if err != nil {
+			base.Fatalf("go: updating %s: %v", base.ShortPath(gomod), err)
+		}
+		if err := modfetch.AddGoSums(filepath.Join(dir, "go.sum"), keep); err != nil {
+			base.Fatalf("go: updating %s: %v", base.ShortPath(filepath.Join(dir, "go.sum")), err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go work use
+
+package workcmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/fsys"
+	"cmd/go/internal/modload"
+)
+
+var cmdUse = &base.Command{
+	UsageLine: "go work use [-r] moddirs",
+	Short:     "add modules to workspace file",
+	Long: `
+Use provides a command-line interface for adding
+directories, optionally recursively, to a go.work file.
+
+A use directive will be added to the go.work file for each argument
+directory listed on the command line, if it contains a go.mod file,
+or removed from the go.work file if it does not.
+
+The -r flag searches recursively for modules in the argument
+directories, and the use command operates as if each of the directories
+were specified as arguments: namely, use directives will be added for
+directories that contain a module, and removed for directories that
+no longer do.
+`,
+}
+
+var useR = cmdUse.Flag.Bool("r", false, "")
+
+func init() {
+	cmdUse.Run = runUse // break init cycle
+
+	base.AddModCommonFlags(&cmdUse.Flag)
+}
+
+func runUse(ctx context.Context, cmd *base.Command, args []string) {
+	modload.ForceUseModules = true
+
+	gowork := workFilePath()
+	wf, err := modload.ReadWorkFile(gowork)
+	if err != nil {
+		base.Fatalf("go: %v", err)
+	}
+	workDir := filepath.Dir(gowork)
+
+	// haveDirs maps the absolute directory of each existing use directive
+	// to the paths with which the directive refers to it.
+	haveDirs := make(map[string][]string)
+	for _, u := range wf.Use {
+		dir := useDir(workDir, u.Path)
+		haveDirs[dir] = append(haveDirs[dir], u.Path)
+	}
+
+	addDirs := make(map[string]bool)
+	removeDirs := make(map[string]bool)
+	lookDir := func(dir string) {
+		abs := useDir(base.Cwd(), filepath.ToSlash(dir))
+		if fi, err := fsys.Stat(filepath.Join(abs, "go.mod")); err == nil && !fi.IsDir() {
+			addDirs[abs] = true
+			delete(removeDirs, abs)
+		} else if len(haveDirs[abs]) > 0 {
+			removeDirs[abs] = true
+		}
+	}
+
+	for _, dir := range args {
+		if !*useR {
+			lookDir(dir)
+			continue
+		}
+
+		// Add or remove entries for any subdirectories that still exist.
+		err := fsys.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				if info.Mode()&fs.ModeSymlink != 0 {
+					if target, err := fsys.Stat(path); err == nil && target.IsDir() {
+						fmt.Fprintf(os.Stderr, "warning: ignoring symlink %s\n", path)
+					}
+				}
+				return nil
+			}
+			lookDir(path)
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			base.Errorf("go: %v", err)
+		}
+
+		// Remove entries for subdirectories that no longer exist.
+		// Because they don't exist, they will be skipped by Walk.
+		abs := useDir(base.Cwd(), filepath.ToSlash(dir))
+		for d := range haveDirs {
+			if d == abs || strings.HasPrefix(d, abs+string(filepath.Separator)) {
+				lookDir(d)
+			}
+		}
+	}
+	base.ExitIfErrors()
+
+	for dir := range removeDirs {
+		for _, path := range haveDirs[dir] {
+			wf.DropUse(path)
+		}
+	}
+	var add []string
+	for dir := range addDirs {
+		if len(haveDirs[dir]) == 0 {
+			add = append(add, dir)
+		}
+	}
+	sort.Strings(add)
+	for _, dir := range add {
+		wf.AddUse(usePath(workDir, dir))
+	}
+	if err := modload.WriteWorkFile(gowork, wf); err != nil {
+		base.Fatalf("go: %v", err)
+	}
+}
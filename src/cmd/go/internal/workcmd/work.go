@@ -0,0 +1,125 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package workcmd implements the ``go work'' command.
+package workcmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/modload"
+)
+
+var CmdWork = &base.Command{
+	UsageLine: "go work",
+	Short:     "workspace maintenance",
+	Long: `Go workspace provides access to operations on workspaces.
+
+Note that support for workspaces is built into many other commands, not
+just 'go work'.
+
+See 'go help modules' for information about Go's module system of which
+workspaces are a part.
+
+A workspace is specified by a go.work file that specifies a set of
+module directories with the "use" directive. These modules are used as
+root modules by the go command for builds and related operations. A
+workspace that does not specify modules to be used cannot be used to do
+builds from local modules.
+
+go.work files are line-oriented. Each line holds a single directive,
+made up of a keyword followed by arguments. For example:
+
+	go 1.17
+
+	use ../foo/bar
+	use ./baz
+
+	replace example.com/foo v1.2.3 => example.com/bar v1.4.5
+
+The leading keyword can be factored out of adjacent lines to create a block,
+like in Go imports.
+
+	use (
+	  ../foo/bar
+	  ./baz
+	)
+
+The use directive specifies a module to be included in the workspace's
+set of main modules. The argument to the use directive is the directory
+containing the module's go.mod file.
+
+The go directive specifies the version of Go the file was written at. It
+is possible there may be future changes in the semantics of workspaces
+that could be controlled by this version, but for now the version
+specified has no effect.
+
+The replace directive has the same syntax as the replace directive in a
+go.mod file and takes precedence over replaces in go.mod files. It is
+primarily intended to override conflicting replaces in different workspace
+modules.
+
+In workspace mode, the go command finds the main module as usual, from
+the current directory, among the modules listed in go.work, or uses the
+first one listed. Each of the other modules is loaded from its directory,
+as if replaced there, and required by the main module. Packages from all
+of them can be built, listed, and tested together. The go command reads,
+but never writes, the go.mod and go.sum files of the modules in a
+workspace; checksums that none of their go.sum files record are written
+to a go.work.sum file next to go.work.
+
+To determine whether the go command is operating in workspace mode, use
+the "go env GOWORK" command. This will specify the workspace file being
+used.
+`,
+
+	Commands: []*base.Command{
+		cmdEdit,
+		cmdInit,
+		cmdSync,
+		cmdUse,
+	},
+}
+
+// workFilePath returns the path of the go.work file of the current
+// workspace. It calls base.Fatalf if there is none.
+func workFilePath() string {
+	modload.InitWorkfile()
+	gowork := modload.WorkFilePath()
+	if gowork == "" {
+		base.Fatalf("go: no go.work file found\n\t(run 'go work init' first or specify path using GOWORK environment variable)")
+	}
+	return gowork
+}
+
+// usePath returns the path with which a use directive in a go.work file in
+// workDir refers to the directory dir: a path relative to workDir, starting
+// with "./" or "../", if there is one, or else the absolute path of dir.
+func usePath(workDir, dir string) string {
+	abs := dir
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(base.Cwd(), dir)
+	}
+	rel, err := filepath.Rel(workDir, abs)
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return rel
+	}
+	return "./" + rel
+}
+
+// useDir returns the absolute directory named by a use directive with the
+// given path in a go.work file in workDir.
+func useDir(workDir, path string) string {
+	dir := filepath.FromSlash(path)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(workDir, dir)
+	}
+	return filepath.Clean(dir)
+}
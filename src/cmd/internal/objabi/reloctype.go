@@ -234,6 +234,32 @@ const (
 	// address using an AUIPC + S-type instruction pair.
 	R_RISCV_TLS_IE_STYPE
 
+	// Loong64.
+
+	// R_ADDRLOONG64 resolves to the low 12 bits of a PC-relative external
+	// address, by encoding it into the instruction following a PCALAU12I.
+	R_ADDRLOONG64
+
+	// R_ADDRLOONG64U resolves to the sign-adjusted "upper" 20 bits of a
+	// PC-relative external address, by encoding it into a PCALAU12I instruction.
+	R_ADDRLOONG64U
+
+	// R_ADDRLOONG64TLS resolves to the low 12 bits of a TLS address (offset from
+	// thread pointer), by encoding it into the instruction.
+	R_ADDRLOONG64TLS
+
+	// R_ADDRLOONG64TLSU resolves to the high 20 bits of a TLS address (offset from
+	// thread pointer), by encoding it into the instruction.
+	R_ADDRLOONG64TLSU
+
+	// R_CALLLOONG64 resolves to the PC-relative target address of a BL
+	// instruction, by encoding the 26-bit word offset into the instruction.
+	R_CALLLOONG64
+
+	// R_JMPLOONG64 resolves to the PC-relative target address of a B
+	// instruction, by encoding the 26-bit word offset into the instruction.
+	R_JMPLOONG64
+
 	// R_PCRELDBL relocates s390x 2-byte aligned PC-relative addresses.
 	// TODO(mundaym): remove once variants can be serialized - see issue 14218.
 	R_PCRELDBL
@@ -274,7 +300,7 @@ const (
 // the target address in register or memory.
 func (r RelocType) IsDirectCall() bool {
 	switch r {
-	case R_CALL, R_CALLARM, R_CALLARM64, R_CALLMIPS, R_CALLPOWER, R_CALLRISCV:
+	case R_CALL, R_CALLARM, R_CALLARM64, R_CALLLOONG64, R_CALLMIPS, R_CALLPOWER, R_CALLRISCV:
 		return true
 	}
 	return false
@@ -287,7 +313,7 @@ func (r RelocType) IsDirectCall() bool {
 // the target address in register or memory.
 func (r RelocType) IsDirectJump() bool {
 	switch r {
-	case R_JMPMIPS:
+	case R_JMPLOONG64, R_JMPMIPS:
 		return true
 	}
 	return false
@@ -34,6 +34,19 @@ func MSanSupported(goos, goarch string) bool {
 	}
 }
 
+// FuzzInstrumented reports whether fuzzing on goos/goarch uses coverage
+// instrumentation. (FuzzInstrumented implies that 'go test -fuzz' is also
+// supported, but 'go test -fuzz' may also run without instrumentation.)
+func FuzzInstrumented(goos, goarch string) bool {
+	switch goarch {
+	case "amd64", "arm64":
+		// The linker only lays out the counter section for ELF targets.
+		return goos == "linux" || goos == "freebsd"
+	default:
+		return false
+	}
+}
+
 // MustLinkExternal reports whether goos/goarch requires external linking.
 // (This is the opposite of internal/testenv.CanInternalLink. Keep them in sync.)
 func MustLinkExternal(goos, goarch string) bool {
@@ -2529,6 +2529,7 @@ func (ctxt *Link) address() []*sym.Segment {
 	var noptr *sym.Section
 	var bss *sym.Section
 	var noptrbss *sym.Section
+	var fuzzCounters *sym.Section
 	for i, s := range Segdata.Sections {
 		if (ctxt.IsELF || ctxt.HeadType == objabi.Haix) && s.Name == ".tbss" {
 			continue
@@ -2552,6 +2553,9 @@ func (ctxt *Link) address() []*sym.Segment {
 		if s.Name == ".noptrbss" {
 			noptrbss = s
 		}
+		if s.Name == "__libfuzzer_extra_counters" {
+			fuzzCounters = s
+		}
 	}
 
 	// Assign Segdata's Filelen omitting the BSS. We do this here
@@ -2667,6 +2671,18 @@ func (ctxt *Link) address() []*sym.Segment {
 	ctxt.xdefine("runtime.enoptrbss", sym.SNOPTRBSS, int64(noptrbss.Vaddr+noptrbss.Length))
 	ctxt.xdefine("runtime.end", sym.SBSS, int64(Segdata.Vaddr+Segdata.Length))
 
+	// internal/fuzz reads the coverage counters through _counters and
+	// _ecounters. If nothing was instrumented, point both at the same
+	// address so that the counter slice is empty.
+	if ldr.Lookup("internal/fuzz._counters", 0) != 0 {
+		kind, sect, start, end := sym.SNOPTRBSS, noptrbss, noptrbss.Vaddr+noptrbss.Length, noptrbss.Vaddr+noptrbss.Length
+		if fuzzCounters != nil {
+			kind, sect, start, end = sym.SLIBFUZZER_EXTRA_COUNTER, fuzzCounters, fuzzCounters.Vaddr, fuzzCounters.Vaddr+fuzzCounters.Length
+		}
+		ldr.SetSymSect(ctxt.xdefine("internal/fuzz._counters", kind, int64(start)), sect)
+		ldr.SetSymSect(ctxt.xdefine("internal/fuzz._ecounters", kind, int64(end)), sect)
+	}
+
 	if ctxt.IsSolaris() {
 		// On Solaris, in the runtime it sets the external names of the
 		// end symbols. Unset them and define separate symbols, so we
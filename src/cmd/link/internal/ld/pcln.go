@@ -148,7 +148,7 @@ func computeDeferReturn(ctxt *Link, deferReturnSym, s loader.Sym) uint32 {
 				switch target.Arch.Family {
 				case sys.AMD64, sys.I386:
 					deferreturn--
-				case sys.PPC64, sys.ARM, sys.ARM64, sys.MIPS, sys.MIPS64:
+				case sys.PPC64, sys.ARM, sys.ARM64, sys.Loong64, sys.MIPS, sys.MIPS64:
 					// no change
 				case sys.RISCV64:
 					// TODO(jsing): The JALR instruction is marked with
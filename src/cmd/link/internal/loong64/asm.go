@@ -0,0 +1,85 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package loong64
+
+import (
+	"cmd/internal/objabi"
+	"cmd/internal/sys"
+	"cmd/link/internal/ld"
+	"cmd/link/internal/loader"
+	"cmd/link/internal/sym"
+	"log"
+)
+
+func gentext(ctxt *ld.Link, ldr *loader.Loader) {}
+
+func elfreloc1(ctxt *ld.Link, out *ld.OutBuf, ldr *loader.Loader, s loader.Sym, r loader.ExtReloc, ri int, sectoff int64) bool {
+	// External linking is not supported on loong64 yet.
+	return false
+}
+
+func elfsetupplt(ctxt *ld.Link, plt, gotplt *loader.SymbolBuilder, dynamic loader.Sym) {
+	return
+}
+
+func machoreloc1(*sys.Arch, *ld.OutBuf, *loader.Loader, loader.Sym, loader.ExtReloc, int64) bool {
+	return false
+}
+
+func archreloc(target *ld.Target, ldr *loader.Loader, syms *ld.ArchSyms, r loader.Reloc, s loader.Sym, val int64) (o int64, nExtReloc int, ok bool) {
+	if target.IsExternal() {
+		return val, 0, false
+	}
+
+	const isOk = true
+	const noExtReloc = 0
+	rs := r.Sym()
+	rs = ldr.ResolveABIAlias(rs)
+	switch r.Type() {
+	case objabi.R_ADDRLOONG64U:
+		// pcalau12i: si20 = ((S + A + 0x800) >> 12) - (PC >> 12)
+		pc := ldr.SymValue(s) + int64(r.Off())
+		t := (ldr.SymValue(rs)+r.Add()+0x800)>>12 - pc>>12
+		if t < -1<<19 || t >= 1<<19 {
+			ldr.Errorf(s, "program too large, address relocation distance = %d", t)
+		}
+		return int64(val&0xfe00001f | (t&0xfffff)<<5), noExtReloc, isOk
+	case objabi.R_ADDRLOONG64:
+		// Low 12 bits of S + A, sign-extended by the load, store or addi.d.
+		t := ldr.SymValue(rs) + r.Add()
+		return int64(val&0xffc003ff | (t&0xfff)<<10), noExtReloc, isOk
+	case objabi.R_ADDRLOONG64TLSU:
+		// The thread pointer points at the start of the TLS block.
+		t := ldr.SymValue(rs) + r.Add()
+		if t < 0 || t >= 1<<31 {
+			ldr.Errorf(s, "TLS offset out of range %d", t)
+		}
+		return int64(val&0xfe00001f | ((t>>12)&0xfffff)<<5), noExtReloc, isOk
+	case objabi.R_ADDRLOONG64TLS:
+		t := ldr.SymValue(rs) + r.Add()
+		return int64(val&0xffc003ff | (t&0xfff)<<10), noExtReloc, isOk
+	case objabi.R_CALLLOONG64,
+		objabi.R_JMPLOONG64:
+		// offs26 = (S + A - PC) >> 2, stored as offs[15:0] in bits 25:10
+		// and offs[25:16] in bits 9:0.
+		pc := ldr.SymValue(s) + int64(r.Off())
+		t := (ldr.SymValue(rs) + r.Add() - pc) >> 2
+		if t < -1<<25 || t >= 1<<25 {
+			ldr.Errorf(s, "program too large, call relocation distance = %d", t)
+		}
+		return int64(val&0xfc000000 | (t&0xffff)<<10 | (t>>16)&0x3ff), noExtReloc, isOk
+	}
+
+	return val, 0, false
+}
+
+func archrelocvariant(*ld.Target, *loader.Loader, loader.Reloc, sym.RelocVariant, loader.Sym, int64, []byte) int64 {
+	log.Fatalf("archrelocvariant")
+	return -1
+}
+
+func extreloc(target *ld.Target, ldr *loader.Loader, r loader.Reloc, s loader.Sym) (loader.ExtReloc, bool) {
+	return loader.ExtReloc{}, false
+}
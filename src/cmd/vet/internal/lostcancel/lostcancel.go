@@ -4,6 +4,10 @@
 
 // Package lostcancel defines an Analyzer that checks for failure to
 // call a context cancellation function.
+//
+// It is a copy of golang.org/x/tools/go/analysis/passes/lostcancel
+// that also knows about the "Cause" variants of the context functions.
+// Switch vet back to the vendored analyzer once x/tools has them.
 package lostcancel
 
 import (
@@ -21,8 +25,8 @@ import (
 const Doc = `check cancel func returned by context.WithCancel is called
 
 The cancellation function returned by context.WithCancel, WithTimeout,
-and WithDeadline must be called or the new context will remain live
-until its parent context is cancelled.
+WithDeadline and variants such as WithCancelCause must be called or the
+new context will remain live until its parent context is cancelled.
 (The background context is never cancelled.)`
 
 var Analyzer = &analysis.Analyzer{
@@ -192,14 +196,16 @@ func hasImport(pkg *types.Package, path string) bool {
 }
 
 // isContextWithCancel reports whether n is one of the qualified identifiers
-// context.With{Cancel,Timeout,Deadline}.
+// context.With{Cancel,Timeout,Deadline} and their "Cause" variants.
 func isContextWithCancel(info *types.Info, n ast.Node) bool {
 	sel, ok := n.(*ast.SelectorExpr)
 	if !ok {
 		return false
 	}
 	switch sel.Sel.Name {
-	case "WithCancel", "WithTimeout", "WithDeadline":
+	case "WithCancel", "WithCancelCause",
+		"WithTimeout", "WithTimeoutCause",
+		"WithDeadline", "WithDeadlineCause":
 	default:
 		return false
 	}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"cmd/internal/objabi"
+	"cmd/vet/internal/lostcancel"
 
 	"golang.org/x/tools/go/analysis/unitchecker"
 
@@ -22,7 +23,6 @@ import (
 	"golang.org/x/tools/go/analysis/passes/httpresponse"
 	"golang.org/x/tools/go/analysis/passes/ifaceassert"
 	"golang.org/x/tools/go/analysis/passes/loopclosure"
-	"golang.org/x/tools/go/analysis/passes/lostcancel"
 	"golang.org/x/tools/go/analysis/passes/nilfunc"
 	"golang.org/x/tools/go/analysis/passes/printf"
 	"golang.org/x/tools/go/analysis/passes/shift"
@@ -4,7 +4,10 @@
 
 package copylock
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 func BadFunc() {
 	var x *sync.Mutex
@@ -13,3 +16,9 @@ func BadFunc() {
 	p = &y
 	*p = *x // ERROR "assignment copies lock value to \*p: sync.Mutex"
 }
+
+func BadAtomic() {
+	var x atomic.Int64
+	y := x // ERROR "assignment copies lock value to y: sync/atomic.Int64 contains sync/atomic.noCopy"
+	y.Store(1)
+}
@@ -12,3 +12,15 @@ func _() {
 		_ = cancel
 	}
 } // ERROR "this return statement may be reached without using the cancel var defined on line 10"
+
+func _() {
+	var _, cancel = context.WithTimeoutCause(context.Background(), 0, nil) // ERROR "the cancel function is not used on all paths \(possible context leak\)"
+	if false {
+		_ = cancel
+	}
+} // ERROR "this return statement may be reached without using the cancel var defined on line 17"
+
+func _() {
+	ctx, _ := context.WithCancelCause(context.Background()) // ERROR "the cancel function returned by context.WithCancelCause should be called, not discarded, to avoid a context leak"
+	_ = ctx
+}
@@ -80,6 +80,12 @@ type Reader struct {
 	buf          [512]byte
 	err          error
 	multistream  bool
+
+	// Set by NewReaderIndex; see Seek.
+	rs      io.ReadSeeker
+	index   *Index
+	pos     int64 // uncompressed offset
+	partial bool  // reading from the middle of a member
 }
 
 // NewReader creates a new Reader reading the given reader.
@@ -251,6 +257,7 @@ func (z *Reader) Read(p []byte) (n int, err error) {
 	n, z.err = z.decompressor.Read(p)
 	z.digest = crc32.Update(z.digest, crc32.IEEETable, p[:n])
 	z.size += uint32(n)
+	z.pos += int64(n)
 	if z.err != io.EOF {
 		// In the normal case we return here.
 		return n, z.err
@@ -263,11 +270,11 @@ func (z *Reader) Read(p []byte) (n int, err error) {
 	}
 	digest := le.Uint32(z.buf[:4])
 	size := le.Uint32(z.buf[4:8])
-	if digest != z.digest || size != z.size {
+	if !z.partial && (digest != z.digest || size != z.size) {
 		z.err = ErrChecksum
 		return n, z.err
 	}
-	z.digest, z.size = 0, 0
+	z.digest, z.size, z.partial = 0, 0, false
 
 	// File is ok; check if there is another.
 	if !z.multistream {
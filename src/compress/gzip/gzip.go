@@ -36,6 +36,8 @@ type Writer struct {
 	closed      bool
 	buf         [10]byte
 	err         error
+	multistream bool
+	par         *parallel // set by SetConcurrency
 }
 
 // NewWriter returns a new Writer.
@@ -71,20 +73,28 @@ func (z *Writer) init(w io.Writer, level int) {
 	if compressor != nil {
 		compressor.Reset(w)
 	}
+	par := z.par
+	if par != nil {
+		par.reset(w)
+		w = &par.cw
+	}
 	*z = Writer{
 		Header: Header{
 			OS: 255, // unknown
 		},
-		w:          w,
-		level:      level,
-		compressor: compressor,
+		w:           w,
+		level:       level,
+		compressor:  compressor,
+		multistream: z.multistream,
+		par:         par,
 	}
 }
 
 // Reset discards the Writer z's state and makes it equivalent to the
 // result of its original state from NewWriter or NewWriterLevel, but
 // writing to w instead. This permits reusing a Writer rather than
-// allocating a new one.
+// allocating a new one. Settings made by SetConcurrency and Multistream
+// are kept.
 func (z *Writer) Reset(w io.Writer) {
 	z.init(w, z.level)
 }
@@ -134,17 +144,11 @@ func (z *Writer) writeString(s string) (err error) {
 	return err
 }
 
-// Write writes a compressed form of p to the underlying io.Writer. The
-// compressed bytes are not necessarily flushed until the Writer is closed.
-func (z *Writer) Write(p []byte) (int, error) {
-	if z.err != nil {
-		return 0, z.err
-	}
-	var n int
-	// Write the GZIP header lazily.
-	if !z.wroteHeader {
-		z.wroteHeader = true
-		z.buf = [10]byte{0: gzipID1, 1: gzipID2, 2: gzipDeflate}
+// writeHeader writes a GZIP header to z.w. If full is false, the
+// fields of z.Header other than OS are omitted.
+func (z *Writer) writeHeader(full bool) error {
+	z.buf = [10]byte{0: gzipID1, 1: gzipID2, 2: gzipDeflate}
+	if full {
 		if z.Extra != nil {
 			z.buf[3] |= 0x04
 		}
@@ -159,33 +163,52 @@ func (z *Writer) Write(p []byte) (int, error) {
 			// modified time is not set.
 			le.PutUint32(z.buf[4:8], uint32(z.ModTime.Unix()))
 		}
-		if z.level == BestCompression {
-			z.buf[8] = 2
-		} else if z.level == BestSpeed {
-			z.buf[8] = 4
-		}
-		z.buf[9] = z.OS
-		_, z.err = z.w.Write(z.buf[:10])
-		if z.err != nil {
-			return 0, z.err
+	}
+	if z.level == BestCompression {
+		z.buf[8] = 2
+	} else if z.level == BestSpeed {
+		z.buf[8] = 4
+	}
+	z.buf[9] = z.OS
+	if _, err := z.w.Write(z.buf[:10]); err != nil {
+		return err
+	}
+	if !full {
+		return nil
+	}
+	if z.Extra != nil {
+		if err := z.writeBytes(z.Extra); err != nil {
+			return err
 		}
-		if z.Extra != nil {
-			z.err = z.writeBytes(z.Extra)
-			if z.err != nil {
-				return 0, z.err
-			}
+	}
+	if z.Name != "" {
+		if err := z.writeString(z.Name); err != nil {
+			return err
 		}
-		if z.Name != "" {
-			z.err = z.writeString(z.Name)
-			if z.err != nil {
-				return 0, z.err
-			}
+	}
+	if z.Comment != "" {
+		if err := z.writeString(z.Comment); err != nil {
+			return err
 		}
-		if z.Comment != "" {
-			z.err = z.writeString(z.Comment)
-			if z.err != nil {
-				return 0, z.err
-			}
+	}
+	return nil
+}
+
+// Write writes a compressed form of p to the underlying io.Writer. The
+// compressed bytes are not necessarily flushed until the Writer is closed.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.par != nil {
+		return z.writeConcurrent(p)
+	}
+	var n int
+	// Write the GZIP header lazily.
+	if !z.wroteHeader {
+		z.wroteHeader = true
+		if z.err = z.writeHeader(true); z.err != nil {
+			return 0, z.err
 		}
 		if z.compressor == nil {
 			z.compressor, _ = flate.NewWriter(z.w, z.level)
@@ -212,6 +235,10 @@ func (z *Writer) Flush() error {
 	if z.closed {
 		return nil
 	}
+	if z.par != nil {
+		z.err = z.flushConcurrent(false)
+		return z.err
+	}
 	if !z.wroteHeader {
 		z.Write(nil)
 		if z.err != nil {
@@ -233,6 +260,10 @@ func (z *Writer) Close() error {
 		return nil
 	}
 	z.closed = true
+	if z.par != nil {
+		z.err = z.flushConcurrent(true)
+		return z.err
+	}
 	if !z.wroteHeader {
 		z.Write(nil)
 		if z.err != nil {
@@ -7,7 +7,9 @@ package gzip
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
+	"os"
 	"reflect"
 	"testing"
 	"time"
@@ -273,3 +275,135 @@ func TestLimitedWrite(t *testing.T) {
 		}
 	}
 }
+
+// writeConcurrent compresses data with a concurrent Writer, writing it
+// in uneven pieces, and returns the output and the Writer's Index.
+func writeConcurrent(t *testing.T, data []byte, blockSize, blocks int, multistream bool) ([]byte, *Index) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Name = "name"
+	if err := w.SetConcurrency(blockSize, blocks); err != nil {
+		t.Fatal(err)
+	}
+	w.Multistream(multistream)
+	for p := data; len(p) > 0; {
+		n := 1234
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), w.Index()
+}
+
+func TestWriterConcurrent(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/Isaac.Newton-Opticks.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, multistream := range []bool{false, true} {
+		for _, size := range []int{0, 100, 64 << 10, len(data)} {
+			t.Run(fmt.Sprintf("multistream=%v/size=%d", multistream, size), func(t *testing.T) {
+				out, idx := writeConcurrent(t, data[:size], 10000, 4, multistream)
+				r, err := NewReader(bytes.NewReader(out))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if r.Name != "name" {
+					t.Errorf("Name = %q, want %q", r.Name, "name")
+				}
+				// A single-stream file must decompress as one member.
+				r.Multistream(multistream)
+				got, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, data[:size]) {
+					t.Fatalf("got %d bytes, want %d", len(got), size)
+				}
+				if idx.Size != int64(size) || idx.Multistream != multistream {
+					t.Errorf("Index: Size = %d, Multistream = %v; want %d, %v", idx.Size, idx.Multistream, size, multistream)
+				}
+				if want := (size + 9999) / 10000; len(idx.Blocks) < want {
+					t.Errorf("Index has %d blocks, want at least %d", len(idx.Blocks), want)
+				}
+			})
+		}
+	}
+}
+
+func TestWriterConcurrentFlush(t *testing.T) {
+	for _, multistream := range []bool{false, true} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		w.SetConcurrency(1<<10, 2)
+		w.Multistream(multistream)
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		msg := bytes.Repeat([]byte("hello, world\n"), 500)
+		w.Write(msg)
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		r, err := NewReader(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, len(msg))
+		if _, err := io.ReadFull(r, got); err != nil {
+			t.Fatalf("multistream=%v: reading flushed data: %v", multistream, err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Fatalf("multistream=%v: flushed data mismatch", multistream)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(msg); err == nil {
+			t.Errorf("multistream=%v: Write after Close succeeded", multistream)
+		}
+	}
+}
+
+func TestWriterConcurrentReset(t *testing.T) {
+	msg := bytes.Repeat([]byte("hello, world\n"), 500)
+	var buf, buf2 bytes.Buffer
+	z := NewWriter(&buf)
+	z.SetConcurrency(1<<10, 3)
+	z.Write(msg)
+	z.Close()
+	z.Reset(&buf2)
+	z.Write(msg)
+	z.Close()
+	if !bytes.Equal(buf.Bytes(), buf2.Bytes()) {
+		t.Error("output after Reset differs from the first output")
+	}
+	if idx := z.Index(); idx.Size != int64(len(msg)) || idx.Blocks[0].Offset != 0 {
+		t.Errorf("Index after Reset = %+v", idx)
+	}
+}
+
+func TestSetConcurrency(t *testing.T) {
+	z := NewWriter(io.Discard)
+	if z.Index() != nil {
+		t.Error("Index is not nil without concurrency")
+	}
+	if err := z.SetConcurrency(0, 1); err == nil {
+		t.Error("SetConcurrency(0, 1) succeeded")
+	}
+	if err := z.SetConcurrency(1<<10, 0); err == nil {
+		t.Error("SetConcurrency(1<<10, 0) succeeded")
+	}
+	z.Write([]byte("x"))
+	if err := z.SetConcurrency(1<<10, 1); err == nil {
+		t.Error("SetConcurrency after Write succeeded")
+	}
+}
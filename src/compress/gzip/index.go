@@ -0,0 +1,115 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"bufio"
+	"compress/flate"
+	"errors"
+	"io"
+	"sort"
+)
+
+// An Index records the boundaries of the blocks in a gzip stream written
+// by a Writer with concurrency enabled. Each block is compressed
+// independently of the data before it, so a Reader created by
+// NewReaderIndex can use the Index to seek within the stream without
+// decompressing it from the start.
+//
+// The fields are exported so that an Index can be stored alongside the
+// compressed data in whatever encoding suits the application.
+type Index struct {
+	Multistream bool         // each block is a separate gzip member
+	Size        int64        // total uncompressed size
+	Blocks      []IndexBlock // in stream order
+}
+
+// An IndexBlock gives the position of a block in the compressed stream
+// and in the uncompressed data.
+type IndexBlock struct {
+	Offset             int64 // start of the block in the compressed stream
+	UncompressedOffset int64 // start of the block in the uncompressed data
+}
+
+// NewReaderIndex is like NewReader but also takes idx, the Index
+// returned by Writer.Index for the data in r. The returned Reader
+// implements io.Seeker. Calling Reset on it discards the index.
+func NewReaderIndex(r io.ReadSeeker, idx *Index) (*Reader, error) {
+	z, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	z.rs, z.index = r, idx
+	return z, nil
+}
+
+// Seek implements io.Seeker for a Reader created by NewReaderIndex.
+// It positions the underlying reader at the start of the block holding
+// the new offset and decompresses forward from there, unless the new
+// offset lies ahead of the current one in the same block.
+//
+// Unless the Index is Multistream, the checksum at the end of the stream
+// covers all of the data, so it is not verified once Seek has moved to a
+// block other than the first.
+func (z *Reader) Seek(offset int64, whence int) (int64, error) {
+	if z.index == nil {
+		return 0, errors.New("gzip.Reader.Seek: no index")
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += z.pos
+	case io.SeekEnd:
+		offset += z.index.Size
+	default:
+		return 0, errors.New("gzip.Reader.Seek: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("gzip.Reader.Seek: negative position")
+	}
+	blocks := z.index.Blocks
+	i := sort.Search(len(blocks), func(i int) bool {
+		return blocks[i].UncompressedOffset > offset
+	})
+	var b IndexBlock // the start of the stream if the index is empty
+	if i > 0 {
+		b = blocks[i-1]
+	}
+	if z.err != nil && z.err != io.EOF || offset < z.pos || b.UncompressedOffset > z.pos {
+		if err := z.seekBlock(b); err != nil {
+			z.err = err
+			return 0, err
+		}
+	}
+	if _, err := io.CopyN(io.Discard, z, offset-z.pos); err != nil && err != io.EOF {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// seekBlock positions z at the start of block b.
+func (z *Reader) seekBlock(b IndexBlock) error {
+	if _, err := z.rs.Seek(b.Offset, io.SeekStart); err != nil {
+		return err
+	}
+	if rr, ok := z.rs.(flate.Reader); ok {
+		z.r = rr
+	} else if br, ok := z.r.(*bufio.Reader); ok {
+		br.Reset(z.rs)
+	} else {
+		z.r = bufio.NewReader(z.rs)
+	}
+	z.pos = b.UncompressedOffset
+	z.digest, z.size, z.err = 0, 0, nil
+	if b.Offset == 0 || z.index.Multistream {
+		// b starts a gzip member.
+		z.partial = false
+		_, err := z.readHeader()
+		return err
+	}
+	z.partial = true
+	z.decompressor.(flate.Resetter).Reset(z.r, nil)
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestReaderSeek(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/Isaac.Newton-Opticks.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const blockSize = 50000
+	for _, multistream := range []bool{false, true} {
+		out, idx := writeConcurrent(t, data, blockSize, 3, multistream)
+		r, err := NewReaderIndex(bytes.NewReader(out), idx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := int64(len(data))
+		tests := []struct {
+			offset int64
+			whence int
+			want   int64
+		}{
+			{3*blockSize + 17, io.SeekStart, 3*blockSize + 17},
+			{blockSize, io.SeekStart, blockSize},
+			{0, io.SeekStart, 0},
+			{10, io.SeekCurrent, 110},
+			{blockSize - 50, io.SeekStart, blockSize - 50},
+			{-1000, io.SeekEnd, size - 1000},
+			{-2 * blockSize, io.SeekCurrent, size - 900 - 2*blockSize},
+			{-50, io.SeekEnd, size - 50},
+		}
+		for _, tt := range tests {
+			pos, err := r.Seek(tt.offset, tt.whence)
+			if err != nil || pos != tt.want {
+				t.Fatalf("multistream=%v: Seek(%d, %d) = %d, %v; want %d, nil", multistream, tt.offset, tt.whence, pos, err, tt.want)
+			}
+			end := pos + 100
+			if end > size {
+				end = size
+			}
+			got := make([]byte, end-pos)
+			if _, err := io.ReadFull(r, got); err != nil {
+				t.Fatalf("multistream=%v: reading at %d: %v", multistream, pos, err)
+			}
+			if !bytes.Equal(got, data[pos:end]) {
+				t.Fatalf("multistream=%v: at %d got %q, want %q", multistream, pos, got, data[pos:end])
+			}
+		}
+		// Reading to the end after a Seek ends cleanly.
+		if n, err := io.Copy(io.Discard, r); n != 0 || err != nil {
+			t.Errorf("multistream=%v: reading at end = %d, %v; want 0, nil", multistream, n, err)
+		}
+		if _, err := r.Seek(-1, io.SeekStart); err == nil {
+			t.Errorf("multistream=%v: Seek to negative offset succeeded", multistream)
+		}
+		if pos, err := r.Seek(10, io.SeekEnd); err != nil || pos != size+10 {
+			t.Errorf("multistream=%v: Seek past end = %d, %v", multistream, pos, err)
+		}
+		if n, err := r.Read(make([]byte, 10)); n != 0 || err != io.EOF {
+			t.Errorf("multistream=%v: Read past end = %d, %v; want 0, io.EOF", multistream, n, err)
+		}
+	}
+}
+
+func TestReaderSeekNoIndex(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Write([]byte("hello"))
+	w.Close()
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err == nil {
+		t.Error("Seek without an index succeeded")
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// parallel holds the state of a Writer that compresses concurrently.
+type parallel struct {
+	blockSize int
+	blocks    int
+	cw        countWriter
+	cur       *block   // block being filled by Write
+	queue     []*block // blocks being compressed, oldest first
+	free      []*block
+	index     Index
+}
+
+// A block is a piece of the input that is compressed on its own goroutine.
+type block struct {
+	data   []byte
+	out    bytes.Buffer
+	fw     *flate.Writer
+	digest uint32 // CRC-32 of data, for multistream output
+	last   bool
+	done   chan struct{}
+}
+
+// countWriter counts the bytes written to w.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func (p *parallel) reset(w io.Writer) {
+	p.cw = countWriter{w: w}
+	p.cur = nil
+	p.queue = nil
+	p.index = Index{}
+}
+
+func (p *parallel) newBlock() *block {
+	if n := len(p.free); n > 0 {
+		b := p.free[n-1]
+		p.free = p.free[:n-1]
+		b.data = b.data[:0]
+		b.out.Reset()
+		return b
+	}
+	return &block{data: make([]byte, 0, p.blockSize)}
+}
+
+func (b *block) compress(level int, member bool) {
+	defer close(b.done)
+	if b.fw == nil {
+		b.fw, _ = flate.NewWriter(&b.out, level)
+	} else {
+		b.fw.Reset(&b.out)
+	}
+	// Writes to a bytes.Buffer do not fail.
+	b.fw.Write(b.data)
+	if member || b.last {
+		b.fw.Close()
+	} else {
+		// A sync flush ends the block on a byte boundary, where a
+		// decompressor can start reading.
+		b.fw.Flush()
+	}
+	if member {
+		b.digest = crc32.ChecksumIEEE(b.data)
+	}
+}
+
+// SetConcurrency enables concurrent compression. The input is split into
+// blocks of blockSize bytes, and up to blocks of them are compressed at
+// once on separate goroutines. Each block is compressed without reference
+// to the data before it, which costs some compression but lets a Reader
+// start decompressing at any block; see Index.
+//
+// The output is still a standard gzip stream. By default it is a single
+// gzip member; see Multistream.
+//
+// SetConcurrency must be called before the first call to Write, Flush,
+// or Close.
+func (z *Writer) SetConcurrency(blockSize, blocks int) error {
+	if blockSize <= 0 || blocks <= 0 {
+		return fmt.Errorf("gzip: invalid concurrency: %d blocks of %d bytes", blocks, blockSize)
+	}
+	if z.wroteHeader || z.closed || z.par != nil && (z.par.cur != nil || len(z.par.queue) > 0) {
+		return errors.New("gzip: SetConcurrency called after Write")
+	}
+	if z.par == nil {
+		z.par = new(parallel)
+		z.par.reset(z.w)
+		z.w = &z.par.cw
+	}
+	z.par.blockSize, z.par.blocks = blockSize, blocks
+	return nil
+}
+
+// Multistream controls whether a Writer with concurrency enabled writes
+// each block as a separate gzip member, with its own header and trailer.
+// Readers treat a sequence of members as the concatenation of their data,
+// so the decompressed output is the same either way, but each member
+// carries its own checksum. Only the first member records the fields of
+// z.Header.
+//
+// Multistream has no effect unless SetConcurrency is also called, and it
+// must be called before the first call to Write, Flush, or Close.
+func (z *Writer) Multistream(ok bool) {
+	z.multistream = ok
+}
+
+// Index returns an index of the blocks written so far, or nil if
+// concurrency is not enabled. The index is complete once Close returns
+// without error. See NewReaderIndex.
+func (z *Writer) Index() *Index {
+	if z.par == nil {
+		return nil
+	}
+	idx := z.par.index
+	idx.Blocks = append([]IndexBlock(nil), idx.Blocks...)
+	return &idx
+}
+
+func (z *Writer) writeConcurrent(p []byte) (int, error) {
+	if z.closed {
+		return 0, errors.New("gzip: write to closed Writer")
+	}
+	par := z.par
+	n := len(p)
+	if !z.multistream {
+		z.size += uint32(n)
+		z.digest = crc32.Update(z.digest, crc32.IEEETable, p)
+	}
+	for len(p) > 0 {
+		if par.cur == nil {
+			par.cur = par.newBlock()
+		}
+		m := par.blockSize - len(par.cur.data)
+		if m > len(p) {
+			m = len(p)
+		}
+		par.cur.data = append(par.cur.data, p[:m]...)
+		p = p[m:]
+		if len(par.cur.data) == par.blockSize {
+			if z.err = z.startBlock(false); z.err != nil {
+				return 0, z.err
+			}
+		}
+	}
+	return n, nil
+}
+
+// startBlock starts compressing the current block, then writes out
+// finished blocks until fewer than par.blocks are in flight.
+func (z *Writer) startBlock(last bool) error {
+	par := z.par
+	b := par.cur
+	par.cur = nil
+	b.last = last
+	b.done = make(chan struct{})
+	go b.compress(z.level, z.multistream)
+	par.queue = append(par.queue, b)
+	for len(par.queue) >= par.blocks {
+		if err := z.writeBlock(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBlock waits for the oldest block in flight and writes it to z.w.
+func (z *Writer) writeBlock() error {
+	par := z.par
+	b := par.queue[0]
+	<-b.done
+	copy(par.queue, par.queue[1:])
+	par.queue[len(par.queue)-1] = nil
+	par.queue = par.queue[:len(par.queue)-1]
+
+	ib := IndexBlock{Offset: par.cw.n, UncompressedOffset: par.index.Size}
+	if !z.wroteHeader {
+		z.wroteHeader = true
+		if err := z.writeHeader(true); err != nil {
+			return err
+		}
+	} else if z.multistream {
+		if err := z.writeHeader(false); err != nil {
+			return err
+		}
+	}
+	par.index.Multistream = z.multistream
+	par.index.Size += int64(len(b.data))
+	par.index.Blocks = append(par.index.Blocks, ib)
+	if _, err := z.w.Write(b.out.Bytes()); err != nil {
+		return err
+	}
+	if z.multistream {
+		le.PutUint32(z.buf[:4], b.digest)
+		le.PutUint32(z.buf[4:8], uint32(len(b.data)))
+		if _, err := z.w.Write(z.buf[:8]); err != nil {
+			return err
+		}
+	}
+	par.free = append(par.free, b)
+	return nil
+}
+
+// flushConcurrent compresses and writes out all pending data.
+// If last is set, it also ends the stream.
+func (z *Writer) flushConcurrent(last bool) error {
+	par := z.par
+	// A single stream always ends with a final block, and the output
+	// must hold at least a header even if nothing was written.
+	needBlock := par.cur != nil ||
+		last && !z.multistream ||
+		!z.wroteHeader && len(par.queue) == 0
+	if needBlock {
+		if par.cur == nil {
+			par.cur = par.newBlock()
+		}
+		if err := z.startBlock(last); err != nil {
+			return err
+		}
+	}
+	for len(par.queue) > 0 {
+		if err := z.writeBlock(); err != nil {
+			return err
+		}
+	}
+	if last && !z.multistream {
+		le.PutUint32(z.buf[:4], z.digest)
+		le.PutUint32(z.buf[4:8], z.size)
+		if _, err := z.w.Write(z.buf[:8]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import "math/bits"
+
+// A forwardBitReader reads bits from the start of a byte slice, least
+// significant bit first. It is used for FSE table descriptions.
+type forwardBitReader struct {
+	data []byte
+	pos  uint // bit position of the next unread bit
+}
+
+// readBits returns the next n bits. Bits past the end of the data read
+// as zero; callers check overflow once they are done.
+func (br *forwardBitReader) readBits(n uint) uint32 {
+	var v uint32
+	for i := uint(0); i < n; i++ {
+		b := br.pos >> 3
+		if b < uint(len(br.data)) {
+			v |= uint32(br.data[b]>>(br.pos&7)&1) << i
+		}
+		br.pos++
+	}
+	return v
+}
+
+// peekBits returns the next n bits without consuming them.
+func (br *forwardBitReader) peekBits(n uint) uint32 {
+	pos := br.pos
+	v := br.readBits(n)
+	br.pos = pos
+	return v
+}
+
+func (br *forwardBitReader) overflow() bool {
+	return br.pos > uint(len(br.data))*8
+}
+
+// bytesRead returns the number of bytes touched by the bits read so far.
+func (br *forwardBitReader) bytesRead() int {
+	return int((br.pos + 7) >> 3)
+}
+
+// A reverseBitReader reads a Zstandard bitstream, which is consumed from
+// the last byte towards the first, starting just below the highest set
+// bit of the last byte.
+type reverseBitReader struct {
+	data []byte
+	off  int    // index of the next byte to load, counting down
+	bits uint64 // bits loaded but not yet consumed, in the low cnt bits
+	cnt  int    // number of valid bits in bits; negative after overflow
+}
+
+func (br *reverseBitReader) init(data []byte) error {
+	if len(data) == 0 {
+		return StructuralError("empty bitstream")
+	}
+	last := data[len(data)-1]
+	if last == 0 {
+		return StructuralError("missing bitstream end marker")
+	}
+	br.data = data
+	br.off = len(data) - 1
+	br.bits = uint64(last)
+	br.cnt = bits.Len8(last) - 1
+	return nil
+}
+
+// fill loads bytes until at least n bits are available or the data is
+// exhausted.
+func (br *reverseBitReader) fill(n int) {
+	for br.cnt < n && br.off > 0 {
+		br.off--
+		br.bits = br.bits<<8 | uint64(br.data[br.off])
+		br.cnt += 8
+	}
+}
+
+// readBits consumes and returns the next n bits, n <= 32. Reading past
+// the start of the stream yields zero bits and marks the reader as
+// overflowed.
+func (br *reverseBitReader) readBits(n int) uint32 {
+	if n == 0 {
+		return 0
+	}
+	br.fill(n)
+	var v uint64
+	if br.cnt >= n {
+		v = br.bits >> uint(br.cnt-n)
+	} else if br.cnt > 0 {
+		v = br.bits << uint(n-br.cnt)
+	}
+	br.cnt -= n
+	return uint32(v & (1<<uint(n) - 1))
+}
+
+// peekBits returns the next n bits without consuming them, padding with
+// zero bits past the start of the stream.
+func (br *reverseBitReader) peekBits(n int) uint32 {
+	br.fill(n)
+	var v uint64
+	if br.cnt >= n {
+		v = br.bits >> uint(br.cnt-n)
+	} else if br.cnt > 0 {
+		v = br.bits << uint(n-br.cnt)
+	}
+	return uint32(v & (1<<uint(n) - 1))
+}
+
+// skipBits consumes n bits previously examined with peekBits.
+func (br *reverseBitReader) skipBits(n int) {
+	br.cnt -= n
+}
+
+// overflow reports whether more bits were consumed than the stream holds.
+func (br *reverseBitReader) overflow() bool {
+	return br.cnt < 0
+}
+
+// finished reports whether every bit of the stream has been consumed.
+func (br *reverseBitReader) finished() bool {
+	return br.off == 0 && br.cnt == 0
+}
+
+// A bitWriter produces a bitstream in the order expected by
+// reverseBitReader: the last bits written are the first read.
+type bitWriter struct {
+	out   []byte
+	bits  uint64
+	nbits uint
+}
+
+// addBits appends the low n bits of v, n <= 32.
+func (bw *bitWriter) addBits(v uint32, n uint) {
+	bw.bits |= uint64(v&(1<<n-1)) << bw.nbits
+	bw.nbits += n
+	for bw.nbits >= 8 {
+		bw.out = append(bw.out, byte(bw.bits))
+		bw.bits >>= 8
+		bw.nbits -= 8
+	}
+}
+
+// close writes the end marker and flushes the final partial byte.
+func (bw *bitWriter) close() []byte {
+	bw.addBits(1, 1)
+	if bw.nbits > 0 {
+		bw.out = append(bw.out, byte(bw.bits))
+	}
+	bw.bits, bw.nbits = 0, 0
+	return bw.out
+}
+
+// flushBytes appends any complete bytes and then the final partial byte
+// without an end marker. It is used for forward bitstreams.
+func (bw *bitWriter) flushBytes() []byte {
+	if bw.nbits > 0 {
+		bw.out = append(bw.out, byte(bw.bits))
+	}
+	bw.bits, bw.nbits = 0, 0
+	return bw.out
+}
@@ -0,0 +1,378 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+// Decoding of compressed blocks, RFC 8878 Section 3.1.1.3.
+
+const (
+	literalsRaw        = 0
+	literalsRLE        = 1
+	literalsCompressed = 2
+	literalsTreeless   = 3
+
+	modePredefined = 0
+	modeRLE        = 1
+	modeFSE        = 2
+	modeRepeat     = 3
+
+	maxLiteralLengthCode = 35
+	maxMatchLengthCode   = 52
+	maxOffsetCode        = 31
+
+	maxLiteralLengthLog = 9
+	maxMatchLengthLog   = 9
+	maxOffsetLog        = 8
+)
+
+// Baselines and extra bits of the literal length and match length codes,
+// RFC 8878 Section 3.1.1.3.2.1.1.
+var (
+	literalLengthBase = [maxLiteralLengthCode + 1]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	literalLengthBits = [maxLiteralLengthCode + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	matchLengthBase = [maxMatchLengthCode + 1]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	matchLengthBits = [maxMatchLengthCode + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+)
+
+// A blockDecoder holds the state that carries over from one block to
+// the next within a frame.
+type blockDecoder struct {
+	huff     *huffTable
+	llTable  *fseTable
+	ofTable  *fseTable
+	mlTable  *fseTable
+	reps     [3]uint32
+	literals []byte
+}
+
+// reset prepares the decoder for a new frame using dictionary d,
+// which may be nil.
+func (d *blockDecoder) reset(dt *dict) {
+	d.huff, d.llTable, d.ofTable, d.mlTable = nil, nil, nil, nil
+	d.reps = [3]uint32{1, 4, 8}
+	if dt != nil {
+		d.huff = dt.huff
+		d.llTable, d.ofTable, d.mlTable = dt.llTable, dt.ofTable, dt.mlTable
+		d.reps = dt.reps
+	}
+}
+
+// decode decodes the compressed block data and appends the result to
+// hist, which holds the window preceding the block.
+func (d *blockDecoder) decode(data, hist []byte) ([]byte, error) {
+	n, err := d.readLiterals(data)
+	if err != nil {
+		return hist, err
+	}
+	return d.execSequences(data[n:], hist)
+}
+
+// readLiterals decodes the literals section into d.literals and returns
+// its size.
+func (d *blockDecoder) readLiterals(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, StructuralError("missing literals section")
+	}
+	typ := data[0] & 3
+	sizeFormat := data[0] >> 2 & 3
+	var regenerated, compressed, hdr int
+	fourStreams := false
+	switch typ {
+	case literalsRaw, literalsRLE:
+		switch sizeFormat {
+		case 0, 2:
+			regenerated = int(data[0] >> 3)
+			hdr = 1
+		case 1:
+			if len(data) < 2 {
+				return 0, StructuralError("truncated literals header")
+			}
+			regenerated = int(data[0]>>4) | int(data[1])<<4
+			hdr = 2
+		case 3:
+			if len(data) < 3 {
+				return 0, StructuralError("truncated literals header")
+			}
+			regenerated = int(data[0]>>4) | int(data[1])<<4 | int(data[2])<<12
+			hdr = 3
+		}
+	default:
+		switch sizeFormat {
+		case 0, 1:
+			if len(data) < 3 {
+				return 0, StructuralError("truncated literals header")
+			}
+			v := int(data[0]) | int(data[1])<<8 | int(data[2])<<16
+			regenerated = v >> 4 & 0x3ff
+			compressed = v >> 14 & 0x3ff
+			hdr = 3
+			fourStreams = sizeFormat == 1
+		case 2:
+			if len(data) < 4 {
+				return 0, StructuralError("truncated literals header")
+			}
+			v := int(le.Uint32(data))
+			regenerated = v >> 4 & 0x3fff
+			compressed = v >> 18 & 0x3fff
+			hdr = 4
+			fourStreams = true
+		case 3:
+			if len(data) < 5 {
+				return 0, StructuralError("truncated literals header")
+			}
+			v := int(le.Uint32(data)) | int(data[4])<<32
+			regenerated = v >> 4 & 0x3ffff
+			compressed = v >> 22 & 0x3ffff
+			hdr = 5
+			fourStreams = true
+		}
+	}
+	if regenerated > maxBlockSize {
+		return 0, StructuralError("literals section too large")
+	}
+	if cap(d.literals) < regenerated {
+		d.literals = make([]byte, regenerated, maxBlockSize)
+	}
+	d.literals = d.literals[:regenerated]
+	data = data[hdr:]
+
+	switch typ {
+	case literalsRaw:
+		if len(data) < regenerated {
+			return 0, StructuralError("truncated raw literals")
+		}
+		copy(d.literals, data)
+		return hdr + regenerated, nil
+	case literalsRLE:
+		if len(data) < 1 {
+			return 0, StructuralError("truncated RLE literals")
+		}
+		for i := range d.literals {
+			d.literals[i] = data[0]
+		}
+		return hdr + 1, nil
+	}
+
+	if len(data) < compressed {
+		return 0, StructuralError("truncated compressed literals")
+	}
+	data = data[:compressed]
+	if typ == literalsCompressed {
+		t, n, err := readHuffmanTable(data)
+		if err != nil {
+			return 0, err
+		}
+		d.huff = t
+		data = data[n:]
+	} else if d.huff == nil {
+		return 0, StructuralError("treeless literals without a previous Huffman table")
+	}
+	if err := d.huff.decode(d.literals, data, fourStreams); err != nil {
+		return 0, err
+	}
+	return hdr + compressed, nil
+}
+
+// execSequences decodes the sequences section and executes the
+// sequences, appending the block content to hist.
+func (d *blockDecoder) execSequences(data, hist []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return hist, StructuralError("missing sequences section")
+	}
+	nseq := int(data[0])
+	switch {
+	case nseq == 0:
+		if len(data) != 1 {
+			return hist, StructuralError("trailing data after sequences section")
+		}
+		return append(hist, d.literals...), nil
+	case nseq < 128:
+		data = data[1:]
+	case nseq < 255:
+		if len(data) < 2 {
+			return hist, StructuralError("truncated sequences header")
+		}
+		nseq = (nseq-128)<<8 | int(data[1])
+		data = data[2:]
+	default:
+		if len(data) < 3 {
+			return hist, StructuralError("truncated sequences header")
+		}
+		nseq = int(le.Uint16(data[1:])) + 0x7f00
+		data = data[3:]
+	}
+	if len(data) < 1 {
+		return hist, StructuralError("truncated sequences header")
+	}
+	modes := data[0]
+	if modes&3 != 0 {
+		return hist, StructuralError("reserved bits set in sequences header")
+	}
+	data = data[1:]
+
+	var err error
+	var n int
+	if d.llTable, n, err = d.readSeqTable(data, modes>>6, d.llTable, predefinedLiteralLengthTable, maxLiteralLengthCode, maxLiteralLengthLog); err != nil {
+		return hist, err
+	}
+	data = data[n:]
+	if d.ofTable, n, err = d.readSeqTable(data, modes>>4&3, d.ofTable, predefinedOffsetTable, maxOffsetCode, maxOffsetLog); err != nil {
+		return hist, err
+	}
+	data = data[n:]
+	if d.mlTable, n, err = d.readSeqTable(data, modes>>2&3, d.mlTable, predefinedMatchLengthTable, maxMatchLengthCode, maxMatchLengthLog); err != nil {
+		return hist, err
+	}
+	data = data[n:]
+
+	var br reverseBitReader
+	if err := br.init(data); err != nil {
+		return hist, err
+	}
+	var ll, of, ml fseDecoder
+	ll.init(&br, d.llTable)
+	of.init(&br, d.ofTable)
+	ml.init(&br, d.mlTable)
+
+	start := len(hist)
+	lits := d.literals
+	for i := 0; i < nseq; i++ {
+		llCode, ofCode, mlCode := ll.symbol(), of.symbol(), ml.symbol()
+		if llCode > maxLiteralLengthCode || mlCode > maxMatchLengthCode || ofCode > maxOffsetCode {
+			return hist, StructuralError("invalid sequence code")
+		}
+		offsetValue := uint32(1)<<ofCode + br.readBits(int(ofCode))
+		matchLen := int(matchLengthBase[mlCode] + br.readBits(int(matchLengthBits[mlCode])))
+		litLen := int(literalLengthBase[llCode] + br.readBits(int(literalLengthBits[llCode])))
+
+		offset := applyOffset(&d.reps, offsetValue, litLen == 0)
+		if offset == 0 {
+			return hist, StructuralError("invalid repeat offset")
+		}
+
+		if litLen > len(lits) {
+			return hist, StructuralError("literal length exceeds literals")
+		}
+		hist = append(hist, lits[:litLen]...)
+		lits = lits[litLen:]
+		if len(hist)-start+matchLen > maxBlockSize {
+			return hist, StructuralError("block content too large")
+		}
+		if int(offset) > len(hist) {
+			return hist, StructuralError("offset out of range")
+		}
+		hist = appendMatch(hist, int(offset), matchLen)
+
+		if i < nseq-1 {
+			ll.update(&br)
+			ml.update(&br)
+			of.update(&br)
+		}
+		if br.overflow() {
+			return hist, StructuralError("truncated sequences bitstream")
+		}
+	}
+	if !br.finished() {
+		return hist, StructuralError("sequences bitstream size mismatch")
+	}
+	if len(hist)-start+len(lits) > maxBlockSize {
+		return hist, StructuralError("block content too large")
+	}
+	return append(hist, lits...), nil
+}
+
+// applyOffset resolves a sequence's offset value against the repeat
+// offsets and updates them, RFC 8878 Section 3.1.1.5. ll0 reports whether
+// the sequence has no literals. It returns zero for an invalid offset.
+func applyOffset(reps *[3]uint32, offsetValue uint32, ll0 bool) uint32 {
+	if offsetValue > 3 {
+		offset := offsetValue - 3
+		reps[2], reps[1], reps[0] = reps[1], reps[0], offset
+		return offset
+	}
+	idx := offsetValue - 1
+	if ll0 {
+		idx++
+	}
+	var offset uint32
+	switch idx {
+	case 0:
+		return reps[0]
+	case 1:
+		offset = reps[1]
+		reps[1], reps[0] = reps[0], offset
+		return offset
+	case 2:
+		offset = reps[2]
+	default:
+		offset = reps[0] - 1
+		if offset == 0 {
+			return 0
+		}
+	}
+	reps[2], reps[1], reps[0] = reps[1], reps[0], offset
+	return offset
+}
+
+// appendMatch appends length bytes copied from offset bytes back in b.
+// The source and destination may overlap.
+func appendMatch(b []byte, offset, length int) []byte {
+	src := len(b) - offset
+	if offset >= length {
+		return append(b, b[src:src+length]...)
+	}
+	for length > 0 {
+		n := offset
+		if n > length {
+			n = length
+		}
+		b = append(b, b[src:src+n]...)
+		length -= n
+		// The copied region repeats with period offset.
+		offset += n
+	}
+	return b
+}
+
+// readSeqTable reads the table for one sequence symbol type according to
+// mode, returning the table and the number of bytes consumed.
+func (d *blockDecoder) readSeqTable(data []byte, mode uint8, prev, predefined *fseTable, maxSymbol, maxLog int) (*fseTable, int, error) {
+	switch mode {
+	case modePredefined:
+		return predefined, 0, nil
+	case modeRLE:
+		if len(data) < 1 {
+			return nil, 0, StructuralError("truncated RLE sequence table")
+		}
+		if int(data[0]) > maxSymbol {
+			return nil, 0, StructuralError("invalid RLE sequence symbol")
+		}
+		return rleFSETable(data[0]), 1, nil
+	case modeFSE:
+		return readFSETable(data, maxSymbol, maxLog)
+	default:
+		if prev == nil {
+			return nil, 0, StructuralError("repeat mode without a previous table")
+		}
+		return prev, 0, nil
+	}
+}
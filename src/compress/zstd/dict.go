@@ -0,0 +1,61 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+// A dict is a parsed dictionary, RFC 8878 Section 5.
+// Data without the dictionary magic number is used as raw content,
+// with ID zero and no entropy tables.
+type dict struct {
+	id      uint32
+	content []byte
+	reps    [3]uint32
+
+	huff    *huffTable
+	llTable *fseTable
+	ofTable *fseTable
+	mlTable *fseTable
+}
+
+func parseDict(data []byte) (*dict, error) {
+	d := &dict{reps: [3]uint32{1, 4, 8}}
+	if len(data) < 8 || le.Uint32(data) != dictMagic {
+		d.content = data
+		return d, nil
+	}
+	d.id = le.Uint32(data[4:])
+	data = data[8:]
+
+	var err error
+	var n int
+	if d.huff, n, err = readHuffmanTable(data); err != nil {
+		return nil, err
+	}
+	data = data[n:]
+	if d.ofTable, n, err = readFSETable(data, maxOffsetCode, maxOffsetLog); err != nil {
+		return nil, err
+	}
+	data = data[n:]
+	if d.mlTable, n, err = readFSETable(data, maxMatchLengthCode, maxMatchLengthLog); err != nil {
+		return nil, err
+	}
+	data = data[n:]
+	if d.llTable, n, err = readFSETable(data, maxLiteralLengthCode, maxLiteralLengthLog); err != nil {
+		return nil, err
+	}
+	data = data[n:]
+	if len(data) < 12 {
+		return nil, StructuralError("truncated dictionary")
+	}
+	for i := range d.reps {
+		d.reps[i] = le.Uint32(data[4*i:])
+	}
+	d.content = data[12:]
+	for _, r := range d.reps {
+		if r == 0 || int(r) > len(d.content) {
+			return nil, StructuralError("invalid dictionary repeat offset")
+		}
+	}
+	return d, nil
+}
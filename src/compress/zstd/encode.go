@@ -0,0 +1,531 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Block compression: an LZ77 match finder produces sequences, which are
+// then entropy coded as described in RFC 8878 Section 3.1.1.3.
+
+// levelParams controls the match finder for one compression level.
+type levelParams struct {
+	windowLog uint
+	hashLog   uint
+	chainLog  uint // zero disables hash chains
+	depth     int  // number of chain candidates examined per position
+	lazy      bool // whether to try a match one byte later
+}
+
+var levels = [...]levelParams{
+	1: {windowLog: 19, hashLog: 16},
+	2: {windowLog: 21, hashLog: 17, chainLog: 16, depth: 8, lazy: true},
+	3: {windowLog: 22, hashLog: 18, chainLog: 18, depth: 32, lazy: true},
+	4: {windowLog: 23, hashLog: 19, chainLog: 20, depth: 128, lazy: true},
+}
+
+const (
+	minMatch = 4
+
+	// Matches do not start within the last few bytes of a block so that
+	// the match finder can always load four bytes.
+	blockTail = 8
+)
+
+// A sequence is one literal run followed by one match.
+type sequence struct {
+	litLen      uint32
+	matchLen    uint32
+	offsetValue uint32 // offset as coded: a repeat code or offset+3
+}
+
+// An encoder holds the match finder state for one frame.
+type encoder struct {
+	p          levelParams
+	windowSize int
+	table      []int32 // hash -> most recent position+1
+	chain      []int32 // position & chainMask -> previous position+1
+	reps       [3]uint32
+
+	seqs []sequence
+	lits []byte
+}
+
+func (e *encoder) init(p levelParams) {
+	e.p = p
+	e.windowSize = 1 << p.windowLog
+	if len(e.table) != 1<<p.hashLog {
+		e.table = make([]int32, 1<<p.hashLog)
+	}
+	if p.chainLog > 0 && len(e.chain) != 1<<p.chainLog {
+		e.chain = make([]int32, 1<<p.chainLog)
+	}
+}
+
+// reset clears the match finder for a new frame.
+func (e *encoder) reset(reps [3]uint32) {
+	for i := range e.table {
+		e.table[i] = 0
+	}
+	for i := range e.chain {
+		e.chain[i] = 0
+	}
+	e.reps = reps
+}
+
+// shift adjusts stored positions after the history buffer dropped its
+// first n bytes. For hash chains n is a multiple of the chain size, so
+// chain slots stay in place.
+func (e *encoder) shift(n int) {
+	for _, t := range [][]int32{e.table, e.chain} {
+		for i, v := range t {
+			if int(v) > n {
+				t[i] = v - int32(n)
+			} else {
+				t[i] = 0
+			}
+		}
+	}
+}
+
+func (e *encoder) hash(u uint32) uint32 {
+	return u * 2654435761 >> (32 - e.p.hashLog)
+}
+
+// insert records position i of b in the match finder.
+func (e *encoder) insert(b []byte, i int) {
+	h := e.hash(le.Uint32(b[i:]))
+	if e.chain != nil {
+		e.chain[i&(len(e.chain)-1)] = e.table[h]
+	}
+	e.table[h] = int32(i + 1)
+}
+
+// matchLen returns the length of the common prefix of b[i:end] and b[j:].
+func matchLen(b []byte, i, j, end int) int {
+	n := 0
+	for i+n+8 <= end {
+		x := le.Uint64(b[i+n:]) ^ le.Uint64(b[j+n:])
+		if x != 0 {
+			return n + bits.TrailingZeros64(x)/8
+		}
+		n += 8
+	}
+	for i+n < end && b[i+n] == b[j+n] {
+		n++
+	}
+	return n
+}
+
+// findMatch returns the longest match for position i of b found by the
+// match finder, or a length of zero.
+func (e *encoder) findMatch(b []byte, i, end int) (length, offset int) {
+	low := i - e.windowSize
+	if low < 0 {
+		low = 0
+	}
+	// The most recent offset is cheap to code, so try it first.
+	if r := int(e.reps[0]); i-r >= low && le.Uint32(b[i-r:]) == le.Uint32(b[i:]) {
+		length, offset = matchLen(b, i, i-r, end), r
+	}
+	c := int(e.table[e.hash(le.Uint32(b[i:]))]) - 1
+	if e.chain == nil {
+		if c >= low && c < i && le.Uint32(b[c:]) == le.Uint32(b[i:]) {
+			if n := matchLen(b, i, c, end); n > length {
+				length, offset = n, i-c
+			}
+		}
+		return
+	}
+	chainLow := i - len(e.chain)
+	if chainLow < low {
+		chainLow = low
+	}
+	for d := e.p.depth; d > 0 && c >= chainLow && c < i && i+length < end; d-- {
+		if b[c+length] == b[i+length] || length < minMatch {
+			if n := matchLen(b, i, c, end); n > length {
+				length, offset = n, i-c
+			}
+		}
+		next := int(e.chain[c&(len(e.chain)-1)]) - 1
+		if next >= c {
+			break
+		}
+		c = next
+	}
+	if length < minMatch {
+		return 0, 0
+	}
+	return
+}
+
+// parse finds the sequences for the block b[start:end], appending them
+// to e.seqs and the literals to e.lits. b[:start] is the history
+// available for matches.
+func (e *encoder) parse(b []byte, start, end int) {
+	e.seqs = e.seqs[:0]
+	e.lits = e.lits[:0]
+	lit := start
+	limit := end - blockTail
+	// With hash chains every position before ins has been inserted.
+	ins := start
+	insertTo := func(n int) {
+		for ; ins < n; ins++ {
+			e.insert(b, ins)
+		}
+	}
+	for i := start; i < limit; {
+		length, offset := e.findMatch(b, i, end)
+		if length < minMatch {
+			if e.chain == nil {
+				e.insert(b, i)
+				// Skip ahead faster through data that does not compress.
+				i += 1 + (i-lit)>>6
+			} else {
+				i++
+				insertTo(i)
+			}
+			continue
+		}
+		if e.p.lazy {
+			for i+1 < limit {
+				insertTo(i + 1)
+				l2, o2 := e.findMatch(b, i+1, end)
+				if l2 <= length {
+					break
+				}
+				i++
+				length, offset = l2, o2
+			}
+		}
+		// Extend the match backwards into the pending literals.
+		for i > lit && i-offset > 0 && b[i-1] == b[i-1-offset] {
+			i--
+			length++
+		}
+		e.addSequence(b[lit:i], offset, length)
+
+		next := i + length
+		if e.chain == nil {
+			e.insert(b, i)
+			if next-2 < limit {
+				e.insert(b, next-2)
+			}
+		} else if next < limit {
+			insertTo(next)
+		} else {
+			insertTo(limit)
+		}
+		i = next
+		lit = i
+	}
+	e.lits = append(e.lits, b[lit:end]...)
+}
+
+// addSequence records literals followed by a match, choosing a repeat
+// code for the offset when possible.
+func (e *encoder) addSequence(lits []byte, offset, length int) {
+	ll := uint32(len(lits))
+	off := uint32(offset)
+	ov := off + 3
+	if ll > 0 {
+		switch off {
+		case e.reps[0]:
+			ov = 1
+		case e.reps[1]:
+			ov = 2
+		case e.reps[2]:
+			ov = 3
+		}
+	} else {
+		switch off {
+		case e.reps[1]:
+			ov = 1
+		case e.reps[2]:
+			ov = 2
+		case e.reps[0] - 1:
+			ov = 3
+		}
+	}
+	applyOffset(&e.reps, ov, ll == 0)
+	e.lits = append(e.lits, lits...)
+	e.seqs = append(e.seqs, sequence{litLen: ll, matchLen: uint32(length), offsetValue: ov})
+}
+
+func literalLengthCode(ll uint32) uint8 {
+	if ll < 16 {
+		return uint8(ll)
+	}
+	if ll >= 64 {
+		return uint8(bits.Len32(ll) - 1 + 19)
+	}
+	c := uint8(16)
+	for c < 24 && literalLengthBase[c+1] <= ll {
+		c++
+	}
+	return c
+}
+
+func matchLengthCode(ml uint32) uint8 {
+	v := ml - 3
+	if v < 32 {
+		return uint8(v)
+	}
+	if v >= 128 {
+		return uint8(bits.Len32(v) - 1 + 36)
+	}
+	c := uint8(32)
+	for c < 42 && matchLengthBase[c+1] <= ml {
+		c++
+	}
+	return c
+}
+
+// encodeBlock appends the compressed block content for the parsed
+// sequences and literals.
+func (e *encoder) encodeBlock(out []byte) []byte {
+	out = encodeLiterals(out, e.lits)
+	return encodeSequences(out, e.seqs)
+}
+
+// encodeLiterals appends a literals section, RFC 8878 Section 3.1.1.3.1.
+func encodeLiterals(out, lits []byte) []byte {
+	n := len(lits)
+	if n == 0 {
+		return append(out, 0)
+	}
+	var counts [256]int
+	for _, c := range lits {
+		counts[c]++
+	}
+	if counts[lits[0]] == n && n > 1 {
+		out = appendLiteralsHeader(out, literalsRLE, n)
+		return append(out, lits[0])
+	}
+	if n >= 32 {
+		if enc := newHuffEncoder(&counts); enc != nil && enc.estimateSize(&counts) < n-n/16 {
+			if b, ok := encodeHuffmanLiterals(out, lits, enc); ok {
+				return b
+			}
+		}
+	}
+	out = appendLiteralsHeader(out, literalsRaw, n)
+	return append(out, lits...)
+}
+
+// appendLiteralsHeader appends the header of a raw or RLE literals section.
+func appendLiteralsHeader(out []byte, typ uint8, n int) []byte {
+	switch {
+	case n < 32:
+		return append(out, typ|byte(n)<<3)
+	case n < 4096:
+		return append(out, typ|1<<2|byte(n)<<4, byte(n>>4))
+	default:
+		return append(out, typ|3<<2|byte(n)<<4, byte(n>>4), byte(n>>12))
+	}
+}
+
+// encodeHuffmanLiterals appends a compressed literals section. It reports
+// false if that would not be smaller than raw literals.
+func encodeHuffmanLiterals(out, lits []byte, enc *huffEncoder) ([]byte, bool) {
+	n := len(lits)
+	orig := len(out)
+	hdrSize := 5
+	switch {
+	case n <= 1023:
+		hdrSize = 3
+	case n <= 16383:
+		hdrSize = 4
+	}
+	out = append(out, make([]byte, hdrSize)...)
+	out, ok := enc.appendTable(out)
+	if !ok {
+		return out[:orig], false
+	}
+	fourStreams := n > 1023
+	if !fourStreams {
+		out = enc.encodeStream(out, lits)
+	} else {
+		seg := (n + 3) / 4
+		jump := len(out)
+		out = append(out, 0, 0, 0, 0, 0, 0)
+		for i := 0; i < 4; i++ {
+			s := lits[i*seg:]
+			if i < 3 {
+				s = s[:seg]
+			}
+			before := len(out)
+			out = enc.encodeStream(out, s)
+			if i < 3 {
+				le.PutUint16(out[jump+2*i:], uint16(len(out)-before))
+			}
+		}
+	}
+	compressed := len(out) - orig - hdrSize
+	if compressed >= n || compressed >= 1<<18 {
+		return out[:orig], false
+	}
+	hdr := out[orig : orig+hdrSize]
+	switch hdrSize {
+	case 3:
+		if compressed > 1023 {
+			return out[:orig], false
+		}
+		v := uint32(literalsCompressed) | uint32(n)<<4 | uint32(compressed)<<14
+		hdr[0], hdr[1], hdr[2] = byte(v), byte(v>>8), byte(v>>16)
+	case 4:
+		if compressed > 16383 {
+			return out[:orig], false
+		}
+		le.PutUint32(hdr, uint32(literalsCompressed)|2<<2|uint32(n)<<4|uint32(compressed)<<18)
+	case 5:
+		v := uint64(literalsCompressed) | 3<<2 | uint64(n)<<4 | uint64(compressed)<<22
+		le.PutUint32(hdr, uint32(v))
+		hdr[4] = byte(v >> 32)
+	}
+	return out, true
+}
+
+// A seqTableChoice is the encoding chosen for one sequence symbol type.
+type seqTableChoice struct {
+	mode uint8
+	enc  *fseEncoder // nil for RLE
+	desc []byte      // table description or RLE symbol
+}
+
+// chooseSeqTable picks the cheapest of the predefined table, an RLE
+// table and a table fitted to the symbol counts.
+func chooseSeqTable(codes []uint8, predefined *fseEncoder, predefinedNorm []int16, maxLog int) seqTableChoice {
+	counts := make([]int, len(predefinedNorm))
+	maxSym := 0
+	for _, c := range codes {
+		counts[c]++
+		if int(c) > maxSym {
+			maxSym = int(c)
+		}
+	}
+	if counts[codes[0]] == len(codes) && len(codes) > 2 {
+		return seqTableChoice{mode: modeRLE, desc: []byte{codes[0]}}
+	}
+	best := seqTableChoice{mode: modePredefined, enc: predefined}
+	bestCost := tableCost(counts, predefinedNorm, predefined.accuracyLog)
+	if len(codes) < 16 {
+		return best
+	}
+	log := bits.Len(uint(len(codes)))
+	if log > maxLog {
+		log = maxLog
+	}
+	if log < minFSEAccuracyLog {
+		log = minFSEAccuracyLog
+	}
+	norm, ok := normalizeCounts(counts[:maxSym+1], len(codes), log)
+	if !ok {
+		return best
+	}
+	desc := writeNormalizedCounts(nil, norm, log)
+	if cost := tableCost(counts, norm, log) + float64(8*len(desc)); cost < bestCost {
+		best = seqTableChoice{mode: modeFSE, enc: newFSEEncoder(norm, log), desc: desc}
+	}
+	return best
+}
+
+// tableCost estimates the number of bits needed to code the symbols
+// with the given distribution.
+func tableCost(counts []int, norm []int16, accuracyLog int) float64 {
+	cost := 0.0
+	for s, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if s >= len(norm) || norm[s] == 0 {
+			return math.Inf(1)
+		}
+		p := float64(norm[s])
+		if p < 0 {
+			p = 1
+		}
+		cost += float64(c) * (float64(accuracyLog) - math.Log2(p))
+	}
+	return cost
+}
+
+// encodeSequences appends a sequences section,
+// RFC 8878 Section 3.1.1.3.2.
+func encodeSequences(out []byte, seqs []sequence) []byte {
+	n := len(seqs)
+	switch {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7f00:
+		out = append(out, byte(n>>8)+128, byte(n))
+	default:
+		out = append(out, 255, byte(n-0x7f00), byte((n-0x7f00)>>8))
+	}
+	if n == 0 {
+		return out
+	}
+
+	llCodes := make([]uint8, n)
+	ofCodes := make([]uint8, n)
+	mlCodes := make([]uint8, n)
+	for i, s := range seqs {
+		llCodes[i] = literalLengthCode(s.litLen)
+		ofCodes[i] = uint8(bits.Len32(s.offsetValue) - 1)
+		mlCodes[i] = matchLengthCode(s.matchLen)
+	}
+	ll := chooseSeqTable(llCodes, predefinedLiteralLengthEncoder, predefinedLiteralLengths, maxLiteralLengthLog)
+	of := chooseSeqTable(ofCodes, predefinedOffsetEncoder, predefinedOffsets, maxOffsetLog)
+	ml := chooseSeqTable(mlCodes, predefinedMatchLengthEncoder, predefinedMatchLengths, maxMatchLengthLog)
+	out = append(out, ll.mode<<6|of.mode<<4|ml.mode<<2)
+	out = append(out, ll.desc...)
+	out = append(out, of.desc...)
+	out = append(out, ml.desc...)
+
+	bw := bitWriter{out: out}
+	var llState, ofState, mlState fseEncoderState
+	last := n - 1
+	if ll.enc != nil {
+		llState.init(ll.enc, llCodes[last])
+	}
+	if of.enc != nil {
+		ofState.init(of.enc, ofCodes[last])
+	}
+	if ml.enc != nil {
+		mlState.init(ml.enc, mlCodes[last])
+	}
+	writeExtra := func(i int) {
+		s := seqs[i]
+		llc, mlc, ofc := llCodes[i], mlCodes[i], ofCodes[i]
+		bw.addBits(s.litLen-literalLengthBase[llc], uint(literalLengthBits[llc]))
+		bw.addBits(s.matchLen-matchLengthBase[mlc], uint(matchLengthBits[mlc]))
+		bw.addBits(s.offsetValue-1<<ofc, uint(ofc))
+	}
+	writeExtra(last)
+	for i := last - 1; i >= 0; i-- {
+		if of.enc != nil {
+			ofState.encode(&bw, ofCodes[i])
+		}
+		if ml.enc != nil {
+			mlState.encode(&bw, mlCodes[i])
+		}
+		if ll.enc != nil {
+			llState.encode(&bw, llCodes[i])
+		}
+		writeExtra(i)
+	}
+	if ml.enc != nil {
+		mlState.flush(&bw)
+	}
+	if of.enc != nil {
+		ofState.flush(&bw)
+	}
+	if ll.enc != nil {
+		llState.flush(&bw)
+	}
+	return bw.close()
+}
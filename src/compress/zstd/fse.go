@@ -0,0 +1,373 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import "math/bits"
+
+// Finite State Entropy coding, RFC 8878 Section 4.1.
+
+const minFSEAccuracyLog = 5
+
+// An fseEntry is one state of an FSE decoding table.
+type fseEntry struct {
+	symbol   uint8
+	bits     uint8  // number of bits to read for the next state
+	newState uint16 // base of the next state
+}
+
+// An fseTable is an FSE decoding table.
+type fseTable struct {
+	accuracyLog int
+	entries     []fseEntry
+}
+
+// spreadSymbols assigns symbols to table positions as described in
+// RFC 8878 Section 4.1.1. It is shared by the decoder and the encoder.
+// norm holds the normalized counts, with -1 marking "less than one"
+// probabilities. The returned slice maps positions to symbols.
+func spreadSymbols(norm []int16, accuracyLog int) []uint8 {
+	size := 1 << uint(accuracyLog)
+	table := make([]uint8, size)
+	high := size - 1
+	for s, n := range norm {
+		if n == -1 {
+			table[high] = uint8(s)
+			high--
+		}
+	}
+	step := size>>1 + size>>3 + 3
+	mask := size - 1
+	pos := 0
+	for s, n := range norm {
+		for i := 0; i < int(n); i++ {
+			table[pos] = uint8(s)
+			pos = (pos + step) & mask
+			for pos > high {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	return table
+}
+
+// buildFSETable builds a decoding table from normalized counts.
+func buildFSETable(norm []int16, accuracyLog int) *fseTable {
+	size := 1 << uint(accuracyLog)
+	symbols := spreadSymbols(norm, accuracyLog)
+	next := make([]uint16, len(norm))
+	for s, n := range norm {
+		if n == -1 {
+			next[s] = 1
+		} else {
+			next[s] = uint16(n)
+		}
+	}
+	t := &fseTable{accuracyLog: accuracyLog, entries: make([]fseEntry, size)}
+	for u, s := range symbols {
+		ns := next[s]
+		next[s]++
+		nb := accuracyLog - (bits.Len16(ns) - 1)
+		t.entries[u] = fseEntry{
+			symbol:   s,
+			bits:     uint8(nb),
+			newState: uint16(int(ns)<<uint(nb) - size),
+		}
+	}
+	return t
+}
+
+// rleFSETable returns a table that always decodes to sym.
+func rleFSETable(sym uint8) *fseTable {
+	return &fseTable{entries: []fseEntry{{symbol: sym}}}
+}
+
+// readFSETable reads an FSE table description from the start of data,
+// returning the table and the number of bytes consumed.
+func readFSETable(data []byte, maxSymbol, maxAccuracyLog int) (*fseTable, int, error) {
+	norm, accuracyLog, n, err := readNormalizedCounts(data, maxSymbol, maxAccuracyLog)
+	if err != nil {
+		return nil, 0, err
+	}
+	return buildFSETable(norm, accuracyLog), n, nil
+}
+
+func readNormalizedCounts(data []byte, maxSymbol, maxAccuracyLog int) ([]int16, int, int, error) {
+	br := forwardBitReader{data: data}
+	accuracyLog := int(br.readBits(4)) + minFSEAccuracyLog
+	if accuracyLog > maxAccuracyLog {
+		return nil, 0, 0, StructuralError("FSE accuracy log too large")
+	}
+	remaining := 1<<uint(accuracyLog) + 1
+	threshold := 1 << uint(accuracyLog)
+	nbBits := uint(accuracyLog + 1)
+	norm := make([]int16, 0, maxSymbol+1)
+	previous0 := false
+	for remaining > 1 {
+		if previous0 {
+			for br.peekBits(2) == 3 {
+				br.readBits(2)
+				for i := 0; i < 3; i++ {
+					norm = append(norm, 0)
+				}
+				if len(norm) > maxSymbol+1 || br.overflow() {
+					return nil, 0, 0, StructuralError("invalid FSE table description")
+				}
+			}
+			for i := br.readBits(2); i > 0; i-- {
+				norm = append(norm, 0)
+			}
+		}
+		if len(norm) > maxSymbol {
+			return nil, 0, 0, StructuralError("invalid FSE table description")
+		}
+		max := 2*threshold - 1 - remaining
+		var count int
+		if v := int(br.peekBits(nbBits - 1)); v < max {
+			count = v
+			br.readBits(nbBits - 1)
+		} else {
+			count = int(br.readBits(nbBits))
+			if count >= threshold {
+				count -= max
+			}
+		}
+		count--
+		if count < 0 {
+			remaining--
+		} else {
+			remaining -= count
+		}
+		if remaining < 1 {
+			return nil, 0, 0, StructuralError("invalid FSE table description")
+		}
+		norm = append(norm, int16(count))
+		previous0 = count == 0
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+		if br.overflow() {
+			return nil, 0, 0, StructuralError("truncated FSE table description")
+		}
+	}
+	if remaining != 1 {
+		return nil, 0, 0, StructuralError("invalid FSE table description")
+	}
+	return norm, accuracyLog, br.bytesRead(), nil
+}
+
+// An fseDecoder tracks the state of one FSE stream.
+type fseDecoder struct {
+	table *fseTable
+	state uint16
+}
+
+func (d *fseDecoder) init(br *reverseBitReader, t *fseTable) {
+	d.table = t
+	d.state = uint16(br.readBits(t.accuracyLog))
+}
+
+func (d *fseDecoder) symbol() uint8 {
+	return d.table.entries[d.state].symbol
+}
+
+func (d *fseDecoder) update(br *reverseBitReader) {
+	e := &d.table.entries[d.state]
+	d.state = e.newState + uint16(br.readBits(int(e.bits)))
+}
+
+// Predefined distributions, RFC 8878 Section 3.1.1.3.2.2.
+var (
+	predefinedLiteralLengths = []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}
+	predefinedMatchLengths = []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}
+	predefinedOffsets = []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}
+
+	predefinedLiteralLengthTable = buildFSETable(predefinedLiteralLengths, 6)
+	predefinedMatchLengthTable   = buildFSETable(predefinedMatchLengths, 6)
+	predefinedOffsetTable        = buildFSETable(predefinedOffsets, 5)
+
+	predefinedLiteralLengthEncoder = newFSEEncoder(predefinedLiteralLengths, 6)
+	predefinedMatchLengthEncoder   = newFSEEncoder(predefinedMatchLengths, 6)
+	predefinedOffsetEncoder        = newFSEEncoder(predefinedOffsets, 5)
+)
+
+// An fseSymbolTransform holds the per-symbol encoding parameters.
+type fseSymbolTransform struct {
+	deltaNbBits    uint32
+	deltaFindState int32
+}
+
+// An fseEncoder is an FSE encoding table.
+type fseEncoder struct {
+	accuracyLog int
+	stateTable  []uint16
+	symbolTT    []fseSymbolTransform
+}
+
+// newFSEEncoder builds an encoding table matching the decoding table
+// that buildFSETable produces for the same counts.
+func newFSEEncoder(norm []int16, accuracyLog int) *fseEncoder {
+	size := 1 << uint(accuracyLog)
+	symbols := spreadSymbols(norm, accuracyLog)
+	cumul := make([]int, len(norm)+1)
+	for s, n := range norm {
+		if n == -1 {
+			cumul[s+1] = cumul[s] + 1
+		} else {
+			cumul[s+1] = cumul[s] + int(n)
+		}
+	}
+	e := &fseEncoder{
+		accuracyLog: accuracyLog,
+		stateTable:  make([]uint16, size),
+		symbolTT:    make([]fseSymbolTransform, len(norm)),
+	}
+	for u, s := range symbols {
+		e.stateTable[cumul[s]] = uint16(size + u)
+		cumul[s]++
+	}
+	total := 0
+	for s, n := range norm {
+		switch n {
+		case 0:
+			e.symbolTT[s].deltaNbBits = uint32(accuracyLog+1)<<16 - uint32(size)
+		case -1, 1:
+			e.symbolTT[s].deltaNbBits = uint32(accuracyLog)<<16 - uint32(size)
+			e.symbolTT[s].deltaFindState = int32(total - 1)
+			total++
+		default:
+			maxBitsOut := uint32(accuracyLog - (bits.Len16(uint16(n-1)) - 1))
+			minStatePlus := uint32(n) << maxBitsOut
+			e.symbolTT[s].deltaNbBits = maxBitsOut<<16 - minStatePlus
+			e.symbolTT[s].deltaFindState = int32(total - int(n))
+			total += int(n)
+		}
+	}
+	return e
+}
+
+// An fseEncoderState tracks the state of one FSE stream being encoded.
+type fseEncoderState struct {
+	enc   *fseEncoder
+	value uint32
+}
+
+// init sets the initial state so that the first symbol the decoder
+// produces is sym.
+func (st *fseEncoderState) init(enc *fseEncoder, sym uint8) {
+	st.enc = enc
+	tt := enc.symbolTT[sym]
+	nbBitsOut := (tt.deltaNbBits + 1<<15) >> 16
+	v := nbBitsOut<<16 - tt.deltaNbBits
+	st.value = uint32(enc.stateTable[int32(v>>nbBitsOut)+tt.deltaFindState])
+}
+
+func (st *fseEncoderState) encode(bw *bitWriter, sym uint8) {
+	tt := st.enc.symbolTT[sym]
+	nbBitsOut := (st.value + tt.deltaNbBits) >> 16
+	bw.addBits(st.value, uint(nbBitsOut))
+	st.value = uint32(st.enc.stateTable[int32(st.value>>nbBitsOut)+tt.deltaFindState])
+}
+
+func (st *fseEncoderState) flush(bw *bitWriter) {
+	bw.addBits(st.value, uint(st.enc.accuracyLog))
+}
+
+// normalizeCounts scales the histogram counts so that they sum to
+// 1<<accuracyLog. Symbols that would round to zero get the special
+// "less than one" probability -1. It reports false if no valid
+// distribution can be found.
+func normalizeCounts(counts []int, total, accuracyLog int) ([]int16, bool) {
+	size := 1 << uint(accuracyLog)
+	norm := make([]int16, len(counts))
+	sum := 0
+	largest := -1
+	for s, c := range counts {
+		if c == 0 {
+			continue
+		}
+		n := c * size / total
+		if n == 0 {
+			norm[s] = -1
+			sum++
+		} else {
+			norm[s] = int16(n)
+			sum += n
+		}
+		if largest < 0 || c > counts[largest] {
+			largest = s
+		}
+	}
+	if largest < 0 {
+		return nil, false
+	}
+	if int(norm[largest])+size-sum < 1 {
+		return nil, false
+	}
+	norm[largest] += int16(size - sum)
+	if int(norm[largest]) == size {
+		// A single symbol owning every state cannot be encoded.
+		return nil, false
+	}
+	return norm, true
+}
+
+// writeNormalizedCounts appends the FSE table description for norm.
+func writeNormalizedCounts(out []byte, norm []int16, accuracyLog int) []byte {
+	bw := bitWriter{out: out}
+	bw.addBits(uint32(accuracyLog-minFSEAccuracyLog), 4)
+	remaining := 1<<uint(accuracyLog) + 1
+	threshold := 1 << uint(accuracyLog)
+	nbBits := uint(accuracyLog + 1)
+	previous0 := false
+	for s := 0; remaining > 1; {
+		if previous0 {
+			start := s
+			for norm[s] == 0 {
+				s++
+			}
+			for s >= start+3 {
+				start += 3
+				bw.addBits(3, 2)
+			}
+			bw.addBits(uint32(s-start), 2)
+		}
+		count := int(norm[s])
+		s++
+		max := 2*threshold - 1 - remaining
+		if count < 0 {
+			remaining += count
+		} else {
+			remaining -= count
+		}
+		count++
+		if count >= threshold {
+			count += max
+		}
+		if count < max {
+			bw.addBits(uint32(count), nbBits-1)
+		} else {
+			bw.addBits(uint32(count), nbBits)
+		}
+		previous0 = count == 1
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+	}
+	return bw.flushBytes()
+}
@@ -0,0 +1,448 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Huffman coding of literals, RFC 8878 Section 4.2.
+
+const (
+	maxHuffmanBits        = 11
+	maxHuffmanWeightLog   = 6
+	maxHuffmanSymbolValue = 255
+)
+
+// A huffEntry is one entry of a Huffman decoding table.
+type huffEntry struct {
+	symbol uint8
+	bits   uint8
+}
+
+// A huffTable is a Huffman decoding table indexed by the next maxBits
+// bits of the stream.
+type huffTable struct {
+	maxBits int
+	entries []huffEntry
+}
+
+// readHuffmanTable reads a Huffman tree description from the start of
+// data, returning the table and the number of bytes consumed.
+func readHuffmanTable(data []byte) (*huffTable, int, error) {
+	if len(data) == 0 {
+		return nil, 0, StructuralError("missing Huffman tree description")
+	}
+	var weights [maxHuffmanSymbolValue + 1]uint8
+	var nweights int
+	hdr := int(data[0])
+	n := 1
+	if hdr >= 128 {
+		// Direct representation: 4 bits per weight.
+		nweights = hdr - 127
+		size := (nweights + 1) / 2
+		if len(data) < 1+size {
+			return nil, 0, StructuralError("truncated Huffman tree description")
+		}
+		for i := 0; i < nweights; i++ {
+			b := data[1+i/2]
+			if i%2 == 0 {
+				weights[i] = b >> 4
+			} else {
+				weights[i] = b & 0xf
+			}
+		}
+		n += size
+	} else {
+		// FSE-compressed weights.
+		if len(data) < 1+hdr {
+			return nil, 0, StructuralError("truncated Huffman tree description")
+		}
+		src := data[1 : 1+hdr]
+		t, tn, err := readFSETable(src, maxHuffmanBits, maxHuffmanWeightLog)
+		if err != nil {
+			return nil, 0, err
+		}
+		var br reverseBitReader
+		if err := br.init(src[tn:]); err != nil {
+			return nil, 0, err
+		}
+		var s1, s2 fseDecoder
+		s1.init(&br, t)
+		s2.init(&br, t)
+		for {
+			if nweights >= maxHuffmanSymbolValue-1 {
+				return nil, 0, StructuralError("too many Huffman weights")
+			}
+			weights[nweights] = s1.symbol()
+			nweights++
+			s1.update(&br)
+			if br.overflow() {
+				weights[nweights] = s2.symbol()
+				nweights++
+				break
+			}
+			weights[nweights] = s2.symbol()
+			nweights++
+			s2.update(&br)
+			if br.overflow() {
+				weights[nweights] = s1.symbol()
+				nweights++
+				break
+			}
+		}
+		n += hdr
+	}
+	t, err := buildHuffmanTable(weights[:], nweights)
+	if err != nil {
+		return nil, 0, err
+	}
+	return t, n, nil
+}
+
+// buildHuffmanTable builds a decoding table from the first nweights
+// weights, deriving the weight of the final symbol.
+func buildHuffmanTable(weights []uint8, nweights int) (*huffTable, error) {
+	if nweights >= len(weights) {
+		return nil, StructuralError("too many Huffman weights")
+	}
+	total := 0
+	for _, w := range weights[:nweights] {
+		if w > maxHuffmanBits {
+			return nil, StructuralError("invalid Huffman weight")
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return nil, StructuralError("invalid Huffman weights")
+	}
+	maxBits := bits.Len(uint(total))
+	if maxBits > maxHuffmanBits {
+		return nil, StructuralError("Huffman table too deep")
+	}
+	rest := 1<<uint(maxBits) - total
+	if rest&(rest-1) != 0 {
+		return nil, StructuralError("incomplete Huffman tree")
+	}
+	weights[nweights] = uint8(bits.Len(uint(rest)))
+	nsym := nweights + 1
+
+	var rankStart [maxHuffmanBits + 2]int
+	for _, w := range weights[:nsym] {
+		if w > 0 {
+			rankStart[w] += 1 << (w - 1)
+		}
+	}
+	next := 0
+	for w := 1; w <= maxBits; w++ {
+		c := rankStart[w]
+		rankStart[w] = next
+		next += c
+	}
+	t := &huffTable{maxBits: maxBits, entries: make([]huffEntry, 1<<uint(maxBits))}
+	for s, w := range weights[:nsym] {
+		if w == 0 {
+			continue
+		}
+		length := 1 << (w - 1)
+		e := huffEntry{symbol: uint8(s), bits: uint8(maxBits + 1 - int(w))}
+		for i := rankStart[w]; i < rankStart[w]+length; i++ {
+			t.entries[i] = e
+		}
+		rankStart[w] += length
+	}
+	return t, nil
+}
+
+// decodeStream decodes len(out) literals from a single Huffman stream.
+func (t *huffTable) decodeStream(out, data []byte) error {
+	var br reverseBitReader
+	if err := br.init(data); err != nil {
+		return err
+	}
+	for i := range out {
+		e := t.entries[br.peekBits(t.maxBits)]
+		br.skipBits(int(e.bits))
+		out[i] = e.symbol
+	}
+	if !br.finished() {
+		return StructuralError("Huffman stream size mismatch")
+	}
+	return nil
+}
+
+// decode decodes len(out) literals from one or four Huffman streams.
+func (t *huffTable) decode(out, data []byte, fourStreams bool) error {
+	if !fourStreams {
+		return t.decodeStream(out, data)
+	}
+	if len(data) < 6 {
+		return StructuralError("truncated Huffman jump table")
+	}
+	s1 := int(le.Uint16(data[0:]))
+	s2 := int(le.Uint16(data[2:]))
+	s3 := int(le.Uint16(data[4:]))
+	data = data[6:]
+	if s1+s2+s3 > len(data) {
+		return StructuralError("invalid Huffman jump table")
+	}
+	seg := (len(out) + 3) / 4
+	if 3*seg > len(out) {
+		return StructuralError("too few literals for four streams")
+	}
+	streams := [4][]byte{data[:s1], data[s1 : s1+s2], data[s1+s2 : s1+s2+s3], data[s1+s2+s3:]}
+	for i, s := range streams {
+		o := out[i*seg:]
+		if i < 3 {
+			o = o[:seg]
+		}
+		if err := t.decodeStream(o, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A huffEncoder holds the code of each symbol.
+type huffEncoder struct {
+	maxBits int
+	codes   [maxHuffmanSymbolValue + 1]uint16
+	lengths [maxHuffmanSymbolValue + 1]uint8
+	maxSym  int
+}
+
+// newHuffEncoder builds a length-limited Huffman code for the histogram.
+// It returns nil if fewer than two symbols occur.
+func newHuffEncoder(counts *[256]int) *huffEncoder {
+	type node struct {
+		count  int
+		sym    int // leaf symbol, or -1
+		parent int
+	}
+	var nodes []node
+	maxSym := -1
+	for s, c := range counts {
+		if c > 0 {
+			nodes = append(nodes, node{count: c, sym: s, parent: -1})
+			maxSym = s
+		}
+	}
+	nleaves := len(nodes)
+	if nleaves < 2 {
+		return nil
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].count < nodes[j].count })
+
+	// Two-queue Huffman construction over leaves sorted by count.
+	leaf, inner := 0, nleaves
+	pick := func() int {
+		if leaf < nleaves && (inner >= len(nodes) || nodes[leaf].count <= nodes[inner].count) {
+			leaf++
+			return leaf - 1
+		}
+		inner++
+		return inner - 1
+	}
+	for len(nodes)-nleaves < nleaves-1 {
+		a := pick()
+		b := pick()
+		nodes = append(nodes, node{count: nodes[a].count + nodes[b].count, sym: -1, parent: -1})
+		nodes[a].parent = len(nodes) - 1
+		nodes[b].parent = len(nodes) - 1
+	}
+	depth := make([]int, len(nodes))
+	for i := len(nodes) - 2; i >= 0; i-- {
+		depth[i] = depth[nodes[i].parent] + 1
+	}
+
+	// Leaves in order of decreasing count.
+	ls := make([]huffLeaf, nleaves)
+	for i := 0; i < nleaves; i++ {
+		ls[nleaves-1-i] = huffLeaf{nodes[i].sym, depth[i]}
+	}
+	limitLengths(ls, maxHuffmanBits)
+
+	e := &huffEncoder{maxSym: maxSym}
+	for _, l := range ls {
+		e.lengths[l.sym] = uint8(l.length)
+		if l.length > e.maxBits {
+			e.maxBits = l.length
+		}
+	}
+	e.assignCodes()
+	return e
+}
+
+type huffLeaf struct {
+	sym, length int
+}
+
+// limitLengths adjusts code lengths, given in order of decreasing count,
+// so that none exceeds limit while keeping the code complete.
+func limitLengths(ls []huffLeaf, limit int) {
+	unit := func(l int) int { return 1 << uint(limit-l) }
+	kraft := 0
+	for i := range ls {
+		if ls[i].length > limit {
+			ls[i].length = limit
+		}
+		kraft += unit(ls[i].length)
+	}
+	full := 1 << uint(limit)
+	// Lengthen the least frequent codes that are still short enough
+	// until the code is no longer over-subscribed.
+	for kraft > full {
+		for i := len(ls) - 1; i >= 0 && kraft > full; i-- {
+			if ls[i].length < limit {
+				kraft -= unit(ls[i].length + 1)
+				ls[i].length++
+			}
+		}
+	}
+	// Shorten the longest codes, preferring frequent symbols, until the
+	// code is complete again.
+	for kraft < full {
+		longest := 0
+		for i := range ls {
+			if ls[i].length > ls[longest].length {
+				longest = i
+			}
+		}
+		kraft += unit(ls[longest].length)
+		ls[longest].length--
+	}
+}
+
+// weights returns the Huffman weight of every symbol up to maxSym.
+func (e *huffEncoder) weights() []uint8 {
+	w := make([]uint8, e.maxSym+1)
+	for s := range w {
+		if l := int(e.lengths[s]); l > 0 {
+			w[s] = uint8(e.maxBits + 1 - l)
+		}
+	}
+	return w
+}
+
+// assignCodes computes the canonical codes that buildHuffmanTable
+// derives from the same weights.
+func (e *huffEncoder) assignCodes() {
+	w := e.weights()
+	var rankStart [maxHuffmanBits + 2]int
+	for _, x := range w {
+		if x > 0 {
+			rankStart[x] += 1 << (x - 1)
+		}
+	}
+	next := 0
+	for x := 1; x <= e.maxBits; x++ {
+		c := rankStart[x]
+		rankStart[x] = next
+		next += c
+	}
+	for s, x := range w {
+		if x == 0 {
+			continue
+		}
+		nb := e.maxBits + 1 - int(x)
+		e.codes[s] = uint16(rankStart[x] >> uint(e.maxBits-nb))
+		rankStart[x] += 1 << (x - 1)
+	}
+}
+
+// estimateSize returns the size in bytes of the encoded literals,
+// excluding the tree description.
+func (e *huffEncoder) estimateSize(counts *[256]int) int {
+	n := 0
+	for s, c := range counts {
+		n += c * int(e.lengths[s])
+	}
+	return (n + 7) / 8
+}
+
+// appendTable appends the tree description. It reports false if the
+// weights cannot be described.
+func (e *huffEncoder) appendTable(out []byte) ([]byte, bool) {
+	w := e.weights()
+	w = w[:len(w)-1] // the last weight is implied
+	// Try FSE compression first when it can be smaller.
+	if len(w) > 2 {
+		if enc, ok := compressWeights(w); ok && (len(w) > 128 || len(enc) < (len(w)+1)/2) {
+			out = append(out, byte(len(enc)))
+			return append(out, enc...), true
+		}
+	}
+	if len(w) > 128 {
+		return out, false
+	}
+	out = append(out, byte(127+len(w)))
+	for i := 0; i < len(w); i += 2 {
+		b := w[i] << 4
+		if i+1 < len(w) {
+			b |= w[i+1]
+		}
+		out = append(out, b)
+	}
+	return out, true
+}
+
+// compressWeights FSE-encodes Huffman weights using two interleaved
+// states, the inverse of the decoding loop in readHuffmanTable.
+func compressWeights(w []uint8) ([]byte, bool) {
+	var counts [maxHuffmanBits + 1]int
+	for _, x := range w {
+		counts[x]++
+	}
+	maxSym := 0
+	for s, c := range counts {
+		if c > 0 {
+			maxSym = s
+		}
+	}
+	norm, ok := normalizeCounts(counts[:maxSym+1], len(w), maxHuffmanWeightLog)
+	if !ok {
+		return nil, false
+	}
+	out := writeNormalizedCounts(nil, norm, maxHuffmanWeightLog)
+	enc := newFSEEncoder(norm, maxHuffmanWeightLog)
+	bw := bitWriter{out: out}
+	var s1, s2 fseEncoderState
+	i := len(w)
+	if i&1 != 0 {
+		s1.init(enc, w[i-1])
+		s2.init(enc, w[i-2])
+		s1.encode(&bw, w[i-3])
+		i -= 3
+	} else {
+		s2.init(enc, w[i-1])
+		s1.init(enc, w[i-2])
+		i -= 2
+	}
+	for i > 0 {
+		s2.encode(&bw, w[i-1])
+		s1.encode(&bw, w[i-2])
+		i -= 2
+	}
+	s2.flush(&bw)
+	s1.flush(&bw)
+	out = bw.close()
+	if len(out) >= 128 {
+		return nil, false
+	}
+	return out, true
+}
+
+// encodeStream appends the Huffman encoding of src as a single stream.
+func (e *huffEncoder) encodeStream(out, src []byte) []byte {
+	bw := bitWriter{out: out}
+	for i := len(src) - 1; i >= 0; i-- {
+		s := src[i]
+		bw.addBits(uint32(e.codes[s]), uint(e.lengths[s]))
+	}
+	return bw.close()
+}
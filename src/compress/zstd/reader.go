@@ -0,0 +1,337 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bufio"
+	"io"
+)
+
+// A Reader is an io.Reader that can be read to retrieve uncompressed
+// data from a Zstandard stream.
+//
+// A Zstandard stream is a sequence of frames. Reads from the Reader
+// return the concatenation of the uncompressed content of each frame;
+// skippable frames are ignored.
+//
+// Frames may carry a checksum of their content. The Reader returns
+// ErrChecksum when Read reaches the end of such a frame if the content
+// does not match. Clients should treat data returned by Read as
+// tentative until they receive the io.EOF marking the end of the data.
+type Reader struct {
+	r     io.Reader
+	dicts []*dict
+	err   error
+
+	buf [18]byte // frame and block headers
+
+	// Per-frame state.
+	inFrame     bool
+	lastBlock   bool
+	windowSize  int
+	hasChecksum bool
+	hasSize     bool
+	size        uint64 // content size from the frame header
+	decoded     uint64 // content bytes decoded so far
+	digest      xxhash
+	block       blockDecoder
+
+	// hist holds the window of previously decoded data followed by the
+	// output that has not been read yet, which starts at hist[off].
+	hist  []byte
+	off   int
+	input []byte // compressed block data
+}
+
+// NewReader creates a new Reader reading the given reader.
+// If r does not also implement io.ByteReader,
+// the decompressor may read more data than necessary from r.
+//
+// NewReader reads the header of the first frame and returns an error
+// if it is invalid. A stream holding no frames at all yields io.EOF.
+func NewReader(r io.Reader) (*Reader, error) {
+	return NewReaderDict(r)
+}
+
+// NewReaderDict is like NewReader but makes the given dictionaries
+// available to frames that require them.
+//
+// Each dictionary is either in the Zstandard dictionary format, in which
+// case it is used for frames naming its ID, or raw content. Frames that
+// do not name a dictionary use the first dictionary, if any.
+func NewReaderDict(r io.Reader, dicts ...[]byte) (*Reader, error) {
+	z := new(Reader)
+	for _, b := range dicts {
+		d, err := parseDict(b)
+		if err != nil {
+			return nil, err
+		}
+		z.dicts = append(z.dicts, d)
+	}
+	if err := z.Reset(r); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// Reset discards the Reader z's state and makes it equivalent to the
+// result of its original state from NewReader or NewReaderDict, but
+// reading from r instead. The dictionaries are retained. This permits
+// reusing a Reader rather than allocating a new one.
+func (z *Reader) Reset(r io.Reader) error {
+	*z = Reader{
+		dicts: z.dicts,
+		hist:  z.hist[:0],
+		input: z.input,
+		block: blockDecoder{literals: z.block.literals},
+	}
+	if _, ok := r.(io.ByteReader); ok {
+		z.r = r
+	} else {
+		z.r = bufio.NewReader(r)
+	}
+	z.err = z.readFrameHeader()
+	return z.err
+}
+
+// readFrameHeader skips any skippable frames and reads the next frame
+// header, RFC 8878 Section 3.1.1.1. It returns io.EOF if the input ends
+// before a new frame starts. This method does not set z.err.
+func (z *Reader) readFrameHeader() error {
+	for {
+		if _, err := io.ReadFull(z.r, z.buf[:4]); err != nil {
+			return err
+		}
+		magic := le.Uint32(z.buf[:4])
+		if magic == frameMagic {
+			break
+		}
+		if magic&skippableMagicMask != skippableMagic {
+			return ErrHeader
+		}
+		if _, err := io.ReadFull(z.r, z.buf[:4]); err != nil {
+			return noEOF(err)
+		}
+		n := int64(le.Uint32(z.buf[:4]))
+		if m, err := io.CopyN(io.Discard, z.r, n); m != n {
+			if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+			return noEOF(err)
+		}
+	}
+
+	if _, err := io.ReadFull(z.r, z.buf[:1]); err != nil {
+		return noEOF(err)
+	}
+	desc := z.buf[0]
+	sizeFlag := desc >> 6
+	singleSegment := desc&(1<<5) != 0
+	if desc&(1<<3) != 0 {
+		return ErrHeader
+	}
+	z.hasChecksum = desc&(1<<2) != 0
+	dictIDSize := [4]int{0, 1, 2, 4}[desc&3]
+	sizeSize := [4]int{0, 2, 4, 8}[sizeFlag]
+	if sizeFlag == 0 && singleSegment {
+		sizeSize = 1
+	}
+	n := dictIDSize + sizeSize
+	if !singleSegment {
+		n++
+	}
+	b := z.buf[:n]
+	if _, err := io.ReadFull(z.r, b); err != nil {
+		return noEOF(err)
+	}
+
+	var windowSize uint64
+	if !singleSegment {
+		exp := uint(b[0] >> 3)
+		mantissa := uint64(b[0] & 7)
+		windowLog := 10 + exp
+		base := uint64(1) << windowLog
+		windowSize = base + base/8*mantissa
+		b = b[1:]
+	}
+
+	var dictID uint32
+	switch dictIDSize {
+	case 1:
+		dictID = uint32(b[0])
+	case 2:
+		dictID = uint32(le.Uint16(b))
+	case 4:
+		dictID = le.Uint32(b)
+	}
+	b = b[dictIDSize:]
+
+	z.hasSize = sizeSize > 0
+	switch sizeSize {
+	case 1:
+		z.size = uint64(b[0])
+	case 2:
+		z.size = uint64(le.Uint16(b)) + 256
+	case 4:
+		z.size = uint64(le.Uint32(b))
+	case 8:
+		z.size = le.Uint64(b)
+	}
+	if singleSegment {
+		windowSize = z.size
+	}
+	if windowSize > maxWindowSize {
+		return ErrWindowTooLarge
+	}
+	z.windowSize = int(windowSize)
+
+	var d *dict
+	if dictID != 0 {
+		for _, dd := range z.dicts {
+			if dd.id == dictID {
+				d = dd
+				break
+			}
+		}
+		if d == nil {
+			return ErrMissingDictionary
+		}
+	} else if len(z.dicts) > 0 {
+		d = z.dicts[0]
+	}
+
+	z.block.reset(d)
+	z.hist = z.hist[:0]
+	if d != nil {
+		z.hist = append(z.hist, d.content...)
+	}
+	z.off = len(z.hist)
+	z.inFrame = true
+	z.lastBlock = false
+	z.decoded = 0
+	z.digest.reset()
+	return nil
+}
+
+// readBlock decodes the next block of the current frame,
+// RFC 8878 Section 3.1.1.2. This method does not set z.err.
+func (z *Reader) readBlock() error {
+	// Discard history that has fallen out of the window. Everything in
+	// hist has been read at this point.
+	if keep := z.windowSize; len(z.hist) > 2*keep+maxBlockSize {
+		n := copy(z.hist, z.hist[len(z.hist)-keep:])
+		z.hist = z.hist[:n]
+		z.off = n
+	}
+
+	if _, err := io.ReadFull(z.r, z.buf[:3]); err != nil {
+		return noEOF(err)
+	}
+	hdr := uint32(z.buf[0]) | uint32(z.buf[1])<<8 | uint32(z.buf[2])<<16
+	z.lastBlock = hdr&1 != 0
+	typ := hdr >> 1 & 3
+	size := int(hdr >> 3)
+
+	start := len(z.hist)
+	switch typ {
+	case blockRaw:
+		if size > maxBlockSize {
+			return StructuralError("block too large")
+		}
+		z.hist = append(z.hist, make([]byte, size)...)
+		if _, err := io.ReadFull(z.r, z.hist[start:]); err != nil {
+			return noEOF(err)
+		}
+	case blockRLE:
+		if size > maxBlockSize {
+			return StructuralError("block too large")
+		}
+		if _, err := io.ReadFull(z.r, z.buf[:1]); err != nil {
+			return noEOF(err)
+		}
+		for i := 0; i < size; i++ {
+			z.hist = append(z.hist, z.buf[0])
+		}
+	case blockCompressed:
+		if size > maxBlockSize {
+			return StructuralError("block too large")
+		}
+		if cap(z.input) < size {
+			z.input = make([]byte, size, maxBlockSize)
+		}
+		z.input = z.input[:size]
+		if _, err := io.ReadFull(z.r, z.input); err != nil {
+			return noEOF(err)
+		}
+		var err error
+		if z.hist, err = z.block.decode(z.input, z.hist); err != nil {
+			return err
+		}
+	default:
+		return StructuralError("reserved block type")
+	}
+
+	out := z.hist[start:]
+	z.decoded += uint64(len(out))
+	if z.hasSize && z.decoded > z.size {
+		return StructuralError("frame content exceeds declared size")
+	}
+	if z.hasChecksum {
+		z.digest.write(out)
+	}
+	return nil
+}
+
+// finishFrame verifies the end of the current frame.
+// This method does not set z.err.
+func (z *Reader) finishFrame() error {
+	z.inFrame = false
+	if z.hasSize && z.decoded != z.size {
+		return StructuralError("frame content does not match declared size")
+	}
+	if !z.hasChecksum {
+		return nil
+	}
+	if _, err := io.ReadFull(z.r, z.buf[:4]); err != nil {
+		return noEOF(err)
+	}
+	if le.Uint32(z.buf[:4]) != uint32(z.digest.sum64()) {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// Read implements io.Reader, reading uncompressed bytes from its
+// underlying Reader.
+func (z *Reader) Read(p []byte) (n int, err error) {
+	for {
+		if z.off < len(z.hist) {
+			n = copy(p, z.hist[z.off:])
+			z.off += n
+			return n, nil
+		}
+		if z.err != nil {
+			return 0, z.err
+		}
+		switch {
+		case !z.inFrame:
+			z.err = z.readFrameHeader()
+		case z.lastBlock:
+			z.err = z.finishFrame()
+		default:
+			z.err = z.readBlock()
+		}
+	}
+}
+
+// Close closes the Reader. It does not close the underlying io.Reader.
+// In order for the Zstandard checksums to be verified, the reader must
+// be fully consumed until the io.EOF.
+func (z *Reader) Close() error {
+	if z.err == io.EOF {
+		return nil
+	}
+	return z.err
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func mustReadFile(t testing.TB, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// The files in testdata were produced by the reference zstd tool:
+//
+//	zstd -19 e.txt
+//	zstd -3 --long=24 Isaac.Newton-Opticks.txt
+//	zstd --train ... --maxdict=4096 -o dict
+//	zstd -D dict gettysburg.txt
+//
+// gettysburg.txt.zst holds a frame made with zstd -1 --no-check,
+// a skippable frame and a frame made with zstd -19.
+func TestReader(t *testing.T) {
+	gettysburg := mustReadFile(t, "../testdata/gettysburg.txt")
+	tests := []struct {
+		name string
+		want []byte
+		dict []byte
+	}{
+		{"testdata/e.txt.zst", mustReadFile(t, "../testdata/e.txt"), nil},
+		{"testdata/Isaac.Newton-Opticks.txt.zst", mustReadFile(t, "../../testdata/Isaac.Newton-Opticks.txt"), nil},
+		{"testdata/gettysburg.txt.zst", append(append([]byte(nil), gettysburg...), gettysburg...), nil},
+		{"testdata/gettysburg.txt.dict.zst", gettysburg, mustReadFile(t, "testdata/dict")},
+	}
+	for _, tt := range tests {
+		var r *Reader
+		var err error
+		if tt.dict != nil {
+			r, err = NewReaderDict(bytes.NewReader(mustReadFile(t, tt.name)), tt.dict)
+		} else {
+			r, err = NewReader(bytes.NewReader(mustReadFile(t, tt.name)))
+		}
+		if err != nil {
+			t.Errorf("%s: NewReader: %v", tt.name, err)
+			continue
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Errorf("%s: ReadAll: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: got %d bytes, want %d bytes", tt.name, len(got), len(tt.want))
+		}
+		if err := r.Close(); err != nil {
+			t.Errorf("%s: Close: %v", tt.name, err)
+		}
+	}
+}
+
+func TestReaderMissingDictionary(t *testing.T) {
+	_, err := NewReader(bytes.NewReader(mustReadFile(t, "testdata/gettysburg.txt.dict.zst")))
+	if err != ErrMissingDictionary {
+		t.Errorf("NewReader: got %v, want %v", err, ErrMissingDictionary)
+	}
+}
+
+func TestReaderEmpty(t *testing.T) {
+	if _, err := NewReader(strings.NewReader("")); err != io.EOF {
+		t.Errorf("NewReader on empty input: got %v, want io.EOF", err)
+	}
+	// A single skippable frame and nothing else.
+	if _, err := NewReader(strings.NewReader("\x50\x2a\x4d\x18\x01\x00\x00\x00x")); err != io.EOF {
+		t.Errorf("NewReader on skippable frame: got %v, want io.EOF", err)
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	valid := mustReadFile(t, "testdata/e.txt.zst")
+	corrupt := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte(nil), valid...))
+	}
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"bad magic", []byte("\x28\xb5\x2f\xfe\x00"), ErrHeader},
+		{"reserved bit", []byte("\x28\xb5\x2f\xfd\x08\x00"), ErrHeader},
+		{"window too large", []byte("\x28\xb5\x2f\xfd\x00\xf8"), ErrWindowTooLarge},
+		{"checksum", corrupt(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }), ErrChecksum},
+		{"truncated", valid[:len(valid)/2], io.ErrUnexpectedEOF},
+		{"truncated checksum", valid[:len(valid)-2], io.ErrUnexpectedEOF},
+		{"trailing garbage", append(append([]byte(nil), valid...), 1, 2, 3, 4), ErrHeader},
+		{"reserved block type", []byte("\x28\xb5\x2f\xfd\x00\x00\x07\x00\x00"), StructuralError("")},
+		{"oversized block", []byte("\x28\xb5\x2f\xfd\x00\x00\x09\x00\x20"), StructuralError("")},
+	}
+	for _, tt := range tests {
+		r, err := NewReader(bytes.NewReader(tt.data))
+		if err == nil {
+			_, err = io.Copy(io.Discard, r)
+		}
+		if _, ok := tt.err.(StructuralError); ok {
+			var se StructuralError
+			if !errors.As(err, &se) {
+				t.Errorf("%s: got %v, want a StructuralError", tt.name, err)
+			}
+			continue
+		}
+		if err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+// TestReaderCorrupt checks that damaged input produces errors rather
+// than panics.
+func TestReaderCorrupt(t *testing.T) {
+	data := mustReadFile(t, "testdata/gettysburg.txt.zst")
+	dictData := mustReadFile(t, "testdata/gettysburg.txt.dict.zst")
+	dict := mustReadFile(t, "testdata/dict")
+	seed := uint32(1)
+	rand := func(n int) int {
+		seed = seed*1664525 + 1013904223
+		return int(seed>>8) % n
+	}
+	for i := 0; i < 2000; i++ {
+		src, dicts := data, [][]byte(nil)
+		if i%2 == 1 {
+			src, dicts = dictData, [][]byte{dict}
+		}
+		b := append([]byte(nil), src...)
+		for k := rand(4); k >= 0; k-- {
+			b[rand(len(b))] ^= 1 << rand(8)
+		}
+		r, err := NewReaderDict(bytes.NewReader(b), dicts...)
+		if err != nil {
+			continue
+		}
+		io.Copy(io.Discard, r)
+	}
+}
+
+func TestReaderReset(t *testing.T) {
+	e := mustReadFile(t, "testdata/e.txt.zst")
+	g := mustReadFile(t, "testdata/gettysburg.txt.dict.zst")
+	r, err := NewReaderDict(bytes.NewReader(e), mustReadFile(t, "testdata/dict"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reset(bytes.NewReader(g)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := mustReadFile(t, "../testdata/gettysburg.txt"); !bytes.Equal(got, want) {
+		t.Errorf("after Reset: got %q, want %q", got, want)
+	}
+}
+
+func TestXXHash(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+		{"Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+	}
+	for _, tt := range tests {
+		var h xxhash
+		h.reset()
+		h.write([]byte(tt.in))
+		if got := h.sum64(); got != tt.want {
+			t.Errorf("xxhash(%q) = %#x, want %#x", tt.in, got, tt.want)
+		}
+		// Byte at a time.
+		h.reset()
+		for i := 0; i < len(tt.in); i++ {
+			h.write([]byte{tt.in[i]})
+		}
+		if got := h.sum64(); got != tt.want {
+			t.Errorf("xxhash(%q) in single bytes = %#x, want %#x", tt.in, got, tt.want)
+		}
+	}
+}
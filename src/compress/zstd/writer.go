@@ -0,0 +1,275 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A Writer is an io.WriteCloser.
+// Writes to a Writer are compressed and written to w.
+//
+// The output is a single Zstandard frame with a content checksum.
+type Writer struct {
+	w           io.Writer
+	level       int
+	dict        *dict
+	err         error
+	wroteHeader bool
+	closed      bool
+	digest      xxhash
+	enc         encoder
+
+	// hist holds the window of previously compressed data followed by
+	// the pending input, which starts at hist[pos].
+	hist []byte
+	pos  int
+	out  []byte
+}
+
+// NewWriter returns a new Writer.
+// Writes to the returned writer are compressed and written to w.
+//
+// It is the caller's responsibility to call Close on the Writer when done.
+// Writes may be buffered and not flushed until Close.
+func NewWriter(w io.Writer) *Writer {
+	z, _ := NewWriterLevel(w, DefaultCompression)
+	return z
+}
+
+// NewWriterLevel is like NewWriter but specifies the compression level instead
+// of assuming DefaultCompression.
+//
+// The compression level can be DefaultCompression or any integer value
+// between BestSpeed and BestCompression inclusive. Higher levels search
+// harder for matches and use a larger window.
+// The error returned will be nil if the level is valid.
+func NewWriterLevel(w io.Writer, level int) (*Writer, error) {
+	return NewWriterLevelDict(w, level, nil)
+}
+
+// NewWriterLevelDict is like NewWriterLevel but specifies a dictionary to
+// compress with.
+//
+// The dictionary is either in the Zstandard dictionary format, in which
+// case its ID is recorded in the frame header, or raw content. The same
+// dictionary must be supplied to NewReaderDict to decompress the data.
+func NewWriterLevelDict(w io.Writer, level int, dict []byte) (*Writer, error) {
+	if level == DefaultCompression {
+		level = 2
+	}
+	if level < BestSpeed || level > BestCompression {
+		return nil, fmt.Errorf("zstd: invalid compression level: %d", level)
+	}
+	z := &Writer{level: level}
+	if dict != nil {
+		d, err := parseDict(dict)
+		if err != nil {
+			return nil, err
+		}
+		z.dict = d
+	}
+	z.enc.init(levels[level])
+	z.Reset(w)
+	return z, nil
+}
+
+// Reset discards the Writer z's state and makes it equivalent to the
+// result of its original state from NewWriter, NewWriterLevel or
+// NewWriterLevelDict, but writing to w instead. This permits reusing a
+// Writer rather than allocating a new one.
+func (z *Writer) Reset(w io.Writer) {
+	*z = Writer{
+		w:     w,
+		level: z.level,
+		dict:  z.dict,
+		enc:   z.enc,
+		hist:  z.hist[:0],
+		out:   z.out[:0],
+	}
+	z.digest.reset()
+	reps := [3]uint32{1, 4, 8}
+	if z.dict != nil {
+		reps = z.dict.reps
+		content := z.dict.content
+		if len(content) > z.enc.windowSize {
+			content = content[len(content)-z.enc.windowSize:]
+		}
+		z.hist = append(z.hist, content...)
+	}
+	z.enc.reset(reps)
+	for i := 0; i+blockTail <= len(z.hist); i++ {
+		z.enc.insert(z.hist, i)
+	}
+	z.pos = len(z.hist)
+}
+
+// writeHeader writes the frame header, RFC 8878 Section 3.1.1.1.
+func (z *Writer) writeHeader() error {
+	z.wroteHeader = true
+	var id uint32
+	if z.dict != nil {
+		id = z.dict.id
+	}
+	desc := byte(1 << 2) // content checksum
+	var idBytes int
+	switch {
+	case id == 0:
+	case id < 1<<8:
+		desc |= 1
+		idBytes = 1
+	case id < 1<<16:
+		desc |= 2
+		idBytes = 2
+	default:
+		desc |= 3
+		idBytes = 4
+	}
+	var buf [10]byte
+	le.PutUint32(buf[:], frameMagic)
+	buf[4] = desc
+	buf[5] = byte(z.enc.p.windowLog-10) << 3
+	le.PutUint32(buf[6:], id)
+	_, err := z.w.Write(buf[:6+idBytes])
+	return err
+}
+
+// Write writes a compressed form of p to the underlying io.Writer. The
+// compressed bytes are not necessarily flushed until the Writer is closed.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.closed {
+		return 0, errors.New("zstd: write to closed Writer")
+	}
+	n := len(p)
+	z.digest.write(p)
+	for len(p) > 0 {
+		if len(z.hist)-z.pos == maxBlockSize {
+			if z.err = z.writeBlock(false); z.err != nil {
+				return 0, z.err
+			}
+		}
+		z.makeRoom()
+		m := maxBlockSize - (len(z.hist) - z.pos)
+		if m > len(p) {
+			m = len(p)
+		}
+		z.hist = append(z.hist, p[:m]...)
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// makeRoom drops history that has fallen out of the window once the
+// buffer grows past twice the window size.
+func (z *Writer) makeRoom() {
+	window := z.enc.windowSize
+	if len(z.hist) <= 2*window || z.pos <= window {
+		return
+	}
+	n := z.pos - window
+	if c := len(z.enc.chain); c > 0 {
+		n -= n % c
+	}
+	if n <= 0 {
+		return
+	}
+	copy(z.hist, z.hist[n:])
+	z.hist = z.hist[:len(z.hist)-n]
+	z.pos -= n
+	z.enc.shift(n)
+}
+
+// writeBlock compresses and writes the pending input as one block.
+func (z *Writer) writeBlock(last bool) error {
+	if !z.wroteHeader {
+		if err := z.writeHeader(); err != nil {
+			return err
+		}
+	}
+	src := z.hist[z.pos:]
+	hdr := uint32(len(src)) << 3
+	if last {
+		hdr |= 1
+	}
+	out := append(z.out[:0], 0, 0, 0)
+
+	rle := len(src) > 1
+	for _, c := range src {
+		if c != src[0] {
+			rle = false
+			break
+		}
+	}
+	switch {
+	case rle:
+		hdr |= blockRLE << 1
+		out = append(out, src[0])
+	case len(src) > blockTail+minMatch:
+		reps := z.enc.reps
+		z.enc.parse(z.hist, z.pos, len(z.hist))
+		out = z.enc.encodeBlock(out)
+		if n := len(out) - 3; n < len(src) {
+			hdr = uint32(n)<<3 | blockCompressed<<1 | hdr&1
+			break
+		}
+		// A raw block leaves the decoder's repeat offsets unchanged.
+		z.enc.reps = reps
+		out = append(out[:3], src...)
+	default:
+		out = append(out, src...)
+	}
+	out[0], out[1], out[2] = byte(hdr), byte(hdr>>8), byte(hdr>>16)
+	z.out = out
+	z.pos = len(z.hist)
+	_, err := z.w.Write(out)
+	return err
+}
+
+// Flush writes any pending data to the underlying writer as a complete
+// block, so that a reader can decompress everything written so far.
+//
+// It is useful mainly in compressed network protocols, to ensure that
+// a remote reader has enough data to reconstruct a packet. Flush does
+// not return until the data has been written. If the underlying
+// writer returns an error, Flush returns that error.
+func (z *Writer) Flush() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	if len(z.hist) > z.pos {
+		z.err = z.writeBlock(false)
+	} else if !z.wroteHeader {
+		z.err = z.writeHeader()
+	}
+	return z.err
+}
+
+// Close closes the Writer by flushing any unwritten data to the underlying
+// io.Writer and writing the frame checksum.
+// It does not close the underlying io.Writer.
+func (z *Writer) Close() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	z.closed = true
+	if z.err = z.writeBlock(true); z.err != nil {
+		return z.err
+	}
+	var buf [4]byte
+	le.PutUint32(buf[:], uint32(z.digest.sum64()))
+	_, z.err = z.w.Write(buf[:])
+	return z.err
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func roundTrip(t *testing.T, data []byte, level int, dict []byte) int {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriterLevelDict(&buf, level, dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write in uneven pieces to exercise buffering.
+	for p := data; len(p) > 0; {
+		n := 12345
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	size := buf.Len()
+	var dicts [][]byte
+	if dict != nil {
+		dicts = append(dicts, dict)
+	}
+	r, err := NewReaderDict(&buf, dicts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("round trip mismatch: got %d bytes, want %d bytes", len(got), len(data))
+	}
+	return size
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	isaac := mustReadFile(t, "../../testdata/Isaac.Newton-Opticks.txt")
+	// Pseudo-random bytes do not compress and take the raw block path.
+	random := make([]byte, 300000)
+	seed := uint32(1)
+	for i := range random {
+		seed = seed*1664525 + 1013904223
+		random[i] = byte(seed >> 24)
+	}
+	// A long input with repeats farther apart than the smaller windows
+	// exercises window sliding.
+	long := bytes.Join([][]byte{isaac, random[:100000], isaac, isaac}, nil)
+	inputs := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"short", []byte("hello, world")},
+		{"zeros", make([]byte, 300000)},
+		{"e.txt", mustReadFile(t, "../testdata/e.txt")},
+		{"random", random},
+		{"isaac", isaac},
+		{"long", long},
+	}
+	for _, in := range inputs {
+		for level := BestSpeed; level <= BestCompression; level++ {
+			t.Run(fmt.Sprintf("%s/%d", in.name, level), func(t *testing.T) {
+				if testing.Short() && len(in.data) > len(isaac) && level > BestSpeed {
+					t.Skip("skipping in short mode")
+				}
+				size := roundTrip(t, in.data, level, nil)
+				if in.name == "isaac" && size > len(in.data)/2 {
+					t.Errorf("compressed %d bytes to %d, want at most half", len(in.data), size)
+				}
+			})
+		}
+	}
+}
+
+func TestWriterDict(t *testing.T) {
+	gettysburg := mustReadFile(t, "../testdata/gettysburg.txt")
+	dict := mustReadFile(t, "testdata/dict")
+	withDict := roundTrip(t, gettysburg, DefaultCompression, dict)
+	without := roundTrip(t, gettysburg, DefaultCompression, nil)
+	if withDict >= without {
+		t.Errorf("dictionary did not help: %d bytes with, %d without", withDict, without)
+	}
+	// Raw content dictionaries work too.
+	roundTrip(t, gettysburg, DefaultCompression, gettysburg[:500])
+}
+
+func TestWriterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	data := mustReadFile(t, "../testdata/gettysburg.txt")
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Write wrote %d bytes before Flush", buf.Len())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(data))
+	if _, err := io.ReadFull(r, got); err != nil {
+		t.Fatalf("reading flushed data: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("flushed data mismatch")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	if _, err := w.Write(data); err == nil {
+		t.Fatal("Write after Close succeeded")
+	}
+}
+
+func TestWriterReset(t *testing.T) {
+	data := mustReadFile(t, "../testdata/e.txt")
+	var buf1, buf2 bytes.Buffer
+	w, err := NewWriterLevel(&buf1, BestCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(data)
+	w.Close()
+	w.Reset(&buf2)
+	w.Write(data)
+	w.Close()
+	if !bytes.Equal(buf1.Bytes(), buf2.Bytes()) {
+		t.Error("output after Reset differs from the first output")
+	}
+}
+
+func TestNewWriterLevel(t *testing.T) {
+	for _, level := range []int{DefaultCompression - 1, 0, BestCompression + 1} {
+		if _, err := NewWriterLevel(io.Discard, level); err == nil {
+			t.Errorf("NewWriterLevel(%d) succeeded", level)
+		}
+	}
+}
+
+func TestFSEWeights(t *testing.T) {
+	// Skewed byte frequencies over the whole byte range need
+	// FSE-compressed Huffman weights.
+	data := make([]byte, 100000)
+	seed := uint32(7)
+	for i := range data {
+		seed = seed*1664525 + 1013904223
+		v := seed >> 8
+		data[i] = byte(v % (1 + v>>16&0xff))
+	}
+	var counts [256]int
+	for _, c := range data {
+		counts[c]++
+	}
+	enc := newHuffEncoder(&counts)
+	if enc == nil || enc.maxSym <= 128 {
+		t.Fatal("test data does not exercise FSE-compressed weights")
+	}
+	b, ok := enc.appendTable(nil)
+	if !ok || b[0] >= 128 {
+		t.Fatalf("appendTable did not use FSE-compressed weights")
+	}
+	table, n, err := readHuffmanTable(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(b) || table.maxBits != enc.maxBits {
+		t.Fatalf("readHuffmanTable consumed %d of %d bytes, maxBits %d, want %d", n, len(b), table.maxBits, enc.maxBits)
+	}
+	roundTrip(t, data, BestSpeed, nil)
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import "math/bits"
+
+// Zstandard frames carry the low 32 bits of the XXH64 hash of the
+// decompressed content, computed with a seed of zero.
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// xxhash is a streaming XXH64 digest with a seed of zero.
+type xxhash struct {
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int // number of bytes buffered in buf
+}
+
+func (h *xxhash) reset() {
+	p1 := xxPrime1
+	h.v[0] = p1 + xxPrime2
+	h.v[1] = xxPrime2
+	h.v[2] = 0
+	h.v[3] = -p1
+	h.total = 0
+	h.n = 0
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	val = xxRound(0, val)
+	acc ^= val
+	return acc*xxPrime1 + xxPrime4
+}
+
+func (h *xxhash) write(p []byte) {
+	h.total += uint64(len(p))
+	if h.n > 0 {
+		c := copy(h.buf[h.n:], p)
+		h.n += c
+		p = p[c:]
+		if h.n < len(h.buf) {
+			return
+		}
+		h.blocks(h.buf[:])
+		h.n = 0
+	}
+	if len(p) >= 32 {
+		m := len(p) &^ 31
+		h.blocks(p[:m])
+		p = p[m:]
+	}
+	h.n = copy(h.buf[:], p)
+}
+
+func (h *xxhash) blocks(p []byte) {
+	v0, v1, v2, v3 := h.v[0], h.v[1], h.v[2], h.v[3]
+	for ; len(p) >= 32; p = p[32:] {
+		v0 = xxRound(v0, le.Uint64(p[0:]))
+		v1 = xxRound(v1, le.Uint64(p[8:]))
+		v2 = xxRound(v2, le.Uint64(p[16:]))
+		v3 = xxRound(v3, le.Uint64(p[24:]))
+	}
+	h.v[0], h.v[1], h.v[2], h.v[3] = v0, v1, v2, v3
+}
+
+func (h *xxhash) sum64() uint64 {
+	var acc uint64
+	if h.total >= 32 {
+		v0, v1, v2, v3 := h.v[0], h.v[1], h.v[2], h.v[3]
+		acc = bits.RotateLeft64(v0, 1) + bits.RotateLeft64(v1, 7) +
+			bits.RotateLeft64(v2, 12) + bits.RotateLeft64(v3, 18)
+		acc = xxMergeRound(acc, v0)
+		acc = xxMergeRound(acc, v1)
+		acc = xxMergeRound(acc, v2)
+		acc = xxMergeRound(acc, v3)
+	} else {
+		acc = xxPrime5
+	}
+	acc += h.total
+
+	p := h.buf[:h.n]
+	for ; len(p) >= 8; p = p[8:] {
+		acc ^= xxRound(0, le.Uint64(p))
+		acc = bits.RotateLeft64(acc, 27)*xxPrime1 + xxPrime4
+	}
+	if len(p) >= 4 {
+		acc ^= uint64(le.Uint32(p)) * xxPrime1
+		acc = bits.RotateLeft64(acc, 23)*xxPrime2 + xxPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		acc ^= uint64(b) * xxPrime5
+		acc = bits.RotateLeft64(acc, 11) * xxPrime1
+	}
+
+	acc ^= acc >> 33
+	acc *= xxPrime2
+	acc ^= acc >> 29
+	acc *= xxPrime3
+	acc ^= acc >> 32
+	return acc
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package zstd implements reading and writing of Zstandard compressed data,
+// as specified in RFC 8878.
+//
+// The Reader supports every feature of the format: concatenated and
+// skippable frames, raw, RLE and compressed blocks, content checksums and
+// dictionaries. The Writer produces single-frame streams with a content
+// checksum at a small number of compression levels.
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Compression levels accepted by NewWriterLevel.
+const (
+	BestSpeed          = 1
+	BestCompression    = 4
+	DefaultCompression = -1
+)
+
+var (
+	// ErrChecksum is returned when reading Zstandard data that has an
+	// invalid checksum.
+	ErrChecksum = errors.New("zstd: invalid checksum")
+	// ErrHeader is returned when reading Zstandard data that has an
+	// invalid frame header.
+	ErrHeader = errors.New("zstd: invalid header")
+	// ErrMissingDictionary is returned when reading a frame that was
+	// compressed with a dictionary that was not supplied to the Reader.
+	ErrMissingDictionary = errors.New("zstd: missing dictionary")
+	// ErrWindowTooLarge is returned when reading a frame whose window size
+	// exceeds the limit of the Reader.
+	ErrWindowTooLarge = errors.New("zstd: window size too large")
+)
+
+// A StructuralError is returned when the Zstandard data is found to be
+// syntactically invalid.
+type StructuralError string
+
+func (s StructuralError) Error() string {
+	return "zstd data invalid: " + string(s)
+}
+
+const (
+	frameMagic         = 0xfd2fb528
+	skippableMagic     = 0x184d2a50
+	skippableMagicMask = 0xfffffff0
+	dictMagic          = 0xec30a437
+
+	// maxBlockSize is the largest amount of data a single block may
+	// decompress to (RFC 8878, Section 3.1.1.2.4).
+	maxBlockSize = 128 << 10
+
+	// maxWindowSize is the largest window the Reader accepts. It matches
+	// the default limit of the reference implementation.
+	maxWindowSize = 1 << 27
+
+	blockRaw        = 0
+	blockRLE        = 1
+	blockCompressed = 2
+)
+
+var le = binary.LittleEndian
+
+// noEOF converts io.EOF to io.ErrUnexpectedEOF.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,302 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ecdh_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"testing"
+)
+
+// Check that PublicKey and PrivateKey implement the interfaces documented in
+// crypto.PublicKey and crypto.PrivateKey.
+var _ interface {
+	Equal(x crypto.PublicKey) bool
+} = &ecdh.PublicKey{}
+var _ interface {
+	Public() crypto.PublicKey
+	Equal(x crypto.PrivateKey) bool
+} = &ecdh.PrivateKey{}
+
+var curves = []ecdh.Curve{ecdh.P256(), ecdh.P384(), ecdh.P521(), ecdh.X25519()}
+
+func TestECDH(t *testing.T) {
+	for _, curve := range curves {
+		t.Run(curve.(interface{ String() string }).String(), func(t *testing.T) {
+			aliceKey, err := curve.GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bobKey, err := curve.GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			alicePubKey, err := curve.NewPublicKey(aliceKey.PublicKey().Bytes())
+			if err != nil {
+				t.Error(err)
+			}
+			if !alicePubKey.Equal(aliceKey.PublicKey()) {
+				t.Error("encoded and decoded public keys are different")
+			}
+			if !alicePubKey.Equal(aliceKey.Public()) {
+				t.Error("encoded and decoded public keys are different")
+			}
+
+			alicePrivKey, err := curve.NewPrivateKey(aliceKey.Bytes())
+			if err != nil {
+				t.Error(err)
+			}
+			if !alicePrivKey.Equal(aliceKey) {
+				t.Error("encoded and decoded private keys are different")
+			}
+			if alicePrivKey.Equal(bobKey) || alicePubKey.Equal(bobKey.PublicKey()) {
+				t.Error("different keys are equal")
+			}
+			if alicePrivKey.Curve() != curve || alicePubKey.Curve() != curve {
+				t.Error("key curves do not match")
+			}
+
+			bobSecret, err := bobKey.ECDH(aliceKey.PublicKey())
+			if err != nil {
+				t.Fatal(err)
+			}
+			aliceSecret, err := aliceKey.ECDH(bobKey.PublicKey())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(bobSecret, aliceSecret) {
+				t.Error("two ECDH computations came out different")
+			}
+		})
+	}
+}
+
+func TestECDHCurveMismatch(t *testing.T) {
+	a, _ := ecdh.P256().GenerateKey(rand.Reader)
+	b, _ := ecdh.P384().GenerateKey(rand.Reader)
+	if _, err := a.ECDH(b.PublicKey()); err == nil {
+		t.Error("ECDH across curves succeeded")
+	}
+}
+
+func hexDecode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// TestX25519 uses the test vectors in RFC 7748, Section 6.1.
+func TestX25519(t *testing.T) {
+	alice, err := ecdh.X25519().NewPrivateKey(hexDecode(t, "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := ecdh.X25519().NewPrivateKey(hexDecode(t, "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(alice.PublicKey().Bytes()), "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"; got != want {
+		t.Errorf("Alice's public key = %s, want %s", got, want)
+	}
+	if got, want := hex.EncodeToString(bob.PublicKey().Bytes()), "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"; got != want {
+		t.Errorf("Bob's public key = %s, want %s", got, want)
+	}
+	secret, err := alice.ECDH(bob.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(secret), "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"; got != want {
+		t.Errorf("shared secret = %s, want %s", got, want)
+	}
+}
+
+// TestX25519Iterated uses the iterated test vectors in RFC 7748, Section 5.2.
+func TestX25519Iterated(t *testing.T) {
+	k := make([]byte, 32)
+	k[0] = 9
+	u := append([]byte(nil), k...)
+	want := map[int]string{
+		1:    "422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079",
+		1000: "684cf59ba83309552800ef566f2f4d3c1c3887c49360e3875f2eb94d99532c51",
+	}
+	n := 1000
+	if testing.Short() {
+		n = 1
+	}
+	for i := 1; i <= n; i++ {
+		priv, err := ecdh.X25519().NewPrivateKey(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := ecdh.X25519().NewPublicKey(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := priv.ECDH(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, k = k, out
+		if w, ok := want[i]; ok && hex.EncodeToString(k) != w {
+			t.Fatalf("after %d iterations: got %x, want %s", i, k, w)
+		}
+	}
+}
+
+func TestX25519LowOrderPoint(t *testing.T) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The all-zero point and the point of order 8 both produce a zero
+	// shared secret.
+	for _, s := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800",
+	} {
+		pub, err := ecdh.X25519().NewPublicKey(hexDecode(t, s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := priv.ECDH(pub); err == nil {
+			t.Errorf("ECDH with low order point %s succeeded", s)
+		}
+	}
+}
+
+var nistCurves = []struct {
+	curve ecdh.Curve
+	ref   elliptic.Curve
+}{
+	{ecdh.P256(), elliptic.P256()},
+	{ecdh.P384(), elliptic.P384()},
+	{ecdh.P521(), elliptic.P521()},
+}
+
+// TestNISTAgainstElliptic checks the NIST curves against the big.Int based
+// implementations in crypto/elliptic.
+func TestNISTAgainstElliptic(t *testing.T) {
+	for _, tt := range nistCurves {
+		params := tt.ref.Params()
+		for i := 0; i < 5; i++ {
+			d, x, y, err := elliptic.GenerateKey(tt.ref, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			priv, err := tt.curve.NewPrivateKey(d)
+			if err != nil {
+				t.Fatalf("%s: NewPrivateKey: %v", params.Name, err)
+			}
+			if got, want := priv.PublicKey().Bytes(), elliptic.Marshal(tt.ref, x, y); !bytes.Equal(got, want) {
+				t.Fatalf("%s: public key = %x, want %x", params.Name, got, want)
+			}
+
+			_, px, py, err := elliptic.GenerateKey(tt.ref, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, err := tt.curve.NewPublicKey(elliptic.Marshal(tt.ref, px, py))
+			if err != nil {
+				t.Fatalf("%s: NewPublicKey: %v", params.Name, err)
+			}
+			secret, err := priv.ECDH(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sx, _ := tt.ref.ScalarMult(px, py, d)
+			if want := sx.FillBytes(make([]byte, len(d))); !bytes.Equal(secret, want) {
+				t.Fatalf("%s: shared secret = %x, want %x", params.Name, secret, want)
+			}
+		}
+	}
+}
+
+func TestNISTInvalidKeys(t *testing.T) {
+	for _, tt := range nistCurves {
+		params := tt.ref.Params()
+		size := (params.N.BitLen() + 7) / 8
+		nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+		if _, err := tt.curve.NewPrivateKey(nMinus1.FillBytes(make([]byte, size))); err != nil {
+			t.Errorf("%s: NewPrivateKey(N-1): %v", params.Name, err)
+		}
+		for _, k := range [][]byte{
+			make([]byte, size),
+			make([]byte, size-1),
+			params.N.FillBytes(make([]byte, size)),
+			new(big.Int).Add(params.N, big.NewInt(1)).FillBytes(make([]byte, size)),
+			bytes.Repeat([]byte{0xff}, size),
+		} {
+			if _, err := tt.curve.NewPrivateKey(k); err == nil {
+				t.Errorf("%s: NewPrivateKey(%x) succeeded", params.Name, k)
+			}
+		}
+
+		g := elliptic.Marshal(tt.ref, params.Gx, params.Gy)
+		notOnCurve := append([]byte(nil), g...)
+		notOnCurve[len(notOnCurve)-1] ^= 1
+		for _, k := range [][]byte{
+			nil,
+			{0},
+			g[:len(g)-1],
+			notOnCurve,
+			elliptic.MarshalCompressed(tt.ref, params.Gx, params.Gy),
+		} {
+			if _, err := tt.curve.NewPublicKey(k); err == nil {
+				t.Errorf("%s: NewPublicKey(%x) succeeded", params.Name, k)
+			}
+		}
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestGenerateKeyZeroReader(t *testing.T) {
+	for _, curve := range curves {
+		if _, err := curve.GenerateKey(zeroReader{}); err != nil {
+			t.Errorf("%v: GenerateKey: %v", curve, err)
+		}
+		if _, err := curve.GenerateKey(io.LimitReader(rand.Reader, 10)); err == nil {
+			t.Errorf("%v: GenerateKey with a short reader succeeded", curve)
+		}
+	}
+}
+
+func BenchmarkECDH(b *testing.B) {
+	for _, curve := range curves {
+		b.Run(curve.(interface{ String() string }).String(), func(b *testing.B) {
+			key, err := curve.GenerateKey(rand.Reader)
+			if err != nil {
+				b.Fatal(err)
+			}
+			peer, err := curve.GenerateKey(rand.Reader)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := key.ECDH(peer.PublicKey()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ecdh
+
+import (
+	"crypto/elliptic"
+	"crypto/internal/nistec"
+	"crypto/internal/randutil"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+type nistCurve struct {
+	name  string
+	order []byte
+
+	// P-256 uses the constant-time implementation in crypto/elliptic.
+	// The other curves use crypto/internal/nistec, as the crypto/elliptic
+	// implementations of P-384 and P-521 are not constant time.
+	ec func() *nistec.Curve
+}
+
+func (c *nistCurve) String() string {
+	return c.name
+}
+
+var errInvalidPrivateKey = errors.New("crypto/ecdh: invalid private key")
+
+func (c *nistCurve) GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	key := make([]byte, len(c.order))
+	randutil.MaybeReadByte(rand)
+	for {
+		if _, err := io.ReadFull(rand, key); err != nil {
+			return nil, err
+		}
+
+		// Mask off any excess bits if the size of the order is not a whole
+		// number of bytes, which is only the case for P-521.
+		key[0] &= 0xff >> bits.LeadingZeros8(c.order[0])
+
+		// In tests, rand will return all zeros and NewPrivateKey would
+		// reject the zero key, so flip some bits. The map is a bijection,
+		// so the distribution of keys is unchanged.
+		key[1] ^= 0x42
+
+		// Keys not lower than the order are rejected and sampled again.
+		k, err := c.NewPrivateKey(key)
+		if err == errInvalidPrivateKey {
+			continue
+		}
+		return k, err
+	}
+}
+
+func (c *nistCurve) NewPrivateKey(key []byte) (*PrivateKey, error) {
+	if len(key) != len(c.order) {
+		return nil, errors.New("crypto/ecdh: invalid private key size")
+	}
+	if isZero(key) || !isLess(key, c.order) {
+		return nil, errInvalidPrivateKey
+	}
+	k := &PrivateKey{
+		curve:      c,
+		privateKey: append([]byte(nil), key...),
+	}
+	k.publicKey = c.privateKeyToPublicKey(k)
+	return k, nil
+}
+
+func (c *nistCurve) privateKeyToPublicKey(key *PrivateKey) *PublicKey {
+	if key.curve != c {
+		panic("crypto/ecdh: internal error: converting the wrong key type")
+	}
+	var publicKey []byte
+	if c.ec == nil {
+		x, y := elliptic.P256().ScalarBaseMult(key.privateKey)
+		publicKey = elliptic.Marshal(elliptic.P256(), x, y)
+	} else {
+		publicKey = new(nistec.Point).ScalarBaseMult(c.ec(), key.privateKey).Bytes()
+	}
+	if len(publicKey) == 1 {
+		// The encoding of the identity is a single 0x00 byte, which
+		// NewPrivateKey prevents by rejecting the zero and order scalars.
+		panic("crypto/ecdh: internal error: ScalarBaseMult returned the identity")
+	}
+	return &PublicKey{
+		curve:     key.curve,
+		publicKey: publicKey,
+	}
+}
+
+func (c *nistCurve) NewPublicKey(key []byte) (*PublicKey, error) {
+	// Reject the point at infinity and compressed encodings.
+	if len(key) == 0 || key[0] != 4 {
+		return nil, errors.New("crypto/ecdh: invalid public key")
+	}
+	if c.ec == nil {
+		if x, _ := elliptic.Unmarshal(elliptic.P256(), key); x == nil {
+			return nil, errors.New("crypto/ecdh: invalid public key")
+		}
+	} else if _, err := new(nistec.Point).SetBytes(c.ec(), key); err != nil {
+		return nil, errors.New("crypto/ecdh: invalid public key")
+	}
+	return &PublicKey{
+		curve:     c,
+		publicKey: append([]byte(nil), key...),
+	}, nil
+}
+
+func (c *nistCurve) ecdh(local *PrivateKey, remote *PublicKey) ([]byte, error) {
+	// Note that this function can't return an error, as NewPublicKey rejects
+	// invalid points and the point at infinity, and NewPrivateKey rejects
+	// invalid scalars and the zero value. BytesX returns an error for the
+	// point at infinity, but in a prime order group such as the NIST curves
+	// that can only be the result of a scalar multiplication if one of the
+	// inputs is the zero scalar or the point at infinity.
+
+	if c.ec == nil {
+		curve := elliptic.P256()
+		x, y := elliptic.Unmarshal(curve, remote.publicKey)
+		x, _ = curve.ScalarMult(x, y, local.privateKey)
+		return x.FillBytes(make([]byte, len(c.order))), nil
+	}
+	p, err := new(nistec.Point).SetBytes(c.ec(), remote.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return p.ScalarMult(p, local.privateKey).BytesX()
+}
+
+// isZero returns whether a is all zeroes in constant time.
+func isZero(a []byte) bool {
+	var acc byte
+	for _, b := range a {
+		acc |= b
+	}
+	return acc == 0
+}
+
+// isLess returns whether a < b, where a and b are big-endian buffers of
+// the same length and shorter than 72 bytes.
+func isLess(a, b []byte) bool {
+	if len(a) != len(b) {
+		panic("crypto/ecdh: internal error: mismatched isLess inputs")
+	}
+
+	// Copy the values into fixed-size little-endian buffers. 72 bytes is
+	// enough for every scalar in this package.
+	if len(a) > 72 {
+		panic("crypto/ecdh: internal error: isLess input too large")
+	}
+	var bufA, bufB [72]byte
+	for i := range a {
+		bufA[i], bufB[i] = a[len(a)-i-1], b[len(b)-i-1]
+	}
+
+	// Perform a subtraction with borrow.
+	var borrow uint64
+	for i := 0; i < len(bufA); i += 8 {
+		limbA, limbB := leUint64(bufA[i:]), leUint64(bufB[i:])
+		_, borrow = bits.Sub64(limbA, limbB, borrow)
+	}
+
+	// If there is a borrow at the end of the operation, then a < b.
+	return borrow == 1
+}
+
+func leUint64(b []byte) uint64 {
+	_ = b[7] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
+
+// P256 returns a Curve which implements NIST P-256 (FIPS 186-3, section
+// D.2.3), also known as secp256r1 or prime256v1.
+//
+// Multiple invocations of this function will return the same value, which
+// can be used for equality checks and switch statements.
+func P256() Curve { return p256 }
+
+var p256 = &nistCurve{
+	name: "P-256",
+	order: []byte{
+		0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xbc, 0xe6, 0xfa, 0xad, 0xa7, 0x17, 0x9e, 0x84,
+		0xf3, 0xb9, 0xca, 0xc2, 0xfc, 0x63, 0x25, 0x51,
+	},
+}
+
+// P384 returns a Curve which implements NIST P-384 (FIPS 186-3, section
+// D.2.4), also known as secp384r1.
+//
+// Multiple invocations of this function will return the same value, which
+// can be used for equality checks and switch statements.
+func P384() Curve { return p384 }
+
+var p384 = &nistCurve{
+	name: "P-384",
+	order: []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xc7, 0x63, 0x4d, 0x81, 0xf4, 0x37, 0x2d, 0xdf,
+		0x58, 0x1a, 0x0d, 0xb2, 0x48, 0xb0, 0xa7, 0x7a,
+		0xec, 0xec, 0x19, 0x6a, 0xcc, 0xc5, 0x29, 0x73,
+	},
+	ec: nistec.P384,
+}
+
+// P521 returns a Curve which implements NIST P-521 (FIPS 186-3, section
+// D.2.5), also known as secp521r1.
+//
+// Multiple invocations of this function will return the same value, which
+// can be used for equality checks and switch statements.
+func P521() Curve { return p521 }
+
+var p521 = &nistCurve{
+	name: "P-521",
+	order: []byte{
+		0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xfa, 0x51, 0x86, 0x87, 0x83, 0xbf, 0x2f,
+		0x96, 0x6b, 0x7f, 0xcc, 0x01, 0x48, 0xf7, 0x09,
+		0xa5, 0xd0, 0x3b, 0xb5, 0xc9, 0xb8, 0x89, 0x9c,
+		0x47, 0xae, 0xbb, 0x6f, 0xb7, 0x1e, 0x91, 0x38,
+		0x64, 0x09,
+	},
+	ec: nistec.P521,
+}
@@ -30,6 +30,7 @@ import (
 	"crypto"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ecdh"
 	"crypto/elliptic"
 	"crypto/internal/randutil"
 	"crypto/sha512"
@@ -105,6 +106,90 @@ func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
 	return priv.PublicKey.Equal(&xx.PublicKey) && priv.D.Cmp(xx.D) == 0
 }
 
+// ECDH returns k as a ecdh.PublicKey. It returns an error if the key is
+// invalid according to the definition of ecdh.Curve.NewPublicKey, or if the
+// Curve is not supported by crypto/ecdh.
+func (k *PublicKey) ECDH() (*ecdh.PublicKey, error) {
+	c := curveToECDH(k.Curve)
+	if c == nil {
+		return nil, errors.New("ecdsa: unsupported curve by crypto/ecdh")
+	}
+	if !k.Curve.IsOnCurve(k.X, k.Y) {
+		return nil, errors.New("ecdsa: invalid public key")
+	}
+	return c.NewPublicKey(elliptic.Marshal(k.Curve, k.X, k.Y))
+}
+
+// ECDH returns k as a ecdh.PrivateKey. It returns an error if the key is
+// invalid according to the definition of ecdh.Curve.NewPrivateKey, or if the
+// Curve is not supported by crypto/ecdh.
+func (k *PrivateKey) ECDH() (*ecdh.PrivateKey, error) {
+	c := curveToECDH(k.Curve)
+	if c == nil {
+		return nil, errors.New("ecdsa: unsupported curve by crypto/ecdh")
+	}
+	size := (k.Curve.Params().N.BitLen() + 7) / 8
+	if k.D.Sign() < 0 || k.D.BitLen() > size*8 {
+		return nil, errors.New("ecdsa: invalid private key")
+	}
+	return c.NewPrivateKey(k.D.FillBytes(make([]byte, size)))
+}
+
+func curveToECDH(c elliptic.Curve) ecdh.Curve {
+	switch c {
+	case elliptic.P256():
+		return ecdh.P256()
+	case elliptic.P384():
+		return ecdh.P384()
+	case elliptic.P521():
+		return ecdh.P521()
+	default:
+		return nil
+	}
+}
+
+// ParseRawPrivateKey parses a private key encoded as a fixed-length
+// big-endian integer, according to SEC 1, Version 2.0, Section 2.3.6
+// (sometimes referred to as the raw format). It returns an error if the
+// value is not reduced modulo the curve's order, or if it's zero.
+//
+// This is the encoding returned by ecdh.PrivateKey.Bytes for the NIST
+// curves, so ParseRawPrivateKey converts an ecdh.PrivateKey to a PrivateKey.
+func ParseRawPrivateKey(curve elliptic.Curve, data []byte) (*PrivateKey, error) {
+	params := curve.Params()
+	if len(data) != (params.N.BitLen()+7)/8 {
+		return nil, errors.New("ecdsa: invalid private key size")
+	}
+	d := new(big.Int).SetBytes(data)
+	if d.Sign() == 0 || d.Cmp(params.N) >= 0 {
+		return nil, errors.New("ecdsa: invalid private key")
+	}
+	priv := new(PrivateKey)
+	priv.PublicKey.Curve = curve
+	priv.D = d
+	priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(data)
+	return priv, nil
+}
+
+// ParseUncompressedPublicKey parses a public key encoded as an uncompressed
+// point according to SEC 1, Version 2.0, Section 2.3.3 (also known as the
+// X9.62 uncompressed format). It returns an error if the point is not in
+// uncompressed form, is not on the curve, or is the point at infinity.
+//
+// This is the encoding returned by ecdh.PublicKey.Bytes for the NIST
+// curves, so ParseUncompressedPublicKey converts an ecdh.PublicKey to a
+// PublicKey.
+func ParseUncompressedPublicKey(curve elliptic.Curve, data []byte) (*PublicKey, error) {
+	if len(data) == 0 || data[0] != 4 {
+		return nil, errors.New("ecdsa: invalid uncompressed public key")
+	}
+	x, y := elliptic.Unmarshal(curve, data)
+	if x == nil {
+		return nil, errors.New("ecdsa: invalid public key")
+	}
+	return &PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
 // Sign signs digest with priv, reading randomness from rand. The opts argument
 // is not currently used but, in keeping with the crypto.Signer interface,
 // should be the hash function used to digest the message.
@@ -327,6 +327,57 @@ func testZeroHashSignature(t *testing.T, curve elliptic.Curve) {
 	}
 }
 
+func TestECDHConversion(t *testing.T) {
+	testAllCurves(t, testECDHConversion)
+}
+
+func testECDHConversion(t *testing.T, curve elliptic.Curve) {
+	priv, err := GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdhPriv, err := priv.ECDH()
+	if curve == elliptic.P224() {
+		if err == nil {
+			t.Error("ECDH conversion of a P-224 key succeeded")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdhPub, err := priv.PublicKey.ECDH()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdhPriv.PublicKey().Equal(ecdhPub) {
+		t.Error("converted public keys do not match")
+	}
+
+	priv2, err := ParseRawPrivateKey(curve, ecdhPriv.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.Equal(priv2) {
+		t.Error("private key did not round-trip")
+	}
+	pub2, err := ParseUncompressedPublicKey(curve, ecdhPub.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.PublicKey.Equal(pub2) {
+		t.Error("public key did not round-trip")
+	}
+
+	if _, err := ParseRawPrivateKey(curve, make([]byte, len(ecdhPriv.Bytes()))); err == nil {
+		t.Error("zero private key was accepted")
+	}
+	if _, err := ParseUncompressedPublicKey(curve, elliptic.MarshalCompressed(curve, priv.X, priv.Y)); err == nil {
+		t.Error("compressed public key was accepted")
+	}
+}
+
 func benchmarkAllCurves(t *testing.B, f func(*testing.B, elliptic.Curve)) {
 	tests := []struct {
 		name  string
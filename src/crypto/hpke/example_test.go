@@ -0,0 +1,50 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hpke_test
+
+import (
+	"crypto/ecdh"
+	"crypto/hpke"
+	"crypto/rand"
+	"fmt"
+)
+
+func Example() {
+	suite := hpke.Suite{
+		KEM:  hpke.DHKEM_X25519_HKDF_SHA256,
+		KDF:  hpke.HKDF_SHA256,
+		AEAD: hpke.ChaCha20Poly1305,
+	}
+	info := []byte("example application")
+
+	// The recipient generates a key pair and publishes the public key.
+	recipientKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	// The sender sets up a context to the recipient's public key, and sends
+	// the encapsulated key along with the ciphertexts.
+	enc, sender, err := hpke.SetupBaseSender(suite, recipientKey.PublicKey(), info)
+	if err != nil {
+		panic(err)
+	}
+	ciphertext, err := sender.Seal(nil, []byte("hello, world"))
+	if err != nil {
+		panic(err)
+	}
+
+	// The recipient uses the encapsulated key to set up the matching context.
+	recipient, err := hpke.SetupBaseRecipient(suite, enc, recipientKey, info)
+	if err != nil {
+		panic(err)
+	}
+	plaintext, err := recipient.Open(nil, ciphertext)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s\n", plaintext)
+	// Output: hello, world
+}
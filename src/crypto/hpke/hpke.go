@@ -0,0 +1,415 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hpke implements Hybrid Public Key Encryption (HPKE) as specified
+// in RFC 9180.
+//
+// All four modes of RFC 9180 are supported: base, PSK, auth and auth-PSK.
+// The supported KEMs are DHKEM(X25519, HKDF-SHA256) and DHKEM(P-256,
+// HKDF-SHA256), the supported KDFs are HKDF-SHA256, HKDF-SHA384 and
+// HKDF-SHA512, and the supported AEADs are AES-128-GCM, AES-256-GCM,
+// ChaCha20-Poly1305 and the export-only AEAD.
+//
+// Keys are represented as crypto/ecdh keys on the curve of the KEM.
+package hpke
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	_ "crypto/sha256" // for crypto.SHA256
+	_ "crypto/sha512" // for crypto.SHA384 and crypto.SHA512
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// A KEM is a key encapsulation mechanism identifier, from the IANA HPKE
+// registry.
+type KEM uint16
+
+const (
+	DHKEM_P256_HKDF_SHA256   KEM = 0x0010 // DHKEM(P-256, HKDF-SHA256)
+	DHKEM_X25519_HKDF_SHA256 KEM = 0x0020 // DHKEM(X25519, HKDF-SHA256)
+)
+
+// Available reports whether the KEM is implemented by this package.
+func (k KEM) Available() bool {
+	return k == DHKEM_P256_HKDF_SHA256 || k == DHKEM_X25519_HKDF_SHA256
+}
+
+// Curve returns the crypto/ecdh curve of the KEM's keys. It panics if the
+// KEM is not available.
+func (k KEM) Curve() ecdh.Curve {
+	switch k {
+	case DHKEM_P256_HKDF_SHA256:
+		return ecdh.P256()
+	case DHKEM_X25519_HKDF_SHA256:
+		return ecdh.X25519()
+	}
+	panic("hpke: requested KEM is unavailable")
+}
+
+// A KDF is a key derivation function identifier, from the IANA HPKE
+// registry.
+type KDF uint16
+
+const (
+	HKDF_SHA256 KDF = 0x0001
+	HKDF_SHA384 KDF = 0x0002
+	HKDF_SHA512 KDF = 0x0003
+)
+
+// Available reports whether the KDF is implemented by this package.
+func (k KDF) Available() bool {
+	return k == HKDF_SHA256 || k == HKDF_SHA384 || k == HKDF_SHA512
+}
+
+func (k KDF) hash() crypto.Hash {
+	switch k {
+	case HKDF_SHA256:
+		return crypto.SHA256
+	case HKDF_SHA384:
+		return crypto.SHA384
+	case HKDF_SHA512:
+		return crypto.SHA512
+	}
+	panic("hpke: requested KDF is unavailable")
+}
+
+// An AEAD is an authenticated encryption identifier, from the IANA HPKE
+// registry.
+type AEAD uint16
+
+const (
+	AES_128_GCM      AEAD = 0x0001
+	AES_256_GCM      AEAD = 0x0002
+	ChaCha20Poly1305 AEAD = 0x0003
+
+	// ExportOnly is the AEAD identifier of contexts that can only be used
+	// with Export. See RFC 9180, Section 5.3.
+	ExportOnly AEAD = 0xffff
+)
+
+// Available reports whether the AEAD is implemented by this package.
+func (a AEAD) Available() bool {
+	switch a {
+	case AES_128_GCM, AES_256_GCM, ChaCha20Poly1305, ExportOnly:
+		return true
+	}
+	return false
+}
+
+// keySize returns Nk.
+func (a AEAD) keySize() int {
+	switch a {
+	case AES_128_GCM:
+		return 16
+	case ExportOnly:
+		return 0
+	}
+	return 32
+}
+
+func (a AEAD) new(key []byte) (cipher.AEAD, error) {
+	switch a {
+	case AES_128_GCM, AES_256_GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case ChaCha20Poly1305:
+		return chacha20poly1305.New(key)
+	}
+	return nil, errors.New("hpke: unsupported AEAD")
+}
+
+// A Suite is a combination of KEM, KDF and AEAD.
+type Suite struct {
+	KEM  KEM
+	KDF  KDF
+	AEAD AEAD
+}
+
+func (s Suite) check() error {
+	if !s.KEM.Available() {
+		return errors.New("hpke: unsupported KEM")
+	}
+	if !s.KDF.Available() {
+		return errors.New("hpke: unsupported KDF")
+	}
+	if !s.AEAD.Available() {
+		return errors.New("hpke: unsupported AEAD")
+	}
+	return nil
+}
+
+// Modes, from RFC 9180, Section 5.
+const (
+	modeBase    = 0x00
+	modePSK     = 0x01
+	modeAuth    = 0x02
+	modeAuthPSK = 0x03
+)
+
+// labeledKDF implements the labeled KDF functions of RFC 9180, Section 4.
+type labeledKDF struct {
+	hash    crypto.Hash
+	suiteID []byte
+}
+
+func (kdf *labeledKDF) labeledExtract(salt []byte, label string, ikm []byte) []byte {
+	labeledIKM := make([]byte, 0, 7+len(kdf.suiteID)+len(label)+len(ikm))
+	labeledIKM = append(labeledIKM, "HPKE-v1"...)
+	labeledIKM = append(labeledIKM, kdf.suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+	return hkdf.Extract(kdf.hash.New, labeledIKM, salt)
+}
+
+func (kdf *labeledKDF) labeledExpand(prk []byte, label string, info []byte, length uint16) []byte {
+	labeledInfo := make([]byte, 0, 2+7+len(kdf.suiteID)+len(label)+len(info))
+	labeledInfo = append(labeledInfo, byte(length>>8), byte(length))
+	labeledInfo = append(labeledInfo, "HPKE-v1"...)
+	labeledInfo = append(labeledInfo, kdf.suiteID...)
+	labeledInfo = append(labeledInfo, label...)
+	labeledInfo = append(labeledInfo, info...)
+	out := make([]byte, length)
+	if _, err := hkdf.Expand(kdf.hash.New, prk, labeledInfo).Read(out); err != nil {
+		panic("hpke: HKDF-Expand failed unexpectedly")
+	}
+	return out
+}
+
+// context is the encryption context of RFC 9180, Section 5.1.
+type context struct {
+	aead      cipher.AEAD // nil for ExportOnly
+	key       []byte
+	baseNonce []byte
+	seqNum    uint64
+
+	exporterSecret []byte
+	kdf            *labeledKDF
+}
+
+// newContext runs the key schedule of RFC 9180, Section 5.1.
+func newContext(s Suite, mode byte, sharedSecret, info, psk, pskID []byte) (*context, error) {
+	gotPSK, gotPSKID := len(psk) != 0, len(pskID) != 0
+	if gotPSK != gotPSKID {
+		return nil, errors.New("hpke: inconsistent PSK inputs")
+	}
+	if mode == modePSK || mode == modeAuthPSK {
+		if !gotPSK {
+			return nil, errors.New("hpke: missing required PSK input")
+		}
+	} else if gotPSK {
+		return nil, errors.New("hpke: PSK input provided when not needed")
+	}
+
+	kdf := &labeledKDF{hash: s.KDF.hash(), suiteID: []byte{'H', 'P', 'K', 'E',
+		byte(s.KEM >> 8), byte(s.KEM),
+		byte(s.KDF >> 8), byte(s.KDF),
+		byte(s.AEAD >> 8), byte(s.AEAD)}}
+
+	pskIDHash := kdf.labeledExtract(nil, "psk_id_hash", pskID)
+	infoHash := kdf.labeledExtract(nil, "info_hash", info)
+	ksContext := append([]byte{mode}, pskIDHash...)
+	ksContext = append(ksContext, infoHash...)
+
+	secret := kdf.labeledExtract(sharedSecret, "secret", psk)
+	c := &context{
+		exporterSecret: kdf.labeledExpand(secret, "exp", ksContext, uint16(kdf.hash.Size())),
+		kdf:            kdf,
+	}
+	if s.AEAD != ExportOnly {
+		key := kdf.labeledExpand(secret, "key", ksContext, uint16(s.AEAD.keySize()))
+		aead, err := s.AEAD.new(key)
+		if err != nil {
+			return nil, err
+		}
+		c.aead, c.key = aead, key
+		c.baseNonce = kdf.labeledExpand(secret, "base_nonce", ksContext, uint16(aead.NonceSize()))
+	}
+	return c, nil
+}
+
+func (c *context) nextNonce() []byte {
+	nonce := make([]byte, len(c.baseNonce))
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], c.seqNum)
+	for i := range nonce {
+		nonce[i] ^= c.baseNonce[i]
+	}
+	return nonce
+}
+
+func (c *context) checkSeal() error {
+	if c.aead == nil {
+		return errors.New("hpke: Seal or Open on an export-only context")
+	}
+	// The limit of RFC 9180, Section 5.2 is 2^96-1 for every nonce size
+	// supported by this package, so the uint64 sequence number runs out
+	// first.
+	if c.seqNum == math.MaxUint64 {
+		return errors.New("hpke: message limit reached")
+	}
+	return nil
+}
+
+func (c *context) export(exporterContext []byte, length int) ([]byte, error) {
+	if length < 0 || length > 255*c.kdf.hash.Size() {
+		return nil, errors.New("hpke: invalid export length")
+	}
+	return c.kdf.labeledExpand(c.exporterSecret, "sec", exporterContext, uint16(length)), nil
+}
+
+// A Sender is the sender side of an HPKE encryption context.
+type Sender struct {
+	c *context
+}
+
+// Seal encrypts and authenticates plaintext, authenticates aad, and
+// advances the sequence number. Messages must be opened by the Recipient
+// in the same order they are sealed.
+func (s *Sender) Seal(aad, plaintext []byte) ([]byte, error) {
+	if err := s.c.checkSeal(); err != nil {
+		return nil, err
+	}
+	ciphertext := s.c.aead.Seal(nil, s.c.nextNonce(), plaintext, aad)
+	s.c.seqNum++
+	return ciphertext, nil
+}
+
+// Export derives a secret of the given length from the context and
+// exporterContext, as described in RFC 9180, Section 5.3. The length can
+// be at most 255 times the output size of the KDF hash.
+func (s *Sender) Export(exporterContext []byte, length int) ([]byte, error) {
+	return s.c.export(exporterContext, length)
+}
+
+// A Recipient is the recipient side of an HPKE encryption context.
+type Recipient struct {
+	c *context
+}
+
+// Open decrypts and authenticates ciphertext and aad, and advances the
+// sequence number. The sequence number is not advanced if Open fails.
+func (r *Recipient) Open(aad, ciphertext []byte) ([]byte, error) {
+	if err := r.c.checkSeal(); err != nil {
+		return nil, err
+	}
+	plaintext, err := r.c.aead.Open(nil, r.c.nextNonce(), ciphertext, aad)
+	if err != nil {
+		return nil, err
+	}
+	r.c.seqNum++
+	return plaintext, nil
+}
+
+// Export derives a secret of the given length from the context and
+// exporterContext, as described in RFC 9180, Section 5.3. The length can
+// be at most 255 times the output size of the KDF hash.
+func (r *Recipient) Export(exporterContext []byte, length int) ([]byte, error) {
+	return r.c.export(exporterContext, length)
+}
+
+func setupSender(s Suite, mode byte, pkR *ecdh.PublicKey, info, psk, pskID []byte, skS *ecdh.PrivateKey) ([]byte, *Sender, error) {
+	if err := s.check(); err != nil {
+		return nil, nil, err
+	}
+	sharedSecret, enc, err := encap(s.KEM, pkR, skS)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := newContext(s, mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, &Sender{c}, nil
+}
+
+func setupRecipient(s Suite, mode byte, enc []byte, skR *ecdh.PrivateKey, info, psk, pskID []byte, pkS *ecdh.PublicKey) (*Recipient, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	sharedSecret, err := decap(s.KEM, enc, skR, pkS)
+	if err != nil {
+		return nil, err
+	}
+	c, err := newContext(s, mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, err
+	}
+	return &Recipient{c}, nil
+}
+
+// SetupBaseSender sets up a base mode context to the public key pkR, and
+// returns the encapsulated key to send to the recipient along with it.
+func SetupBaseSender(s Suite, pkR *ecdh.PublicKey, info []byte) ([]byte, *Sender, error) {
+	return setupSender(s, modeBase, pkR, info, nil, nil, nil)
+}
+
+// SetupPSKSender sets up a PSK mode context to the public key pkR, and
+// returns the encapsulated key to send to the recipient along with it.
+// The psk and pskID must both be non-empty.
+func SetupPSKSender(s Suite, pkR *ecdh.PublicKey, info, psk, pskID []byte) ([]byte, *Sender, error) {
+	return setupSender(s, modePSK, pkR, info, psk, pskID, nil)
+}
+
+// SetupAuthSender sets up an auth mode context to the public key pkR,
+// authenticated with the sender's private key skS, and returns the
+// encapsulated key to send to the recipient along with it.
+func SetupAuthSender(s Suite, pkR *ecdh.PublicKey, info []byte, skS *ecdh.PrivateKey) ([]byte, *Sender, error) {
+	if skS == nil {
+		return nil, nil, errors.New("hpke: missing sender private key")
+	}
+	return setupSender(s, modeAuth, pkR, info, nil, nil, skS)
+}
+
+// SetupAuthPSKSender sets up an auth-PSK mode context to the public key
+// pkR, authenticated with both the sender's private key skS and the psk, and
+// returns the encapsulated key to send to the recipient along with it.
+func SetupAuthPSKSender(s Suite, pkR *ecdh.PublicKey, info, psk, pskID []byte, skS *ecdh.PrivateKey) ([]byte, *Sender, error) {
+	if skS == nil {
+		return nil, nil, errors.New("hpke: missing sender private key")
+	}
+	return setupSender(s, modeAuthPSK, pkR, info, psk, pskID, skS)
+}
+
+// SetupBaseRecipient sets up a base mode context for the encapsulated key
+// enc, using the recipient's private key skR.
+func SetupBaseRecipient(s Suite, enc []byte, skR *ecdh.PrivateKey, info []byte) (*Recipient, error) {
+	return setupRecipient(s, modeBase, enc, skR, info, nil, nil, nil)
+}
+
+// SetupPSKRecipient sets up a PSK mode context for the encapsulated key
+// enc, using the recipient's private key skR. The psk and pskID must both
+// be non-empty.
+func SetupPSKRecipient(s Suite, enc []byte, skR *ecdh.PrivateKey, info, psk, pskID []byte) (*Recipient, error) {
+	return setupRecipient(s, modePSK, enc, skR, info, psk, pskID, nil)
+}
+
+// SetupAuthRecipient sets up an auth mode context for the encapsulated key
+// enc, using the recipient's private key skR, and checks that the sender
+// holds the private key for pkS.
+func SetupAuthRecipient(s Suite, enc []byte, skR *ecdh.PrivateKey, info []byte, pkS *ecdh.PublicKey) (*Recipient, error) {
+	if pkS == nil {
+		return nil, errors.New("hpke: missing sender public key")
+	}
+	return setupRecipient(s, modeAuth, enc, skR, info, nil, nil, pkS)
+}
+
+// SetupAuthPSKRecipient sets up an auth-PSK mode context for the
+// encapsulated key enc, using the recipient's private key skR, and checks
+// that the sender holds both the psk and the private key for pkS.
+func SetupAuthPSKRecipient(s Suite, enc []byte, skR *ecdh.PrivateKey, info, psk, pskID []byte, pkS *ecdh.PublicKey) (*Recipient, error) {
+	if pkS == nil {
+		return nil, errors.New("hpke: missing sender public key")
+	}
+	return setupRecipient(s, modeAuthPSK, enc, skR, info, psk, pskID, pkS)
+}
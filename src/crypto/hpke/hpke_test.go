@@ -0,0 +1,707 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+type encryptionVector struct {
+	seq             int
+	aad, ciphertext string
+}
+
+type exportVector struct {
+	context string
+	length  int
+	value   string
+}
+
+type rfc9180Vector struct {
+	name  string
+	mode  byte
+	suite Suite
+
+	info, ikmE, skEm, pkEm, ikmR, skRm, pkRm string
+	skSm, pkSm, psk, pskID                   string
+
+	sharedSecret, key, baseNonce, exporterSecret string
+
+	encryptions []encryptionVector
+	exports     []exportVector
+}
+
+// rfc9180Vectors are from RFC 9180, Appendix A. The first two encryptions
+// and the exports of each vector are included.
+var rfc9180Vectors = []rfc9180Vector{
+	{
+		name:  "A.1.1 X25519 base",
+		mode:  modeBase,
+		suite: Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, AES_128_GCM},
+
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		ikmE: "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234",
+		skEm: "52c4a758a802cd8b936eceea314432798d5baf2d7e9235dc084ab1b9cfa2f736",
+		pkEm: "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431",
+		ikmR: "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037",
+		skRm: "4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8",
+		pkRm: "3948cfe0ad1ddb695d780e59077195da6c56506b027329794ab02bca80815c4d",
+
+		sharedSecret:   "fe0e18c9f024ce43799ae393c7e8fe8fce9d218875e8227b0187c04e7d2ea1fc",
+		key:            "4531685d41d65f03dc48f6b8302c05b0",
+		baseNonce:      "56d890e5accaaf011cff4b7d",
+		exporterSecret: "45ff1c2e220db587171952c0592d5f5ebe103f1561a2614e38f2ffd47e99e3f8",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "f938558b5d72f1a23810b4be2ab4f84331acc02fc97babc53a52ae8218a355a96d8770ac83d07bea87e13c512a"},
+			{1, "436f756e742d31", "af2d7e9ac9ae7e270f46ba1f975be53c09f8d875bdc8535458c2494e8a6eab251c03d0c22a56b8ca42c2063b84"},
+		},
+		exports: []exportVector{
+			{"", 32, "3853fe2b4035195a573ffc53856e77058e15d9ea064de3e59f4961d0095250ee"},
+			{"00", 32, "2e8f0b54673c7029649d4eb9d5e33bf1872cf76d623ff164ac185da9e88c21a5"},
+			{"54657374436f6e74657874", 32, "e9e43065102c3836401bed8c3c3c75ae46be1639869391d62c61f1ec7af54931"},
+		},
+	},
+	{
+		name:  "A.1.2 X25519 PSK",
+		mode:  modePSK,
+		suite: Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, AES_128_GCM},
+
+		info:  "4f6465206f6e2061204772656369616e2055726e",
+		ikmE:  "78628c354e46f3e169bd231be7b2ff1c77aa302460a26dbfa15515684c00130b",
+		skEm:  "463426a9ffb42bb17dbe6044b9abd1d4e4d95f9041cef0e99d7824eef2b6f588",
+		pkEm:  "0ad0950d9fb9588e59690b74f1237ecdf1d775cd60be2eca57af5a4b0471c91b",
+		ikmR:  "d4a09d09f575fef425905d2ab396c1449141463f698f8efdb7accfaff8995098",
+		skRm:  "c5eb01eb457fe6c6f57577c5413b931550a162c71a03ac8d196babbd4e5ce0fd",
+		pkRm:  "9fed7e8c17387560e92cc6462a68049657246a09bfa8ade7aefe589672016366",
+		psk:   "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82",
+		pskID: "456e6e796e20447572696e206172616e204d6f726961",
+
+		sharedSecret:   "727699f009ffe3c076315019c69648366b69171439bd7dd0807743bde76986cd",
+		key:            "15026dba546e3ae05836fc7de5a7bb26",
+		baseNonce:      "9518635eba129d5ce0914555",
+		exporterSecret: "3d76025dbbedc49448ec3f9080a1abab6b06e91c0b11ad23c912f043a0ee7655",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "e52c6fed7f758d0cf7145689f21bc1be6ec9ea097fef4e959440012f4feb73fb611b946199e681f4cfc34db8ea"},
+			{1, "436f756e742d31", "49f3b19b28a9ea9f43e8c71204c00d4a490ee7f61387b6719db765e948123b45b61633ef059ba22cd62437c8ba"},
+		},
+		exports: []exportVector{
+			{"", 32, "dff17af354c8b41673567db6259fd6029967b4e1aad13023c2ae5df8f4f43bf6"},
+			{"00", 32, "6a847261d8207fe596befb52928463881ab493da345b10e1dcc645e3b94e2d95"},
+			{"54657374436f6e74657874", 32, "8aff52b45a1be3a734bc7a41e20b4e055ad4c4d22104b0c20285a7c4302401cd"},
+		},
+	},
+	{
+		name:  "A.1.3 X25519 auth",
+		mode:  modeAuth,
+		suite: Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, AES_128_GCM},
+
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		ikmE: "6e6d8f200ea2fb20c30b003a8b4f433d2f4ed4c2658d5bc8ce2fef718059c9f7",
+		skEm: "ff4442ef24fbc3c1ff86375b0be1e77e88a0de1e79b30896d73411c5ff4c3518",
+		pkEm: "23fb952571a14a25e3d678140cd0e5eb47a0961bb18afcf85896e5453c312e76",
+		ikmR: "f1d4a30a4cef8d6d4e3b016e6fd3799ea057db4f345472ed302a67ce1c20cdec",
+		skRm: "fdea67cf831f1ca98d8e27b1f6abeb5b7745e9d35348b80fa407ff6958f9137e",
+		pkRm: "1632d5c2f71c2b38d0a8fcc359355200caa8b1ffdf28618080466c909cb69b2e",
+		skSm: "dc4a146313cce60a278a5323d321f051c5707e9c45ba21a3479fecdf76fc69dd",
+		pkSm: "8b0c70873dc5aecb7f9ee4e62406a397b350e57012be45cf53b7105ae731790b",
+
+		sharedSecret:   "2d6db4cf719dc7293fcbf3fa64690708e44e2bebc81f84608677958c0d4448a7",
+		key:            "b062cb2c4dd4bca0ad7c7a12bbc341e6",
+		baseNonce:      "a1bc314c1942ade7051ffed0",
+		exporterSecret: "ee1a093e6e1c393c162ea98fdf20560c75909653550540a2700511b65c88c6f1",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "5fd92cc9d46dbf8943e72a07e42f363ed5f721212cd90bcfd072bfd9f44e06b80fd17824947496e21b680c141b"},
+			{1, "436f756e742d31", "d3736bb256c19bfa93d79e8f80b7971262cb7c887e35c26370cfed62254369a1b52e3d505b79dd699f002bc8ed"},
+		},
+		exports: []exportVector{
+			{"", 32, "28c70088017d70c896a8420f04702c5a321d9cbf0279fba899b59e51bac72c85"},
+			{"00", 32, "25dfc004b0892be1888c3914977aa9c9bbaf2c7471708a49e1195af48a6f29ce"},
+			{"54657374436f6e74657874", 32, "5a0131813abc9a522cad678eb6bafaabc43389934adb8097d23c5ff68059eb64"},
+		},
+	},
+	{
+		name:  "A.1.4 X25519 auth-PSK",
+		mode:  modeAuthPSK,
+		suite: Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, AES_128_GCM},
+
+		info:  "4f6465206f6e2061204772656369616e2055726e",
+		ikmE:  "4303619085a20ebcf18edd22782952b8a7161e1dbae6e46e143a52a96127cf84",
+		skEm:  "14de82a5897b613616a00c39b87429df35bc2b426bcfd73febcb45e903490768",
+		pkEm:  "820818d3c23993492cc5623ab437a48a0a7ca3e9639c140fe1e33811eb844b7c",
+		ikmR:  "4b16221f3b269a88e207270b5e1de28cb01f847841b344b8314d6a622fe5ee90",
+		skRm:  "cb29a95649dc5656c2d054c1aa0d3df0493155e9d5da6d7e344ed8b6a64a9423",
+		pkRm:  "1d11a3cd247ae48e901939659bd4d79b6b959e1f3e7d66663fbc9412dd4e0976",
+		skSm:  "fc1c87d2f3832adb178b431fce2ac77c7ca2fd680f3406c77b5ecdf818b119f4",
+		pkSm:  "2bfb2eb18fcad1af0e4f99142a1c474ae74e21b9425fc5c589382c69b50cc57e",
+		psk:   "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82",
+		pskID: "456e6e796e20447572696e206172616e204d6f726961",
+
+		sharedSecret:   "f9d0e870aba28d04709b2680cb8185466c6a6ff1d6e9d1091d5bf5e10ce3a577",
+		key:            "1364ead92c47aa7becfa95203037b19a",
+		baseNonce:      "99d8b5c54669807e9fc70df1",
+		exporterSecret: "f048d55eacbf60f9c6154bd4021774d1075ebf963c6adc71fa846f183ab2dde6",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "a84c64df1e11d8fd11450039d4fe64ff0c8a99fca0bd72c2d4c3e0400bc14a40f27e45e141a24001697737533e"},
+			{1, "436f756e742d31", "4d19303b848f424fc3c3beca249b2c6de0a34083b8e909b6aa4c3688505c05ffe0c8f57a0a4c5ab9da127435d9"},
+		},
+		exports: []exportVector{
+			{"", 32, "08f7e20644bb9b8af54ad66d2067457c5f9fcb2a23d9f6cb4445c0797b330067"},
+			{"00", 32, "52e51ff7d436557ced5265ff8b94ce69cf7583f49cdb374e6aad801fc063b010"},
+			{"54657374436f6e74657874", 32, "a30c20370c026bbea4dca51cb63761695132d342bae33a6a11527d3e7679436d"},
+		},
+	},
+	{
+		name:  "A.2.1 X25519 ChaCha20Poly1305 base",
+		mode:  modeBase,
+		suite: Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, ChaCha20Poly1305},
+
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		ikmE: "909a9b35d3dc4713a5e72a4da274b55d3d3821a37e5d099e74a647db583a904b",
+		skEm: "f4ec9b33b792c372c1d2c2063507b684ef925b8c75a42dbcbf57d63ccd381600",
+		pkEm: "1afa08d3dec047a643885163f1180476fa7ddb54c6a8029ea33f95796bf2ac4a",
+		ikmR: "1ac01f181fdf9f352797655161c58b75c656a6cc2716dcb66372da835542e1df",
+		skRm: "8057991eef8f1f1af18f4a9491d16a1ce333f695d4db8e38da75975c4478e0fb",
+		pkRm: "4310ee97d88cc1f088a5576c77ab0cf5c3ac797f3d95139c6c84b5429c59662a",
+
+		sharedSecret:   "0bbe78490412b4bbea4812666f7916932b828bba79942424abb65244930d69a7",
+		key:            "ad2744de8e17f4ebba575b3f5f5a8fa1f69c2a07f6e7500bc60ca6e3e3ec1c91",
+		baseNonce:      "5c4d98150661b848853b547f",
+		exporterSecret: "a3b010d4994890e2c6968a36f64470d3c824c8f5029942feb11e7a74b2921922",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "1c5250d8034ec2b784ba2cfd69dbdb8af406cfe3ff938e131f0def8c8b60b4db21993c62ce81883d2dd1b51a28"},
+			{1, "436f756e742d31", "6b53c051e4199c518de79594e1c4ab18b96f081549d45ce015be002090bb119e85285337cc95ba5f59992dc98c"},
+		},
+		exports: []exportVector{
+			{"", 32, "4bbd6243b8bb54cec311fac9df81841b6fd61f56538a775e7c80a9f40160606e"},
+			{"00", 32, "8c1df14732580e5501b00f82b10a1647b40713191b7c1240ac80e2b68808ba69"},
+			{"54657374436f6e74657874", 32, "5acb09211139c43b3090489a9da433e8a30ee7188ba8b0a9a1ccf0c229283e53"},
+		},
+	},
+	{
+		name:  "A.3.1 P-256 base",
+		mode:  modeBase,
+		suite: Suite{DHKEM_P256_HKDF_SHA256, HKDF_SHA256, AES_128_GCM},
+
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		ikmE: "4270e54ffd08d79d5928020af4686d8f6b7d35dbe470265f1f5aa22816ce860e",
+		skEm: "4995788ef4b9d6132b249ce59a77281493eb39af373d236a1fe415cb0c2d7beb",
+		pkEm: "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f951c0900be863c403ce65c9bfcb9382657222d18c4",
+		ikmR: "668b37171f1072f3cf12ea8a236a45df23fc13b82af3609ad1e354f6ef817550",
+		skRm: "f3ce7fdae57e1a310d87f1ebbde6f328be0a99cdbcadf4d6589cf29de4b8ffd2",
+		pkRm: "04fe8c19ce0905191ebc298a9245792531f26f0cece2460639e8bc39cb7f706a826a779b4cf969b8a0e539c7f62fb3d30ad6aa8f80e30f1d128aafd68a2ce72ea0",
+
+		sharedSecret:   "c0d26aeab536609a572b07695d933b589dcf363ff9d93c93adea537aeabb8cb8",
+		key:            "868c066ef58aae6dc589b6cfdd18f97e",
+		baseNonce:      "4e0bc5018beba4bf004cca59",
+		exporterSecret: "14ad94af484a7ad3ef40e9f3be99ecc6fa9036df9d4920548424df127ee0d99f",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "5ad590bb8baa577f8619db35a36311226a896e7342a6d836d8b7bcd2f20b6c7f9076ac232e3ab2523f39513434"},
+			{1, "436f756e742d31", "fa6f037b47fc21826b610172ca9637e82d6e5801eb31cbd3748271affd4ecb06646e0329cbdf3c3cd655b28e82"},
+		},
+		exports: []exportVector{
+			{"", 32, "5e9bc3d236e1911d95e65b576a8a86d478fb827e8bdfe77b741b289890490d4d"},
+			{"00", 32, "6cff87658931bda83dc857e6353efe4987a201b849658d9b047aab4cf216e796"},
+			{"54657374436f6e74657874", 32, "d8f1ea7942adbba7412c6d431c62d01371ea476b823eb697e1f6e6cae1dab85a"},
+		},
+	},
+	{
+		name:  "A.4.1 P-256 HKDF-SHA512 base",
+		mode:  modeBase,
+		suite: Suite{DHKEM_P256_HKDF_SHA256, HKDF_SHA512, AES_128_GCM},
+
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		ikmE: "4ab11a9dd78c39668f7038f921ffc0993b368171d3ddde8031501ee1e08c4c9a",
+		skEm: "2292bf14bb6e15b8c81a0f45b7a6e93e32d830e48cca702e0affcfb4d07e1b5c",
+		pkEm: "0493ed86735bdfb978cc055c98b45695ad7ce61ce748f4dd63c525a3b8d53a15565c6897888070070c1579db1f86aaa56deb8297e64db7e8924e72866f9a472580",
+		ikmR: "ea9ff7cc5b2705b188841c7ace169290ff312a9cb31467784ca92d7a2e6e1be8",
+		skRm: "3ac8530ad1b01885960fab38cf3cdc4f7aef121eaa239f222623614b4079fb38",
+		pkRm: "04085aa5b665dc3826f9650ccbcc471be268c8ada866422f739e2d531d4a8818a9466bc6b449357096232919ec4fe9070ccbac4aac30f4a1a53efcf7af90610edd",
+
+		sharedSecret:   "02f584736390fc93f5b4ad039826a3fa08e9911bd1215a3db8e8791ba533cafd",
+		key:            "090ca96e5f8aa02b69fac360da50ddf9",
+		baseNonce:      "9c995e621bf9a20c5ca45546",
+		exporterSecret: "4a7abb2ac43e6553f129b2c5750a7e82d149a76ed56dc342d7bca61e26d494f4855dff0d0165f27ce57756f7f16baca006539bb8e4518987ba610480ac03efa8",
+
+		encryptions: []encryptionVector{
+			{0, "436f756e742d30", "d3cf4984931484a080f74c1bb2a6782700dc1fef9abe8442e44a6f09044c88907200b332003543754eb51917ba"},
+			{1, "436f756e742d31", "d14414555a47269dfead9fbf26abb303365e40709a4ed16eaefe1f2070f1ddeb1bdd94d9e41186f124e0acc62d"},
+		},
+		exports: []exportVector{
+			{"", 32, "a32186b8946f61aeead1c093fe614945f85833b165b28c46bf271abf16b57208"},
+			{"00", 32, "84998b304a0ea2f11809398755f0abd5f9d2c141d1822def79dd15c194803c2a"},
+			{"54657374436f6e74657874", 32, "93fb9411430b2cfa2cf0bed448c46922a5be9beff20e2e621df7e4655852edbc"},
+		},
+	},
+}
+
+func TestRFC9180Vectors(t *testing.T) {
+	for _, v := range rfc9180Vectors {
+		t.Run(v.name, func(t *testing.T) {
+			testRFC9180Vector(t, v)
+		})
+	}
+}
+
+func testRFC9180Vector(t *testing.T, v rfc9180Vector) {
+	curve := v.suite.KEM.Curve()
+	checkKey := func(name, ikm, sk, pk string) *ecdh.PrivateKey {
+		k, err := curve.NewPrivateKey(mustDecodeHex(t, sk))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := hex.EncodeToString(k.PublicKey().Bytes()); got != pk {
+			t.Errorf("%s: public key = %s, want %s", name, got, pk)
+		}
+		if ikm != "" {
+			dk, err := v.suite.KEM.DeriveKeyPair(mustDecodeHex(t, ikm))
+			if err != nil {
+				t.Fatalf("%s: DeriveKeyPair: %v", name, err)
+			}
+			if !dk.Equal(k) {
+				t.Errorf("%s: DeriveKeyPair = %x, want %s", name, dk.Bytes(), sk)
+			}
+		}
+		return k
+	}
+	skE := checkKey("skEm", v.ikmE, v.skEm, v.pkEm)
+	skR := checkKey("skRm", v.ikmR, v.skRm, v.pkRm)
+	var skS *ecdh.PrivateKey
+	var pkS *ecdh.PublicKey
+	if v.skSm != "" {
+		skS = checkKey("skSm", "", v.skSm, v.pkSm)
+		pkS = skS.PublicKey()
+	}
+
+	testingOnlyGenerateKey = func() (*ecdh.PrivateKey, error) { return skE, nil }
+	defer func() { testingOnlyGenerateKey = nil }()
+
+	suite, pkR := v.suite, skR.PublicKey()
+	info, psk, pskID := mustDecodeHex(t, v.info), mustDecodeHex(t, v.psk), mustDecodeHex(t, v.pskID)
+	var enc []byte
+	var sender *Sender
+	var recipient *Recipient
+	var err, rerr error
+	switch v.mode {
+	case modeBase:
+		enc, sender, err = SetupBaseSender(suite, pkR, info)
+		if err == nil {
+			recipient, rerr = SetupBaseRecipient(suite, enc, skR, info)
+		}
+	case modePSK:
+		enc, sender, err = SetupPSKSender(suite, pkR, info, psk, pskID)
+		if err == nil {
+			recipient, rerr = SetupPSKRecipient(suite, enc, skR, info, psk, pskID)
+		}
+	case modeAuth:
+		enc, sender, err = SetupAuthSender(suite, pkR, info, skS)
+		if err == nil {
+			recipient, rerr = SetupAuthRecipient(suite, enc, skR, info, pkS)
+		}
+	case modeAuthPSK:
+		enc, sender, err = SetupAuthPSKSender(suite, pkR, info, psk, pskID, skS)
+		if err == nil {
+			recipient, rerr = SetupAuthPSKRecipient(suite, enc, skR, info, psk, pskID, pkS)
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	if got := hex.EncodeToString(enc); got != v.pkEm {
+		t.Errorf("enc = %s, want %s", got, v.pkEm)
+	}
+
+	ss, err := decap(suite.KEM, enc, skR, pkS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(ss); got != v.sharedSecret {
+		t.Errorf("shared_secret = %s, want %s", got, v.sharedSecret)
+	}
+	for _, c := range []*context{sender.c, recipient.c} {
+		if got := hex.EncodeToString(c.key); got != v.key {
+			t.Errorf("key = %s, want %s", got, v.key)
+		}
+		if got := hex.EncodeToString(c.baseNonce); got != v.baseNonce {
+			t.Errorf("base_nonce = %s, want %s", got, v.baseNonce)
+		}
+		if got := hex.EncodeToString(c.exporterSecret); got != v.exporterSecret {
+			t.Errorf("exporter_secret = %s, want %s", got, v.exporterSecret)
+		}
+	}
+
+	pt := mustDecodeHex(t, "4265617574792069732074727574682c20747275746820626561757479")
+	for _, e := range v.encryptions {
+		if sender.c.seqNum != uint64(e.seq) {
+			t.Fatalf("sequence number = %d, want %d", sender.c.seqNum, e.seq)
+		}
+		aad := mustDecodeHex(t, e.aad)
+		ct, err := sender.Seal(aad, pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(ct); got != e.ciphertext {
+			t.Errorf("seq %d: ciphertext = %s, want %s", e.seq, got, e.ciphertext)
+		}
+		got, err := recipient.Open(aad, ct)
+		if err != nil {
+			t.Fatalf("seq %d: %v", e.seq, err)
+		}
+		if !bytes.Equal(got, pt) {
+			t.Errorf("seq %d: plaintext = %x, want %x", e.seq, got, pt)
+		}
+	}
+
+	for _, e := range v.exports {
+		for _, exp := range []interface {
+			Export([]byte, int) ([]byte, error)
+		}{sender, recipient} {
+			got, err := exp.Export(mustDecodeHex(t, e.context), e.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(got) != e.value {
+				t.Errorf("%T: export(%q) = %x, want %s", exp, e.context, got, e.value)
+			}
+		}
+	}
+}
+
+// accumulatedVector is a base mode vector of testdata/rfc9180.json. Instead
+// of listing encryptions and exports, it has SHAKE128 digests over 1000
+// encryptions and exports of pseudorandom inputs drawn from SHAKE128.
+type accumulatedVector struct {
+	Mode        byte   `json:"mode"`
+	KEM         KEM    `json:"kem_id"`
+	KDF         KDF    `json:"kdf_id"`
+	AEAD        AEAD   `json:"aead_id"`
+	Info        string `json:"info"`
+	IkmE        string `json:"ikmE"`
+	IkmR        string `json:"ikmR"`
+	SkRm        string `json:"skRm"`
+	PkRm        string `json:"pkRm"`
+	Enc         string `json:"enc"`
+	Encryptions string `json:"encryptions_accumulated"`
+	Exports     string `json:"exports_accumulated"`
+}
+
+// TestAccumulatedVectors checks the vectors of testdata/rfc9180.json, which
+// cover every supported suite.
+func TestAccumulatedVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/rfc9180.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []accumulatedVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range vectors {
+		t.Run(fmt.Sprintf("%04x-%04x-%04x", v.KEM, v.KDF, v.AEAD), func(t *testing.T) {
+			testAccumulatedVector(t, v)
+		})
+	}
+}
+
+func testAccumulatedVector(t *testing.T, v accumulatedVector) {
+	if v.Mode != modeBase {
+		t.Fatalf("unexpected mode %d", v.Mode)
+	}
+	s := Suite{v.KEM, v.KDF, v.AEAD}
+	info, skRm, pkRm, enc := v.Info, v.SkRm, v.PkRm, v.Enc
+	encryptions, exports := v.Encryptions, v.Exports
+	skE, err := s.KEM.DeriveKeyPair(mustDecodeHex(t, v.IkmE))
+	if err != nil {
+		t.Fatal(err)
+	}
+	skR, err := s.KEM.DeriveKeyPair(mustDecodeHex(t, v.IkmR))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(skR.Bytes()); got != skRm {
+		t.Errorf("skRm = %s, want %s", got, skRm)
+	}
+	if got := hex.EncodeToString(skR.PublicKey().Bytes()); got != pkRm {
+		t.Errorf("pkRm = %s, want %s", got, pkRm)
+	}
+
+	testingOnlyGenerateKey = func() (*ecdh.PrivateKey, error) { return skE, nil }
+	defer func() { testingOnlyGenerateKey = nil }()
+
+	gotEnc, sender, err := SetupBaseSender(s, skR.PublicKey(), mustDecodeHex(t, info))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(gotEnc); got != enc {
+		t.Errorf("enc = %s, want %s", got, enc)
+	}
+	recipient, err := SetupBaseRecipient(s, gotEnc, skR, mustDecodeHex(t, info))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.AEAD == ExportOnly {
+		if encryptions != "" {
+			t.Errorf("unexpected encryptions for an export-only suite")
+		}
+	} else {
+		source, sink := sha3.NewShake128(), sha3.NewShake128()
+		for i := 0; i < 1000; i++ {
+			aad, pt := drawRandomInput(t, source), drawRandomInput(t, source)
+			ct, err := sender.Seal(aad, pt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sink.Write(ct)
+			got, err := recipient.Open(aad, ct)
+			if err != nil {
+				t.Fatalf("message %d: %v", i, err)
+			}
+			if !bytes.Equal(got, pt) {
+				t.Fatalf("message %d: plaintext = %x, want %x", i, got, pt)
+			}
+		}
+		sum := make([]byte, 16)
+		sink.Read(sum)
+		if got := hex.EncodeToString(sum); got != encryptions {
+			t.Errorf("accumulated encryptions = %s, want %s", got, encryptions)
+		}
+	}
+
+	source, sink := sha3.NewShake128(), sha3.NewShake128()
+	for length := 0; length < 1000; length++ {
+		exporterContext := drawRandomInput(t, source)
+		value, err := sender.Export(exporterContext, length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sink.Write(value)
+		got, err := recipient.Export(exporterContext, length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, value) {
+			t.Fatalf("export %d: recipient = %x, sender = %x", length, got, value)
+		}
+	}
+	sum := make([]byte, 16)
+	sink.Read(sum)
+	if got := hex.EncodeToString(sum); got != exports {
+		t.Errorf("accumulated exports = %s, want %s", got, exports)
+	}
+}
+
+// drawRandomInput reads a length byte from r, followed by that many bytes.
+func drawRandomInput(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+	l := make([]byte, 1)
+	if _, err := io.ReadFull(r, l); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, l[0])
+	if _, err := io.ReadFull(r, b); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+var allSuites = func() []Suite {
+	var suites []Suite
+	for _, kem := range []KEM{DHKEM_X25519_HKDF_SHA256, DHKEM_P256_HKDF_SHA256} {
+		for _, kdf := range []KDF{HKDF_SHA256, HKDF_SHA384, HKDF_SHA512} {
+			for _, aead := range []AEAD{AES_128_GCM, AES_256_GCM, ChaCha20Poly1305, ExportOnly} {
+				suites = append(suites, Suite{kem, kdf, aead})
+			}
+		}
+	}
+	return suites
+}()
+
+func TestRoundTrip(t *testing.T) {
+	info := []byte("info")
+	psk, pskID := []byte("0123456789abcdef0123456789abcdef"), []byte("psk id")
+	for _, s := range allSuites {
+		skR, err := s.KEM.Curve().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		skS, err := s.KEM.Curve().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkR, pkS := skR.PublicKey(), skS.PublicKey()
+
+		setups := []struct {
+			name   string
+			sender func() ([]byte, *Sender, error)
+			recip  func(enc []byte) (*Recipient, error)
+		}{
+			{"base",
+				func() ([]byte, *Sender, error) { return SetupBaseSender(s, pkR, info) },
+				func(enc []byte) (*Recipient, error) { return SetupBaseRecipient(s, enc, skR, info) }},
+			{"psk",
+				func() ([]byte, *Sender, error) { return SetupPSKSender(s, pkR, info, psk, pskID) },
+				func(enc []byte) (*Recipient, error) { return SetupPSKRecipient(s, enc, skR, info, psk, pskID) }},
+			{"auth",
+				func() ([]byte, *Sender, error) { return SetupAuthSender(s, pkR, info, skS) },
+				func(enc []byte) (*Recipient, error) { return SetupAuthRecipient(s, enc, skR, info, pkS) }},
+			{"auth-psk",
+				func() ([]byte, *Sender, error) { return SetupAuthPSKSender(s, pkR, info, psk, pskID, skS) },
+				func(enc []byte) (*Recipient, error) {
+					return SetupAuthPSKRecipient(s, enc, skR, info, psk, pskID, pkS)
+				}},
+		}
+		for _, setup := range setups {
+			enc, sender, err := setup.sender()
+			if err != nil {
+				t.Fatalf("%v %s: %v", s, setup.name, err)
+			}
+			recipient, err := setup.recip(enc)
+			if err != nil {
+				t.Fatalf("%v %s: %v", s, setup.name, err)
+			}
+
+			se, err := sender.Export([]byte("context"), 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			re, err := recipient.Export([]byte("context"), 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(se, re) {
+				t.Errorf("%v %s: exported secrets differ", s, setup.name)
+			}
+
+			if s.AEAD == ExportOnly {
+				if _, err := sender.Seal(nil, []byte("message")); err == nil {
+					t.Errorf("%v %s: Seal succeeded on an export-only context", s, setup.name)
+				}
+				continue
+			}
+			for i := 0; i < 3; i++ {
+				aad, pt := []byte{byte(i)}, []byte("message")
+				ct, err := sender.Seal(aad, pt)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := recipient.Open([]byte("wrong aad"), ct); err == nil {
+					t.Errorf("%v %s: Open succeeded with the wrong aad", s, setup.name)
+				}
+				got, err := recipient.Open(aad, ct)
+				if err != nil {
+					t.Fatalf("%v %s: message %d: %v", s, setup.name, i, err)
+				}
+				if !bytes.Equal(got, pt) {
+					t.Errorf("%v %s: message %d: got %q", s, setup.name, i, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	s := Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, AES_128_GCM}
+	skR, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	skP256, _ := ecdh.P256().GenerateKey(rand.Reader)
+	psk := []byte("0123456789abcdef0123456789abcdef")
+
+	if _, _, err := SetupBaseSender(Suite{0x42, HKDF_SHA256, AES_128_GCM}, skR.PublicKey(), nil); err == nil {
+		t.Error("unsupported KEM was accepted")
+	}
+	if _, _, err := SetupBaseSender(Suite{DHKEM_X25519_HKDF_SHA256, 0x42, AES_128_GCM}, skR.PublicKey(), nil); err == nil {
+		t.Error("unsupported KDF was accepted")
+	}
+	if _, _, err := SetupBaseSender(Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, 0x42}, skR.PublicKey(), nil); err == nil {
+		t.Error("unsupported AEAD was accepted")
+	}
+	if _, _, err := SetupBaseSender(s, skP256.PublicKey(), nil); err == nil {
+		t.Error("P-256 key was accepted for an X25519 KEM")
+	}
+	if _, _, err := SetupPSKSender(s, skR.PublicKey(), nil, psk, nil); err == nil {
+		t.Error("PSK without an ID was accepted")
+	}
+	if _, _, err := SetupPSKSender(s, skR.PublicKey(), nil, nil, nil); err == nil {
+		t.Error("PSK mode without a PSK was accepted")
+	}
+	if _, _, err := SetupAuthSender(s, skR.PublicKey(), nil, nil); err == nil {
+		t.Error("auth mode without a sender key was accepted")
+	}
+	if _, err := SetupBaseRecipient(s, make([]byte, 5), skR, nil); err == nil {
+		t.Error("short encapsulated key was accepted")
+	}
+	if _, err := SetupBaseRecipient(s, make([]byte, 32), skR, nil); err == nil {
+		t.Error("low order encapsulated key was accepted")
+	}
+
+	// Auth mode fails to decrypt if the recipient expects another sender.
+	skS, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	other, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	enc, sender, err := SetupAuthSender(s, skR.PublicKey(), nil, skS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient, err := SetupAuthRecipient(s, enc, skR, nil, other.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := sender.Seal(nil, []byte("message"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recipient.Open(nil, ct); err == nil {
+		t.Error("message from the wrong sender was opened")
+	}
+
+	if _, err := sender.Export(nil, 255*32+1); err == nil {
+		t.Error("overlong export was accepted")
+	}
+	if _, err := sender.Export(nil, -1); err == nil {
+		t.Error("negative export length was accepted")
+	}
+}
+
+func TestMessageLimit(t *testing.T) {
+	s := Suite{DHKEM_X25519_HKDF_SHA256, HKDF_SHA256, ChaCha20Poly1305}
+	skR, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	_, sender, err := SetupBaseSender(s, skR.PublicKey(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.c.seqNum = 1<<64 - 2
+	if _, err := sender.Seal(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sender.Seal(nil, nil); err == nil {
+		t.Error("Seal succeeded after the message limit")
+	}
+}
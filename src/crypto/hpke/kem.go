@@ -0,0 +1,134 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hpke
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+)
+
+// testingOnlyGenerateKey, if not nil, replaces the ephemeral key generation
+// in the Setup*Sender functions. It is used to check the package against
+// the RFC 9180 test vectors.
+var testingOnlyGenerateKey func() (*ecdh.PrivateKey, error)
+
+// kemKDF returns the labeled KDF of DHKEM, which for both supported KEMs is
+// HKDF-SHA256. See RFC 9180, Section 4.1.
+func kemKDF(kem KEM) *labeledKDF {
+	return &labeledKDF{
+		hash:    crypto.SHA256,
+		suiteID: []byte{'K', 'E', 'M', byte(kem >> 8), byte(kem)},
+	}
+}
+
+func extractAndExpand(kem KEM, dh, kemContext []byte) []byte {
+	kdf := kemKDF(kem)
+	eaePRK := kdf.labeledExtract(nil, "eae_prk", dh)
+	return kdf.labeledExpand(eaePRK, "shared_secret", kemContext, 32)
+}
+
+// checkCurve returns an error if a key is not on the curve of kem.
+func checkCurve(kem KEM, curve ecdh.Curve) error {
+	if curve != kem.Curve() {
+		return errors.New("hpke: key does not match the KEM")
+	}
+	return nil
+}
+
+// encap implements Encap and AuthEncap of RFC 9180, Section 4.1. skS is nil
+// for Encap.
+func encap(kem KEM, pkR *ecdh.PublicKey, skS *ecdh.PrivateKey) (sharedSecret, enc []byte, err error) {
+	if err := checkCurve(kem, pkR.Curve()); err != nil {
+		return nil, nil, err
+	}
+	if skS != nil {
+		if err := checkCurve(kem, skS.Curve()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var skE *ecdh.PrivateKey
+	if testingOnlyGenerateKey != nil {
+		skE, err = testingOnlyGenerateKey()
+	} else {
+		skE, err = kem.Curve().GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dh, err := skE.ECDH(pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc = skE.PublicKey().Bytes()
+	kemContext := append(append([]byte{}, enc...), pkR.Bytes()...)
+	if skS != nil {
+		dhS, err := skS.ECDH(pkR)
+		if err != nil {
+			return nil, nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, skS.PublicKey().Bytes()...)
+	}
+	return extractAndExpand(kem, dh, kemContext), enc, nil
+}
+
+// decap implements Decap and AuthDecap of RFC 9180, Section 4.1. pkS is nil
+// for Decap.
+func decap(kem KEM, enc []byte, skR *ecdh.PrivateKey, pkS *ecdh.PublicKey) ([]byte, error) {
+	if err := checkCurve(kem, skR.Curve()); err != nil {
+		return nil, err
+	}
+	if pkS != nil {
+		if err := checkCurve(kem, pkS.Curve()); err != nil {
+			return nil, err
+		}
+	}
+
+	pkE, err := kem.Curve().NewPublicKey(enc)
+	if err != nil {
+		return nil, err
+	}
+	dh, err := skR.ECDH(pkE)
+	if err != nil {
+		return nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), skR.PublicKey().Bytes()...)
+	if pkS != nil {
+		dhS, err := skR.ECDH(pkS)
+		if err != nil {
+			return nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkS.Bytes()...)
+	}
+	return extractAndExpand(kem, dh, kemContext), nil
+}
+
+// DeriveKeyPair deterministically derives a key pair for the KEM from the
+// input keying material ikm, as specified in RFC 9180, Section 7.1.3. The
+// ikm must have at least as much entropy as the private key.
+func (k KEM) DeriveKeyPair(ikm []byte) (*ecdh.PrivateKey, error) {
+	if !k.Available() {
+		return nil, errors.New("hpke: unsupported KEM")
+	}
+	kdf := kemKDF(k)
+	dkpPRK := kdf.labeledExtract(nil, "dkp_prk", ikm)
+	if k == DHKEM_X25519_HKDF_SHA256 {
+		sk := kdf.labeledExpand(dkpPRK, "sk", nil, 32)
+		return ecdh.X25519().NewPrivateKey(sk)
+	}
+	// Rejection sampling for P-256, where the bitmask is 0xff.
+	for counter := 0; counter < 256; counter++ {
+		sk := kdf.labeledExpand(dkpPRK, "candidate", []byte{byte(counter)}, 32)
+		if k, err := ecdh.P256().NewPrivateKey(sk); err == nil {
+			return k, nil
+		}
+	}
+	return nil, errors.New("hpke: DeriveKeyPair failed")
+}
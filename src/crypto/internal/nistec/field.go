@@ -0,0 +1,312 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nistec
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// maxLimbs is the number of 64-bit limbs needed for the largest field,
+// the one of P-521.
+const maxLimbs = 9
+
+// A field is a prime field GF(p), whose elements are kept in the
+// Montgomery domain with R = 2^(64*n).
+type field struct {
+	n       int              // number of limbs in use
+	byteLen int              // length of the big-endian encoding
+	p       [maxLimbs]uint64 // the modulus, little-endian limbs
+	m0inv   uint64           // -p⁻¹ mod 2⁶⁴
+	rr      [maxLimbs]uint64 // R² mod p
+	one     [maxLimbs]uint64 // R mod p, that is 1 in the Montgomery domain
+	pMinus2 []byte           // big-endian exponent for inversion
+}
+
+// newField returns the field modulo p, which is given in big-endian
+// hexadecimal and must be odd.
+func newField(p string) *field {
+	pb := mustDecodeHex(p)
+	f := &field{byteLen: len(pb)}
+	f.n = (len(pb) + 7) / 8
+	limbsFromBytes(&f.p, pb)
+
+	// Newton's iteration doubles the number of correct low bits of the
+	// inverse each step, starting from the 1 bit that is always right.
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+	f.m0inv = -inv
+
+	// Find R mod p and R² mod p by doubling 1 repeatedly.
+	var x [maxLimbs]uint64
+	x[0] = 1
+	for i := 0; i < 2*64*f.n; i++ {
+		if i == 64*f.n {
+			f.one = x
+		}
+		f.add(&x, &x, &x)
+	}
+	f.rr = x
+
+	f.pMinus2 = append([]byte(nil), pb...)
+	var borrow uint32 = 2
+	for i := len(f.pMinus2) - 1; i >= 0 && borrow > 0; i-- {
+		v := uint32(f.pMinus2[i]) - borrow
+		f.pMinus2[i] = byte(v)
+		borrow = (v >> 8) & 1
+	}
+	return f
+}
+
+func mustDecodeHex(s string) []byte {
+	b := make([]byte, len(s)/2)
+	for i := range b {
+		b[i] = fromHexChar(s[2*i])<<4 | fromHexChar(s[2*i+1])
+	}
+	return b
+}
+
+func fromHexChar(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	panic("nistec: invalid hex constant")
+}
+
+// limbsFromBytes decodes the big-endian b into little-endian limbs.
+func limbsFromBytes(l *[maxLimbs]uint64, b []byte) {
+	*l = [maxLimbs]uint64{}
+	for i, c := range b {
+		j := len(b) - 1 - i
+		l[j/8] |= uint64(c) << (8 * (j % 8))
+	}
+}
+
+// add sets out = a + b mod p. The inputs must be reduced.
+func (f *field) add(out, a, b *[maxLimbs]uint64) {
+	var t [maxLimbs]uint64
+	var carry uint64
+	for i := 0; i < f.n; i++ {
+		t[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	f.reduceOnce(out, &t, carry)
+}
+
+// sub sets out = a - b mod p. The inputs must be reduced.
+func (f *field) sub(out, a, b *[maxLimbs]uint64) {
+	var t [maxLimbs]uint64
+	var borrow uint64
+	for i := 0; i < f.n; i++ {
+		t[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	// Add p back if the subtraction underflowed.
+	mask := -borrow
+	var carry uint64
+	for i := 0; i < f.n; i++ {
+		out[i], carry = bits.Add64(t[i], f.p[i]&mask, carry)
+	}
+}
+
+// reduceOnce sets out = t - p if carry:t >= p, and out = t otherwise.
+// carry:t must be less than 2p.
+func (f *field) reduceOnce(out, t *[maxLimbs]uint64, carry uint64) {
+	var s [maxLimbs]uint64
+	var borrow uint64
+	for i := 0; i < f.n; i++ {
+		s[i], borrow = bits.Sub64(t[i], f.p[i], borrow)
+	}
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	// borrow is 1 if and only if carry:t < p.
+	mask := -borrow
+	for i := 0; i < f.n; i++ {
+		out[i] = t[i]&mask | s[i]&^mask
+	}
+}
+
+// mul sets out = a * b * R⁻¹ mod p, using the Coarsely Integrated
+// Operand Scanning Montgomery multiplication. The inputs must be reduced.
+func (f *field) mul(out, a, b *[maxLimbs]uint64) {
+	var tt [maxLimbs + 2]uint64
+	n := f.n
+	t, x, p := tt[:n+2], a[:n], f.p[:n]
+	for _, y := range b[:n] {
+		// t += a * b[i]
+		var c uint64
+		for j, xj := range x {
+			hi, lo := bits.Mul64(xj, y)
+			var cc uint64
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[n], c = bits.Add64(t[n], c, 0)
+		t[n+1] = c
+
+		// t = (t + m * p) / 2⁶⁴, where m makes the low limb zero.
+		m := t[0] * f.m0inv
+		hi, lo := bits.Mul64(m, p[0])
+		_, cc := bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < n; j++ {
+			hi, lo := bits.Mul64(m, p[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[n-1], c = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + c
+	}
+	var r [maxLimbs]uint64
+	copy(r[:n], t[:n])
+	f.reduceOnce(out, &r, t[n])
+}
+
+// A fieldElement is an element of a field, in the Montgomery domain.
+//
+// The zero value is not usable; elements are created by field methods or
+// by Set.
+type fieldElement struct {
+	f *field
+	l [maxLimbs]uint64
+}
+
+// newElement returns a new zero element of f.
+func (f *field) newElement() *fieldElement {
+	return &fieldElement{f: f}
+}
+
+// One sets e = 1, and returns e.
+func (e *fieldElement) One() *fieldElement {
+	e.l = e.f.one
+	return e
+}
+
+// Zero sets e = 0, and returns e.
+func (e *fieldElement) Zero() *fieldElement {
+	e.l = [maxLimbs]uint64{}
+	return e
+}
+
+// Set sets e = t, and returns e.
+func (e *fieldElement) Set(t *fieldElement) *fieldElement {
+	*e = *t
+	return e
+}
+
+// Add sets e = t1 + t2, and returns e.
+func (e *fieldElement) Add(t1, t2 *fieldElement) *fieldElement {
+	e.f = t1.f
+	e.f.add(&e.l, &t1.l, &t2.l)
+	return e
+}
+
+// Sub sets e = t1 - t2, and returns e.
+func (e *fieldElement) Sub(t1, t2 *fieldElement) *fieldElement {
+	e.f = t1.f
+	e.f.sub(&e.l, &t1.l, &t2.l)
+	return e
+}
+
+// Mul sets e = t1 * t2, and returns e.
+func (e *fieldElement) Mul(t1, t2 *fieldElement) *fieldElement {
+	e.f = t1.f
+	e.f.mul(&e.l, &t1.l, &t2.l)
+	return e
+}
+
+// Square sets e = t * t, and returns e.
+func (e *fieldElement) Square(t *fieldElement) *fieldElement {
+	return e.Mul(t, t)
+}
+
+// Invert sets e = 1/t, and returns e. If t == 0, Invert sets e = 0.
+func (e *fieldElement) Invert(t *fieldElement) *fieldElement {
+	// Fermat's little theorem: t⁻¹ = t^(p-2). The exponent is public, so
+	// square-and-multiply does not leak anything about t.
+	f := t.f
+	x := *t
+	r := fieldElement{f: f}
+	r.One()
+	for _, b := range f.pMinus2 {
+		for i := 7; i >= 0; i-- {
+			r.Square(&r)
+			if b>>i&1 == 1 {
+				r.Mul(&r, &x)
+			}
+		}
+	}
+	*e = r
+	return e
+}
+
+// Select sets e to a if cond == 1, and to b if cond == 0.
+func (e *fieldElement) Select(a, b *fieldElement, cond int) *fieldElement {
+	mask := -uint64(cond)
+	e.f = a.f
+	for i := range e.l {
+		e.l[i] = a.l[i]&mask | b.l[i]&^mask
+	}
+	return e
+}
+
+// IsZero returns 1 if e == 0, and zero otherwise.
+func (e *fieldElement) IsZero() int {
+	var acc uint64
+	for _, l := range e.l {
+		acc |= l
+	}
+	// The top bit of acc | -acc is set if and only if acc != 0.
+	return int((acc|-acc)>>63) ^ 1
+}
+
+// Equal returns 1 if e == t, and zero otherwise.
+func (e *fieldElement) Equal(t *fieldElement) int {
+	d := new(fieldElement).Sub(e, t)
+	return d.IsZero()
+}
+
+// Bytes returns the big-endian encoding of e.
+func (e *fieldElement) Bytes() []byte {
+	f := e.f
+	var one, l [maxLimbs]uint64
+	one[0] = 1
+	f.mul(&l, &e.l, &one)
+	out := make([]byte, f.byteLen)
+	for i := range out {
+		j := len(out) - 1 - i
+		out[i] = byte(l[j/8] >> (8 * (j % 8)))
+	}
+	return out
+}
+
+// SetBytes sets e = v, where v is a big-endian encoding of a value less
+// than p of the field's byte length, and returns e. Otherwise, SetBytes
+// returns nil and an error, and e is unchanged.
+func (e *fieldElement) SetBytes(v []byte) (*fieldElement, error) {
+	f := e.f
+	if len(v) != f.byteLen {
+		return nil, errors.New("invalid field element encoding")
+	}
+	var l [maxLimbs]uint64
+	limbsFromBytes(&l, v)
+	var borrow uint64
+	for i := 0; i < f.n; i++ {
+		_, borrow = bits.Sub64(l[i], f.p[i], borrow)
+	}
+	if borrow == 0 {
+		return nil, errors.New("invalid field element encoding")
+	}
+	f.mul(&e.l, &l, &f.rr)
+	return e, nil
+}
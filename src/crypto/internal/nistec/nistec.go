@@ -0,0 +1,356 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package nistec implements the NIST P-384 and P-521 elliptic curves in
+// constant time. P-256 already has constant-time implementations in
+// crypto/elliptic.
+//
+// Field arithmetic uses Montgomery multiplication over 64-bit limbs, and
+// points use the complete projective addition formulas of Renes, Costello
+// and Batina (https://eprint.iacr.org/2015/1060), so neither depends on
+// the values being processed. Scalar multiplication uses a fixed 4-bit
+// window and constant-time table lookups.
+package nistec
+
+import (
+	"crypto/subtle"
+	"errors"
+	"sync"
+)
+
+// A Curve is one of the NIST prime-order curves y² = x³ - 3x + b.
+type Curve struct {
+	name   string
+	f      *field
+	b      *fieldElement
+	gx, gy *fieldElement
+}
+
+var (
+	p256, p384, p521             *Curve
+	p256Once, p384Once, p521Once sync.Once
+)
+
+func newCurve(name, p, b, gx, gy string) *Curve {
+	f := newField(p)
+	c := &Curve{name: name, f: f}
+	var err error
+	if c.b, err = f.newElement().SetBytes(mustDecodeHex(b)); err != nil {
+		panic("nistec: invalid curve constant")
+	}
+	if c.gx, err = f.newElement().SetBytes(mustDecodeHex(gx)); err != nil {
+		panic("nistec: invalid curve constant")
+	}
+	if c.gy, err = f.newElement().SetBytes(mustDecodeHex(gy)); err != nil {
+		panic("nistec: invalid curve constant")
+	}
+	return c
+}
+
+// P384 returns the NIST P-384 curve (FIPS 186-3, section D.2.4).
+func P384() *Curve {
+	p384Once.Do(func() {
+		p384 = newCurve("P-384",
+			"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe"+
+				"ffffffff0000000000000000ffffffff",
+			"b3312fa7e23ee7e4988e056be3f82d19181d9c6efe8141120314088f5013875a"+
+				"c656398d8a2ed19d2a85c8edd3ec2aef",
+			"aa87ca22be8b05378eb1c71ef320ad746e1d3b628ba79b9859f741e082542a38"+
+				"5502f25dbf55296c3a545e3872760ab7",
+			"3617de4a96262c6f5d9e98bf9292dc29f8f41dbd289a147ce9da3113b5f0b8c0"+
+				"0a60b1ce1d7e819d7a431d7c90ea0e5f")
+	})
+	return p384
+}
+
+// P521 returns the NIST P-521 curve (FIPS 186-3, section D.2.5).
+func P521() *Curve {
+	p521Once.Do(func() {
+		p521 = newCurve("P-521",
+			"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"+
+				"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"+
+				"ffff",
+			"0051953eb9618e1c9a1f929a21a0b68540eea2da725b99b315f3b8b489918ef1"+
+				"09e156193951ec7e937b1652c0bd3bb1bf073573df883d2c34f1ef451fd46b50"+
+				"3f00",
+			"00c6858e06b70404e9cd9e3ecb662395b4429c648139053fb521f828af606b4d"+
+				"3dbaa14b5e77efe75928fe1dc127a2ffa8de3348b3c1856a429bf97e7e31c2e5"+
+				"bd66",
+			"011839296a789a3bc0045c8a5fb42c7d1bd998f54449579b446817afbd17273e"+
+				"662c97ee72995ef42640c550b9013fad0761353c7086a272c24088be94769fd1"+
+				"6650")
+	})
+	return p521
+}
+
+// Name returns the name of the curve, such as "P-384".
+func (c *Curve) Name() string { return c.name }
+
+// ByteLen returns the length of an encoded field element or scalar.
+func (c *Curve) ByteLen() int { return c.f.byteLen }
+
+// A Point is a point on a Curve, in projective coordinates. The zero value
+// is not valid and may be used only as a receiver.
+type Point struct {
+	c *Curve
+	// The point at infinity is (0:1:0). Any other point (x:y:z) has
+	// affine coordinates (x/z, y/z).
+	x, y, z fieldElement
+}
+
+// NewPoint returns a new Point representing the point at infinity.
+func (c *Curve) NewPoint() *Point {
+	p := &Point{c: c}
+	p.x.f, p.y.f, p.z.f = c.f, c.f, c.f
+	p.y.One()
+	return p
+}
+
+// NewGenerator returns a new Point set to the canonical generator.
+func (c *Curve) NewGenerator() *Point {
+	p := c.NewPoint()
+	p.x.Set(c.gx)
+	p.y.Set(c.gy)
+	p.z.One()
+	return p
+}
+
+// Set sets p = q and returns p.
+func (p *Point) Set(q *Point) *Point {
+	*p = *q
+	return p
+}
+
+// SetBytes sets p to the point encoded in b, and returns p.
+// b must be the uncompressed form, 0x04 followed by the coordinates, or
+// the single byte 0x00 encoding the point at infinity. If b does not
+// encode a point on the curve, SetBytes returns nil and an error, and p
+// is unchanged.
+func (p *Point) SetBytes(c *Curve, b []byte) (*Point, error) {
+	n := c.f.byteLen
+	switch {
+	case len(b) == 1 && b[0] == 0:
+		return p.Set(c.NewPoint()), nil
+	case len(b) == 1+2*n && b[0] == 4:
+		x, err := c.f.newElement().SetBytes(b[1 : 1+n])
+		if err != nil {
+			return nil, err
+		}
+		y, err := c.f.newElement().SetBytes(b[1+n:])
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkOnCurve(x, y); err != nil {
+			return nil, err
+		}
+		q := c.NewPoint()
+		q.x.Set(x)
+		q.y.Set(y)
+		q.z.One()
+		return p.Set(q), nil
+	}
+	return nil, errors.New("invalid " + c.name + " point encoding")
+}
+
+// checkOnCurve returns an error unless y² = x³ - 3x + b.
+func (c *Curve) checkOnCurve(x, y *fieldElement) error {
+	rhs := c.f.newElement().Square(x)
+	rhs.Mul(rhs, x)
+	threeX := c.f.newElement().Add(x, x)
+	threeX.Add(threeX, x)
+	rhs.Sub(rhs, threeX)
+	rhs.Add(rhs, c.b)
+
+	y2 := c.f.newElement().Square(y)
+	if rhs.Equal(y2) != 1 {
+		return errors.New(c.name + " point not on curve")
+	}
+	return nil
+}
+
+// affine returns the affine coordinates of p, and whether p is the point
+// at infinity.
+func (p *Point) affine() (x, y *fieldElement, infinity bool) {
+	zinv := p.c.f.newElement().Invert(&p.z)
+	x = p.c.f.newElement().Mul(&p.x, zinv)
+	y = p.c.f.newElement().Mul(&p.y, zinv)
+	return x, y, p.z.IsZero() == 1
+}
+
+// Bytes returns the uncompressed encoding of p, 0x04 followed by the
+// coordinates, or the single byte 0x00 for the point at infinity.
+func (p *Point) Bytes() []byte {
+	x, y, inf := p.affine()
+	if inf {
+		return []byte{0}
+	}
+	out := make([]byte, 1, 1+2*p.c.f.byteLen)
+	out[0] = 4
+	out = append(out, x.Bytes()...)
+	return append(out, y.Bytes()...)
+}
+
+// BytesX returns the encoding of the x-coordinate of p, or an error if p
+// is the point at infinity.
+func (p *Point) BytesX() ([]byte, error) {
+	x, _, inf := p.affine()
+	if inf {
+		return nil, errors.New(p.c.name + " point is the point at infinity")
+	}
+	return x.Bytes(), nil
+}
+
+// Add sets q = p1 + p2, and returns q. The points may overlap.
+func (q *Point) Add(p1, p2 *Point) *Point {
+	// Complete addition formula for a = -3 from "Complete addition
+	// formulas for prime order elliptic curves"
+	// (https://eprint.iacr.org/2015/1060), §A.2.
+	f, b := p1.c.f, p1.c.b
+
+	t0 := f.newElement().Mul(&p1.x, &p2.x) // t0 := X1 * X2
+	t1 := f.newElement().Mul(&p1.y, &p2.y) // t1 := Y1 * Y2
+	t2 := f.newElement().Mul(&p1.z, &p2.z) // t2 := Z1 * Z2
+	t3 := f.newElement().Add(&p1.x, &p1.y) // t3 := X1 + Y1
+	t4 := f.newElement().Add(&p2.x, &p2.y) // t4 := X2 + Y2
+	t3.Mul(t3, t4)                         // t3 := t3 * t4
+	t4.Add(t0, t1)                         // t4 := t0 + t1
+	t3.Sub(t3, t4)                         // t3 := t3 - t4
+	t4.Add(&p1.y, &p1.z)                   // t4 := Y1 + Z1
+	x3 := f.newElement().Add(&p2.y, &p2.z) // X3 := Y2 + Z2
+	t4.Mul(t4, x3)                         // t4 := t4 * X3
+	x3.Add(t1, t2)                         // X3 := t1 + t2
+	t4.Sub(t4, x3)                         // t4 := t4 - X3
+	x3.Add(&p1.x, &p1.z)                   // X3 := X1 + Z1
+	y3 := f.newElement().Add(&p2.x, &p2.z) // Y3 := X2 + Z2
+	x3.Mul(x3, y3)                         // X3 := X3 * Y3
+	y3.Add(t0, t2)                         // Y3 := t0 + t2
+	y3.Sub(x3, y3)                         // Y3 := X3 - Y3
+	z3 := f.newElement().Mul(b, t2)        // Z3 := b * t2
+	x3.Sub(y3, z3)                         // X3 := Y3 - Z3
+	z3.Add(x3, x3)                         // Z3 := X3 + X3
+	x3.Add(x3, z3)                         // X3 := X3 + Z3
+	z3.Sub(t1, x3)                         // Z3 := t1 - X3
+	x3.Add(t1, x3)                         // X3 := t1 + X3
+	y3.Mul(b, y3)                          // Y3 := b * Y3
+	t1.Add(t2, t2)                         // t1 := t2 + t2
+	t2.Add(t1, t2)                         // t2 := t1 + t2
+	y3.Sub(y3, t2)                         // Y3 := Y3 - t2
+	y3.Sub(y3, t0)                         // Y3 := Y3 - t0
+	t1.Add(y3, y3)                         // t1 := Y3 + Y3
+	y3.Add(t1, y3)                         // Y3 := t1 + Y3
+	t1.Add(t0, t0)                         // t1 := t0 + t0
+	t0.Add(t1, t0)                         // t0 := t1 + t0
+	t0.Sub(t0, t2)                         // t0 := t0 - t2
+	t1.Mul(t4, y3)                         // t1 := t4 * Y3
+	t2.Mul(t0, y3)                         // t2 := t0 * Y3
+	y3.Mul(x3, z3)                         // Y3 := X3 * Z3
+	y3.Add(y3, t2)                         // Y3 := Y3 + t2
+	x3.Mul(t3, x3)                         // X3 := t3 * X3
+	x3.Sub(x3, t1)                         // X3 := X3 - t1
+	z3.Mul(t4, z3)                         // Z3 := t4 * Z3
+	t1.Mul(t3, t0)                         // t1 := t3 * t0
+	z3.Add(z3, t1)                         // Z3 := Z3 + t1
+
+	q.c = p1.c
+	q.x.Set(x3)
+	q.y.Set(y3)
+	q.z.Set(z3)
+	return q
+}
+
+// Double sets q = p + p, and returns q. The points may overlap.
+func (q *Point) Double(p *Point) *Point {
+	// Complete doubling formula for a = -3 from "Complete addition
+	// formulas for prime order elliptic curves"
+	// (https://eprint.iacr.org/2015/1060), §A.2.
+	f, b := p.c.f, p.c.b
+
+	t0 := f.newElement().Square(&p.x)    // t0 := X ^ 2
+	t1 := f.newElement().Square(&p.y)    // t1 := Y ^ 2
+	t2 := f.newElement().Square(&p.z)    // t2 := Z ^ 2
+	t3 := f.newElement().Mul(&p.x, &p.y) // t3 := X * Y
+	t3.Add(t3, t3)                       // t3 := t3 + t3
+	z3 := f.newElement().Mul(&p.x, &p.z) // Z3 := X * Z
+	z3.Add(z3, z3)                       // Z3 := Z3 + Z3
+	y3 := f.newElement().Mul(b, t2)      // Y3 := b * t2
+	y3.Sub(y3, z3)                       // Y3 := Y3 - Z3
+	x3 := f.newElement().Add(y3, y3)     // X3 := Y3 + Y3
+	y3.Add(x3, y3)                       // Y3 := X3 + Y3
+	x3.Sub(t1, y3)                       // X3 := t1 - Y3
+	y3.Add(t1, y3)                       // Y3 := t1 + Y3
+	y3.Mul(x3, y3)                       // Y3 := X3 * Y3
+	x3.Mul(x3, t3)                       // X3 := X3 * t3
+	t3.Add(t2, t2)                       // t3 := t2 + t2
+	t2.Add(t2, t3)                       // t2 := t2 + t3
+	z3.Mul(b, z3)                        // Z3 := b * Z3
+	z3.Sub(z3, t2)                       // Z3 := Z3 - t2
+	z3.Sub(z3, t0)                       // Z3 := Z3 - t0
+	t3.Add(z3, z3)                       // t3 := Z3 + Z3
+	z3.Add(z3, t3)                       // Z3 := Z3 + t3
+	t3.Add(t0, t0)                       // t3 := t0 + t0
+	t0.Add(t3, t0)                       // t0 := t3 + t0
+	t0.Sub(t0, t2)                       // t0 := t0 - t2
+	t0.Mul(t0, z3)                       // t0 := t0 * Z3
+	y3.Add(y3, t0)                       // Y3 := Y3 + t0
+	t0.Mul(&p.y, &p.z)                   // t0 := Y * Z
+	t0.Add(t0, t0)                       // t0 := t0 + t0
+	z3.Mul(t0, z3)                       // Z3 := t0 * Z3
+	x3.Sub(x3, z3)                       // X3 := X3 - Z3
+	z3.Mul(t0, t1)                       // Z3 := t0 * t1
+	z3.Add(z3, z3)                       // Z3 := Z3 + Z3
+	z3.Add(z3, z3)                       // Z3 := Z3 + Z3
+
+	q.c = p.c
+	q.x.Set(x3)
+	q.y.Set(y3)
+	q.z.Set(z3)
+	return q
+}
+
+// Select sets q to p1 if cond == 1, and to p2 if cond == 0.
+func (q *Point) Select(p1, p2 *Point, cond int) *Point {
+	q.c = p1.c
+	q.x.Select(&p1.x, &p2.x, cond)
+	q.y.Select(&p1.y, &p2.y, cond)
+	q.z.Select(&p1.z, &p2.z, cond)
+	return q
+}
+
+// ScalarMult sets p = scalar * q, and returns p. The scalar is a
+// big-endian value of any length; it need not be reduced modulo the order.
+func (p *Point) ScalarMult(q *Point, scalar []byte) *Point {
+	c := q.c
+	// table[i] = i * q, for i in [0, 15].
+	var table [16]Point
+	table[0].Set(c.NewPoint())
+	table[1].Set(q)
+	for i := 2; i < 16; i += 2 {
+		table[i].Double(&table[i/2])
+		table[i+1].Add(&table[i], q)
+	}
+
+	acc := c.NewPoint()
+	t := c.NewPoint()
+	for _, b := range scalar {
+		for _, w := range [2]byte{b >> 4, b & 0xf} {
+			// Doubling the point at infinity in the first iterations is
+			// harmless with complete formulas.
+			acc.Double(acc)
+			acc.Double(acc)
+			acc.Double(acc)
+			acc.Double(acc)
+			for j := 0; j < 16; j++ {
+				t.Select(&table[j], t, subtle.ConstantTimeByteEq(w, uint8(j)))
+			}
+			acc.Add(acc, t)
+		}
+	}
+	return p.Set(acc)
+}
+
+// ScalarBaseMult sets p = scalar * G, where G is the generator, and
+// returns p.
+func (p *Point) ScalarBaseMult(c *Curve, scalar []byte) *Point {
+	return p.ScalarMult(c.NewGenerator(), scalar)
+}
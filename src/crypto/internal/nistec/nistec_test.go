@@ -0,0 +1,134 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nistec_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/internal/nistec"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+var curves = []struct {
+	c   *nistec.Curve
+	ref elliptic.Curve
+}{
+	{nistec.P384(), elliptic.P384()},
+	{nistec.P521(), elliptic.P521()},
+}
+
+func TestCurveParams(t *testing.T) {
+	for _, tt := range curves {
+		params := tt.ref.Params()
+		if tt.c.Name() != params.Name {
+			t.Errorf("Name() = %q, want %q", tt.c.Name(), params.Name)
+		}
+		want := elliptic.Marshal(tt.ref, params.Gx, params.Gy)
+		if got := tt.c.NewGenerator().Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("%s: generator = %x, want %x", params.Name, got, want)
+		}
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range curves {
+		params := tt.ref.Params()
+		n := tt.c.ByteLen()
+		nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+		scalars := [][]byte{
+			make([]byte, n),
+			big.NewInt(1).FillBytes(make([]byte, n)),
+			big.NewInt(2).FillBytes(make([]byte, n)),
+			nMinus1.FillBytes(make([]byte, n)),
+			params.N.FillBytes(make([]byte, n)),
+		}
+		for i := 0; i < 10; i++ {
+			s := make([]byte, n)
+			r.Read(s)
+			scalars = append(scalars, s)
+		}
+		for _, s := range scalars {
+			x, y := tt.ref.ScalarBaseMult(s)
+			want := []byte{0}
+			if x.Sign() != 0 || y.Sign() != 0 {
+				want = elliptic.Marshal(tt.ref, x, y)
+			}
+			p := new(nistec.Point).ScalarBaseMult(tt.c, s)
+			if got := p.Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("%s: ScalarBaseMult(%x) = %x, want %x", params.Name, s, got, want)
+			}
+
+			// Multiply a non-generator point, decoded from bytes.
+			q, err := new(nistec.Point).SetBytes(tt.c, p.Bytes())
+			if err != nil {
+				t.Fatalf("%s: SetBytes: %v", params.Name, err)
+			}
+			x, y = tt.ref.ScalarMult(x, y, s)
+			want = []byte{0}
+			if x.Sign() != 0 || y.Sign() != 0 {
+				want = elliptic.Marshal(tt.ref, x, y)
+			}
+			if got := q.ScalarMult(q, s).Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("%s: ScalarMult(%x) = %x, want %x", params.Name, s, got, want)
+			}
+		}
+	}
+}
+
+func TestAddDouble(t *testing.T) {
+	for _, tt := range curves {
+		g := tt.c.NewGenerator()
+		inf := tt.c.NewPoint()
+		if got := new(nistec.Point).Add(g, inf).Bytes(); !bytes.Equal(got, g.Bytes()) {
+			t.Errorf("%s: G + ∞ != G", tt.c.Name())
+		}
+		double := new(nistec.Point).Double(g).Bytes()
+		if got := new(nistec.Point).Add(g, g).Bytes(); !bytes.Equal(got, double) {
+			t.Errorf("%s: G + G != 2G", tt.c.Name())
+		}
+		if got := new(nistec.Point).Double(inf).Bytes(); !bytes.Equal(got, []byte{0}) {
+			t.Errorf("%s: 2∞ = %x, want ∞", tt.c.Name(), got)
+		}
+		if _, err := g.BytesX(); err != nil {
+			t.Errorf("%s: BytesX: %v", tt.c.Name(), err)
+		}
+		if _, err := inf.BytesX(); err == nil {
+			t.Errorf("%s: BytesX of ∞ succeeded", tt.c.Name())
+		}
+	}
+}
+
+func TestSetBytesInvalid(t *testing.T) {
+	for _, tt := range curves {
+		g := tt.c.NewGenerator().Bytes()
+		n := tt.c.ByteLen()
+		notOnCurve := append([]byte(nil), g...)
+		notOnCurve[len(notOnCurve)-1] ^= 1
+		tooLarge := append([]byte{4}, bytes.Repeat([]byte{0xff}, 2*n)...)
+		for _, b := range [][]byte{nil, {4}, g[:len(g)-1], notOnCurve, tooLarge, append([]byte{2}, g[1:1+n]...)} {
+			if _, err := new(nistec.Point).SetBytes(tt.c, b); err == nil {
+				t.Errorf("%s: SetBytes(%x) succeeded", tt.c.Name(), b)
+			}
+		}
+	}
+}
+
+func BenchmarkScalarMult(b *testing.B) {
+	for _, tt := range curves {
+		b.Run(tt.c.Name(), func(b *testing.B) {
+			s := make([]byte, tt.c.ByteLen())
+			rand.New(rand.NewSource(1)).Read(s)
+			p := tt.c.NewGenerator()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				p.ScalarMult(p, s)
+			}
+		})
+	}
+}
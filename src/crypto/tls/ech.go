@@ -0,0 +1,685 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"crypto/hpke"
+	"errors"
+	"hash"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// This file implements Encrypted Client Hello (ECH), as specified in
+// draft-ietf-tls-esni-18, in shared mode: the client-facing server and the
+// backend server are the same.
+
+// An EncryptedClientHelloKey is an ECH key used by a server, along with the
+// ECHConfig that clients use to encrypt their ClientHelloInner to it.
+type EncryptedClientHelloKey struct {
+	// Config is the marshaled ECHConfig corresponding to PrivateKey. Its
+	// KEM must be DHKEM(X25519, HKDF-SHA256) or DHKEM(P-256, HKDF-SHA256).
+	Config []byte
+	// PrivateKey is the marshaled HPKE private key for Config.
+	PrivateKey []byte
+	// SendAsRetry indicates whether Config is sent to clients that used an
+	// unknown or unsupported ECHConfig, as a retry configuration.
+	SendAsRetry bool
+}
+
+// ECHRejectionError is the error returned by a client handshake when the
+// server rejects Encrypted Client Hello. The connection is not usable, and
+// the client may retry the connection with RetryConfigList, if not empty, as
+// the EncryptedClientHelloConfigList.
+type ECHRejectionError struct {
+	// RetryConfigList is the serialized ECHConfigList sent by the server, or
+	// nil if the server didn't send any.
+	RetryConfigList []byte
+}
+
+func (e *ECHRejectionError) Error() string {
+	return "tls: server rejected ECH"
+}
+
+const (
+	// ECHClientHelloType values. See draft-ietf-tls-esni-18, Section 5.
+	echTypeOuter uint8 = 0
+	echTypeInner uint8 = 1
+
+	// echConfirmationLength is the length of the ECH acceptance confirmation
+	// signals. See draft-ietf-tls-esni-18, Section 7.2.
+	echConfirmationLength = 8
+
+	echAcceptConfirmationLabel    = "ech accept confirmation"
+	echHRRAcceptConfirmationLabel = "hrr ech accept confirmation"
+
+	// echAEADTagSize is the tag size of all the supported HPKE AEADs.
+	echAEADTagSize = 16
+)
+
+// echCipher is an HpkeSymmetricCipherSuite.
+type echCipher struct {
+	kdfID  uint16
+	aeadID uint16
+}
+
+// suite returns the HPKE suite of cs, without a KEM, which comes from the
+// ECHConfig.
+func (cs echCipher) suite() hpke.Suite {
+	return hpke.Suite{KDF: hpke.KDF(cs.kdfID), AEAD: hpke.AEAD(cs.aeadID)}
+}
+
+// echConfig is a parsed ECHConfig with version 0xfe0d.
+type echConfig struct {
+	raw []byte // the whole ECHConfig, used as part of the HPKE info
+
+	configID     uint8
+	kemID        uint16
+	publicKey    []byte
+	cipherSuites []echCipher
+
+	maxNameLength uint8
+	publicName    string
+
+	// hasMandatoryExtension is set if the ECHConfig has a mandatory
+	// extension. None are supported, so such configs can't be used.
+	hasMandatoryExtension bool
+}
+
+var errMalformedECHConfig = errors.New("tls: malformed ECHConfig")
+
+// parseECHConfigContents parses the ECHConfigContents in s. raw is the whole
+// ECHConfig s was read from.
+func parseECHConfigContents(raw []byte, s cryptobyte.String) (*echConfig, error) {
+	ec := &echConfig{raw: raw}
+	var publicKey, cipherSuites, publicName, extensions cryptobyte.String
+	if !s.ReadUint8(&ec.configID) || !s.ReadUint16(&ec.kemID) ||
+		!s.ReadUint16LengthPrefixed(&publicKey) || publicKey.Empty() ||
+		!s.ReadUint16LengthPrefixed(&cipherSuites) || cipherSuites.Empty() ||
+		!s.ReadUint8(&ec.maxNameLength) ||
+		!s.ReadUint8LengthPrefixed(&publicName) || publicName.Empty() ||
+		!s.ReadUint16LengthPrefixed(&extensions) || !s.Empty() {
+		return nil, errMalformedECHConfig
+	}
+	ec.publicKey = publicKey
+	ec.publicName = string(publicName)
+	for !cipherSuites.Empty() {
+		var cs echCipher
+		if !cipherSuites.ReadUint16(&cs.kdfID) || !cipherSuites.ReadUint16(&cs.aeadID) {
+			return nil, errMalformedECHConfig
+		}
+		ec.cipherSuites = append(ec.cipherSuites, cs)
+	}
+	for !extensions.Empty() {
+		var extType uint16
+		var extData cryptobyte.String
+		if !extensions.ReadUint16(&extType) || !extensions.ReadUint16LengthPrefixed(&extData) {
+			return nil, errMalformedECHConfig
+		}
+		if extType&0x8000 != 0 {
+			ec.hasMandatoryExtension = true
+		}
+	}
+	return ec, nil
+}
+
+// readECHConfig reads an ECHConfig from s. It returns a nil config if the
+// ECHConfig has an unknown version.
+func readECHConfig(s *cryptobyte.String) (*echConfig, error) {
+	raw := []byte(*s)
+	var version uint16
+	var contents cryptobyte.String
+	if !s.ReadUint16(&version) || !s.ReadUint16LengthPrefixed(&contents) {
+		return nil, errMalformedECHConfig
+	}
+	if version != extensionEncryptedClientHello {
+		return nil, nil
+	}
+	return parseECHConfigContents(raw[:len(raw)-len(*s)], contents)
+}
+
+// parseECHConfig parses a single marshaled ECHConfig, as used in
+// EncryptedClientHelloKey.
+func parseECHConfig(data []byte) (*echConfig, error) {
+	s := cryptobyte.String(data)
+	ec, err := readECHConfig(&s)
+	if err != nil {
+		return nil, err
+	}
+	if ec == nil {
+		return nil, errors.New("tls: unsupported ECHConfig version")
+	}
+	if !s.Empty() {
+		return nil, errMalformedECHConfig
+	}
+	return ec, nil
+}
+
+// parseECHConfigList parses an ECHConfigList, skipping the ECHConfigs with
+// unknown versions.
+func parseECHConfigList(data []byte) ([]*echConfig, error) {
+	s := cryptobyte.String(data)
+	var list cryptobyte.String
+	if !s.ReadUint16LengthPrefixed(&list) || list.Empty() || !s.Empty() {
+		return nil, errors.New("tls: malformed ECHConfigList")
+	}
+	var configs []*echConfig
+	for !list.Empty() {
+		ec, err := readECHConfig(&list)
+		if err != nil {
+			return nil, err
+		}
+		if ec != nil {
+			configs = append(configs, ec)
+		}
+	}
+	return configs, nil
+}
+
+// pickECHConfig returns the first config that can be used by the client, or
+// nil if there is none.
+func pickECHConfig(configs []*echConfig) *echConfig {
+	for _, ec := range configs {
+		if !hpke.KEM(ec.kemID).Available() || ec.hasMandatoryExtension {
+			continue
+		}
+		if _, ok := pickECHCipherSuite(ec.cipherSuites); !ok {
+			continue
+		}
+		// The public name must be a valid DNS name, and not an IP address.
+		// See draft-ietf-tls-esni-18, Section 4.
+		if strings.HasSuffix(ec.publicName, ".") || net.ParseIP(ec.publicName) != nil {
+			continue
+		}
+		return ec
+	}
+	return nil
+}
+
+// pickECHCipherSuite returns the first supported cipher suite in suites.
+func pickECHCipherSuite(suites []echCipher) (echCipher, bool) {
+	for _, cs := range suites {
+		// The export-only AEAD can't be used to encrypt the ClientHelloInner.
+		aead := hpke.AEAD(cs.aeadID)
+		if hpke.KDF(cs.kdfID).Available() && aead.Available() && aead != hpke.ExportOnly {
+			return cs, true
+		}
+	}
+	return echCipher{}, false
+}
+
+// echInfo returns the HPKE info parameter for ec.
+func (ec *echConfig) echInfo() []byte {
+	info := make([]byte, 0, len("tls ech\x00")+len(ec.raw))
+	info = append(info, "tls ech\x00"...)
+	return append(info, ec.raw...)
+}
+
+func (ec *echConfig) supportsCipherSuite(cs echCipher) bool {
+	for _, s := range ec.cipherSuites {
+		if s == cs {
+			return true
+		}
+	}
+	return false
+}
+
+// echExtension is a parsed ECHClientHello of type outer.
+type echExtension struct {
+	cipherSuite echCipher
+	configID    uint8
+	enc         []byte
+	payload     []byte
+}
+
+func marshalOuterECHExtension(ext *echExtension) []byte {
+	var b cryptobyte.Builder
+	b.AddUint8(echTypeOuter)
+	b.AddUint16(ext.cipherSuite.kdfID)
+	b.AddUint16(ext.cipherSuite.aeadID)
+	b.AddUint8(ext.configID)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(ext.enc)
+	})
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(ext.payload)
+	})
+	return b.BytesOrPanic()
+}
+
+// parseOuterECHExtension parses the data of an encrypted_client_hello
+// extension sent in a ClientHelloOuter.
+func parseOuterECHExtension(data []byte) (*echExtension, error) {
+	s := cryptobyte.String(data)
+	var echType uint8
+	if !s.ReadUint8(&echType) {
+		return nil, errors.New("tls: malformed encrypted_client_hello extension")
+	}
+	if echType != echTypeOuter {
+		return nil, errors.New("tls: unexpected inner encrypted_client_hello extension")
+	}
+	ext := new(echExtension)
+	if !s.ReadUint16(&ext.cipherSuite.kdfID) || !s.ReadUint16(&ext.cipherSuite.aeadID) ||
+		!s.ReadUint8(&ext.configID) ||
+		!readUint16LengthPrefixed(&s, &ext.enc) ||
+		!readUint16LengthPrefixed(&s, &ext.payload) || len(ext.payload) == 0 ||
+		!s.Empty() {
+		return nil, errors.New("tls: malformed encrypted_client_hello extension")
+	}
+	return ext, nil
+}
+
+// echClientContext is the client state of an ECH offer.
+type echClientContext struct {
+	config          *echConfig
+	cipherSuite     echCipher
+	encapsulatedKey []byte
+	hpkeContext     *hpke.Sender
+
+	innerHello      *clientHelloMsg
+	outerHello      *clientHelloMsg
+	innerTranscript hash.Hash
+
+	retryConfigs []byte
+}
+
+// newECHClientContext picks an ECHConfig from the configured list and sets
+// up the HPKE context to encrypt the ClientHelloInner with.
+func (c *Conn) newECHClientContext() (*echClientContext, error) {
+	configs, err := parseECHConfigList(c.config.EncryptedClientHelloConfigList)
+	if err != nil {
+		return nil, err
+	}
+	ec := pickECHConfig(configs)
+	if ec == nil {
+		return nil, errors.New("tls: EncryptedClientHelloConfigList contains no supported configs")
+	}
+	cs, _ := pickECHCipherSuite(ec.cipherSuites)
+	suite := cs.suite()
+	suite.KEM = hpke.KEM(ec.kemID)
+	pk, err := suite.KEM.Curve().NewPublicKey(ec.publicKey)
+	if err != nil {
+		return nil, errors.New("tls: invalid ECHConfig public key: " + err.Error())
+	}
+	enc, hpkeContext, err := hpke.SetupBaseSender(suite, pk, ec.echInfo())
+	if err != nil {
+		return nil, err
+	}
+	return &echClientContext{
+		config:          ec,
+		cipherSuite:     cs,
+		encapsulatedKey: enc,
+		hpkeContext:     hpkeContext,
+	}, nil
+}
+
+// encodeInnerClientHello returns the padded EncodedClientHelloInner for
+// inner, which doesn't use ech_outer_extensions compression. See
+// draft-ietf-tls-esni-18, Sections 5.1 and 6.1.3.
+func encodeInnerClientHello(inner *clientHelloMsg, maxNameLength int) []byte {
+	h := *inner
+	h.raw = nil
+	h.sessionId = nil
+	encoded := h.marshal()[4:] // strip the handshake message header
+
+	var paddingLen int
+	if inner.serverName != "" {
+		if n := maxNameLength - len(inner.serverName); n > 0 {
+			paddingLen = n
+		}
+	} else {
+		paddingLen = 9 + maxNameLength
+	}
+	paddingLen += 31 - ((len(encoded) + paddingLen - 1) % 32)
+
+	return append(encoded, make([]byte, paddingLen)...)
+}
+
+// sealOuterHello encrypts ech.innerHello into the encrypted_client_hello
+// extension of ech.outerHello. The encapsulated key is only sent in the first
+// ClientHelloOuter. See draft-ietf-tls-esni-18, Section 6.1.
+func (ech *echClientContext) sealOuterHello(first bool) error {
+	encoded := encodeInnerClientHello(ech.innerHello, int(ech.config.maxNameLength))
+
+	ext := &echExtension{
+		cipherSuite: ech.cipherSuite,
+		configID:    ech.config.configID,
+		payload:     make([]byte, len(encoded)+echAEADTagSize),
+	}
+	if first {
+		ext.enc = ech.encapsulatedKey
+	}
+	ech.outerHello.encryptedClientHello = marshalOuterECHExtension(ext)
+	ech.outerHello.raw = nil
+	aad := ech.outerHello.marshal()[4:] // ClientHelloOuterAAD
+
+	payload, err := ech.hpkeContext.Seal(aad, encoded)
+	if err != nil {
+		return err
+	}
+	ext.payload = payload
+	ech.outerHello.encryptedClientHello = marshalOuterECHExtension(ext)
+	ech.outerHello.raw = nil
+	return nil
+}
+
+// echAcceptConfirmation computes the ECH acceptance signal for the
+// ClientHelloInner random and the given confirmation transcript.
+// See draft-ietf-tls-esni-18, Sections 7.2 and 7.2.1.
+func echAcceptConfirmation(suite *cipherSuiteTLS13, innerRandom []byte, label string, transcript hash.Hash) []byte {
+	return suite.expandLabel(suite.extract(innerRandom, nil), label,
+		transcript.Sum(nil), echConfirmationLength)
+}
+
+// helloExtensions returns the extensions block of a marshaled ClientHello or
+// ServerHello, and its offset in msg.
+func helloExtensions(msg []byte) (cryptobyte.String, int, bool) {
+	s := cryptobyte.String(msg)
+	var msgType uint8
+	var body, skipped cryptobyte.String
+	if !s.ReadUint8(&msgType) || !s.ReadUint24LengthPrefixed(&body) ||
+		!body.Skip(2+32) || !body.ReadUint8LengthPrefixed(&skipped) {
+		return nil, 0, false
+	}
+	switch msgType {
+	case typeClientHello:
+		if !body.ReadUint16LengthPrefixed(&skipped) || !body.ReadUint8LengthPrefixed(&skipped) {
+			return nil, 0, false
+		}
+	case typeServerHello:
+		if !body.Skip(2 + 1) {
+			return nil, 0, false
+		}
+	default:
+		return nil, 0, false
+	}
+	if body.Empty() {
+		// The extensions block is optional.
+		return nil, len(msg), true
+	}
+	var extensions cryptobyte.String
+	if !body.ReadUint16LengthPrefixed(&extensions) || !body.Empty() {
+		return nil, 0, false
+	}
+	return extensions, len(msg) - len(extensions), true
+}
+
+// zeroECHExtensionTail returns a copy of the marshaled ClientHello or
+// ServerHello msg with the last n bytes of the data of its
+// encrypted_client_hello extension set to zero.
+func zeroECHExtensionTail(msg []byte, n int) ([]byte, error) {
+	extensions, offset, ok := helloExtensions(msg)
+	if !ok {
+		return nil, errors.New("tls: malformed hello message")
+	}
+	for !extensions.Empty() {
+		var extType uint16
+		var extData cryptobyte.String
+		if !extensions.ReadUint16(&extType) || !extensions.ReadUint16LengthPrefixed(&extData) {
+			return nil, errors.New("tls: malformed hello message")
+		}
+		offset += 4 + len(extData)
+		if extType != extensionEncryptedClientHello {
+			continue
+		}
+		if len(extData) < n {
+			return nil, errors.New("tls: malformed encrypted_client_hello extension")
+		}
+		out := make([]byte, len(msg))
+		copy(out, msg)
+		for i := offset - n; i < offset; i++ {
+			out[i] = 0
+		}
+		return out, nil
+	}
+	return nil, errors.New("tls: missing encrypted_client_hello extension")
+}
+
+// echServerContext is the server state of an accepted ECH offer.
+type echServerContext struct {
+	configID    uint8
+	cipherSuite echCipher
+	hpkeContext *hpke.Recipient
+}
+
+// processECHClientHello attempts to decrypt the ClientHelloInner carried by
+// the ClientHelloOuter outer. If successful, it returns the ClientHelloInner
+// and the ECH context to use for the rest of the handshake. Otherwise, ECH
+// is rejected, and it returns outer and a nil context.
+func (c *Conn) processECHClientHello(outer *clientHelloMsg) (*clientHelloMsg, *echServerContext, error) {
+	ext, err := parseOuterECHExtension(outer.encryptedClientHello)
+	if err != nil {
+		c.sendAlert(alertIllegalParameter)
+		return nil, nil, err
+	}
+
+	aad, err := zeroECHExtensionTail(outer.marshal(), len(ext.payload))
+	if err != nil {
+		c.sendAlert(alertDecodeError)
+		return nil, nil, err
+	}
+	aad = aad[4:] // ClientHelloOuterAAD
+
+	for _, key := range c.config.EncryptedClientHelloKeys {
+		ec, err := parseECHConfig(key.Config)
+		if err != nil {
+			c.sendAlert(alertInternalError)
+			return nil, nil, errors.New("tls: invalid EncryptedClientHelloKeys Config: " + err.Error())
+		}
+		if ec.configID != ext.configID || !ec.supportsCipherSuite(ext.cipherSuite) {
+			continue
+		}
+		suite := ext.cipherSuite.suite()
+		suite.KEM = hpke.KEM(ec.kemID)
+		if !suite.KEM.Available() {
+			c.sendAlert(alertInternalError)
+			return nil, nil, errors.New("tls: unsupported EncryptedClientHelloKeys KEM")
+		}
+		sk, err := suite.KEM.Curve().NewPrivateKey(key.PrivateKey)
+		if err != nil {
+			c.sendAlert(alertInternalError)
+			return nil, nil, errors.New("tls: invalid EncryptedClientHelloKeys PrivateKey: " + err.Error())
+		}
+		hpkeContext, err := hpke.SetupBaseRecipient(suite, ext.enc, sk, ec.echInfo())
+		if err != nil {
+			continue
+		}
+		encoded, err := hpkeContext.Open(aad, ext.payload)
+		if err != nil {
+			// Trial decryption failed, this is the wrong key, or a GREASE or
+			// stale ECH offer. See draft-ietf-tls-esni-18, Section 7.1.
+			continue
+		}
+		inner, err := decodeInnerClientHello(outer, encoded)
+		if err != nil {
+			c.sendAlert(alertIllegalParameter)
+			return nil, nil, err
+		}
+		c.echAccepted = true
+		return inner, &echServerContext{
+			configID:    ext.configID,
+			cipherSuite: ext.cipherSuite,
+			hpkeContext: hpkeContext,
+		}, nil
+	}
+
+	return outer, nil, nil
+}
+
+// openSecondClientHello decrypts the ClientHelloInner carried by the
+// ClientHelloOuter sent in response to a HelloRetryRequest.
+// See draft-ietf-tls-esni-18, Section 7.1.1.
+func (c *Conn) openSecondClientHello(ech *echServerContext, outer *clientHelloMsg) (*clientHelloMsg, error) {
+	if len(outer.encryptedClientHello) == 0 {
+		c.sendAlert(alertMissingExtension)
+		return nil, errors.New("tls: client sent no encrypted_client_hello extension in second ClientHello")
+	}
+	ext, err := parseOuterECHExtension(outer.encryptedClientHello)
+	if err != nil {
+		c.sendAlert(alertIllegalParameter)
+		return nil, err
+	}
+	if len(ext.enc) != 0 || ext.configID != ech.configID || ext.cipherSuite != ech.cipherSuite {
+		c.sendAlert(alertIllegalParameter)
+		return nil, errors.New("tls: client changed the encrypted_client_hello extension in second ClientHello")
+	}
+	aad, err := zeroECHExtensionTail(outer.marshal(), len(ext.payload))
+	if err != nil {
+		c.sendAlert(alertDecodeError)
+		return nil, err
+	}
+	encoded, err := ech.hpkeContext.Open(aad[4:], ext.payload)
+	if err != nil {
+		c.sendAlert(alertDecryptError)
+		return nil, errors.New("tls: failed to decrypt second ClientHelloInner")
+	}
+	inner, err := decodeInnerClientHello(outer, encoded)
+	if err != nil {
+		c.sendAlert(alertIllegalParameter)
+		return nil, err
+	}
+	return inner, nil
+}
+
+// decodeInnerClientHello reconstructs the ClientHelloInner from the decrypted
+// EncodedClientHelloInner, copying the legacy_session_id and any extensions
+// referenced by ech_outer_extensions from outer. See draft-ietf-tls-esni-18,
+// Section 5.1.
+func decodeInnerClientHello(outer *clientHelloMsg, encoded []byte) (*clientHelloMsg, error) {
+	errInvalid := errors.New("tls: invalid EncodedClientHelloInner")
+
+	s := cryptobyte.String(encoded)
+	var vers uint16
+	var random, sessionID, cipherSuites, compressionMethods []byte
+	var extensions cryptobyte.String
+	if !s.ReadUint16(&vers) || !s.ReadBytes(&random, 32) ||
+		!readUint8LengthPrefixed(&s, &sessionID) || len(sessionID) != 0 ||
+		!readUint16LengthPrefixed(&s, &cipherSuites) ||
+		!readUint8LengthPrefixed(&s, &compressionMethods) ||
+		!s.ReadUint16LengthPrefixed(&extensions) {
+		return nil, errInvalid
+	}
+	for _, b := range s {
+		if b != 0 {
+			return nil, errors.New("tls: invalid EncodedClientHelloInner padding")
+		}
+	}
+
+	outerExtensions, _, ok := helloExtensions(outer.marshal())
+	if !ok {
+		return nil, errInvalid
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(typeClientHello)
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddUint16(vers)
+		b.AddBytes(random)
+		b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(outer.sessionId)
+		})
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(cipherSuites)
+		})
+		b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(compressionMethods)
+		})
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			for !extensions.Empty() {
+				var extType uint16
+				var extData cryptobyte.String
+				if !extensions.ReadUint16(&extType) || !extensions.ReadUint16LengthPrefixed(&extData) {
+					b.SetError(errInvalid)
+					return
+				}
+				if extType != extensionECHOuterExtensions {
+					b.AddUint16(extType)
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						b.AddBytes(extData)
+					})
+					continue
+				}
+				var types cryptobyte.String
+				if !extData.ReadUint8LengthPrefixed(&types) || types.Empty() || !extData.Empty() {
+					b.SetError(errInvalid)
+					return
+				}
+				for !types.Empty() {
+					var want uint16
+					if !types.ReadUint16(&want) || want == extensionEncryptedClientHello {
+						b.SetError(errInvalid)
+						return
+					}
+					// The referenced extensions must appear in the same
+					// relative order in the ClientHelloOuter.
+					found := false
+					for !outerExtensions.Empty() {
+						var outerType uint16
+						var outerData cryptobyte.String
+						if !outerExtensions.ReadUint16(&outerType) ||
+							!outerExtensions.ReadUint16LengthPrefixed(&outerData) {
+							b.SetError(errInvalid)
+							return
+						}
+						if outerType == want {
+							b.AddUint16(outerType)
+							b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+								b.AddBytes(outerData)
+							})
+							found = true
+							break
+						}
+					}
+					if !found {
+						b.SetError(errors.New("tls: invalid ech_outer_extensions extension"))
+						return
+					}
+				}
+			}
+		})
+	})
+	innerBytes, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	inner := new(clientHelloMsg)
+	if !inner.unmarshal(innerBytes) {
+		return nil, errInvalid
+	}
+	if len(inner.encryptedClientHello) != 1 || inner.encryptedClientHello[0] != echTypeInner {
+		return nil, errors.New("tls: ClientHelloInner has no inner encrypted_client_hello extension")
+	}
+	if len(inner.supportedVersions) == 0 {
+		return nil, errors.New("tls: ClientHelloInner does not offer TLS 1.3")
+	}
+	for _, v := range inner.supportedVersions {
+		if v < VersionTLS13 {
+			return nil, errors.New("tls: ClientHelloInner offers TLS 1.2 or earlier")
+		}
+	}
+	return inner, nil
+}
+
+// echRetryConfigList returns the ECHConfigList of the keys with SendAsRetry
+// set, or nil if there are none.
+func (c *Config) echRetryConfigList() ([]byte, error) {
+	var b cryptobyte.Builder
+	hasRetry := false
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, key := range c.EncryptedClientHelloKeys {
+			if key.SendAsRetry {
+				b.AddBytes(key.Config)
+				hasRetry = true
+			}
+		}
+	})
+	if !hasRetry {
+		return nil, nil
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,452 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hpke"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+func marshalTestECHConfig(configID uint8, publicKey []byte, publicName string, maxNameLength uint8) []byte {
+	var b cryptobyte.Builder
+	b.AddUint16(extensionEncryptedClientHello)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddUint8(configID)
+		b.AddUint16(uint16(hpke.DHKEM_X25519_HKDF_SHA256))
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(publicKey)
+		})
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint16(uint16(hpke.HKDF_SHA256))
+			b.AddUint16(uint16(hpke.AES_128_GCM))
+			b.AddUint16(uint16(hpke.HKDF_SHA256))
+			b.AddUint16(uint16(hpke.ChaCha20Poly1305))
+		})
+		b.AddUint8(maxNameLength)
+		b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes([]byte(publicName))
+		})
+		b.AddUint16(0) // extensions
+	})
+	return b.BytesOrPanic()
+}
+
+func marshalTestECHConfigList(configs ...[]byte) []byte {
+	var b cryptobyte.Builder
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, c := range configs {
+			b.AddBytes(c)
+		}
+	})
+	return b.BytesOrPanic()
+}
+
+func newTestECHKey(t *testing.T, configID uint8) EncryptedClientHelloKey {
+	k, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return EncryptedClientHelloKey{
+		Config:      marshalTestECHConfig(configID, k.PublicKey().Bytes(), "public.example", 32),
+		PrivateKey:  k.Bytes(),
+		SendAsRetry: true,
+	}
+}
+
+// testECHConfigs returns a client and server Config with a certificate valid
+// for both "secret.example" and the ECH public name "public.example".
+func testECHConfigs(t *testing.T) (client, server *Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ECH test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{"secret.example", "public.example"},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	echKey := newTestECHKey(t, 42)
+	client = &Config{
+		ServerName:                     "secret.example",
+		RootCAs:                        roots,
+		MinVersion:                     VersionTLS13,
+		EncryptedClientHelloConfigList: marshalTestECHConfigList(echKey.Config),
+	}
+	server = &Config{
+		Certificates:             []Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		EncryptedClientHelloKeys: []EncryptedClientHelloKey{echKey},
+	}
+	return client, server
+}
+
+// testECHHandshake runs a handshake between Client and Server connections
+// over a localPipe, and returns their states and errors.
+func testECHHandshake(t *testing.T, clientConfig, serverConfig *Config) (clientState, serverState ConnectionState, clientErr, serverErr error) {
+	c, s := localPipe(t)
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		cli := Client(c, clientConfig)
+		defer cli.Close()
+		clientErr = cli.Handshake()
+		clientState = cli.ConnectionState()
+	}()
+	srv := Server(s, serverConfig)
+	serverErr = srv.Handshake()
+	serverState = srv.ConnectionState()
+	srv.Close()
+	<-done
+	return
+}
+
+func TestECHAccepted(t *testing.T) {
+	for _, hrr := range []bool{false, true} {
+		clientConfig, serverConfig := testECHConfigs(t)
+		if hrr {
+			serverConfig.CurvePreferences = []CurveID{CurveP256}
+		}
+		var sawServerName string
+		serverConfig.GetConfigForClient = func(chi *ClientHelloInfo) (*Config, error) {
+			sawServerName = chi.ServerName
+			return nil, nil
+		}
+
+		cs, ss, cErr, sErr := testECHHandshake(t, clientConfig, serverConfig)
+		if cErr != nil || sErr != nil {
+			t.Fatalf("hrr=%v: handshake failed: client %v, server %v", hrr, cErr, sErr)
+		}
+		if !cs.ECHAccepted || !ss.ECHAccepted {
+			t.Errorf("hrr=%v: ECHAccepted = %v (client), %v (server), want true", hrr, cs.ECHAccepted, ss.ECHAccepted)
+		}
+		if sawServerName != "secret.example" || ss.ServerName != "secret.example" {
+			t.Errorf("hrr=%v: server saw ServerName %q and %q, want the inner name", hrr, sawServerName, ss.ServerName)
+		}
+	}
+}
+
+func TestECHResumption(t *testing.T) {
+	clientConfig, serverConfig := testECHConfigs(t)
+	clientConfig.ClientSessionCache = NewLRUClientSessionCache(1)
+
+	for i := 0; i < 2; i++ {
+		c, s := localPipe(t)
+		done := make(chan error)
+		go func() {
+			cli := Client(c, clientConfig)
+			defer cli.Close()
+			if err := cli.Handshake(); err != nil {
+				done <- err
+				return
+			}
+			// Read the session ticket.
+			cli.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+			cli.Read(make([]byte, 1))
+			done <- nil
+		}()
+		srv := Server(s, serverConfig)
+		if err := srv.Handshake(); err != nil {
+			t.Fatalf("server: %v", err)
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("client: %v", err)
+		}
+		state := srv.ConnectionState()
+		srv.Close()
+		if !state.ECHAccepted {
+			t.Errorf("connection %d: ECH not accepted", i)
+		}
+		if state.DidResume != (i == 1) {
+			t.Errorf("connection %d: DidResume = %v", i, state.DidResume)
+		}
+	}
+}
+
+func TestECHRejected(t *testing.T) {
+	clientConfig, serverConfig := testECHConfigs(t)
+	// The server no longer has the key the client is using.
+	retryKey := newTestECHKey(t, 43)
+	serverConfig.EncryptedClientHelloKeys = []EncryptedClientHelloKey{retryKey}
+
+	for _, hrr := range []bool{false, true} {
+		if hrr {
+			serverConfig.CurvePreferences = []CurveID{CurveP256}
+		}
+		cs, ss, cErr, sErr := testECHHandshake(t, clientConfig, serverConfig)
+		var echErr *ECHRejectionError
+		if !errors.As(cErr, &echErr) {
+			t.Fatalf("hrr=%v: client error = %v, want *ECHRejectionError", hrr, cErr)
+		}
+		want := marshalTestECHConfigList(retryKey.Config)
+		if !bytes.Equal(echErr.RetryConfigList, want) {
+			t.Errorf("hrr=%v: RetryConfigList = %x, want %x", hrr, echErr.RetryConfigList, want)
+		}
+		if sErr == nil || !bytes.Contains([]byte(sErr.Error()), []byte("encrypted client hello required")) {
+			t.Errorf("hrr=%v: server error = %v, want an ech_required alert", hrr, sErr)
+		}
+		if cs.ECHAccepted || ss.ECHAccepted {
+			t.Errorf("hrr=%v: ECHAccepted set on a rejected connection", hrr)
+		}
+
+		// The retry configs can be used for a new connection.
+		retryConfig := clientConfig.Clone()
+		retryConfig.EncryptedClientHelloConfigList = echErr.RetryConfigList
+		cs, _, cErr, sErr = testECHHandshake(t, retryConfig, serverConfig)
+		if cErr != nil || sErr != nil {
+			t.Fatalf("hrr=%v: retry handshake failed: client %v, server %v", hrr, cErr, sErr)
+		}
+		if !cs.ECHAccepted {
+			t.Errorf("hrr=%v: ECH not accepted with the retry configs", hrr)
+		}
+	}
+}
+
+func TestECHRejectedNoServerSupport(t *testing.T) {
+	clientConfig, serverConfig := testECHConfigs(t)
+	serverConfig.EncryptedClientHelloKeys = nil
+
+	_, _, cErr, _ := testECHHandshake(t, clientConfig, serverConfig)
+	var echErr *ECHRejectionError
+	if !errors.As(cErr, &echErr) {
+		t.Fatalf("client error = %v, want *ECHRejectionError", cErr)
+	}
+	if echErr.RetryConfigList != nil {
+		t.Errorf("RetryConfigList = %x, want nil", echErr.RetryConfigList)
+	}
+}
+
+func TestECHRejectionVerify(t *testing.T) {
+	clientConfig, serverConfig := testECHConfigs(t)
+	serverConfig.EncryptedClientHelloKeys = nil
+
+	// Without a custom verifier, the certificate is checked for the public
+	// name, not for ServerName.
+	k, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := k.PublicKey().Bytes()
+	clientConfig.EncryptedClientHelloConfigList = marshalTestECHConfigList(
+		marshalTestECHConfig(1, pub, "other.example", 32))
+	_, _, cErr, _ := testECHHandshake(t, clientConfig, serverConfig)
+	var certErr x509.HostnameError
+	if !errors.As(cErr, &certErr) || certErr.Host != "other.example" {
+		t.Errorf("client error = %v, want a HostnameError for the public name", cErr)
+	}
+
+	verifyErr := errors.New("rejection verify")
+	called := false
+	clientConfig.EncryptedClientHelloRejectionVerify = func(cs ConnectionState) error {
+		called = true
+		if len(cs.PeerCertificates) == 0 {
+			t.Errorf("PeerCertificates is empty")
+		}
+		return verifyErr
+	}
+	_, _, cErr, _ = testECHHandshake(t, clientConfig, serverConfig)
+	if !called {
+		t.Errorf("EncryptedClientHelloRejectionVerify was not called")
+	}
+	if !errors.Is(cErr, verifyErr) {
+		t.Errorf("client error = %v, want %v", cErr, verifyErr)
+	}
+}
+
+func TestECHRequiresTLS13(t *testing.T) {
+	clientConfig, serverConfig := testECHConfigs(t)
+	clientConfig.MinVersion = 0
+	clientConfig.MaxVersion = VersionTLS12
+	_, _, cErr, _ := testECHHandshake(t, clientConfig, serverConfig)
+	if cErr == nil {
+		t.Fatal("handshake succeeded with ECH and MaxVersion TLS 1.2")
+	}
+
+	// With the default MinVersion, only TLS 1.3 is offered.
+	clientConfig.MaxVersion = 0
+	cs, _, cErr, sErr := testECHHandshake(t, clientConfig, serverConfig)
+	if cErr != nil || sErr != nil {
+		t.Fatalf("handshake failed: client %v, server %v", cErr, sErr)
+	}
+	if cs.Version != VersionTLS13 || !cs.ECHAccepted {
+		t.Errorf("got version %x and ECHAccepted %v", cs.Version, cs.ECHAccepted)
+	}
+}
+
+func TestECHConfigListParsing(t *testing.T) {
+	k, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := k.PublicKey().Bytes()
+	good := marshalTestECHConfig(7, pub, "public.example", 0)
+
+	// A config with an unknown version is skipped.
+	unknown := append([]byte{0xfe, 0x0c}, good[2:]...)
+	configs, err := parseECHConfigList(marshalTestECHConfigList(unknown, good))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].configID != 7 || configs[0].publicName != "public.example" ||
+		!bytes.Equal(configs[0].raw, good) || !bytes.Equal(configs[0].publicKey, pub) {
+		t.Errorf("unexpected parsed configs: %+v", configs)
+	}
+	if pickECHConfig(configs) != configs[0] {
+		t.Errorf("pickECHConfig did not pick the only supported config")
+	}
+
+	for _, bad := range [][]byte{
+		nil,
+		{0, 0},
+		marshalTestECHConfigList(good[:len(good)-1]),
+		append(marshalTestECHConfigList(good), 0),
+	} {
+		if _, err := parseECHConfigList(bad); err == nil {
+			t.Errorf("parseECHConfigList(%x) succeeded", bad)
+		}
+	}
+
+	if pickECHConfig([]*echConfig{{kemID: 0x12, cipherSuites: configs[0].cipherSuites, publicName: "a"}}) != nil {
+		t.Errorf("pickECHConfig picked a config with an unsupported KEM")
+	}
+	if pickECHConfig([]*echConfig{{kemID: uint16(hpke.DHKEM_X25519_HKDF_SHA256), cipherSuites: configs[0].cipherSuites, publicName: "192.0.2.1"}}) != nil {
+		t.Errorf("pickECHConfig picked a config with an IP address public name")
+	}
+}
+
+func TestECHInnerClientHelloRoundTrip(t *testing.T) {
+	inner := &clientHelloMsg{
+		vers:                 VersionTLS12,
+		random:               make([]byte, 32),
+		sessionId:            []byte("session id"),
+		cipherSuites:         []uint16{TLS_AES_128_GCM_SHA256},
+		compressionMethods:   []uint8{compressionNone},
+		serverName:           "secret.example",
+		supportedVersions:    []uint16{VersionTLS13},
+		encryptedClientHello: []byte{echTypeInner},
+	}
+	outer := &clientHelloMsg{
+		vers:               VersionTLS12,
+		random:             make([]byte, 32),
+		sessionId:          inner.sessionId,
+		compressionMethods: []uint8{compressionNone},
+	}
+	for _, maxNameLength := range []int{0, 32, 255} {
+		encoded := encodeInnerClientHello(inner, maxNameLength)
+		if len(encoded)%32 != 0 {
+			t.Errorf("maxNameLength %d: EncodedClientHelloInner length %d is not a multiple of 32", maxNameLength, len(encoded))
+		}
+		decoded, err := decodeInnerClientHello(outer, encoded)
+		if err != nil {
+			t.Fatalf("maxNameLength %d: %v", maxNameLength, err)
+		}
+		if !bytes.Equal(decoded.marshal(), inner.marshal()) {
+			t.Errorf("maxNameLength %d: decoded ClientHelloInner doesn't match", maxNameLength)
+		}
+	}
+}
+
+func TestECHOuterExtensions(t *testing.T) {
+	outer := &clientHelloMsg{
+		vers:               VersionTLS12,
+		random:             make([]byte, 32),
+		sessionId:          []byte("session id"),
+		cipherSuites:       []uint16{TLS_AES_128_GCM_SHA256},
+		compressionMethods: []uint8{compressionNone},
+		serverName:         "public.example",
+		supportedCurves:    []CurveID{X25519, CurveP256},
+		keyShares:          []keyShare{{group: X25519, data: []byte("key share")}},
+		supportedVersions:  []uint16{VersionTLS13},
+	}
+	outer.marshal()
+
+	encode := func(outerTypes ...uint16) []byte {
+		var b cryptobyte.Builder
+		b.AddUint16(VersionTLS12)
+		b.AddBytes(make([]byte, 32))
+		b.AddUint8(0) // legacy_session_id
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint16(TLS_AES_128_GCM_SHA256)
+		})
+		b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint8(compressionNone)
+		})
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint16(extensionEncryptedClientHello)
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddUint8(echTypeInner)
+			})
+			b.AddUint16(extensionSupportedVersions)
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+					b.AddUint16(VersionTLS13)
+				})
+			})
+			b.AddUint16(extensionECHOuterExtensions)
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+					for _, typ := range outerTypes {
+						b.AddUint16(typ)
+					}
+				})
+			})
+		})
+		b.AddBytes(make([]byte, 10)) // padding
+		return b.BytesOrPanic()
+	}
+
+	inner, err := decodeInnerClientHello(outer, encode(extensionSupportedCurves, extensionKeyShare))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inner.supportedCurves) != 2 || len(inner.keyShares) != 1 ||
+		!bytes.Equal(inner.keyShares[0].data, []byte("key share")) {
+		t.Errorf("outer extensions were not copied: %+v", inner)
+	}
+	if !bytes.Equal(inner.sessionId, outer.sessionId) {
+		t.Errorf("legacy_session_id was not copied from the ClientHelloOuter")
+	}
+	if inner.serverName != "" {
+		t.Errorf("server_name was copied from the ClientHelloOuter")
+	}
+
+	for _, types := range [][]uint16{
+		{extensionKeyShare, extensionSupportedCurves}, // wrong order
+		{extensionALPN},                 // not in the ClientHelloOuter
+		{extensionEncryptedClientHello}, // forbidden
+		{},                              // empty
+	} {
+		if _, err := decodeInnerClientHello(outer, encode(types...)); err == nil {
+			t.Errorf("ech_outer_extensions %v was accepted", types)
+		}
+	}
+}
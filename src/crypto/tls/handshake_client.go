@@ -34,27 +34,47 @@ type clientHandshakeState struct {
 	session      *ClientSessionState
 }
 
-func (c *Conn) makeClientHello() (*clientHelloMsg, ecdheParameters, error) {
+func (c *Conn) makeClientHello() (*clientHelloMsg, *keySharePrivateKeys, *echClientContext, error) {
 	config := c.config
 	if len(config.ServerName) == 0 && !config.InsecureSkipVerify {
-		return nil, nil, errors.New("tls: either ServerName or InsecureSkipVerify must be specified in the tls.Config")
+		return nil, nil, nil, errors.New("tls: either ServerName or InsecureSkipVerify must be specified in the tls.Config")
 	}
 
 	nextProtosLength := 0
 	for _, proto := range config.NextProtos {
 		if l := len(proto); l == 0 || l > 255 {
-			return nil, nil, errors.New("tls: invalid NextProtos value")
+			return nil, nil, nil, errors.New("tls: invalid NextProtos value")
 		} else {
 			nextProtosLength += 1 + l
 		}
 	}
 	if nextProtosLength > 0xffff {
-		return nil, nil, errors.New("tls: NextProtos values too large")
+		return nil, nil, nil, errors.New("tls: NextProtos values too large")
 	}
 
 	supportedVersions := config.supportedVersions()
 	if len(supportedVersions) == 0 {
-		return nil, nil, errors.New("tls: no supported versions satisfy MinVersion and MaxVersion")
+		return nil, nil, nil, errors.New("tls: no supported versions satisfy MinVersion and MaxVersion")
+	}
+
+	var ech *echClientContext
+	if config.EncryptedClientHelloConfigList != nil {
+		// ECH requires TLS 1.3, so only offer TLS 1.3 and later.
+		// See draft-ietf-tls-esni-18, Section 6.1.
+		if supportedVersions[0] < VersionTLS13 {
+			return nil, nil, nil, errors.New("tls: EncryptedClientHelloConfigList requires TLS 1.3")
+		}
+		for i, v := range supportedVersions {
+			if v < VersionTLS13 {
+				supportedVersions = supportedVersions[:i]
+				break
+			}
+		}
+		var err error
+		ech, err = c.newECHClientContext()
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	clientHelloVersion := config.maxSupportedVersion()
@@ -69,7 +89,6 @@ func (c *Conn) makeClientHello() (*clientHelloMsg, ecdheParameters, error) {
 		vers:                         clientHelloVersion,
 		compressionMethods:           []uint8{compressionNone},
 		random:                       make([]byte, 32),
-		sessionId:                    make([]byte, 32),
 		ocspStapling:                 true,
 		scts:                         true,
 		serverName:                   hostnameInSNI(config.ServerName),
@@ -80,6 +99,18 @@ func (c *Conn) makeClientHello() (*clientHelloMsg, ecdheParameters, error) {
 		supportedVersions:            supportedVersions,
 	}
 
+	// Key exchange groups that require TLS 1.3 are not advertised to servers
+	// that can't negotiate it.
+	if hello.supportedVersions[0] < VersionTLS13 {
+		var curves []CurveID
+		for _, curve := range hello.supportedCurves {
+			if !isTLS13OnlyKeyExchange(curve) {
+				curves = append(curves, curve)
+			}
+		}
+		hello.supportedCurves = curves
+	}
+
 	if c.handshakes > 0 {
 		hello.secureRenegotiation = c.clientFinished[:]
 	}
@@ -106,21 +137,26 @@ func (c *Conn) makeClientHello() (*clientHelloMsg, ecdheParameters, error) {
 
 	_, err := io.ReadFull(config.rand(), hello.random)
 	if err != nil {
-		return nil, nil, errors.New("tls: short read from Rand: " + err.Error())
+		return nil, nil, nil, errors.New("tls: short read from Rand: " + err.Error())
 	}
 
 	// A random session ID is used to detect when the server accepted a ticket
 	// and is resuming a session (see RFC 5077). In TLS 1.3, it's always set as
 	// a compatibility measure (see RFC 8446, Section 4.1.2).
-	if _, err := io.ReadFull(config.rand(), hello.sessionId); err != nil {
-		return nil, nil, errors.New("tls: short read from Rand: " + err.Error())
+	//
+	// The session ID is not set for QUIC connections (see RFC 9001, Section 8.4).
+	if c.quic == nil {
+		hello.sessionId = make([]byte, 32)
+		if _, err := io.ReadFull(config.rand(), hello.sessionId); err != nil {
+			return nil, nil, nil, errors.New("tls: short read from Rand: " + err.Error())
+		}
 	}
 
 	if hello.vers >= VersionTLS12 {
 		hello.supportedSignatureAlgorithms = supportedSignatureAlgorithms
 	}
 
-	var params ecdheParameters
+	var keyShareKeys *keySharePrivateKeys
 	if hello.supportedVersions[0] == VersionTLS13 {
 		if hasAESGCMHardwareSupport {
 			hello.cipherSuites = append(hello.cipherSuites, defaultCipherSuitesTLS13...)
@@ -128,18 +164,32 @@ func (c *Conn) makeClientHello() (*clientHelloMsg, ecdheParameters, error) {
 			hello.cipherSuites = append(hello.cipherSuites, defaultCipherSuitesTLS13NoAES...)
 		}
 
+		// If both X25519MLKEM768 and X25519 are supported, also send an X25519
+		// key share to avoid a HelloRetryRequest round-trip with servers that
+		// don't support the hybrid group.
 		curveID := config.curvePreferences()[0]
-		if _, ok := curveForCurveID(curveID); curveID != X25519 && !ok {
-			return nil, nil, errors.New("tls: CurvePreferences includes unsupported curve")
-		}
-		params, err = generateECDHEParameters(config.rand(), curveID)
+		keyShareKeys, hello.keyShares, err = generateKeyShares(config.rand(), curveID, config.supportsCurve(X25519))
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
+		}
+
+		if c.quic != nil {
+			p, err := c.quicGetTransportParameters()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if p == nil {
+				p = []byte{}
+			}
+			hello.quicTransportParameters = p
 		}
-		hello.keyShares = []keyShare{{group: curveID, data: params.PublicKey()}}
 	}
 
-	return hello, params, nil
+	if ech != nil {
+		hello.encryptedClientHello = []byte{echTypeInner}
+	}
+
+	return hello, keyShareKeys, ech, nil
 }
 
 func (c *Conn) clientHandshake(ctx context.Context) (err error) {
@@ -151,7 +201,7 @@ func (c *Conn) clientHandshake(ctx context.Context) (err error) {
 	// need to be reset.
 	c.didResume = false
 
-	hello, ecdheParams, err := c.makeClientHello()
+	hello, keyShareKeys, ech, err := c.makeClientHello()
 	if err != nil {
 		return err
 	}
@@ -172,10 +222,42 @@ func (c *Conn) clientHandshake(ctx context.Context) (err error) {
 		}()
 	}
 
+	innerHello := hello
+	if ech != nil {
+		// The ClientHello built so far is the ClientHelloInner. The
+		// ClientHelloOuter is a copy of it with a fresh random, the public
+		// name, and no PSK, which carries the encrypted ClientHelloInner.
+		// See draft-ietf-tls-esni-18, Section 6.1.
+		outer := *hello
+		outer.raw = nil
+		outer.random = make([]byte, 32)
+		if _, err := io.ReadFull(c.config.rand(), outer.random); err != nil {
+			return errors.New("tls: short read from Rand: " + err.Error())
+		}
+		outer.serverName = ech.config.publicName
+		outer.earlyData = false
+		outer.pskIdentities = nil
+		outer.pskBinders = nil
+		ech.innerHello = hello
+		ech.outerHello = &outer
+		if err := ech.sealOuterHello(true); err != nil {
+			return err
+		}
+		hello = ech.outerHello
+	}
+
 	if _, err := c.writeRecord(recordTypeHandshake, hello.marshal()); err != nil {
 		return err
 	}
 
+	if innerHello.earlyData {
+		suite := cipherSuiteTLS13ByID(session.cipherSuite)
+		transcript := suite.hash.New()
+		transcript.Write(innerHello.marshal())
+		earlyTrafficSecret := suite.deriveSecret(earlySecret, clientEarlyTrafficLabel, transcript)
+		c.quicSetWriteSecret(QUICEncryptionLevelEarly, suite.id, earlyTrafficSecret)
+	}
+
 	msg, err := c.readHandshake()
 	if err != nil {
 		return err
@@ -203,16 +285,22 @@ func (c *Conn) clientHandshake(ctx context.Context) (err error) {
 		return errors.New("tls: downgrade attempt detected, possibly due to a MitM attack or a broken middlebox")
 	}
 
+	if ech != nil && c.vers < VersionTLS13 {
+		c.sendAlert(alertProtocolVersion)
+		return errors.New("tls: server selected TLS 1.2 or earlier in response to an ECH offer")
+	}
+
 	if c.vers == VersionTLS13 {
 		hs := &clientHandshakeStateTLS13{
-			c:           c,
-			ctx:         ctx,
-			serverHello: serverHello,
-			hello:       hello,
-			ecdheParams: ecdheParams,
-			session:     session,
-			earlySecret: earlySecret,
-			binderKey:   binderKey,
+			c:            c,
+			ctx:          ctx,
+			serverHello:  serverHello,
+			hello:        hello,
+			keyShareKeys: keyShareKeys,
+			session:      session,
+			earlySecret:  earlySecret,
+			binderKey:    binderKey,
+			echContext:   ech,
 		}
 
 		// In TLS 1.3, session tickets are delivered after the handshake.
@@ -262,7 +350,10 @@ func (c *Conn) loadSession(hello *clientHelloMsg) (cacheKey string,
 	}
 
 	// Try to resume a previously negotiated TLS session, if available.
-	cacheKey = clientSessionCacheKey(c.conn.RemoteAddr(), c.config)
+	cacheKey = c.clientSessionCacheKey()
+	if cacheKey == "" {
+		return "", nil, nil, nil
+	}
 	session, ok := c.config.ClientSessionCache.Get(cacheKey)
 	if !ok || session == nil {
 		return cacheKey, nil, nil, nil
@@ -343,6 +434,18 @@ func (c *Conn) loadSession(hello *clientHelloMsg) (cacheKey string,
 	hello.pskIdentities = []pskIdentity{identity}
 	hello.pskBinders = [][]byte{make([]byte, cipherSuite.hash.Size())}
 
+	// QUIC clients offer 0-RTT if the ticket allows it. The cipher suite has
+	// to match exactly, and we need to be offering the same ALPN protocol.
+	if c.quic != nil && session.earlyData &&
+		mutualCipherSuiteTLS13(hello.cipherSuites, session.cipherSuite) != nil {
+		for _, alpn := range hello.alpnProtocols {
+			if alpn == session.alpnProtocol {
+				hello.earlyData = true
+				break
+			}
+		}
+	}
+
 	// Compute the PSK binders. See RFC 8446, Section 4.2.11.2.
 	psk := cipherSuite.expandLabel(session.masterSecret, "resumption",
 		session.nonce, cipherSuite.hash.Size())
@@ -711,7 +814,7 @@ func (hs *clientHandshakeState) processServerHello() (bool, error) {
 		}
 	}
 
-	if err := checkALPN(hs.hello.alpnProtocols, hs.serverHello.alpnProtocol); err != nil {
+	if err := checkALPN(hs.hello.alpnProtocols, hs.serverHello.alpnProtocol, false); err != nil {
 		c.sendAlert(alertUnsupportedExtension)
 		return false, err
 	}
@@ -749,8 +852,12 @@ func (hs *clientHandshakeState) processServerHello() (bool, error) {
 
 // checkALPN ensure that the server's choice of ALPN protocol is compatible with
 // the protocols that we advertised in the Client Hello.
-func checkALPN(clientProtos []string, serverProto string) error {
+func checkALPN(clientProtos []string, serverProto string, quic bool) error {
 	if serverProto == "" {
+		if quic && len(clientProtos) > 0 {
+			// RFC 9001, Section 8.1
+			return errors.New("tls: server did not select an ALPN protocol")
+		}
 		return nil
 	}
 	if len(clientProtos) == 0 {
@@ -854,13 +961,26 @@ func (c *Conn) verifyServerCertificate(certificates [][]byte) error {
 		certs[i] = cert
 	}
 
-	if !c.config.InsecureSkipVerify {
+	// If ECH was rejected, the server certificate is verified for the public
+	// name of the ECHConfig, which is then in c.serverName.
+	// See draft-ietf-tls-esni-18, Section 6.1.6.
+	echRejected := c.config.EncryptedClientHelloConfigList != nil && !c.echAccepted
+	if echRejected && c.config.EncryptedClientHelloRejectionVerify != nil {
+		c.peerCertificates = certs
+		if err := c.config.EncryptedClientHelloRejectionVerify(c.connectionStateLocked()); err != nil {
+			c.sendAlert(alertBadCertificate)
+			return err
+		}
+	} else if !c.config.InsecureSkipVerify {
 		opts := x509.VerifyOptions{
 			Roots:         c.config.RootCAs,
 			CurrentTime:   c.config.time(),
 			DNSName:       c.config.ServerName,
 			Intermediates: x509.NewCertPool(),
 		}
+		if echRejected {
+			opts.DNSName = c.serverName
+		}
 		for _, cert := range certs[1:] {
 			opts.Intermediates.AddCert(cert)
 		}
@@ -983,11 +1103,16 @@ func (c *Conn) getClientCertificate(cri *CertificateRequestInfo) (*Certificate,
 
 // clientSessionCacheKey returns a key used to cache sessionTickets that could
 // be used to resume previously negotiated TLS sessions with a server.
-func clientSessionCacheKey(serverAddr net.Addr, config *Config) string {
-	if len(config.ServerName) > 0 {
-		return config.ServerName
+// It returns "" if there is no suitable key, as for a QUIC connection
+// without a ServerName.
+func (c *Conn) clientSessionCacheKey() string {
+	if len(c.config.ServerName) > 0 {
+		return c.config.ServerName
+	}
+	if c.conn != nil {
+		return c.conn.RemoteAddr().String()
 	}
-	return serverAddr.String()
+	return ""
 }
 
 // hostnameInSNI converts name into an appropriate hostname for SNI.
@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/hmac"
+	"crypto/internal/mlkem768"
 	"crypto/rsa"
 	"errors"
 	"hash"
@@ -17,16 +18,18 @@ import (
 )
 
 type clientHandshakeStateTLS13 struct {
-	c           *Conn
-	ctx         context.Context
-	serverHello *serverHelloMsg
-	hello       *clientHelloMsg
-	ecdheParams ecdheParameters
+	c            *Conn
+	ctx          context.Context
+	serverHello  *serverHelloMsg
+	hello        *clientHelloMsg
+	keyShareKeys *keySharePrivateKeys
 
 	session     *ClientSessionState
 	earlySecret []byte
 	binderKey   []byte
 
+	echContext *echClientContext
+
 	certReq       *certificateRequestMsgTLS13
 	usingPSK      bool
 	sentDummyCCS  bool
@@ -36,8 +39,9 @@ type clientHandshakeStateTLS13 struct {
 	trafficSecret []byte // client_application_traffic_secret_0
 }
 
-// handshake requires hs.c, hs.hello, hs.serverHello, hs.ecdheParams, and,
-// optionally, hs.session, hs.earlySecret and hs.binderKey to be set.
+// handshake requires hs.c, hs.hello, hs.serverHello, hs.keyShareKeys, and,
+// optionally, hs.session, hs.earlySecret, hs.binderKey and hs.echContext to
+// be set. If hs.echContext is set, hs.hello is the ClientHelloOuter.
 func (hs *clientHandshakeStateTLS13) handshake() error {
 	c := hs.c
 
@@ -49,7 +53,7 @@ func (hs *clientHandshakeStateTLS13) handshake() error {
 	}
 
 	// Consistency check on the presence of a keyShare and its parameters.
-	if hs.ecdheParams == nil || len(hs.hello.keyShares) != 1 {
+	if hs.keyShareKeys == nil || hs.keyShareKeys.ecdhe == nil || len(hs.hello.keyShares) == 0 {
 		return c.sendAlert(alertInternalError)
 	}
 
@@ -60,7 +64,14 @@ func (hs *clientHandshakeStateTLS13) handshake() error {
 	hs.transcript = hs.suite.hash.New()
 	hs.transcript.Write(hs.hello.marshal())
 
-	if bytes.Equal(hs.serverHello.random, helloRetryRequestRandom) {
+	if hs.echContext != nil {
+		hs.echContext.innerTranscript = hs.suite.hash.New()
+		hs.echContext.innerTranscript.Write(hs.echContext.innerHello.marshal())
+	}
+
+	sentHRR := bytes.Equal(hs.serverHello.random, helloRetryRequestRandom)
+	if sentHRR {
+		c.didHRR = true
 		if err := hs.sendDummyChangeCipherSpec(); err != nil {
 			return err
 		}
@@ -69,6 +80,14 @@ func (hs *clientHandshakeStateTLS13) handshake() error {
 		}
 	}
 
+	// If the server rejected ECH in the HelloRetryRequest, the handshake
+	// continues with the ClientHelloOuter.
+	if hs.echContext != nil && (!sentHRR || c.echAccepted) {
+		if err := hs.checkECHAcceptance(); err != nil {
+			return err
+		}
+	}
+
 	hs.transcript.Write(hs.serverHello.marshal())
 
 	c.buffering = true
@@ -90,6 +109,12 @@ func (hs *clientHandshakeStateTLS13) handshake() error {
 	if err := hs.readServerFinished(); err != nil {
 		return err
 	}
+	if hs.echContext != nil && !c.echAccepted {
+		// The server certificate was verified for the public name, and the
+		// handshake can't continue. See draft-ietf-tls-esni-18, Section 6.1.6.
+		c.sendAlert(alertECHRequired)
+		return &ECHRejectionError{RetryConfigList: hs.echContext.retryConfigs}
+	}
 	if err := hs.sendClientCertificate(); err != nil {
 		return err
 	}
@@ -105,6 +130,17 @@ func (hs *clientHandshakeStateTLS13) handshake() error {
 	return nil
 }
 
+// sentKeyShare returns whether the last ClientHello included a key share for
+// the group curveID.
+func (hs *clientHandshakeStateTLS13) sentKeyShare(curveID CurveID) bool {
+	for _, ks := range hs.hello.keyShares {
+		if ks.group == curveID {
+			return true
+		}
+	}
+	return false
+}
+
 // checkServerHelloOrHRR does validity checks that apply to both ServerHello and
 // HelloRetryRequest messages. It sets hs.suite.
 func (hs *clientHandshakeStateTLS13) checkServerHelloOrHRR() error {
@@ -163,6 +199,9 @@ func (hs *clientHandshakeStateTLS13) checkServerHelloOrHRR() error {
 // sendDummyChangeCipherSpec sends a ChangeCipherSpec record for compatibility
 // with middleboxes that didn't implement TLS correctly. See RFC 8446, Appendix D.4.
 func (hs *clientHandshakeStateTLS13) sendDummyChangeCipherSpec() error {
+	if hs.c.quic != nil {
+		return nil
+	}
 	if hs.sentDummyCCS {
 		return nil
 	}
@@ -177,6 +216,12 @@ func (hs *clientHandshakeStateTLS13) sendDummyChangeCipherSpec() error {
 func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 	c := hs.c
 
+	if hs.echContext != nil {
+		if err := hs.checkECHAcceptanceHRR(); err != nil {
+			return err
+		}
+	}
+
 	// The first ClientHello gets double-hashed into the transcript upon a
 	// HelloRetryRequest. (The idea is that the server might offload transcript
 	// storage to the client in the cookie.) See RFC 8446, Section 4.4.1.
@@ -194,8 +239,17 @@ func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 		return errors.New("tls: server sent an unnecessary HelloRetryRequest message")
 	}
 
+	// With ECH, both the ClientHelloInner and the ClientHelloOuter are
+	// updated and resent, whichever is used for the handshake.
+	hellos := []*clientHelloMsg{hs.hello}
+	if hs.echContext != nil {
+		hellos = []*clientHelloMsg{hs.echContext.innerHello, hs.echContext.outerHello}
+	}
+
 	if hs.serverHello.cookie != nil {
-		hs.hello.cookie = hs.serverHello.cookie
+		for _, hello := range hellos {
+			hello.cookie = hs.serverHello.cookie
+		}
 	}
 
 	if hs.serverHello.serverShare.group != 0 {
@@ -218,24 +272,29 @@ func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 			c.sendAlert(alertIllegalParameter)
 			return errors.New("tls: server selected unsupported group")
 		}
-		if hs.ecdheParams.CurveID() == curveID {
+		if hs.sentKeyShare(curveID) {
 			c.sendAlert(alertIllegalParameter)
 			return errors.New("tls: server sent an unnecessary HelloRetryRequest key_share")
 		}
-		if _, ok := curveForCurveID(curveID); curveID != X25519 && !ok {
-			c.sendAlert(alertInternalError)
-			return errors.New("tls: CurvePreferences includes unsupported curve")
-		}
-		params, err := generateECDHEParameters(c.config.rand(), curveID)
+		keys, keyShares, err := generateKeyShares(c.config.rand(), curveID, false)
 		if err != nil {
 			c.sendAlert(alertInternalError)
 			return err
 		}
-		hs.ecdheParams = params
-		hs.hello.keyShares = []keyShare{{group: curveID, data: params.PublicKey()}}
+		hs.keyShareKeys = keys
+		for _, hello := range hellos {
+			hello.keyShares = keyShares
+		}
 	}
 
-	hs.hello.raw = nil
+	if hellos[0].earlyData {
+		hellos[0].earlyData = false
+		c.quicRejectedEarlyData()
+	}
+
+	for _, hello := range hellos {
+		hello.raw = nil
+	}
 	if len(hs.hello.pskIdentities) > 0 {
 		pskSuite := cipherSuiteTLS13ByID(hs.session.cipherSuite)
 		if pskSuite == nil {
@@ -260,8 +319,17 @@ func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 		}
 	}
 
+	sentHello := hs.hello
+	if hs.echContext != nil {
+		if err := hs.echContext.sealOuterHello(false); err != nil {
+			c.sendAlert(alertInternalError)
+			return err
+		}
+		sentHello = hs.echContext.outerHello
+	}
+
 	hs.transcript.Write(hs.hello.marshal())
-	if _, err := c.writeRecord(recordTypeHandshake, hs.hello.marshal()); err != nil {
+	if _, err := c.writeRecord(recordTypeHandshake, sentHello.marshal()); err != nil {
 		return err
 	}
 
@@ -284,6 +352,86 @@ func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 	return nil
 }
 
+// checkECHAcceptanceHRR checks the ECH acceptance signal in the
+// HelloRetryRequest in hs.serverHello, and if ECH was accepted, switches
+// hs.hello and hs.transcript to the ClientHelloInner.
+// See draft-ietf-tls-esni-18, Section 6.1.5.
+func (hs *clientHandshakeStateTLS13) checkECHAcceptanceHRR() error {
+	c := hs.c
+	ech := hs.echContext
+
+	if len(hs.serverHello.encryptedClientHello) == 0 {
+		// ECH was rejected, and the handshake continues with the
+		// ClientHelloOuter. See checkECHAcceptance.
+		c.serverName = ech.config.publicName
+		return nil
+	}
+
+	chHash := ech.innerTranscript.Sum(nil)
+	confTranscript := hs.suite.hash.New()
+	confTranscript.Write([]byte{typeMessageHash, 0, 0, uint8(len(chHash))})
+	confTranscript.Write(chHash)
+	hrr, err := zeroECHExtensionTail(hs.serverHello.marshal(), echConfirmationLength)
+	if err != nil {
+		c.sendAlert(alertInternalError)
+		return err
+	}
+	confTranscript.Write(hrr)
+	confirmation := echAcceptConfirmation(hs.suite, ech.innerHello.random,
+		echHRRAcceptConfirmationLabel, confTranscript)
+	if !hmac.Equal(confirmation, hs.serverHello.encryptedClientHello) {
+		c.sendAlert(alertIllegalParameter)
+		return errors.New("tls: invalid ECH confirmation in HelloRetryRequest")
+	}
+
+	c.echAccepted = true
+	hs.hello = ech.innerHello
+	hs.transcript = ech.innerTranscript
+	return nil
+}
+
+// checkECHAcceptance checks the ECH acceptance signal in the random of the
+// ServerHello in hs.serverHello, and if ECH was accepted, switches hs.hello
+// and hs.transcript to the ClientHelloInner. See draft-ietf-tls-esni-18,
+// Section 6.1.4.
+func (hs *clientHandshakeStateTLS13) checkECHAcceptance() error {
+	c := hs.c
+	ech := hs.echContext
+
+	confTranscript := cloneHash(ech.innerTranscript, hs.suite.hash)
+	if confTranscript == nil {
+		c.sendAlert(alertInternalError)
+		return errors.New("tls: internal error: failed to clone hash")
+	}
+	serverHello := make([]byte, len(hs.serverHello.marshal()))
+	copy(serverHello, hs.serverHello.marshal())
+	// The last 8 bytes of the random, which is at offset 6 after the message
+	// header and legacy_version, are zeroed in the confirmation transcript.
+	copy(serverHello[6+32-echConfirmationLength:6+32], make([]byte, echConfirmationLength))
+	confTranscript.Write(serverHello)
+	confirmation := echAcceptConfirmation(hs.suite, ech.innerHello.random,
+		echAcceptConfirmationLabel, confTranscript)
+
+	if !hmac.Equal(confirmation, hs.serverHello.random[32-echConfirmationLength:]) {
+		if c.echAccepted {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: server rejected ECH after accepting it in HelloRetryRequest")
+		}
+		// The handshake continues with the ClientHelloOuter, for the
+		// public name, until the server certificate is verified.
+		c.serverName = ech.config.publicName
+		if ech.innerHello.earlyData {
+			c.quicRejectedEarlyData()
+		}
+		return nil
+	}
+
+	c.echAccepted = true
+	hs.hello = ech.innerHello
+	hs.transcript = ech.innerTranscript
+	return nil
+}
+
 func (hs *clientHandshakeStateTLS13) processServerHello() error {
 	c := hs.c
 
@@ -306,7 +454,7 @@ func (hs *clientHandshakeStateTLS13) processServerHello() error {
 		c.sendAlert(alertIllegalParameter)
 		return errors.New("tls: server did not send a key share")
 	}
-	if hs.serverHello.serverShare.group != hs.ecdheParams.CurveID() {
+	if !hs.sentKeyShare(hs.serverHello.serverShare.group) {
 		c.sendAlert(alertIllegalParameter)
 		return errors.New("tls: server selected unsupported group")
 	}
@@ -344,11 +492,40 @@ func (hs *clientHandshakeStateTLS13) processServerHello() error {
 func (hs *clientHandshakeStateTLS13) establishHandshakeKeys() error {
 	c := hs.c
 
-	sharedKey := hs.ecdheParams.SharedKey(hs.serverHello.serverShare.data)
-	if sharedKey == nil {
+	// For X25519MLKEM768, the server key share is the ML-KEM-768 ciphertext
+	// followed by the X25519 public key, and the shared secret is the ML-KEM
+	// shared secret followed by the X25519 one.
+	ecdhePeerData := hs.serverHello.serverShare.data
+	if hs.serverHello.serverShare.group == X25519MLKEM768 {
+		if len(ecdhePeerData) != mlkem768.CiphertextSize+x25519PublicKeySize {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: invalid server X25519MLKEM768 key share")
+		}
+		ecdhePeerData = ecdhePeerData[mlkem768.CiphertextSize:]
+	}
+	peerKey, err := hs.keyShareKeys.ecdhe.Curve().NewPublicKey(ecdhePeerData)
+	if err != nil {
+		c.sendAlert(alertIllegalParameter)
+		return errors.New("tls: invalid server key share")
+	}
+	sharedKey, err := hs.keyShareKeys.ecdhe.ECDH(peerKey)
+	if err != nil {
 		c.sendAlert(alertIllegalParameter)
 		return errors.New("tls: invalid server key share")
 	}
+	if hs.serverHello.serverShare.group == X25519MLKEM768 {
+		if hs.keyShareKeys.mlkem == nil {
+			return c.sendAlert(alertInternalError)
+		}
+		ciphertext := hs.serverHello.serverShare.data[:mlkem768.CiphertextSize]
+		mlkemShared, err := mlkem768.Decapsulate(hs.keyShareKeys.mlkem, ciphertext)
+		if err != nil {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: invalid server X25519MLKEM768 key share")
+		}
+		sharedKey = append(mlkemShared, sharedKey...)
+	}
+	c.curveID = hs.serverHello.serverShare.group
 
 	earlySecret := hs.earlySecret
 	if !hs.usingPSK {
@@ -359,12 +536,21 @@ func (hs *clientHandshakeStateTLS13) establishHandshakeKeys() error {
 
 	clientSecret := hs.suite.deriveSecret(handshakeSecret,
 		clientHandshakeTrafficLabel, hs.transcript)
-	c.out.setTrafficSecret(hs.suite, clientSecret)
+	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, clientSecret)
 	serverSecret := hs.suite.deriveSecret(handshakeSecret,
 		serverHandshakeTrafficLabel, hs.transcript)
-	c.in.setTrafficSecret(hs.suite, serverSecret)
+	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, serverSecret)
 
-	err := c.config.writeKeyLog(keyLogLabelClientHandshake, hs.hello.random, clientSecret)
+	if c.quic != nil {
+		if c.hand.Len() != 0 {
+			c.sendAlert(alertUnexpectedMessage)
+			return errors.New("tls: handshake data received at the wrong encryption level")
+		}
+		c.quicSetWriteSecret(QUICEncryptionLevelHandshake, hs.suite.id, clientSecret)
+		c.quicSetReadSecret(QUICEncryptionLevelHandshake, hs.suite.id, serverSecret)
+	}
+
+	err = c.config.writeKeyLog(keyLogLabelClientHandshake, hs.hello.random, clientSecret)
 	if err != nil {
 		c.sendAlert(alertInternalError)
 		return err
@@ -396,12 +582,54 @@ func (hs *clientHandshakeStateTLS13) readServerParameters() error {
 	}
 	hs.transcript.Write(encryptedExtensions.marshal())
 
-	if err := checkALPN(hs.hello.alpnProtocols, encryptedExtensions.alpnProtocol); err != nil {
+	if err := checkALPN(hs.hello.alpnProtocols, encryptedExtensions.alpnProtocol, c.quic != nil); err != nil {
 		c.sendAlert(alertUnsupportedExtension)
 		return err
 	}
 	c.clientProtocol = encryptedExtensions.alpnProtocol
 
+	if c.quic != nil {
+		if encryptedExtensions.quicTransportParameters == nil {
+			// RFC 9001 Section 8.2.
+			c.sendAlert(alertMissingExtension)
+			return errors.New("tls: server did not send a quic_transport_parameters extension")
+		}
+		c.quicSetTransportParameters(encryptedExtensions.quicTransportParameters)
+	} else if encryptedExtensions.quicTransportParameters != nil {
+		c.sendAlert(alertUnsupportedExtension)
+		return errors.New("tls: server sent an unexpected quic_transport_parameters extension")
+	}
+
+	if len(encryptedExtensions.echRetryConfigs) != 0 {
+		if hs.echContext == nil || c.echAccepted {
+			c.sendAlert(alertUnsupportedExtension)
+			return errors.New("tls: server sent unexpected ECH retry configs")
+		}
+		hs.echContext.retryConfigs = encryptedExtensions.echRetryConfigs
+	}
+
+	if !hs.hello.earlyData && encryptedExtensions.earlyData {
+		c.sendAlert(alertUnsupportedExtension)
+		return errors.New("tls: server sent an unexpected early_data extension")
+	}
+	if hs.hello.earlyData && !encryptedExtensions.earlyData {
+		c.quicRejectedEarlyData()
+	}
+	if encryptedExtensions.earlyData {
+		if !hs.usingPSK || hs.serverHello.selectedIdentity != 0 {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: server accepted 0-RTT without the first PSK")
+		}
+		if hs.session.cipherSuite != c.cipherSuite {
+			c.sendAlert(alertHandshakeFailure)
+			return errors.New("tls: server accepted 0-RTT with the wrong cipher suite")
+		}
+		if hs.session.alpnProtocol != c.clientProtocol {
+			c.sendAlert(alertHandshakeFailure)
+			return errors.New("tls: server accepted 0-RTT with the wrong ALPN")
+		}
+	}
+
 	return nil
 }
 
@@ -522,7 +750,7 @@ func (hs *clientHandshakeStateTLS13) readServerFinished() error {
 		clientApplicationTrafficLabel, hs.transcript)
 	serverSecret := hs.suite.deriveSecret(hs.masterSecret,
 		serverApplicationTrafficLabel, hs.transcript)
-	c.in.setTrafficSecret(hs.suite, serverSecret)
+	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelApplication, serverSecret)
 
 	err = c.config.writeKeyLog(keyLogLabelClientTraffic, hs.hello.random, hs.trafficSecret)
 	if err != nil {
@@ -621,7 +849,15 @@ func (hs *clientHandshakeStateTLS13) sendClientFinished() error {
 		return err
 	}
 
-	c.out.setTrafficSecret(hs.suite, hs.trafficSecret)
+	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelApplication, hs.trafficSecret)
+
+	if c.quic != nil {
+		if c.hand.Len() != 0 {
+			c.sendAlert(alertUnexpectedMessage)
+			return errors.New("tls: handshake data received at the wrong encryption level")
+		}
+		c.quicSetWriteSecret(QUICEncryptionLevelApplication, hs.suite.id, hs.trafficSecret)
+	}
 
 	if !c.config.SessionTicketsDisabled && c.config.ClientSessionCache != nil {
 		c.resumptionSecret = hs.suite.deriveSecret(hs.masterSecret,
@@ -645,6 +881,11 @@ func (c *Conn) handleNewSessionTicket(msg *newSessionTicketMsgTLS13) error {
 	if msg.lifetime == 0 {
 		return nil
 	}
+	if c.quic != nil && msg.maxEarlyData != 0 && msg.maxEarlyData != 0xffffffff {
+		// RFC 9001, Section 4.6.1.
+		c.sendAlert(alertIllegalParameter)
+		return errors.New("tls: invalid early data for QUIC connection")
+	}
 	lifetime := time.Duration(msg.lifetime) * time.Second
 	if lifetime > maxSessionTicketLifetime {
 		c.sendAlert(alertIllegalParameter)
@@ -673,10 +914,13 @@ func (c *Conn) handleNewSessionTicket(msg *newSessionTicketMsgTLS13) error {
 		ageAdd:             msg.ageAdd,
 		ocspResponse:       c.ocspResponse,
 		scts:               c.scts,
+		earlyData:          c.quic != nil && msg.maxEarlyData == 0xffffffff,
+		alpnProtocol:       c.clientProtocol,
 	}
 
-	cacheKey := clientSessionCacheKey(c.conn.RemoteAddr(), c.config)
-	c.config.ClientSessionCache.Put(cacheKey, session)
+	if cacheKey := c.clientSessionCacheKey(); cacheKey != "" {
+		c.config.ClientSessionCache.Put(cacheKey, session)
+	}
 
 	return nil
 }
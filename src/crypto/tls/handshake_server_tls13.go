@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/hmac"
+	"crypto/internal/mlkem768"
 	"crypto/rsa"
 	"errors"
 	"hash"
@@ -29,6 +30,7 @@ type serverHandshakeStateTLS13 struct {
 	hello           *serverHelloMsg
 	sentDummyCCS    bool
 	usingPSK        bool
+	earlyData       bool
 	suite           *cipherSuiteTLS13
 	cert            *Certificate
 	sigAlg          SignatureScheme
@@ -39,6 +41,7 @@ type serverHandshakeStateTLS13 struct {
 	trafficSecret   []byte // client_application_traffic_secret_0
 	transcript      hash.Hash
 	clientFinished  []byte
+	echContext      *echServerContext
 }
 
 func (hs *serverHandshakeStateTLS13) handshake() error {
@@ -135,7 +138,12 @@ func (hs *serverHandshakeStateTLS13) processClientHello() error {
 		return errors.New("tls: initial handshake had non-empty renegotiation extension")
 	}
 
-	if hs.clientHello.earlyData {
+	if hs.clientHello.earlyData && c.quic != nil {
+		if len(hs.clientHello.pskIdentities) == 0 {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: early_data without pre_shared_key")
+		}
+	} else if hs.clientHello.earlyData {
 		// See RFC 8446, Section 4.2.10 for the complicated behavior required
 		// here. The scenario is that a different server at our address offered
 		// to accept early data in the past, which we can't handle. For now, all
@@ -198,24 +206,76 @@ GroupSelection:
 		if err := hs.doHelloRetryRequest(selectedGroup); err != nil {
 			return err
 		}
+		c.didHRR = true
 		clientKeyShare = &hs.clientHello.keyShares[0]
 	}
 
-	if _, ok := curveForCurveID(selectedGroup); selectedGroup != X25519 && !ok {
+	c.curveID = selectedGroup
+
+	// For X25519MLKEM768, the client key share is the ML-KEM-768
+	// encapsulation key followed by the X25519 public key. The server replies
+	// with the ML-KEM-768 ciphertext followed by its X25519 public key, and
+	// the shared secret is the ML-KEM shared secret followed by the X25519
+	// one. See draft-kwiatkowski-tls-ecdhe-mlkem-02, Section 4.
+	ecdhGroup := selectedGroup
+	ecdhData := clientKeyShare.data
+	if selectedGroup == X25519MLKEM768 {
+		ecdhGroup = X25519
+		if len(ecdhData) != mlkem768.EncapsulationKeySize+x25519PublicKeySize {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: invalid X25519MLKEM768 client key share")
+		}
+		ecdhData = ecdhData[mlkem768.EncapsulationKeySize:]
+	}
+	if _, ok := curveForCurveID(ecdhGroup); !ok {
 		c.sendAlert(alertInternalError)
 		return errors.New("tls: CurvePreferences includes unsupported curve")
 	}
-	params, err := generateECDHEParameters(c.config.rand(), selectedGroup)
+	key, err := generateECDHEKey(c.config.rand(), ecdhGroup)
 	if err != nil {
 		c.sendAlert(alertInternalError)
 		return err
 	}
-	hs.hello.serverShare = keyShare{group: selectedGroup, data: params.PublicKey()}
-	hs.sharedKey = params.SharedKey(clientKeyShare.data)
+	hs.hello.serverShare = keyShare{group: selectedGroup, data: key.PublicKey().Bytes()}
+	peerKey, err := key.Curve().NewPublicKey(ecdhData)
+	if err == nil {
+		hs.sharedKey, _ = key.ECDH(peerKey)
+	}
 	if hs.sharedKey == nil {
 		c.sendAlert(alertIllegalParameter)
 		return errors.New("tls: invalid client key share")
 	}
+	if selectedGroup == X25519MLKEM768 {
+		ciphertext, mlkemSharedSecret, err := mlkem768.Encapsulate(clientKeyShare.data[:mlkem768.EncapsulationKeySize])
+		if err != nil {
+			c.sendAlert(alertIllegalParameter)
+			return errors.New("tls: invalid X25519MLKEM768 client key share")
+		}
+		hs.sharedKey = append(mlkemSharedSecret, hs.sharedKey...)
+		hs.hello.serverShare.data = append(ciphertext, hs.hello.serverShare.data...)
+	}
+
+	if c.quic != nil {
+		// QUIC connections negotiate ALPN before checking for resumption,
+		// as 0-RTT is only accepted for the protocol of the original
+		// connection. Other connections do so in sendServerParameters.
+		selectedProto, err := negotiateALPN(c.config.NextProtos, hs.clientHello.alpnProtocols, true)
+		if err != nil {
+			c.sendAlert(alertNoApplicationProtocol)
+			return err
+		}
+		c.clientProtocol = selectedProto
+
+		if hs.clientHello.quicTransportParameters == nil {
+			// RFC 9001, Section 8.2.
+			c.sendAlert(alertMissingExtension)
+			return errors.New("tls: client did not send a quic_transport_parameters extension")
+		}
+		c.quicSetTransportParameters(hs.clientHello.quicTransportParameters)
+	} else if hs.clientHello.quicTransportParameters != nil {
+		c.sendAlert(alertUnsupportedExtension)
+		return errors.New("tls: client sent an unexpected quic_transport_parameters extension")
+	}
 
 	c.serverName = hs.clientHello.serverName
 	return nil
@@ -268,7 +328,8 @@ func (hs *serverHandshakeStateTLS13) checkForResumption() error {
 
 		// We don't check the obfuscated ticket age because it's affected by
 		// clock skew and it's only a freshness signal useful for shrinking the
-		// window for replay attacks, which don't affect us as we don't do 0-RTT.
+		// window for replay attacks. 0-RTT is only accepted for QUIC
+		// connections, which leave replay protection to the application.
 
 		pskSuite := cipherSuiteTLS13ByID(sessionState.cipherSuite)
 		if pskSuite == nil || pskSuite.hash != hs.suite.hash {
@@ -309,6 +370,19 @@ func (hs *serverHandshakeStateTLS13) checkForResumption() error {
 			return err
 		}
 
+		// Accept 0-RTT only for the first PSK, with the cipher suite and ALPN
+		// protocol of the original connection. See RFC 8446, Section 4.2.10.
+		if i == 0 && hs.clientHello.earlyData && sessionState.earlyData &&
+			sessionState.cipherSuite == hs.suite.id &&
+			sessionState.alpnProtocol == c.clientProtocol {
+			hs.earlyData = true
+
+			transcript := hs.suite.hash.New()
+			transcript.Write(hs.clientHello.marshal())
+			earlyTrafficSecret := hs.suite.deriveSecret(hs.earlySecret, clientEarlyTrafficLabel, transcript)
+			c.quicSetReadSecret(QUICEncryptionLevelEarly, hs.suite.id, earlyTrafficSecret)
+		}
+
 		hs.hello.selectedIdentityPresent = true
 		hs.hello.selectedIdentity = uint16(i)
 		hs.usingPSK = true
@@ -383,6 +457,9 @@ func (hs *serverHandshakeStateTLS13) pickCertificate() error {
 // sendDummyChangeCipherSpec sends a ChangeCipherSpec record for compatibility
 // with middleboxes that didn't implement TLS correctly. See RFC 8446, Appendix D.4.
 func (hs *serverHandshakeStateTLS13) sendDummyChangeCipherSpec() error {
+	if hs.c.quic != nil {
+		return nil
+	}
 	if hs.sentDummyCCS {
 		return nil
 	}
@@ -413,6 +490,21 @@ func (hs *serverHandshakeStateTLS13) doHelloRetryRequest(selectedGroup CurveID)
 		selectedGroup:     selectedGroup,
 	}
 
+	if hs.echContext != nil {
+		// Signal ECH acceptance in the HelloRetryRequest.
+		// See draft-ietf-tls-esni-18, Section 7.2.1.
+		helloRetryRequest.encryptedClientHello = make([]byte, echConfirmationLength)
+		confTranscript := cloneHash(hs.transcript, hs.suite.hash)
+		if confTranscript == nil {
+			c.sendAlert(alertInternalError)
+			return errors.New("tls: internal error: failed to clone hash")
+		}
+		confTranscript.Write(helloRetryRequest.marshal())
+		helloRetryRequest.encryptedClientHello = echAcceptConfirmation(hs.suite,
+			hs.clientHello.random, echHRRAcceptConfirmationLabel, confTranscript)
+		helloRetryRequest.raw = nil
+	}
+
 	hs.transcript.Write(helloRetryRequest.marshal())
 	if _, err := c.writeRecord(recordTypeHandshake, helloRetryRequest.marshal()); err != nil {
 		return err
@@ -433,6 +525,13 @@ func (hs *serverHandshakeStateTLS13) doHelloRetryRequest(selectedGroup CurveID)
 		return unexpectedMessageError(clientHello, msg)
 	}
 
+	if hs.echContext != nil {
+		clientHello, err = c.openSecondClientHello(hs.echContext, clientHello)
+		if err != nil {
+			return err
+		}
+	}
+
 	if len(clientHello.keyShares) != 1 || clientHello.keyShares[0].group != selectedGroup {
 		c.sendAlert(alertIllegalParameter)
 		return errors.New("tls: client sent invalid key share in second ClientHello")
@@ -514,6 +613,23 @@ func (hs *serverHandshakeStateTLS13) sendServerParameters() error {
 	c := hs.c
 
 	hs.transcript.Write(hs.clientHello.marshal())
+
+	if hs.echContext != nil {
+		// Signal ECH acceptance in the last 8 bytes of the ServerHello random.
+		// See draft-ietf-tls-esni-18, Section 7.2.
+		copy(hs.hello.random[32-echConfirmationLength:], make([]byte, echConfirmationLength))
+		hs.hello.raw = nil
+		confTranscript := cloneHash(hs.transcript, hs.suite.hash)
+		if confTranscript == nil {
+			c.sendAlert(alertInternalError)
+			return errors.New("tls: internal error: failed to clone hash")
+		}
+		confTranscript.Write(hs.hello.marshal())
+		copy(hs.hello.random[32-echConfirmationLength:], echAcceptConfirmation(hs.suite,
+			hs.clientHello.random, echAcceptConfirmationLabel, confTranscript))
+		hs.hello.raw = nil
+	}
+
 	hs.transcript.Write(hs.hello.marshal())
 	if _, err := c.writeRecord(recordTypeHandshake, hs.hello.marshal()); err != nil {
 		return err
@@ -532,10 +648,19 @@ func (hs *serverHandshakeStateTLS13) sendServerParameters() error {
 
 	clientSecret := hs.suite.deriveSecret(hs.handshakeSecret,
 		clientHandshakeTrafficLabel, hs.transcript)
-	c.in.setTrafficSecret(hs.suite, clientSecret)
+	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, clientSecret)
 	serverSecret := hs.suite.deriveSecret(hs.handshakeSecret,
 		serverHandshakeTrafficLabel, hs.transcript)
-	c.out.setTrafficSecret(hs.suite, serverSecret)
+	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, serverSecret)
+
+	if c.quic != nil {
+		if c.hand.Len() != 0 {
+			c.sendAlert(alertUnexpectedMessage)
+			return errors.New("tls: handshake data received at the wrong encryption level")
+		}
+		c.quicSetWriteSecret(QUICEncryptionLevelHandshake, hs.suite.id, serverSecret)
+		c.quicSetReadSecret(QUICEncryptionLevelHandshake, hs.suite.id, clientSecret)
+	}
 
 	err := c.config.writeKeyLog(keyLogLabelClientHandshake, hs.clientHello.random, clientSecret)
 	if err != nil {
@@ -550,13 +675,34 @@ func (hs *serverHandshakeStateTLS13) sendServerParameters() error {
 
 	encryptedExtensions := new(encryptedExtensionsMsg)
 
-	selectedProto, err := negotiateALPN(c.config.NextProtos, hs.clientHello.alpnProtocols)
-	if err != nil {
-		c.sendAlert(alertNoApplicationProtocol)
-		return err
+	if c.quic == nil {
+		selectedProto, err := negotiateALPN(c.config.NextProtos, hs.clientHello.alpnProtocols, false)
+		if err != nil {
+			c.sendAlert(alertNoApplicationProtocol)
+			return err
+		}
+		c.clientProtocol = selectedProto
+	}
+	encryptedExtensions.alpnProtocol = c.clientProtocol
+
+	if c.quic != nil {
+		p, err := c.quicGetTransportParameters()
+		if err != nil {
+			return err
+		}
+		encryptedExtensions.quicTransportParameters = p
+		encryptedExtensions.earlyData = hs.earlyData
+	}
+
+	// If the client offered ECH and it was rejected, send the retry configs.
+	// See draft-ietf-tls-esni-18, Section 7.1.
+	if hs.echContext == nil && len(hs.clientHello.encryptedClientHello) != 0 {
+		encryptedExtensions.echRetryConfigs, err = c.config.echRetryConfigList()
+		if err != nil {
+			c.sendAlert(alertInternalError)
+			return err
+		}
 	}
-	encryptedExtensions.alpnProtocol = selectedProto
-	c.clientProtocol = selectedProto
 
 	hs.transcript.Write(encryptedExtensions.marshal())
 	if _, err := c.writeRecord(recordTypeHandshake, encryptedExtensions.marshal()); err != nil {
@@ -661,7 +807,15 @@ func (hs *serverHandshakeStateTLS13) sendServerFinished() error {
 		clientApplicationTrafficLabel, hs.transcript)
 	serverSecret := hs.suite.deriveSecret(hs.masterSecret,
 		serverApplicationTrafficLabel, hs.transcript)
-	c.out.setTrafficSecret(hs.suite, serverSecret)
+	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelApplication, serverSecret)
+
+	if c.quic != nil {
+		if c.hand.Len() != 0 {
+			c.sendAlert(alertUnexpectedMessage)
+			return errors.New("tls: handshake data received at the wrong encryption level")
+		}
+		c.quicSetWriteSecret(QUICEncryptionLevelApplication, hs.suite.id, serverSecret)
+	}
 
 	err := c.config.writeKeyLog(keyLogLabelClientTraffic, hs.clientHello.random, hs.trafficSecret)
 	if err != nil {
@@ -715,9 +869,27 @@ func (hs *serverHandshakeStateTLS13) sendSessionTickets() error {
 		return nil
 	}
 
-	resumptionSecret := hs.suite.deriveSecret(hs.masterSecret,
+	c.resumptionSecret = hs.suite.deriveSecret(hs.masterSecret,
 		resumptionLabel, hs.transcript)
 
+	// QUIC servers send session tickets on request through
+	// QUICConn.SendSessionTicket, after the handshake completes.
+	if c.quic != nil {
+		return nil
+	}
+
+	return c.sendSessionTicket(false)
+}
+
+// sendSessionTicket sends a NewSessionTicket message for c.resumptionSecret.
+// If earlyData is true, the ticket allows the client to send 0-RTT data,
+// which is only supported for QUIC connections.
+func (c *Conn) sendSessionTicket(earlyData bool) error {
+	suite := cipherSuiteTLS13ByID(c.cipherSuite)
+	if suite == nil {
+		return errors.New("tls: internal error: unknown cipher suite")
+	}
+
 	m := new(newSessionTicketMsgTLS13)
 
 	var certsFromClient [][]byte
@@ -725,14 +897,16 @@ func (hs *serverHandshakeStateTLS13) sendSessionTickets() error {
 		certsFromClient = append(certsFromClient, cert.Raw)
 	}
 	state := sessionStateTLS13{
-		cipherSuite:      hs.suite.id,
+		cipherSuite:      suite.id,
 		createdAt:        uint64(c.config.time().Unix()),
-		resumptionSecret: resumptionSecret,
+		resumptionSecret: c.resumptionSecret,
 		certificate: Certificate{
 			Certificate:                 certsFromClient,
 			OCSPStaple:                  c.ocspResponse,
 			SignedCertificateTimestamps: c.scts,
 		},
+		earlyData:    earlyData,
+		alpnProtocol: c.clientProtocol,
 	}
 	var err error
 	m.label, err = c.encryptTicket(state.marshal())
@@ -740,6 +914,10 @@ func (hs *serverHandshakeStateTLS13) sendSessionTickets() error {
 		return err
 	}
 	m.lifetime = uint32(maxSessionTicketLifetime / time.Second)
+	if earlyData {
+		// RFC 9001, Section 4.6.1
+		m.maxEarlyData = 0xffffffff
+	}
 
 	if _, err := c.writeRecord(recordTypeHandshake, m.marshal()); err != nil {
 		return err
@@ -852,7 +1030,7 @@ func (hs *serverHandshakeStateTLS13) readClientFinished() error {
 		return errors.New("tls: invalid client finished hash")
 	}
 
-	c.in.setTrafficSecret(hs.suite, hs.trafficSecret)
+	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelApplication, hs.trafficSecret)
 
 	return nil
 }
@@ -6,7 +6,9 @@ package tls
 
 import (
 	"bufio"
+	"bytes"
 	"crypto/ed25519"
+	"crypto/internal/mlkem768"
 	"crypto/x509"
 	"encoding/hex"
 	"errors"
@@ -428,6 +430,148 @@ func fromHex(s string) []byte {
 	return b
 }
 
+func TestHandshakeMLKEM(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientCurves []CurveID
+		serverCurves []CurveID
+		expectCurve  CurveID
+		expectHRR    bool
+	}{
+		{
+			name:         "Hybrid",
+			clientCurves: []CurveID{X25519MLKEM768, X25519},
+			serverCurves: []CurveID{X25519MLKEM768, X25519},
+			expectCurve:  X25519MLKEM768,
+		},
+		{
+			name:         "HybridOnly",
+			clientCurves: []CurveID{X25519MLKEM768},
+			serverCurves: []CurveID{X25519MLKEM768},
+			expectCurve:  X25519MLKEM768,
+		},
+		{
+			name:         "ServerPrefersX25519",
+			clientCurves: []CurveID{X25519MLKEM768, X25519},
+			serverCurves: []CurveID{X25519, X25519MLKEM768},
+			expectCurve:  X25519,
+		},
+		{
+			name:         "X25519Fallback",
+			clientCurves: []CurveID{X25519MLKEM768, X25519},
+			serverCurves: nil,
+			expectCurve:  X25519,
+		},
+		{
+			name:         "HRRToHybrid",
+			clientCurves: []CurveID{X25519, X25519MLKEM768},
+			serverCurves: []CurveID{X25519MLKEM768},
+			expectCurve:  X25519MLKEM768,
+			expectHRR:    true,
+		},
+		{
+			name:         "HRRFromHybrid",
+			clientCurves: []CurveID{X25519MLKEM768, CurveP256},
+			serverCurves: []CurveID{CurveP256},
+			expectCurve:  CurveP256,
+			expectHRR:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clientConfig := testConfig.Clone()
+			clientConfig.CurvePreferences = test.clientCurves
+			serverConfig := testConfig.Clone()
+			serverConfig.CurvePreferences = test.serverCurves
+			serverState, clientState, err := testHandshake(t, clientConfig, serverConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serverState.Version != VersionTLS13 || clientState.Version != VersionTLS13 {
+				t.Errorf("got version %x, %x; want TLS 1.3", serverState.Version, clientState.Version)
+			}
+			if serverState.testingOnlyCurveID != test.expectCurve {
+				t.Errorf("server selected curve %v, expected %v", serverState.testingOnlyCurveID, test.expectCurve)
+			}
+			if clientState.testingOnlyCurveID != test.expectCurve {
+				t.Errorf("client selected curve %v, expected %v", clientState.testingOnlyCurveID, test.expectCurve)
+			}
+			if serverState.testingOnlyDidHRR != test.expectHRR {
+				t.Errorf("server HRR = %v, expected %v", serverState.testingOnlyDidHRR, test.expectHRR)
+			}
+			if clientState.testingOnlyDidHRR != test.expectHRR {
+				t.Errorf("client HRR = %v, expected %v", clientState.testingOnlyDidHRR, test.expectHRR)
+			}
+		})
+	}
+
+	t.Run("TLS12", func(t *testing.T) {
+		// X25519MLKEM768 must not be negotiated in TLS 1.2.
+		clientConfig := testConfig.Clone()
+		clientConfig.CurvePreferences = []CurveID{X25519MLKEM768, CurveP256}
+		serverConfig := testConfig.Clone()
+		serverConfig.MaxVersion = VersionTLS12
+		serverConfig.CurvePreferences = []CurveID{X25519MLKEM768, CurveP256}
+		serverConfig.CipherSuites = []uint16{TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+		serverState, _, err := testHandshake(t, clientConfig, serverConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if serverState.Version != VersionTLS12 {
+			t.Errorf("got version %x, want TLS 1.2", serverState.Version)
+		}
+	})
+}
+
+func TestClientHelloMLKEMKeyShares(t *testing.T) {
+	config := testConfig.Clone()
+	config.CurvePreferences = []CurveID{X25519MLKEM768, X25519}
+	c := &Conn{config: config, isClient: true}
+	hello, keys, _, err := c.makeClientHello()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.curveID != X25519MLKEM768 || keys.ecdhe == nil || keys.mlkem == nil {
+		t.Fatalf("unexpected key share private keys: %+v", keys)
+	}
+	if len(hello.keyShares) != 2 {
+		t.Fatalf("got %d key shares, want 2", len(hello.keyShares))
+	}
+	hybrid, x25519 := hello.keyShares[0], hello.keyShares[1]
+	if hybrid.group != X25519MLKEM768 || x25519.group != X25519 {
+		t.Fatalf("got key share groups %v, %v", hybrid.group, x25519.group)
+	}
+	if len(hybrid.data) != mlkem768.EncapsulationKeySize+x25519PublicKeySize {
+		t.Errorf("got X25519MLKEM768 key share of length %d", len(hybrid.data))
+	}
+	if !bytes.Equal(hybrid.data[mlkem768.EncapsulationKeySize:], x25519.data) {
+		t.Error("X25519 key share doesn't match the X25519MLKEM768 one")
+	}
+
+	// Without an X25519 fallback, only the hybrid key share is sent.
+	config.CurvePreferences = []CurveID{X25519MLKEM768}
+	hello, _, _, err = c.makeClientHello()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hello.keyShares) != 1 || hello.keyShares[0].group != X25519MLKEM768 {
+		t.Errorf("unexpected key shares: %v", hello.keyShares)
+	}
+
+	// X25519MLKEM768 is not advertised if TLS 1.3 is disabled.
+	config.CurvePreferences = []CurveID{X25519MLKEM768, CurveP256}
+	config.MaxVersion = VersionTLS12
+	hello, _, _, err = c.makeClientHello()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, curve := range hello.supportedCurves {
+		if curve == X25519MLKEM768 {
+			t.Error("X25519MLKEM768 advertised in a TLS 1.2 ClientHello")
+		}
+	}
+}
+
 var testRSACertificate = fromHex("3082024b308201b4a003020102020900e8f09d3fe25beaa6300d06092a864886f70d01010b0500301f310b3009060355040a1302476f3110300e06035504031307476f20526f6f74301e170d3136303130313030303030305a170d3235303130313030303030305a301a310b3009060355040a1302476f310b300906035504031302476f30819f300d06092a864886f70d010101050003818d0030818902818100db467d932e12270648bc062821ab7ec4b6a25dfe1e5245887a3647a5080d92425bc281c0be97799840fb4f6d14fd2b138bc2a52e67d8d4099ed62238b74a0b74732bc234f1d193e596d9747bf3589f6c613cc0b041d4d92b2b2423775b1c3bbd755dce2054cfa163871d1e24c4f31d1a508baab61443ed97a77562f414c852d70203010001a38193308190300e0603551d0f0101ff0404030205a0301d0603551d250416301406082b0601050507030106082b06010505070302300c0603551d130101ff0402300030190603551d0e041204109f91161f43433e49a6de6db680d79f60301b0603551d230414301280104813494d137e1631bba301d5acab6e7b30190603551d1104123010820e6578616d706c652e676f6c616e67300d06092a864886f70d01010b0500038181009d30cc402b5b50a061cbbae55358e1ed8328a9581aa938a495a1ac315a1a84663d43d32dd90bf297dfd320643892243a00bccf9c7db74020015faad3166109a276fd13c3cce10c5ceeb18782f16c04ed73bbb343778d0c1cf10fa1d8408361c94c722b9daedb4606064df4c1b33ec0d1bd42d4dbfe3d1360845c21d33be9fae7")
 
 var testRSACertificateIssuer = fromHex("3082021930820182a003020102020900ca5e4e811a965964300d06092a864886f70d01010b0500301f310b3009060355040a1302476f3110300e06035504031307476f20526f6f74301e170d3136303130313030303030305a170d3235303130313030303030305a301f310b3009060355040a1302476f3110300e06035504031307476f20526f6f7430819f300d06092a864886f70d010101050003818d0030818902818100d667b378bb22f34143b6cd2008236abefaf2852adf3ab05e01329e2c14834f5105df3f3073f99dab5442d45ee5f8f57b0111c8cb682fbb719a86944eebfffef3406206d898b8c1b1887797c9c5006547bb8f00e694b7a063f10839f269f2c34fff7a1f4b21fbcd6bfdfb13ac792d1d11f277b5c5b48600992203059f2a8f8cc50203010001a35d305b300e0603551d0f0101ff040403020204301d0603551d250416301406082b0601050507030106082b06010505070302300f0603551d130101ff040530030101ff30190603551d0e041204104813494d137e1631bba301d5acab6e7b300d06092a864886f70d01010b050003818100c1154b4bab5266221f293766ae4138899bd4c5e36b13cee670ceeaa4cbdf4f6679017e2fe649765af545749fe4249418a56bd38a04b81e261f5ce86b8d5c65413156a50d12449554748c59a30c515bc36a59d38bddf51173e899820b282e40aa78c806526fd184fb6b4cf186ec728edffa585440d2b3225325f7ab580e87dd76")
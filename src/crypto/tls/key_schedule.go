@@ -5,15 +5,14 @@
 package tls
 
 import (
-	"crypto/elliptic"
+	"crypto/ecdh"
 	"crypto/hmac"
+	"crypto/internal/mlkem768"
 	"errors"
 	"hash"
 	"io"
-	"math/big"
 
 	"golang.org/x/crypto/cryptobyte"
-	"golang.org/x/crypto/curve25519"
 	"golang.org/x/crypto/hkdf"
 )
 
@@ -22,6 +21,7 @@ import (
 
 const (
 	resumptionBinderLabel         = "res binder"
+	clientEarlyTrafficLabel       = "c e traffic"
 	clientHandshakeTrafficLabel   = "c hs traffic"
 	serverHandshakeTrafficLabel   = "s hs traffic"
 	clientApplicationTrafficLabel = "c ap traffic"
@@ -101,99 +101,83 @@ func (c *cipherSuiteTLS13) exportKeyingMaterial(masterSecret []byte, transcript
 	}
 }
 
-// ecdheParameters implements Diffie-Hellman with either NIST curves or X25519,
-// according to RFC 8446, Section 4.2.8.2.
-type ecdheParameters interface {
-	CurveID() CurveID
-	PublicKey() []byte
-	SharedKey(peerPublicKey []byte) []byte
+// keySharePrivateKeys holds the ephemeral private keys for the key shares
+// sent by a TLS 1.3 client.
+type keySharePrivateKeys struct {
+	curveID CurveID
+	ecdhe   *ecdh.PrivateKey
+	mlkem   *mlkem768.DecapsulationKey // only set for X25519MLKEM768
 }
 
-func generateECDHEParameters(rand io.Reader, curveID CurveID) (ecdheParameters, error) {
-	if curveID == X25519 {
-		privateKey := make([]byte, curve25519.ScalarSize)
-		if _, err := io.ReadFull(rand, privateKey); err != nil {
-			return nil, err
+const x25519PublicKeySize = 32
+
+// generateKeyShares generates the ephemeral private keys and the key shares
+// for curveID. For X25519MLKEM768 the key share is the ML-KEM-768
+// encapsulation key followed by the X25519 public key, and if x25519Fallback
+// is true an X25519 key share reusing the same X25519 key is also returned.
+// See draft-kwiatkowski-tls-ecdhe-mlkem-02, Section 4.
+func generateKeyShares(rand io.Reader, curveID CurveID, x25519Fallback bool) (*keySharePrivateKeys, []keyShare, error) {
+	if curveID != X25519MLKEM768 {
+		if _, ok := curveForCurveID(curveID); !ok {
+			return nil, nil, errors.New("tls: CurvePreferences includes unsupported curve")
 		}
-		publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+		key, err := generateECDHEKey(rand, curveID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return &x25519Parameters{privateKey: privateKey, publicKey: publicKey}, nil
+		keys := &keySharePrivateKeys{curveID: curveID, ecdhe: key}
+		return keys, []keyShare{{group: curveID, data: key.PublicKey().Bytes()}}, nil
+	}
+
+	key, err := generateECDHEKey(rand, X25519)
+	if err != nil {
+		return nil, nil, err
+	}
+	seed := make([]byte, mlkem768.SeedSize)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, nil, errors.New("tls: short read from Rand: " + err.Error())
+	}
+	dk, err := mlkem768.NewKeyFromSeed(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := &keySharePrivateKeys{curveID: curveID, ecdhe: key, mlkem: dk}
+	x25519Public := key.PublicKey().Bytes()
+	shares := []keyShare{{group: X25519MLKEM768, data: append(dk.EncapsulationKey(), x25519Public...)}}
+	if x25519Fallback {
+		shares = append(shares, keyShare{group: X25519, data: x25519Public})
 	}
+	return keys, shares, nil
+}
+
+// isTLS13OnlyKeyExchange returns whether curveID is a key exchange group that
+// can't be used in TLS 1.2 and earlier.
+func isTLS13OnlyKeyExchange(curveID CurveID) bool {
+	return curveID == X25519MLKEM768
+}
 
+// generateECDHEKey returns a PrivateKey that implements Diffie-Hellman
+// according to RFC 8446, Section 4.2.8.2.
+func generateECDHEKey(rand io.Reader, curveID CurveID) (*ecdh.PrivateKey, error) {
 	curve, ok := curveForCurveID(curveID)
 	if !ok {
 		return nil, errors.New("tls: internal error: unsupported curve")
 	}
 
-	p := &nistParameters{curveID: curveID}
-	var err error
-	p.privateKey, p.x, p.y, err = elliptic.GenerateKey(curve, rand)
-	if err != nil {
-		return nil, err
-	}
-	return p, nil
+	return curve.GenerateKey(rand)
 }
 
-func curveForCurveID(id CurveID) (elliptic.Curve, bool) {
+func curveForCurveID(id CurveID) (ecdh.Curve, bool) {
 	switch id {
+	case X25519:
+		return ecdh.X25519(), true
 	case CurveP256:
-		return elliptic.P256(), true
+		return ecdh.P256(), true
 	case CurveP384:
-		return elliptic.P384(), true
+		return ecdh.P384(), true
 	case CurveP521:
-		return elliptic.P521(), true
+		return ecdh.P521(), true
 	default:
 		return nil, false
 	}
 }
-
-type nistParameters struct {
-	privateKey []byte
-	x, y       *big.Int // public key
-	curveID    CurveID
-}
-
-func (p *nistParameters) CurveID() CurveID {
-	return p.curveID
-}
-
-func (p *nistParameters) PublicKey() []byte {
-	curve, _ := curveForCurveID(p.curveID)
-	return elliptic.Marshal(curve, p.x, p.y)
-}
-
-func (p *nistParameters) SharedKey(peerPublicKey []byte) []byte {
-	curve, _ := curveForCurveID(p.curveID)
-	// Unmarshal also checks whether the given point is on the curve.
-	x, y := elliptic.Unmarshal(curve, peerPublicKey)
-	if x == nil {
-		return nil
-	}
-
-	xShared, _ := curve.ScalarMult(x, y, p.privateKey)
-	sharedKey := make([]byte, (curve.Params().BitSize+7)/8)
-	return xShared.FillBytes(sharedKey)
-}
-
-type x25519Parameters struct {
-	privateKey []byte
-	publicKey  []byte
-}
-
-func (p *x25519Parameters) CurveID() CurveID {
-	return X25519
-}
-
-func (p *x25519Parameters) PublicKey() []byte {
-	return p.publicKey[:]
-}
-
-func (p *x25519Parameters) SharedKey(peerPublicKey []byte) []byte {
-	sharedKey, err := curve25519.X25519(p.privateKey, peerPublicKey)
-	if err != nil {
-		return nil
-	}
-	return sharedKey
-}
@@ -0,0 +1,432 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+type testQUICConn struct {
+	t                 *testing.T
+	conn              *QUICConn
+	readSecret        map[QUICEncryptionLevel]suiteSecret
+	writeSecret       map[QUICEncryptionLevel]suiteSecret
+	gotParams         []byte
+	earlyDataRejected bool
+	complete          bool
+}
+
+func newTestQUICClient(t *testing.T, config *Config) *testQUICConn {
+	q := &testQUICConn{t: t}
+	q.conn = QUICClient(&QUICConfig{
+		TLSConfig: config,
+	})
+	t.Cleanup(func() {
+		q.conn.Close()
+	})
+	return q
+}
+
+func newTestQUICServer(t *testing.T, config *Config) *testQUICConn {
+	q := &testQUICConn{t: t}
+	q.conn = QUICServer(&QUICConfig{
+		TLSConfig: config,
+	})
+	t.Cleanup(func() {
+		q.conn.Close()
+	})
+	return q
+}
+
+type suiteSecret struct {
+	suite  uint16
+	secret []byte
+}
+
+func (q *testQUICConn) setReadSecret(level QUICEncryptionLevel, suite uint16, secret []byte) {
+	if _, ok := q.readSecret[level]; ok {
+		q.t.Errorf("SetReadSecret for level %v called twice", level)
+	}
+	if q.readSecret == nil {
+		q.readSecret = map[QUICEncryptionLevel]suiteSecret{}
+	}
+	q.readSecret[level] = suiteSecret{suite, append([]byte{}, secret...)}
+}
+
+func (q *testQUICConn) setWriteSecret(level QUICEncryptionLevel, suite uint16, secret []byte) {
+	if _, ok := q.writeSecret[level]; ok {
+		q.t.Errorf("SetWriteSecret for level %v called twice", level)
+	}
+	if q.writeSecret == nil {
+		q.writeSecret = map[QUICEncryptionLevel]suiteSecret{}
+	}
+	q.writeSecret[level] = suiteSecret{suite, append([]byte{}, secret...)}
+}
+
+var errTransportParametersRequired = errors.New("transport parameters required")
+
+// runTestQUICConnection exchanges events between cli and srv until both are
+// idle, or until onEvent returns true.
+func runTestQUICConnection(ctx context.Context, cli, srv *testQUICConn, onEvent func(e QUICEvent, src, dst *testQUICConn) bool) error {
+	a, b := cli, srv
+	for _, c := range []*testQUICConn{a, b} {
+		if !c.conn.conn.quic.started {
+			if err := c.conn.Start(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	idleCount := 0
+	for {
+		e := a.conn.NextEvent()
+		if onEvent != nil && onEvent(e, a, b) {
+			return nil
+		}
+		switch e.Kind {
+		case QUICNoEvent:
+			idleCount++
+			if idleCount == 2 {
+				if !a.complete || !b.complete {
+					return errors.New("handshake incomplete")
+				}
+				return nil
+			}
+			a, b = b, a
+		case QUICSetReadSecret:
+			a.setReadSecret(e.Level, e.Suite, e.Data)
+		case QUICSetWriteSecret:
+			a.setWriteSecret(e.Level, e.Suite, e.Data)
+		case QUICWriteData:
+			if err := b.conn.HandleData(e.Level, e.Data); err != nil {
+				return err
+			}
+		case QUICTransportParameters:
+			a.gotParams = append([]byte{}, e.Data...)
+		case QUICTransportParametersRequired:
+			return errTransportParametersRequired
+		case QUICRejectedEarlyData:
+			a.earlyDataRejected = true
+		case QUICHandshakeDone:
+			a.complete = true
+		}
+		if e.Kind != QUICNoEvent {
+			idleCount = 0
+		}
+	}
+}
+
+// checkQUICSecrets checks that the secrets written by src at each level
+// match the secrets read by dst at that level.
+func checkQUICSecrets(t *testing.T, src, dst *testQUICConn, levels ...QUICEncryptionLevel) {
+	t.Helper()
+	for _, level := range levels {
+		w, ok := src.writeSecret[level]
+		if !ok {
+			t.Errorf("no write secret for level %v", level)
+			continue
+		}
+		r, ok := dst.readSecret[level]
+		if !ok {
+			t.Errorf("no read secret for level %v", level)
+			continue
+		}
+		if w.suite != r.suite || !bytes.Equal(w.secret, r.secret) {
+			t.Errorf("level %v: write secret (%x, %x) != read secret (%x, %x)", level, w.suite, w.secret, r.suite, r.secret)
+		}
+	}
+}
+
+func testQUICConfig() *Config {
+	config := testConfig.Clone()
+	config.MinVersion = VersionTLS13
+	config.ServerName = "example.golang"
+	return config
+}
+
+func TestQUICConnection(t *testing.T) {
+	config := testQUICConfig()
+	config.NextProtos = []string{"h3"}
+
+	cli := newTestQUICClient(t, config)
+	cli.conn.SetTransportParameters([]byte("client params"))
+
+	srv := newTestQUICServer(t, config)
+	srv.conn.SetTransportParameters([]byte("server params"))
+
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during connection handshake: %v", err)
+	}
+
+	if _, ok := cli.readSecret[QUICEncryptionLevelEarly]; ok {
+		t.Errorf("client has an Early read secret")
+	}
+	if _, ok := srv.readSecret[QUICEncryptionLevelEarly]; ok {
+		t.Errorf("server has an Early read secret without 0-RTT")
+	}
+	levels := []QUICEncryptionLevel{QUICEncryptionLevelHandshake, QUICEncryptionLevelApplication}
+	checkQUICSecrets(t, cli, srv, levels...)
+	checkQUICSecrets(t, srv, cli, levels...)
+
+	if !bytes.Equal(cli.gotParams, []byte("server params")) {
+		t.Errorf("client got transport params %q, want %q", cli.gotParams, "server params")
+	}
+	if !bytes.Equal(srv.gotParams, []byte("client params")) {
+		t.Errorf("server got transport params %q, want %q", srv.gotParams, "client params")
+	}
+
+	for _, q := range []*testQUICConn{cli, srv} {
+		cs := q.conn.ConnectionState()
+		if !cs.HandshakeComplete || cs.Version != VersionTLS13 || cs.NegotiatedProtocol != "h3" {
+			t.Errorf("ConnectionState = {HandshakeComplete: %v, Version: %x, NegotiatedProtocol: %q}, want {true, %x, %q}",
+				cs.HandshakeComplete, cs.Version, cs.NegotiatedProtocol, VersionTLS13, "h3")
+		}
+	}
+}
+
+func TestQUICEmptyTransportParameters(t *testing.T) {
+	config := testQUICConfig()
+
+	cli := newTestQUICClient(t, config)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, config)
+	srv.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during connection handshake: %v", err)
+	}
+
+	if cli.gotParams == nil || len(cli.gotParams) != 0 {
+		t.Errorf("client got transport params %v, want empty", cli.gotParams)
+	}
+	if srv.gotParams == nil || len(srv.gotParams) != 0 {
+		t.Errorf("server got transport params %v, want empty", srv.gotParams)
+	}
+}
+
+func TestQUICDelayedTransportParameters(t *testing.T) {
+	config := testQUICConfig()
+
+	cliParams := "client params"
+	srvParams := "server params"
+
+	cli := newTestQUICClient(t, config)
+	srv := newTestQUICServer(t, config)
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != errTransportParametersRequired {
+		t.Fatalf("handshake with no client parameters: %v; want errTransportParametersRequired", err)
+	}
+	cli.conn.SetTransportParameters([]byte(cliParams))
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != errTransportParametersRequired {
+		t.Fatalf("handshake with no server parameters: %v; want errTransportParametersRequired", err)
+	}
+	srv.conn.SetTransportParameters([]byte(srvParams))
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during connection handshake: %v", err)
+	}
+
+	if got, want := string(cli.gotParams), srvParams; got != want {
+		t.Errorf("client got transport params: %q, want %q", got, want)
+	}
+	if got, want := string(srv.gotParams), cliParams; got != want {
+		t.Errorf("server got transport params: %q, want %q", got, want)
+	}
+}
+
+func TestQUICSessionResumption(t *testing.T) {
+	clientConfig := testQUICConfig()
+	clientConfig.ClientSessionCache = NewLRUClientSessionCache(1)
+	serverConfig := testQUICConfig()
+
+	cli := newTestQUICClient(t, clientConfig)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, serverConfig)
+	srv.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during first connection handshake: %v", err)
+	}
+	if cli.conn.ConnectionState().DidResume {
+		t.Errorf("first connection unexpectedly used session resumption")
+	}
+	if err := srv.conn.SendSessionTicket(QUICSessionTicketOptions{}); err != nil {
+		t.Fatalf("SendSessionTicket: %v", err)
+	}
+	if err := srv.conn.SendSessionTicket(QUICSessionTicketOptions{}); err == nil {
+		t.Errorf("second SendSessionTicket succeeded, want error")
+	}
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error delivering session ticket: %v", err)
+	}
+
+	cli2 := newTestQUICClient(t, clientConfig)
+	cli2.conn.SetTransportParameters(nil)
+	srv2 := newTestQUICServer(t, serverConfig)
+	srv2.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli2, srv2, nil); err != nil {
+		t.Fatalf("error during second connection handshake: %v", err)
+	}
+	if !cli2.conn.ConnectionState().DidResume {
+		t.Errorf("second connection did not use session resumption")
+	}
+	if cli2.earlyDataRejected {
+		t.Errorf("client reported rejected early data without offering it")
+	}
+	if _, ok := cli2.writeSecret[QUICEncryptionLevelEarly]; ok {
+		t.Errorf("client offered 0-RTT with a ticket that doesn't allow it")
+	}
+}
+
+func TestQUICEarlyData(t *testing.T) {
+	clientConfig := testQUICConfig()
+	clientConfig.ClientSessionCache = NewLRUClientSessionCache(1)
+	clientConfig.NextProtos = []string{"h3"}
+	serverConfig := testQUICConfig()
+	serverConfig.NextProtos = []string{"h3"}
+
+	cli := newTestQUICClient(t, clientConfig)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, serverConfig)
+	srv.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during first connection handshake: %v", err)
+	}
+	if err := srv.conn.SendSessionTicket(QUICSessionTicketOptions{EarlyData: true}); err != nil {
+		t.Fatalf("SendSessionTicket: %v", err)
+	}
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error delivering session ticket: %v", err)
+	}
+
+	cli2 := newTestQUICClient(t, clientConfig)
+	cli2.conn.SetTransportParameters(nil)
+	srv2 := newTestQUICServer(t, serverConfig)
+	srv2.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli2, srv2, nil); err != nil {
+		t.Fatalf("error during second connection handshake: %v", err)
+	}
+	if !cli2.conn.ConnectionState().DidResume {
+		t.Errorf("second connection did not use session resumption")
+	}
+	if cli2.earlyDataRejected {
+		t.Errorf("client reported 0-RTT was rejected, want accepted")
+	}
+	checkQUICSecrets(t, cli2, srv2, QUICEncryptionLevelEarly)
+}
+
+func TestQUICEarlyDataRejectedByHelloRetryRequest(t *testing.T) {
+	clientConfig := testQUICConfig()
+	clientConfig.ClientSessionCache = NewLRUClientSessionCache(1)
+	clientConfig.CurvePreferences = []CurveID{X25519, CurveP256}
+	clientConfig.NextProtos = []string{"h3"}
+	serverConfig := testQUICConfig()
+	serverConfig.NextProtos = []string{"h3"}
+
+	cli := newTestQUICClient(t, clientConfig)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, serverConfig)
+	srv.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during first connection handshake: %v", err)
+	}
+	if err := srv.conn.SendSessionTicket(QUICSessionTicketOptions{EarlyData: true}); err != nil {
+		t.Fatalf("SendSessionTicket: %v", err)
+	}
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error delivering session ticket: %v", err)
+	}
+
+	// Force a HelloRetryRequest, which rejects 0-RTT.
+	serverConfig.CurvePreferences = []CurveID{CurveP256}
+
+	cli2 := newTestQUICClient(t, clientConfig)
+	cli2.conn.SetTransportParameters(nil)
+	srv2 := newTestQUICServer(t, serverConfig)
+	srv2.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli2, srv2, nil); err != nil {
+		t.Fatalf("error during second connection handshake: %v", err)
+	}
+	if _, ok := cli2.writeSecret[QUICEncryptionLevelEarly]; !ok {
+		t.Errorf("client did not offer 0-RTT")
+	}
+	if !cli2.earlyDataRejected {
+		t.Errorf("client did not report 0-RTT was rejected")
+	}
+	if _, ok := srv2.readSecret[QUICEncryptionLevelEarly]; ok {
+		t.Errorf("server accepted 0-RTT after a HelloRetryRequest")
+	}
+}
+
+func TestQUICHandshakeError(t *testing.T) {
+	clientConfig := testQUICConfig()
+	clientConfig.NextProtos = []string{"h3"}
+	serverConfig := testQUICConfig()
+	serverConfig.NextProtos = []string{"other"}
+
+	cli := newTestQUICClient(t, clientConfig)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, serverConfig)
+	srv.conn.SetTransportParameters(nil)
+	err := runTestQUICConnection(context.Background(), cli, srv, nil)
+	var alertErr AlertError
+	if !errors.As(err, &alertErr) || alertErr != AlertError(alertNoApplicationProtocol) {
+		t.Errorf("handshake with mismatched ALPN: %v; want AlertError(%v)", err, alertNoApplicationProtocol)
+	}
+}
+
+func TestQUICRequiresTLS13(t *testing.T) {
+	config := testConfig.Clone()
+	cli := newTestQUICClient(t, config)
+	var alertErr AlertError
+	if err := cli.conn.Start(context.Background()); !errors.As(err, &alertErr) {
+		t.Errorf("Start with MinVersion < TLS 1.3: %v; want AlertError", err)
+	}
+}
+
+func TestQUICRejectsKeyUpdate(t *testing.T) {
+	config := testQUICConfig()
+
+	cli := newTestQUICClient(t, config)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, config)
+	srv.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during connection handshake: %v", err)
+	}
+
+	keyUpdate := &keyUpdateMsg{}
+	err := cli.conn.HandleData(QUICEncryptionLevelApplication, keyUpdate.marshal())
+	var alertErr AlertError
+	if !errors.As(err, &alertErr) || alertErr != AlertError(alertUnexpectedMessage) {
+		t.Errorf("HandleData(KeyUpdate): %v; want AlertError(%v)", err, alertUnexpectedMessage)
+	}
+}
+
+func TestQUICWrongLevel(t *testing.T) {
+	config := testQUICConfig()
+
+	srv := newTestQUICServer(t, config)
+	srv.conn.SetTransportParameters(nil)
+	if err := srv.conn.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	err := srv.conn.HandleData(QUICEncryptionLevelHandshake, []byte{typeClientHello, 0, 0, 0})
+	if err == nil {
+		t.Errorf("HandleData at the wrong level succeeded, want error")
+	}
+}
+
+func TestQUICEncryptionLevelString(t *testing.T) {
+	for level, want := range map[QUICEncryptionLevel]string{
+		QUICEncryptionLevelInitial:     "Initial",
+		QUICEncryptionLevelEarly:       "Early",
+		QUICEncryptionLevelHandshake:   "Handshake",
+		QUICEncryptionLevelApplication: "Application",
+		QUICEncryptionLevel(42):        "QUICEncryptionLevel(42)",
+	} {
+		if got := level.String(); got != want {
+			t.Errorf("QUICEncryptionLevel(%d).String() = %q, want %q", int(level), got, want)
+		}
+	}
+}
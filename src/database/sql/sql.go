@@ -483,11 +483,15 @@ type DB struct {
 	maxLifetime       time.Duration          // maximum amount of time a connection may be reused
 	maxIdleTime       time.Duration          // maximum amount of time a connection may be idle before being closed
 	cleanerCh         chan struct{}
+	validateInterval  time.Duration // how often idle connections are validated; <= 0 means never
+	validatorCh       chan struct{}
 	waitCount         int64 // Total number of connections waited for.
 	maxIdleClosed     int64 // Total number of connections closed due to idle count.
 	maxIdleTimeClosed int64 // Total number of connections closed due to idle time.
 	maxLifetimeClosed int64 // Total number of connections closed due to max connection lifetime limit.
 
+	hooks atomic.Value // of *ConnHooks
+
 	stop func() // stop cancels the connection opener.
 }
 
@@ -519,10 +523,12 @@ type driverConn struct {
 	openStmt    map[*driverStmt]bool
 
 	// guarded by db.mu
-	inUse      bool
-	returnedAt time.Time // Time the connection was created or returned.
-	onPut      []func()  // code (with db.mu held) run when conn is next returned
-	dbmuClosed bool      // same as closed, but guarded by db.mu, for removeClosedStmtLocked
+	inUse       bool
+	reused      bool            // the connection has been returned to the pool at least once
+	returnedAt  time.Time       // Time the connection was created or returned.
+	onPut       []func()        // code (with db.mu held) run when conn is next returned
+	dbmuClosed  bool            // same as closed, but guarded by db.mu, for removeClosedStmtLocked
+	closeReason ConnCloseReason // why the pool is closing the connection
 }
 
 func (dc *driverConn) releaseConn(err error) {
@@ -572,6 +578,21 @@ func (dc *driverConn) validateConnection(needsReset bool) bool {
 	return true
 }
 
+// healthy reports whether an idle connection passes the driver's
+// validity check and, if the driver supports it, a ping.
+func (dc *driverConn) healthy(ctx context.Context) bool {
+	dc.Lock()
+	defer dc.Unlock()
+
+	if cv, ok := dc.ci.(driver.Validator); ok && !cv.IsValid() {
+		return false
+	}
+	if pinger, ok := dc.ci.(driver.Pinger); ok {
+		return pinger.Ping(ctx) == nil
+	}
+	return true
+}
+
 // prepareLocked prepares the query on dc. When cg == nil the dc must keep track of
 // the prepared statements in a pool.
 func (dc *driverConn) prepareLocked(ctx context.Context, cg stmtConnGrabber, query string) (*driverStmt, error) {
@@ -641,18 +662,22 @@ func (dc *driverConn) finalClose() error {
 	for _, ds := range openStmt {
 		ds.Close()
 	}
+	var ci driver.Conn
 	withLock(dc, func() {
 		dc.finalClosed = true
-		err = dc.ci.Close()
+		ci = dc.ci
+		err = ci.Close()
 		dc.ci = nil
 	})
 
 	dc.db.mu.Lock()
+	reason := dc.closeReason
 	dc.db.numOpen--
 	dc.db.maybeOpenNewConnections()
 	dc.db.mu.Unlock()
 
 	atomic.AddUint64(&dc.db.numClosed, 1)
+	dc.db.runOnClose(ci, reason)
 	return err
 }
 
@@ -889,9 +914,13 @@ func (db *DB) Close() error {
 	if db.cleanerCh != nil {
 		close(db.cleanerCh)
 	}
+	if db.validatorCh != nil {
+		close(db.validatorCh)
+	}
 	var err error
 	fns := make([]func() error, 0, len(db.freeConn))
 	for _, dc := range db.freeConn {
+		dc.closeReason = ConnCloseDBClosed
 		fns = append(fns, dc.closeDBLocked())
 	}
 	db.freeConn = nil
@@ -974,6 +1003,9 @@ func (db *DB) SetMaxIdleConns(n int) {
 	if idleCount > maxIdle {
 		closing = db.freeConn[maxIdle:]
 		db.freeConn = db.freeConn[:maxIdle]
+		for _, c := range closing {
+			c.closeReason = ConnCloseMaxIdle
+		}
 	}
 	db.maxIdleClosed += int64(len(closing))
 	db.mu.Unlock()
@@ -1098,6 +1130,7 @@ func (db *DB) connectionCleanerRunLocked() (closing []*driverConn) {
 		for i := 0; i < len(db.freeConn); i++ {
 			c := db.freeConn[i]
 			if c.createdAt.Before(expiredSince) {
+				c.closeReason = ConnCloseMaxLifetime
 				closing = append(closing, c)
 				last := len(db.freeConn) - 1
 				db.freeConn[i] = db.freeConn[last]
@@ -1115,6 +1148,7 @@ func (db *DB) connectionCleanerRunLocked() (closing []*driverConn) {
 		for i := 0; i < len(db.freeConn); i++ {
 			c := db.freeConn[i]
 			if db.maxIdleTime > 0 && c.returnedAt.Before(expiredSince) {
+				c.closeReason = ConnCloseMaxIdleTime
 				closing = append(closing, c)
 				expiredCount++
 				last := len(db.freeConn) - 1
@@ -1129,6 +1163,203 @@ func (db *DB) connectionCleanerRunLocked() (closing []*driverConn) {
 	return
 }
 
+// ConnCloseReason describes why a DB closed one of its connections.
+type ConnCloseReason int
+
+// Reasons reported to ConnHooks.OnClose.
+const (
+	// ConnCloseBad means the connection was reported bad by the driver,
+	// failed the driver.Validator check when returned to the pool,
+	// or was rejected by ConnHooks.BeforeReuse.
+	ConnCloseBad ConnCloseReason = iota
+	// ConnCloseMaxIdle means the idle pool was full; see SetMaxIdleConns.
+	ConnCloseMaxIdle
+	// ConnCloseMaxOpen means more connections were open than allowed
+	// by SetMaxOpenConns.
+	ConnCloseMaxOpen
+	// ConnCloseMaxIdleTime means the connection was idle for longer
+	// than allowed by SetConnMaxIdleTime.
+	ConnCloseMaxIdleTime
+	// ConnCloseMaxLifetime means the connection was older than allowed
+	// by SetConnMaxLifetime.
+	ConnCloseMaxLifetime
+	// ConnCloseInvalid means the connection failed background
+	// validation; see SetConnValidationInterval.
+	ConnCloseInvalid
+	// ConnCloseDBClosed means the DB was closed.
+	ConnCloseDBClosed
+)
+
+// String returns the name of the close reason.
+func (r ConnCloseReason) String() string {
+	switch r {
+	case ConnCloseBad:
+		return "Bad"
+	case ConnCloseMaxIdle:
+		return "MaxIdle"
+	case ConnCloseMaxOpen:
+		return "MaxOpen"
+	case ConnCloseMaxIdleTime:
+		return "MaxIdleTime"
+	case ConnCloseMaxLifetime:
+		return "MaxLifetime"
+	case ConnCloseInvalid:
+		return "Invalid"
+	case ConnCloseDBClosed:
+		return "DBClosed"
+	default:
+		return "ConnCloseReason(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// ConnHooks is a set of functions run at various stages of the
+// lifecycle of a DB's connections. Any particular hook may be nil.
+// Hooks may be called concurrently from different goroutines.
+type ConnHooks struct {
+	// OnOpen is called after the driver opens a new connection and
+	// before the connection is first used. The context is that of the
+	// operation requesting the connection, or a background context
+	// when the connection is opened by the pool on its own.
+	// If OnOpen returns an error, the connection is closed and the
+	// error is returned to the operation waiting for the connection.
+	OnOpen func(ctx context.Context, c driver.Conn) error
+
+	// BeforeReuse is called before a connection that was previously
+	// returned to the pool is handed out again, after its session has
+	// been reset. If BeforeReuse returns an error, the connection is
+	// closed with ConnCloseBad and another connection is tried.
+	BeforeReuse func(ctx context.Context, c driver.Conn) error
+
+	// OnClose is called after the DB has closed a connection. The
+	// connection must not be used; it is passed only to identify it.
+	OnClose func(c driver.Conn, reason ConnCloseReason)
+}
+
+// SetConnHooks sets the hooks to run on the DB's connections. The hooks
+// apply to connections opened, reused or closed after the call returns.
+//
+// If h is nil, no hooks are run.
+func (db *DB) SetConnHooks(h *ConnHooks) {
+	if h != nil {
+		h1 := *h
+		h = &h1
+	}
+	db.hooks.Store(h)
+}
+
+// connHooks returns the current connection hooks, or nil if none are set.
+func (db *DB) connHooks() *ConnHooks {
+	h, _ := db.hooks.Load().(*ConnHooks)
+	return h
+}
+
+// SetConnValidationInterval sets how often idle connections are validated
+// in the background. A connection is valid if it passes the driver.Validator
+// check and, if the driver implements driver.Pinger, a Ping bounded by d.
+// Invalid connections are closed with ConnCloseInvalid.
+//
+// If d <= 0, idle connections are not validated in the background.
+func (db *DB) SetConnValidationInterval(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// Wake validator up when interval is shortened or validation disabled.
+	if d < db.validateInterval && db.validatorCh != nil {
+		select {
+		case db.validatorCh <- struct{}{}:
+		default:
+		}
+	}
+	db.validateInterval = d
+	db.startValidatorLocked()
+}
+
+// startValidatorLocked starts connectionValidator if needed.
+func (db *DB) startValidatorLocked() {
+	if db.validateInterval > 0 && db.numOpen > 0 && db.validatorCh == nil {
+		db.validatorCh = make(chan struct{}, 1)
+		go db.connectionValidator(db.validateInterval)
+	}
+}
+
+func (db *DB) connectionValidator(d time.Duration) {
+	t := time.NewTimer(d)
+
+	for {
+		select {
+		case <-t.C:
+		case <-db.validatorCh: // validateInterval was changed or db was closed.
+		}
+
+		db.mu.Lock()
+		d = db.validateInterval
+		if db.closed || db.numOpen == 0 || d <= 0 {
+			db.validatorCh = nil
+			db.mu.Unlock()
+			return
+		}
+		db.mu.Unlock()
+
+		db.validateIdleConns(d)
+		t.Reset(d)
+	}
+}
+
+// validateIdleConns checks each idle connection in turn, closing those
+// that are no longer healthy. Connections are taken out of the pool one
+// at a time while checked so that concurrent users are not starved.
+func (db *DB) validateIdleConns(timeout time.Duration) {
+	db.mu.Lock()
+	idle := append([]*driverConn(nil), db.freeConn...)
+	db.mu.Unlock()
+
+	for _, dc := range idle {
+		db.mu.Lock()
+		if !db.takeFreeConnLocked(dc) {
+			// Handed out or closed since the snapshot.
+			db.mu.Unlock()
+			continue
+		}
+		dc.inUse = true
+		db.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ok := dc.healthy(ctx)
+		cancel()
+
+		db.mu.Lock()
+		dc.inUse = false
+		if !ok {
+			dc.closeReason = ConnCloseInvalid
+			db.mu.Unlock()
+			dc.Close()
+			continue
+		}
+		added := db.putConnDBLocked(dc, nil)
+		db.mu.Unlock()
+		if !added {
+			dc.Close()
+		}
+	}
+}
+
+// takeFreeConnLocked removes dc from the idle pool and reports whether
+// it was there.
+func (db *DB) takeFreeConnLocked(dc *driverConn) bool {
+	for i, c := range db.freeConn {
+		if c == dc {
+			copy(db.freeConn[i:], db.freeConn[i+1:])
+			db.freeConn[len(db.freeConn)-1] = nil
+			db.freeConn = db.freeConn[:len(db.freeConn)-1]
+			return true
+		}
+	}
+	return false
+}
+
 // DBStats contains database statistics.
 type DBStats struct {
 	MaxOpenConnections int // Maximum number of open connections to the database.
@@ -1207,20 +1438,22 @@ func (db *DB) openNewConnection(ctx context.Context) {
 	// maybeOpenNewConnections has already executed db.numOpen++ before it sent
 	// on db.openerCh. This function must execute db.numOpen-- if the
 	// connection fails or is closed before returning.
-	ci, err := db.connector.Connect(ctx)
+	ci, err := db.connect(ctx)
 	db.mu.Lock()
-	defer db.mu.Unlock()
 	if db.closed {
+		db.numOpen--
+		db.mu.Unlock()
 		if err == nil {
 			ci.Close()
+			db.runOnClose(ci, ConnCloseDBClosed)
 		}
-		db.numOpen--
 		return
 	}
 	if err != nil {
 		db.numOpen--
 		db.putConnDBLocked(nil, err)
 		db.maybeOpenNewConnections()
+		db.mu.Unlock()
 		return
 	}
 	dc := &driverConn{
@@ -1231,9 +1464,56 @@ func (db *DB) openNewConnection(ctx context.Context) {
 	}
 	if db.putConnDBLocked(dc, err) {
 		db.addDepLocked(dc, dc)
-	} else {
-		db.numOpen--
-		ci.Close()
+		db.mu.Unlock()
+		return
+	}
+	db.numOpen--
+	db.mu.Unlock()
+	ci.Close()
+	db.runOnClose(ci, dc.closeReason)
+}
+
+// connect opens a new driver connection and runs the OnOpen hook, if any.
+// If the hook fails, the connection is closed and the hook's error returned.
+func (db *DB) connect(ctx context.Context) (driver.Conn, error) {
+	ci, err := db.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if h := db.connHooks(); h != nil && h.OnOpen != nil {
+		if err := h.OnOpen(ctx, ci); err != nil {
+			ci.Close()
+			return nil, err
+		}
+	}
+	return ci, nil
+}
+
+// beforeReuse runs the BeforeReuse hook, if any, on a connection
+// previously returned to the pool. If the hook rejects the connection,
+// it is closed and driver.ErrBadConn is returned so that the caller
+// retries with another connection.
+func (db *DB) beforeReuse(ctx context.Context, dc *driverConn) error {
+	h := db.connHooks()
+	if h == nil || h.BeforeReuse == nil {
+		return nil
+	}
+	var err error
+	withLock(dc, func() {
+		err = h.BeforeReuse(ctx, dc.ci)
+	})
+	if err != nil {
+		dc.Close()
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// runOnClose runs the OnClose hook, if any, for a connection the pool
+// has closed.
+func (db *DB) runOnClose(ci driver.Conn, reason ConnCloseReason) {
+	if h := db.connHooks(); h != nil && h.OnClose != nil {
+		h.OnClose(ci, reason)
 	}
 }
 
@@ -1257,6 +1537,9 @@ func (db *DB) nextRequestKeyLocked() uint64 {
 
 // conn returns a newly-opened or cached *driverConn.
 func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn, error) {
+	trace := ContextQueryTrace(ctx)
+	trace.getConn()
+
 	db.mu.Lock()
 	if db.closed {
 		db.mu.Unlock()
@@ -1280,10 +1563,16 @@ func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn
 		conn.inUse = true
 		if conn.expired(lifetime) {
 			db.maxLifetimeClosed++
+			conn.closeReason = ConnCloseMaxLifetime
 			db.mu.Unlock()
 			conn.Close()
 			return nil, driver.ErrBadConn
 		}
+		info := GotConnInfo{
+			Reused:   conn.reused,
+			WasIdle:  true,
+			IdleTime: nowFunc().Sub(conn.returnedAt),
+		}
 		db.mu.Unlock()
 
 		// Reset the session if required.
@@ -1291,7 +1580,11 @@ func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn
 			conn.Close()
 			return nil, driver.ErrBadConn
 		}
+		if err := db.beforeReuse(ctx, conn); err != nil {
+			return nil, err
+		}
 
+		trace.gotConn(info)
 		return conn, nil
 	}
 
@@ -1328,7 +1621,8 @@ func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn
 			}
 			return nil, ctx.Err()
 		case ret, ok := <-req:
-			atomic.AddInt64(&db.waitDuration, int64(time.Since(waitStart)))
+			wait := time.Since(waitStart)
+			atomic.AddInt64(&db.waitDuration, int64(wait))
 
 			if !ok {
 				return nil, errDBClosed
@@ -1342,6 +1636,7 @@ func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn
 			if strategy == cachedOrNewConn && ret.err == nil && ret.conn.expired(lifetime) {
 				db.mu.Lock()
 				db.maxLifetimeClosed++
+				ret.conn.closeReason = ConnCloseMaxLifetime
 				db.mu.Unlock()
 				ret.conn.Close()
 				return nil, driver.ErrBadConn
@@ -1355,13 +1650,21 @@ func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn
 				ret.conn.Close()
 				return nil, driver.ErrBadConn
 			}
+			// The reused field was set before the connection was sent on req.
+			reused := ret.conn.reused
+			if reused {
+				if err := db.beforeReuse(ctx, ret.conn); err != nil {
+					return nil, err
+				}
+			}
+			trace.gotConn(GotConnInfo{Reused: reused, WaitTime: wait})
 			return ret.conn, ret.err
 		}
 	}
 
 	db.numOpen++ // optimistically
 	db.mu.Unlock()
-	ci, err := db.connector.Connect(ctx)
+	ci, err := db.connect(ctx)
 	if err != nil {
 		db.mu.Lock()
 		db.numOpen-- // correct for earlier optimism
@@ -1379,6 +1682,7 @@ func (db *DB) conn(ctx context.Context, strategy connReuseStrategy) (*driverConn
 	}
 	db.addDepLocked(dc, dc)
 	db.mu.Unlock()
+	trace.gotConn(GotConnInfo{})
 	return dc, nil
 }
 
@@ -1428,12 +1732,14 @@ func (db *DB) putConn(dc *driverConn, err error, resetSession bool) {
 
 	if err != driver.ErrBadConn && dc.expired(db.maxLifetime) {
 		db.maxLifetimeClosed++
+		dc.closeReason = ConnCloseMaxLifetime
 		err = driver.ErrBadConn
 	}
 	if debugGetPut {
 		db.lastPut[dc] = stack()
 	}
 	dc.inUse = false
+	dc.reused = true
 	dc.returnedAt = nowFunc()
 
 	for _, fn := range dc.onPut {
@@ -1474,9 +1780,15 @@ func (db *DB) putConn(dc *driverConn, err error, resetSession bool) {
 // freeConn list, then true is returned, otherwise false is returned.
 func (db *DB) putConnDBLocked(dc *driverConn, err error) bool {
 	if db.closed {
+		if err == nil {
+			dc.closeReason = ConnCloseDBClosed
+		}
 		return false
 	}
 	if db.maxOpen > 0 && db.numOpen > db.maxOpen {
+		if err == nil {
+			dc.closeReason = ConnCloseMaxOpen
+		}
 		return false
 	}
 	if c := len(db.connRequests); c > 0 {
@@ -1498,9 +1810,11 @@ func (db *DB) putConnDBLocked(dc *driverConn, err error) bool {
 		if db.maxIdleConnsLocked() > len(db.freeConn) {
 			db.freeConn = append(db.freeConn, dc)
 			db.startCleanerLocked()
+			db.startValidatorLocked()
 			return true
 		}
 		db.maxIdleClosed++
+		dc.closeReason = ConnCloseMaxIdle
 	}
 	return false
 }
@@ -1630,6 +1944,11 @@ func (db *DB) execDC(ctx context.Context, dc *driverConn, release func(error), q
 	defer func() {
 		release(err)
 	}()
+	trace := ContextQueryTrace(ctx)
+	trace.queryStart(query, args)
+	defer func() {
+		trace.queryDone(err)
+	}()
 	execerCtx, ok := dc.ci.(driver.ExecerContext)
 	var execer driver.Execer
 	if !ok {
@@ -1705,6 +2024,8 @@ func (db *DB) query(ctx context.Context, query string, args []interface{}, strat
 // The ctx context is from a query method and the txctx context is from an
 // optional transaction context.
 func (db *DB) queryDC(ctx, txctx context.Context, dc *driverConn, releaseConn func(error), query string, args []interface{}) (*Rows, error) {
+	trace := ContextQueryTrace(ctx)
+	trace.queryStart(query, args)
 	queryerCtx, ok := dc.ci.(driver.QueryerContext)
 	var queryer driver.Queryer
 	if !ok {
@@ -1722,6 +2043,7 @@ func (db *DB) queryDC(ctx, txctx context.Context, dc *driverConn, releaseConn fu
 			rowsi, err = ctxDriverQuery(ctx, queryerCtx, queryer, query, nvdargs)
 		})
 		if err != driver.ErrSkip {
+			trace.queryDone(err)
 			if err != nil {
 				releaseConn(err)
 				return nil, err
@@ -1744,12 +2066,14 @@ func (db *DB) queryDC(ctx, txctx context.Context, dc *driverConn, releaseConn fu
 		si, err = ctxDriverPrepare(ctx, dc.ci, query)
 	})
 	if err != nil {
+		trace.queryDone(err)
 		releaseConn(err)
 		return nil, err
 	}
 
 	ds := &driverStmt{Locker: dc, si: si}
 	rowsi, err := rowsiFromStatement(ctx, dc.ci, ds, args...)
+	trace.queryDone(err)
 	if err != nil {
 		ds.Close()
 		releaseConn(err)
@@ -2592,7 +2916,10 @@ func (s *Stmt) ExecContext(ctx context.Context, args ...interface{}) (Result, er
 			return nil, err
 		}
 
+		trace := ContextQueryTrace(ctx)
+		trace.queryStart(s.query, args)
 		res, err = resultFromStatement(ctx, dc.ci, ds, args...)
+		trace.queryDone(err)
 		releaseConn(err)
 		if err != driver.ErrBadConn {
 			return res, err
@@ -2740,7 +3067,10 @@ func (s *Stmt) QueryContext(ctx context.Context, args ...interface{}) (*Rows, er
 			return nil, err
 		}
 
+		trace := ContextQueryTrace(ctx)
+		trace.queryStart(s.query, args)
 		rowsi, err = rowsiFromStatement(ctx, dc.ci, ds, args...)
+		trace.queryDone(err)
 		if err == nil {
 			// Note: ownership of ci passes to the *Rows, to be freed
 			// with releaseConn.
@@ -2891,6 +3221,10 @@ type Rows struct {
 	// lastcols is only used in Scan, Next, and NextResultSet which are expected
 	// not to be called concurrently.
 	lastcols []driver.Value
+
+	// structPlan caches the column to field mapping used by the last call
+	// to ScanStruct. Like lastcols, it is reset by NextResultSet.
+	structPlan *structScanPlan
 }
 
 // lasterrOrErrLocked returns either lasterr or the provided err.
@@ -3008,6 +3342,7 @@ func (rs *Rows) NextResultSet() bool {
 	}
 
 	rs.lastcols = nil
+	rs.structPlan = nil
 	nextResultSet, ok := rs.rowsi.(driver.RowsNextResultSet)
 	if !ok {
 		doClose = true
@@ -3223,30 +3558,92 @@ func rowsColumnInfoSetupConnLocked(rowsi driver.Rows) []*ColumnType {
 // If any of the first arguments implementing Scanner returns an error,
 // that error will be wrapped in the returned error
 func (rs *Rows) Scan(dest ...interface{}) error {
+	if i, err := rs.scan(dest); err != nil {
+		if i < 0 {
+			return err
+		}
+		return fmt.Errorf(`sql: Scan error on column index %d, name %q: %w`, i, rs.rowsi.Columns()[i], err)
+	}
+	return nil
+}
+
+// scan implements Scan. If converting a column fails, scan returns the
+// index of the column and the unwrapped error; otherwise the index is -1.
+func (rs *Rows) scan(dest []interface{}) (int, error) {
 	rs.closemu.RLock()
 
 	if rs.lasterr != nil && rs.lasterr != io.EOF {
 		rs.closemu.RUnlock()
-		return rs.lasterr
+		return -1, rs.lasterr
 	}
 	if rs.closed {
 		err := rs.lasterrOrErrLocked(errRowsClosed)
 		rs.closemu.RUnlock()
-		return err
+		return -1, err
 	}
 	rs.closemu.RUnlock()
 
 	if rs.lastcols == nil {
-		return errors.New("sql: Scan called without calling Next")
+		return -1, errors.New("sql: Scan called without calling Next")
 	}
 	if len(dest) != len(rs.lastcols) {
-		return fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", len(rs.lastcols), len(dest))
+		return -1, fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", len(rs.lastcols), len(dest))
 	}
 	for i, sv := range rs.lastcols {
 		err := convertAssignRows(dest[i], sv, rs)
 		if err != nil {
-			return fmt.Errorf(`sql: Scan error on column index %d, name %q: %w`, i, rs.rowsi.Columns()[i], err)
+			return i, err
+		}
+	}
+	return -1, nil
+}
+
+// ScanStruct copies the columns in the current row into the fields of the
+// struct pointed at by dest, matching columns to fields by name.
+//
+// A column matches the field whose "sql" struct tag, or whose name if the
+// field has no tag, equals the column name, ignoring case. Unexported fields
+// and fields tagged `sql:"-"` are ignored. The fields of an embedded struct,
+// or of an embedded pointer to a struct, are matched as if they were fields
+// of the outer struct, unless shadowed by a field at a shallower depth. A nil
+// embedded pointer is allocated when a column matches one of its fields.
+// An embedded struct that implements Scanner is not expanded; it is a single
+// field named after its type, like any other field.
+// Every column must match exactly one field; fields that match no column are
+// left unchanged. Columns are matched by name only; two columns with the same
+// name, as from a join, must be renamed in the query.
+//
+// Each field is assigned as described for Scan, so a field that may receive
+// NULL should be a pointer or implement Scanner, such as NullString. If a
+// value cannot be converted, the error names the field and the column type
+// information reported by the driver (see ColumnType).
+func (rs *Rows) ScanStruct(dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sql: ScanStruct requires a non-nil pointer to a struct, not %T", dest)
+	}
+	sv := dv.Elem()
+	plan := rs.structPlan
+	if plan == nil || plan.typ != sv.Type() {
+		cols, err := rs.ColumnTypes()
+		if err != nil {
+			return err
+		}
+		plan, err = newStructScanPlan(sv.Type(), cols)
+		if err != nil {
+			return err
+		}
+		rs.structPlan = plan
+	}
+	args, err := plan.dest(sv)
+	if err != nil {
+		return err
+	}
+	if i, err := rs.scan(args); err != nil {
+		if i < 0 {
+			return err
 		}
+		return plan.columnError(i, err)
 	}
 	return nil
 }
@@ -3344,6 +3741,41 @@ func (r *Row) Scan(dest ...interface{}) error {
 	return r.rows.Close()
 }
 
+// ScanStruct copies the columns from the matched row into the fields of
+// the struct pointed at by dest. See the documentation on Rows.ScanStruct
+// for details. If more than one row matches the query, ScanStruct uses the
+// first row and discards the rest. If no row matches the query, ScanStruct
+// returns ErrNoRows.
+func (r *Row) ScanStruct(dest interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	// As in Scan, the Rows are closed before returning, so no field
+	// may refer to memory owned by the driver.
+	defer r.rows.Close()
+	if t := reflect.TypeOf(dest); t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+		for _, f := range cachedStructFields(t.Elem()) {
+			if t.Elem().FieldByIndex(f.index).Type == rawBytesType {
+				return errors.New("sql: RawBytes isn't allowed on Row.ScanStruct")
+			}
+		}
+	}
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return ErrNoRows
+	}
+	err := r.rows.ScanStruct(dest)
+	if err != nil {
+		return err
+	}
+	// Make sure the query can be processed to completion with no errors.
+	return r.rows.Close()
+}
+
 // Err provides a way for wrapping packages to check for
 // query errors without calling Scan.
 // Err returns the error, if any, that was encountered while running the query.
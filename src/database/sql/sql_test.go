@@ -3951,6 +3951,185 @@ func TestMaxIdleTime(t *testing.T) {
 	}
 }
 
+// connHookRecorder records the calls made to a ConnHooks.
+type connHookRecorder struct {
+	mu          sync.Mutex
+	opens       int
+	reuses      int
+	closes      []ConnCloseReason
+	openErr     error
+	rejectReuse bool
+}
+
+func (r *connHookRecorder) hooks() *ConnHooks {
+	return &ConnHooks{
+		OnOpen: func(ctx context.Context, c driver.Conn) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.opens++
+			return r.openErr
+		},
+		BeforeReuse: func(ctx context.Context, c driver.Conn) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.reuses++
+			if r.rejectReuse {
+				r.rejectReuse = false
+				return errors.New("rejected")
+			}
+			return nil
+		},
+		OnClose: func(c driver.Conn, reason ConnCloseReason) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.closes = append(r.closes, reason)
+		},
+	}
+}
+
+func (r *connHookRecorder) check(t *testing.T, opens, reuses int, closes ...ConnCloseReason) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.opens != opens {
+		t.Errorf("OnOpen called %d times; want %d", r.opens, opens)
+	}
+	if r.reuses != reuses {
+		t.Errorf("BeforeReuse called %d times; want %d", r.reuses, reuses)
+	}
+	if !reflect.DeepEqual(r.closes, closes) {
+		t.Errorf("OnClose reasons = %v; want %v", r.closes, closes)
+	}
+}
+
+func TestConnHooks(t *testing.T) {
+	db := newTestDB(t, "people")
+	db.SetMaxIdleConns(1)
+	if n := db.Stats().Idle; n != 1 {
+		t.Fatalf("idle conns = %d; want 1", n)
+	}
+
+	r := &connHookRecorder{rejectReuse: true}
+	db.SetConnHooks(r.hooks())
+
+	// The idle conn is rejected by BeforeReuse and a new one is opened.
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	r.check(t, 1, 1, ConnCloseBad)
+
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	r.check(t, 1, 2, ConnCloseBad)
+
+	// Holding two conns means one exceeds the idle limit on return.
+	c1, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1.Close()
+	c2.Close()
+	r.check(t, 2, 3, ConnCloseBad, ConnCloseMaxIdle)
+
+	closeDB(t, db)
+	r.check(t, 2, 3, ConnCloseBad, ConnCloseMaxIdle, ConnCloseDBClosed)
+}
+
+func TestConnHooksOpenError(t *testing.T) {
+	db, err := Open("test", fakeDBName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeDB(t, db)
+
+	errOpen := errors.New("open rejected")
+	r := &connHookRecorder{openErr: errOpen}
+	db.SetConnHooks(r.hooks())
+	if err := db.Ping(); err != errOpen {
+		t.Fatalf("Ping = %v; want %v", err, errOpen)
+	}
+	if n := db.Stats().OpenConnections; n != 0 {
+		t.Errorf("open conns = %d; want 0", n)
+	}
+
+	db.SetConnHooks(nil)
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	r.check(t, 1, 0)
+}
+
+func TestConnValidation(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	r := &connHookRecorder{}
+	db.SetConnHooks(r.hooks())
+
+	ctx := context.Background()
+	conns := make([]*Conn, 2)
+	for i := range conns {
+		c, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns[i] = c
+	}
+	var bad *fakeConn
+	err := conns[0].Raw(func(raw interface{}) error {
+		bad = raw.(*fakeConn)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range conns {
+		c.Close()
+	}
+	if n := db.Stats().Idle; n != 2 {
+		t.Fatalf("idle conns = %d; want 2", n)
+	}
+	// Mark the conn bad only once it is idle, so that it is not
+	// already discarded by IsValid on return to the pool.
+	bad.stickyBad = true
+
+	db.validateIdleConns(time.Second)
+
+	r.check(t, 1, 1, ConnCloseInvalid)
+	st := db.Stats()
+	if st.Idle != 1 || st.OpenConnections != 1 {
+		t.Errorf("idle, open conns = %d, %d; want 1, 1", st.Idle, st.OpenConnections)
+	}
+}
+
+func TestConnValidationInterval(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	db.SetConnValidationInterval(time.Hour)
+	db.mu.Lock()
+	started := db.validatorCh != nil
+	db.mu.Unlock()
+	if !started {
+		t.Fatal("validator not started")
+	}
+
+	db.SetConnValidationInterval(0)
+	stopped := waitCondition(5*time.Second, 5*time.Millisecond, func() bool {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		return db.validatorCh == nil
+	})
+	if !stopped {
+		t.Fatal("validator not stopped")
+	}
+}
+
 type nvcDriver struct {
 	fakeDriver
 	skipNamedValueCheck bool
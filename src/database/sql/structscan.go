@@ -0,0 +1,178 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Column to struct field mapping for ScanStruct.
+
+package sql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	rawBytesType = reflect.TypeOf(RawBytes(nil))
+	scannerType  = reflect.TypeOf((*Scanner)(nil)).Elem()
+)
+
+// structField describes a struct field that a column may be scanned into.
+type structField struct {
+	index     []int  // index sequence for reflect.Value.FieldByIndex
+	name      string // Go name of the field, qualified by any embedded structs
+	ambiguous bool   // more than one field at the same depth has this name
+}
+
+var structFieldCache sync.Map // map[reflect.Type]map[string]structField
+
+// cachedStructFields is like typeStructFields but uses a cache to avoid
+// repeated work.
+func cachedStructFields(t reflect.Type) map[string]structField {
+	if f, ok := structFieldCache.Load(t); ok {
+		return f.(map[string]structField)
+	}
+	f, _ := structFieldCache.LoadOrStore(t, typeStructFields(t))
+	return f.(map[string]structField)
+}
+
+// typeStructFields returns the fields of the struct type t that columns
+// may be scanned into, keyed by lower-cased column name.
+func typeStructFields(t reflect.Type) map[string]structField {
+	fields := make(map[string]structField)
+
+	// visiting holds the embedded struct types being walked, so that a
+	// struct embedding a pointer to itself does not recurse forever.
+	visiting := map[reflect.Type]bool{t: true}
+	var walk func(t reflect.Type, index []int, prefix string)
+	walk = func(t reflect.Type, index []int, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("sql")
+			if tag == "-" {
+				continue
+			}
+			fi := make([]int, len(index)+1)
+			copy(fi, index)
+			fi[len(index)] = i
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if sf.Anonymous && tag == "" && ft.Kind() == reflect.Struct && !reflect.PtrTo(ft).Implements(scannerType) {
+				// Promote the fields of embedded structs and
+				// struct pointers, even unexported ones, as
+				// encoding/json does. An embedded Scanner is
+				// scanned as a single column instead.
+				if !visiting[ft] {
+					visiting[ft] = true
+					walk(ft, fi, prefix+sf.Name+".")
+					delete(visiting, ft)
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			name := tag
+			if name == "" {
+				name = sf.Name
+			}
+			key := strings.ToLower(name)
+			if old, ok := fields[key]; ok {
+				if len(old.index) < len(fi) {
+					continue
+				}
+				if len(old.index) == len(fi) {
+					old.ambiguous = true
+					fields[key] = old
+					continue
+				}
+			}
+			fields[key] = structField{index: fi, name: prefix + sf.Name}
+		}
+	}
+	walk(t, nil, "")
+	return fields
+}
+
+// structScanPlan maps the columns of a result set to the fields of a
+// struct type.
+type structScanPlan struct {
+	typ    reflect.Type
+	cols   []*ColumnType
+	fields []structField // field for each column, in column order
+}
+
+// newStructScanPlan matches each of cols to a field of the struct type t,
+// reporting columns that match no field, or more than one.
+func newStructScanPlan(t reflect.Type, cols []*ColumnType) (*structScanPlan, error) {
+	fields := cachedStructFields(t)
+	plan := &structScanPlan{
+		typ:    t,
+		cols:   cols,
+		fields: make([]structField, len(cols)),
+	}
+	used := make(map[string]int, len(cols))
+	for i, col := range cols {
+		key := strings.ToLower(col.Name())
+		f, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("sql: ScanStruct: no field of %v matches column index %d, name %q", t, i, col.Name())
+		}
+		if f.ambiguous {
+			return nil, fmt.Errorf("sql: ScanStruct: column index %d, name %q matches more than one field of %v", i, col.Name(), t)
+		}
+		if j, dup := used[key]; dup {
+			return nil, fmt.Errorf("sql: ScanStruct: column indexes %d and %d both match field %s of %v", j, i, f.name, t)
+		}
+		used[key] = i
+		plan.fields[i] = f
+	}
+	return plan, nil
+}
+
+// dest returns pointers to the fields of sv, in column order. Nil pointers
+// to embedded structs on the way to a field are allocated.
+func (plan *structScanPlan) dest(sv reflect.Value) ([]interface{}, error) {
+	args := make([]interface{}, len(plan.fields))
+	for i, f := range plan.fields {
+		v := sv
+		for j, x := range f.index {
+			if j > 0 && v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if !v.CanSet() {
+						return nil, fmt.Errorf("sql: ScanStruct: cannot set embedded pointer to unexported struct %v for field %s of %v", v.Type().Elem(), f.name, plan.typ)
+					}
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+			v = v.Field(x)
+		}
+		args[i] = v.Addr().Interface()
+	}
+	return args, nil
+}
+
+// columnError describes the failure to convert column i into its field,
+// including what the driver reports about the column type.
+func (plan *structScanPlan) columnError(i int, err error) error {
+	col, f := plan.cols[i], plan.fields[i]
+	var info []string
+	if name := col.DatabaseTypeName(); name != "" {
+		info = append(info, fmt.Sprintf("database type %s", name))
+	}
+	if t := col.ScanType(); t != nil && t.Kind() != reflect.Interface {
+		info = append(info, fmt.Sprintf("scan type %v", t))
+	}
+	if nullable, ok := col.Nullable(); ok && nullable {
+		info = append(info, "nullable")
+	}
+	desc := ""
+	if len(info) > 0 {
+		desc = " (" + strings.Join(info, ", ") + ")"
+	}
+	return fmt.Errorf("sql: ScanStruct error on column index %d, name %q%s into field %s of %v: %w", i, col.Name(), desc, f.name, plan.typ, err)
+}
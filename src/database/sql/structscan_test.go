@@ -0,0 +1,271 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type personBase struct {
+	Name string
+}
+
+type person struct {
+	personBase
+	Years    int        `sql:"age"`
+	Birthday *time.Time `sql:"bdate"`
+	Note     string     `sql:"-"`
+	Photo    []byte
+}
+
+func TestRowsScanStruct(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	rows, err := db.Query("SELECT|people|age,name,bdate|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var got []person
+	for rows.Next() {
+		p := person{Note: "keep"}
+		if err := rows.ScanStruct(&p); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, p)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d rows; want 3", len(got))
+	}
+	for i, want := range []struct {
+		name string
+		age  int
+	}{{"Alice", 1}, {"Bob", 2}, {"Chris", 3}} {
+		p := got[i]
+		if p.Name != want.name || p.Years != want.age || p.Note != "keep" {
+			t.Errorf("row %d = %+v; want name %q, age %d", i, p, want.name, want.age)
+		}
+	}
+	if got[0].Birthday != nil {
+		t.Errorf("Alice birthday = %v; want nil", got[0].Birthday)
+	}
+	if b := got[2].Birthday; b == nil || !b.Equal(chrisBirthday) {
+		t.Errorf("Chris birthday = %v; want %v", b, chrisBirthday)
+	}
+}
+
+func TestRowScanStruct(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	var p person
+	err := db.QueryRow("SELECT|people|age,name|age=?", 2).ScanStruct(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Bob" || p.Years != 2 {
+		t.Errorf("got %+v; want Bob, 2", p)
+	}
+
+	err = db.QueryRow("SELECT|people|age,name|age=?", 99).ScanStruct(&p)
+	if err != ErrNoRows {
+		t.Errorf("err = %v; want ErrNoRows", err)
+	}
+
+	var raw struct {
+		Name RawBytes
+	}
+	err = db.QueryRow("SELECT|people|name|age=?", 2).ScanStruct(&raw)
+	if err == nil || !strings.Contains(err.Error(), "RawBytes isn't allowed") {
+		t.Errorf("err = %v; want RawBytes error", err)
+	}
+}
+
+// PersonName is exported so that a nil *PersonName embedded in a struct
+// can be allocated by ScanStruct.
+type PersonName struct {
+	Name string
+}
+
+func TestScanStructEmbeddedPointer(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	var p struct {
+		*PersonName
+		Age int
+	}
+	err := db.QueryRow("SELECT|people|age,name|age=?", 2).ScanStruct(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.PersonName == nil || p.Name != "Bob" || p.Age != 2 {
+		t.Errorf("got %+v; want Bob, 2", p)
+	}
+
+	// A non-nil embedded pointer is scanned into in place, even if its
+	// type is unexported.
+	base := &personBase{Name: "old"}
+	q := struct {
+		*personBase
+		Age int
+	}{personBase: base}
+	err = db.QueryRow("SELECT|people|age,name|age=?", 3).ScanStruct(&q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.personBase != base || base.Name != "Chris" || q.Age != 3 {
+		t.Errorf("got %+v, name %q; want Chris, 3 in the original struct", q, base.Name)
+	}
+
+	// A struct embedding a pointer to itself only promotes its own fields.
+	type node struct {
+		*node
+		Name string
+	}
+	var n node
+	if err := db.QueryRow("SELECT|people|name|age=?", 1).ScanStruct(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n.Name != "Alice" || n.node != nil {
+		t.Errorf("got %+v; want Alice with a nil embedded node", n)
+	}
+}
+
+// Name implements Scanner, so a struct embedding it is scanned from a
+// "name" column rather than having Value promoted.
+type Name struct {
+	Value string
+}
+
+func (n *Name) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		n.Value = src
+	case []byte:
+		n.Value = string(src)
+	default:
+		return fmt.Errorf("unsupported name type %T", src)
+	}
+	return nil
+}
+
+func TestScanStructEmbeddedScanner(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	var p struct {
+		Name
+		Age int
+	}
+	err := db.QueryRow("SELECT|people|age,name|age=?", 2).ScanStruct(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Value != "Bob" || p.Age != 2 {
+		t.Errorf("got %+v; want Bob, 2", p)
+	}
+
+	var q struct {
+		*Name
+		Age int
+	}
+	err = db.QueryRow("SELECT|people|age,name|age=?", 3).ScanStruct(&q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Name == nil || q.Value != "Chris" || q.Age != 3 {
+		t.Errorf("got %+v; want Chris, 3", q)
+	}
+
+	// Value is not promoted from Name, so it does not conflict with
+	// the outer Value field.
+	err = db.QueryRow("SELECT|people|name|age=?", 1).ScanStruct(&struct {
+		Name
+		Value string
+	}{})
+	if err != nil {
+		t.Errorf("ScanStruct with a Value field = %v; want nil", err)
+	}
+}
+
+func TestScanStructErrors(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	type ambiguousA struct{ Name string }
+	type ambiguousB struct{ Name string }
+	type ambiguous struct {
+		ambiguousA
+		ambiguousB
+		Age int
+	}
+	type renamed struct {
+		Name  string
+		Alias string `sql:"name"`
+	}
+
+	type birthday struct {
+		Bdate time.Time
+	}
+	type intName struct {
+		Name int
+	}
+
+	var p person
+	tests := []struct {
+		query string
+		dest  interface{}
+		want  string
+	}{
+		{"SELECT|people|age,name|", p, "requires a non-nil pointer to a struct"},
+		{"SELECT|people|age,name|", (*person)(nil), "requires a non-nil pointer to a struct"},
+		{"SELECT|people|age,dead|", &p, `no field of sql.person matches column index 1, name "dead"`},
+		{"SELECT|people|age,name|", &ambiguous{}, `column index 1, name "name" matches more than one field`},
+		{"SELECT|people|name|", &renamed{}, `column index 0, name "name" matches more than one field`},
+		{"SELECT|people|name,age|", &struct{ Name, Age string }{}, ""},
+		{"SELECT|people|age,name|", &struct {
+			*personBase
+			Age int
+		}{}, "cannot set embedded pointer to unexported struct sql.personBase for field personBase.Name"},
+
+		// Matching is by name only, so a repeated column name is an
+		// error even if the columns are different.
+		{"SELECT|people|name,name|", &p, "column indexes 0 and 1 both match field personBase.Name"},
+
+		// Conversion errors name the field and the column type.
+		{"SELECT|people|bdate|", &birthday{}, `column index 0, name "bdate" (scan type time.Time) into field Bdate of sql.birthday`},
+		{"SELECT|people|name|", &intName{}, `column index 0, name "name" (scan type string) into field Name of sql.intName: converting driver.Value type []uint8 ("Alice") to a int`},
+	}
+	for _, tt := range tests {
+		rows, err := db.Query(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rows.Next() {
+			t.Fatalf("%s: no rows", tt.query)
+		}
+		err = rows.ScanStruct(tt.dest)
+		rows.Close()
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("ScanStruct(%T) = %v; want nil", tt.dest, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ScanStruct(%T) = %v; want error containing %q", tt.dest, err, tt.want)
+		}
+	}
+}
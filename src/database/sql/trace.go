@@ -0,0 +1,154 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"time"
+)
+
+// QueryTrace is a set of hooks to run at various stages of a database
+// operation. Any particular hook may be nil. Functions may be called
+// concurrently from different goroutines and some may be called
+// after the operation has completed or failed.
+//
+// A single operation may trigger a hook more than once: for example,
+// when a connection is reported bad by the driver the operation is
+// retried on another connection and GetConn, GotConn, QueryStart and
+// QueryDone are called again.
+type QueryTrace struct {
+	// GetConn is called before a connection is requested from the
+	// DB's connection pool. It is not called by operations on a
+	// Conn or Tx, which already own their connection.
+	GetConn func()
+
+	// GotConn is called after a connection is successfully obtained
+	// from the pool.
+	GotConn func(GotConnInfo)
+
+	// QueryStart is called before a query or statement is sent to
+	// the driver.
+	QueryStart func(QueryStartInfo)
+
+	// QueryDone is called after the driver has executed a query or
+	// statement. For queries returning rows, it is called once the
+	// driver has returned a result set, not when the Rows are closed.
+	QueryDone func(QueryDoneInfo)
+}
+
+// GotConnInfo is the argument to QueryTrace.GotConn and contains
+// information about the obtained connection.
+type GotConnInfo struct {
+	// Reused is whether this connection has been previously
+	// used for another operation.
+	Reused bool
+
+	// WasIdle is whether this connection was obtained from the
+	// idle pool.
+	WasIdle bool
+
+	// IdleTime reports how long the connection was previously
+	// idle, if WasIdle is true.
+	IdleTime time.Duration
+
+	// WaitTime reports how long the operation was blocked waiting
+	// for a connection because MaxOpenConns had been reached.
+	WaitTime time.Duration
+}
+
+// QueryStartInfo is the argument to QueryTrace.QueryStart.
+type QueryStartInfo struct {
+	Query string        // The query text, or the query a Stmt was prepared with.
+	Args  []interface{} // The arguments as passed by the caller.
+}
+
+// QueryDoneInfo is the argument to QueryTrace.QueryDone.
+type QueryDoneInfo struct {
+	Err error // Err is the error returned by the driver, if any.
+}
+
+// queryTraceKey is the context key for a *QueryTrace.
+type queryTraceKey struct{}
+
+// ContextQueryTrace returns the QueryTrace associated with the
+// provided context. If none, it returns nil.
+func ContextQueryTrace(ctx context.Context) *QueryTrace {
+	trace, _ := ctx.Value(queryTraceKey{}).(*QueryTrace)
+	return trace
+}
+
+// WithQueryTrace returns a new context based on the provided parent
+// ctx. Database operations made with the returned context will use
+// the provided trace hooks, in addition to any previous hooks
+// registered with ctx. Any hooks defined in the provided trace will
+// be called first.
+func WithQueryTrace(ctx context.Context, trace *QueryTrace) context.Context {
+	if trace == nil {
+		panic("nil trace")
+	}
+	t := *trace
+	t.compose(ContextQueryTrace(ctx))
+	return context.WithValue(ctx, queryTraceKey{}, &t)
+}
+
+// compose modifies t such that it respects the previously-registered
+// hooks in old. Hooks in t run before the corresponding hooks in old.
+func (t *QueryTrace) compose(old *QueryTrace) {
+	if old == nil {
+		return
+	}
+	if f, of := t.GetConn, old.GetConn; of != nil {
+		if f == nil {
+			t.GetConn = of
+		} else {
+			t.GetConn = func() { f(); of() }
+		}
+	}
+	if f, of := t.GotConn, old.GotConn; of != nil {
+		if f == nil {
+			t.GotConn = of
+		} else {
+			t.GotConn = func(info GotConnInfo) { f(info); of(info) }
+		}
+	}
+	if f, of := t.QueryStart, old.QueryStart; of != nil {
+		if f == nil {
+			t.QueryStart = of
+		} else {
+			t.QueryStart = func(info QueryStartInfo) { f(info); of(info) }
+		}
+	}
+	if f, of := t.QueryDone, old.QueryDone; of != nil {
+		if f == nil {
+			t.QueryDone = of
+		} else {
+			t.QueryDone = func(info QueryDoneInfo) { f(info); of(info) }
+		}
+	}
+}
+
+func (t *QueryTrace) getConn() {
+	if t != nil && t.GetConn != nil {
+		t.GetConn()
+	}
+}
+
+func (t *QueryTrace) gotConn(info GotConnInfo) {
+	if t != nil && t.GotConn != nil {
+		t.GotConn(info)
+	}
+}
+
+func (t *QueryTrace) queryStart(query string, args []interface{}) {
+	if t != nil && t.QueryStart != nil {
+		t.QueryStart(QueryStartInfo{Query: query, Args: args})
+	}
+}
+
+func (t *QueryTrace) queryDone(err error) {
+	if t != nil && t.QueryDone != nil {
+		t.QueryDone(QueryDoneInfo{Err: err})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// traceRecorder returns a QueryTrace that appends a line to *events
+// for every hook called.
+func traceRecorder(events *[]string) *QueryTrace {
+	return &QueryTrace{
+		GetConn: func() {
+			*events = append(*events, "GetConn")
+		},
+		GotConn: func(info GotConnInfo) {
+			if info.Reused {
+				*events = append(*events, "GotConn reused")
+			} else {
+				*events = append(*events, "GotConn new")
+			}
+		},
+		QueryStart: func(info QueryStartInfo) {
+			*events = append(*events, "QueryStart "+info.Query)
+		},
+		QueryDone: func(info QueryDoneInfo) {
+			if info.Err != nil {
+				*events = append(*events, "QueryDone error")
+			} else {
+				*events = append(*events, "QueryDone")
+			}
+		},
+	}
+}
+
+func TestQueryTrace(t *testing.T) {
+	db := newTestDB(t, "people")
+	defer closeDB(t, db)
+
+	var events []string
+	ctx := WithQueryTrace(context.Background(), traceRecorder(&events))
+
+	const query = "SELECT|people|name|"
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	_, err = db.ExecContext(ctx, "INSERT|people|name=Dave,age=?", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.QueryContext(ctx, "SELECT|nonexistent|name|")
+	if err == nil {
+		t.Fatal("expected error querying nonexistent table")
+	}
+
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	rows, err = stmt.QueryContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	want := []string{
+		"GetConn", "GotConn reused", "QueryStart " + query, "QueryDone",
+		"GetConn", "GotConn reused", "QueryStart INSERT|people|name=Dave,age=?", "QueryDone",
+		"GetConn", "GotConn reused", "QueryStart SELECT|nonexistent|name|", "QueryDone error",
+		"GetConn", "GotConn reused", "QueryStart " + query, "QueryDone",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("trace events:\n%s\nwant:\n%s", strings.Join(events, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestQueryTraceCompose(t *testing.T) {
+	var events []string
+	old := &QueryTrace{
+		QueryStart: func(QueryStartInfo) { events = append(events, "old QueryStart") },
+		QueryDone:  func(QueryDoneInfo) { events = append(events, "old QueryDone") },
+	}
+	ctx := WithQueryTrace(context.Background(), old)
+	ctx = WithQueryTrace(ctx, &QueryTrace{
+		QueryStart: func(QueryStartInfo) { events = append(events, "new QueryStart") },
+	})
+
+	trace := ContextQueryTrace(ctx)
+	trace.queryStart("q", nil)
+	trace.queryDone(nil)
+	trace.getConn()
+
+	want := []string{"new QueryStart", "old QueryStart", "old QueryDone"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %q; want %q", events, want)
+	}
+	if ContextQueryTrace(context.Background()) != nil {
+		t.Error("ContextQueryTrace of background context is not nil")
+	}
+}
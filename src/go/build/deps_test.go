@@ -243,7 +243,7 @@ var depsRules = `
 
 	# compression
 	FMT, encoding/binary, hash/adler32, hash/crc32
-	< compress/bzip2, compress/flate, compress/lzw
+	< compress/bzip2, compress/flate, compress/lzw, compress/zstd
 	< archive/zip, compress/gzip, compress/zlib;
 
 	# templates
@@ -344,6 +344,12 @@ var depsRules = `
 	sync
 	< internal/singleflight;
 
+	sync, unsafe
+	< internal/intern;
+
+	errors, internal/bytealg, internal/intern, internal/itoa, math/bits, strconv
+	< net/netip;
+
 	os
 	< golang.org/x/net/dns/dnsmessage,
 	  golang.org/x/net/lif,
@@ -361,6 +367,7 @@ var depsRules = `
 	internal/poll,
 	internal/singleflight,
 	internal/race,
+	net/netip,
 	os
 	< net;
 
@@ -376,6 +383,7 @@ var depsRules = `
 
 	# logging - most packages should not import; http and up is allowed
 	FMT
+	< log/internal
 	< log;
 
 	log !< crypto/tls, database/sql, go/importer, testing;
@@ -383,6 +391,9 @@ var depsRules = `
 	FMT, log, net
 	< log/syslog;
 
+	FMT, context, encoding/json, internal/unsafeheader, log, log/internal
+	< log/slog;
+
 	NET, log
 	< net/mail;
 
@@ -393,8 +404,9 @@ var depsRules = `
 	< crypto/subtle
 	< crypto/internal/subtle
 	< crypto/elliptic/internal/fiat
-	< crypto/ed25519/internal/edwards25519/field
-	< crypto/ed25519/internal/edwards25519
+	< crypto/internal/edwards25519/field
+	< crypto/internal/edwards25519
+	< crypto/internal/nistec
 	< crypto/cipher
 	< crypto/aes, crypto/des, crypto/hmac, crypto/md5, crypto/rc4,
 	  crypto/sha1, crypto/sha256, crypto/sha512
@@ -410,20 +422,26 @@ var depsRules = `
 	< encoding/asn1
 	< golang.org/x/crypto/cryptobyte/asn1
 	< golang.org/x/crypto/cryptobyte
-	< golang.org/x/crypto/curve25519
 	< crypto/dsa, crypto/elliptic, crypto/rsa
+	< crypto/ecdh
 	< crypto/ecdsa
+	# x/crypto's curve25519 uses crypto/ecdh when built with go1.20 or later.
+	< golang.org/x/crypto/curve25519/internal/field
+	< golang.org/x/crypto/curve25519
 	< CRYPTO-MATH;
 
 	CGO, net !< CRYPTO-MATH;
 
 	# TLS, Prince of Dependencies.
 	CRYPTO-MATH, NET, container/list, encoding/hex, encoding/pem
-	< golang.org/x/crypto/internal/subtle
+	< golang.org/x/crypto/internal/alias
 	< golang.org/x/crypto/chacha20
-	< golang.org/x/crypto/poly1305
+	< golang.org/x/crypto/internal/poly1305
 	< golang.org/x/crypto/chacha20poly1305
 	< golang.org/x/crypto/hkdf
+	< golang.org/x/crypto/sha3
+	< crypto/internal/mlkem768
+	< crypto/hpke
 	< crypto/x509/internal/macos
 	< crypto/x509/pkix
 	< crypto/x509
@@ -454,12 +472,17 @@ var depsRules = `
 	NET, crypto/tls
 	< net/http/httptrace;
 
+	crypto/tls
+	< net/http/internal/quic;
+
 	compress/gzip,
+	compress/zstd,
 	golang.org/x/net/http/httpguts,
 	golang.org/x/net/http/httpproxy,
 	golang.org/x/net/http2/hpack,
 	net/http/internal,
 	net/http/internal/ascii,
+	net/http/internal/quic,
 	net/http/internal/testcert,
 	net/http/httptrace,
 	mime/multipart,
@@ -485,6 +508,11 @@ var depsRules = `
 	FMT, compress/gzip, encoding/binary, text/tabwriter
 	< runtime/pprof;
 
+	# Coverage
+	FMT, crypto/md5, encoding/binary
+	< internal/coverage
+	< runtime/coverage;
+
 	OS, compress/gzip, regexp
 	< internal/profile;
 
@@ -511,7 +539,10 @@ var depsRules = `
 	FMT, flag, runtime/debug, runtime/trace, internal/sysinfo, math/rand
 	< testing;
 
-	internal/testlog, runtime/pprof, regexp
+	FMT, crypto/sha256, go/ast, go/parser, go/token, internal/unsafeheader, math/rand
+	< internal/fuzz;
+
+	internal/fuzz, internal/testlog, runtime/pprof, regexp, os/signal
 	< testing/internal/testdeps;
 
 	OS, flag, testing, internal/cfg
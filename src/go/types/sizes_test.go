@@ -110,3 +110,48 @@ const _ = unsafe.Offsetof(struct{ x int64 }{}.x)
 		_ = conf.Sizes.Alignof(tv.Type)
 	}
 }
+
+func TestAtomicAlign(t *testing.T) {
+	const src = `
+package main
+
+import "sync/atomic"
+
+var s struct {
+	x int32
+	y atomic.Int64
+	z int64
+}
+`
+
+	want := []int64{0, 8, 16}
+	for _, arch := range []string{"386", "amd64"} {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "x.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+		conf := types.Config{
+			Importer: importer.Default(),
+			Sizes:    types.SizesFor("gc", arch),
+		}
+		pkg, err := conf.Check("x", fset, []*ast.File{f}, &info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj := pkg.Scope().Lookup("s")
+		if obj == nil {
+			t.Fatal("no var s")
+		}
+		ts := obj.Type().(*types.Struct)
+		var fields []*types.Var
+		for i := 0; i < ts.NumFields(); i++ {
+			fields = append(fields, ts.Field(i))
+		}
+		offsets := conf.Sizes.Offsetsof(fields)
+		if offsets[0] != want[0] || offsets[1] != want[1] || offsets[2] != want[2] {
+			t.Errorf("GOARCH=%s: Offsetsof(%v) = %v want %v", arch, ts, offsets, want)
+		}
+	}
+}
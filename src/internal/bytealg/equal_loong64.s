@@ -0,0 +1,116 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "go_asm.h"
+#include "textflag.h"
+
+#define	REGCTXT	R29
+
+// memequal(a, b unsafe.Pointer, size uintptr) bool
+TEXT runtime·memequal(SB),NOSPLIT|NOFRAME,$0-25
+	MOVV	a+0(FP), R4
+	MOVV	b+8(FP), R5
+	BEQ	R4, R5, eq
+	MOVV	size+16(FP), R6
+	ADDV	R4, R6, R7
+
+	// chunk size is 16
+	SGTU	$16, R6, R10
+	BEQ	R0, R10, chunk_entry
+
+byte_loop:
+	BNE	R4, R7, byte_test
+	MOVV	$1, R4
+	MOVB	R4, ret+24(FP)
+	RET
+byte_test:
+	MOVBU	(R4), R8
+	ADDV	$1, R4
+	MOVBU	(R5), R9
+	ADDV	$1, R5
+	BEQ	R8, R9, byte_loop
+	JMP	not_eq
+
+chunk_entry:
+	// make sure both a and b are aligned
+	OR	R4, R5, R11
+	AND	$0x7, R11
+	BNE	R0, R11, byte_loop
+	JMP	chunk_loop_1
+
+chunk_loop:
+	// chunk size is 16
+	SGTU	$16, R6, R10
+	BNE	R0, R10, chunk_tail_8
+chunk_loop_1:
+	MOVV	(R4), R8
+	MOVV	(R5), R9
+	BNE	R8, R9, not_eq
+	MOVV	8(R4), R12
+	MOVV	8(R5), R13
+	ADDV	$16, R4
+	ADDV	$16, R5
+	SUBV	$16, R6
+	BEQ	R12, R13, chunk_loop
+	JMP	not_eq
+
+chunk_tail_8:
+	AND	$8, R6, R14
+	BEQ	R0, R14, chunk_tail_4
+	MOVV	(R4), R8
+	MOVV	(R5), R9
+	BNE	R8, R9, not_eq
+	ADDV	$8, R4
+	ADDV	$8, R5
+
+chunk_tail_4:
+	AND	$4, R6, R14
+	BEQ	R0, R14, chunk_tail_2
+	MOVWU	(R4), R8
+	MOVWU	(R5), R9
+	BNE	R8, R9, not_eq
+	ADDV	$4, R4
+	ADDV	$4, R5
+
+chunk_tail_2:
+	AND	$2, R6, R14
+	BEQ	R0, R14, chunk_tail_1
+	MOVHU	(R4), R8
+	MOVHU	(R5), R9
+	BNE	R8, R9, not_eq
+	ADDV	$2, R4
+	ADDV	$2, R5
+
+chunk_tail_1:
+	AND	$1, R6, R14
+	BEQ	R0, R14, eq
+	MOVBU	(R4), R8
+	MOVBU	(R5), R9
+	BEQ	R8, R9, eq
+
+not_eq:
+	MOVB	R0, ret+24(FP)
+	RET
+eq:
+	MOVV	$1, R4
+	MOVB	R4, ret+24(FP)
+	RET
+
+// memequal_varlen(a, b unsafe.Pointer) bool
+TEXT runtime·memequal_varlen(SB),NOSPLIT,$40-17
+	MOVV	a+0(FP), R4
+	MOVV	b+8(FP), R5
+	BEQ	R4, R5, eq
+	MOVV	8(REGCTXT), R6    // compiler stores size at offset 8 in the closure
+	MOVV	R4, 8(R3)
+	MOVV	R5, 16(R3)
+	MOVV	R6, 24(R3)
+	JAL	runtime·memequal(SB)
+	MOVBU	32(R3), R4
+	MOVB	R4, ret+16(FP)
+	RET
+eq:
+	MOVV	$1, R4
+	MOVB	R4, ret+16(FP)
+	RET
@@ -0,0 +1,325 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package coverage defines the format of the coverage data files
+// written by programs built with "go build -cover" and read by
+// "go tool covdata".
+//
+// A program writes two kinds of file to the directory named by
+// $GOCOVERDIR. A meta-data file, named covmeta.<hash>, describes the
+// source files and blocks instrumented in the program. The hash is the
+// MD5 hash of the file's contents, so all runs of one binary share a
+// single meta-data file. A counter data file, named
+// covcounters.<hash>.<pid>.<nanotime>, holds the counter values of one
+// run of the program, one per block, in the order in which the blocks
+// appear in the meta-data file with that hash.
+//
+// Both kinds of file begin with a four-byte magic number and a format
+// version. All other integers are encoded as uvarints, and strings as
+// a uvarint length followed by the bytes of the string.
+package coverage
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the file format written by this package.
+const Version = 1
+
+const (
+	// MetaFilePref is the prefix of the names of meta-data files.
+	MetaFilePref = "covmeta"
+
+	// CounterFilePref is the prefix of the names of counter data files.
+	CounterFilePref = "covcounters"
+)
+
+var (
+	metaMagic    = [4]byte{'\x00', 'c', 'v', 'm'}
+	counterMagic = [4]byte{'\x00', 'c', 'v', 'c'}
+)
+
+// A Hash identifies the meta-data of a program.
+type Hash [md5.Size]byte
+
+func (h Hash) String() string {
+	return fmt.Sprintf("%x", h[:])
+}
+
+// A Block is a basic block of source code instrumented with a counter.
+type Block struct {
+	StartLine, StartCol uint32
+	EndLine, EndCol     uint32
+	NumStmt             uint32
+}
+
+// A File describes the blocks of one instrumented source file.
+type File struct {
+	// Name is the file name as it appears in textual coverage
+	// profiles: the package's import path followed by a slash and the
+	// file's base name, or an absolute path for local packages.
+	Name   string
+	Blocks []Block
+}
+
+// MetaData describes the instrumented source files of a program.
+type MetaData struct {
+	Mode  string // "set", "count", or "atomic"
+	Files []File
+}
+
+// NumBlocks returns the total number of blocks in m,
+// which is the number of counters in each of its counter data files.
+func (m *MetaData) NumBlocks() int {
+	n := 0
+	for _, f := range m.Files {
+		n += len(f.Blocks)
+	}
+	return n
+}
+
+// Marshal returns the encoding of m as a meta-data file.
+func (m *MetaData) Marshal() []byte {
+	var e encoder
+	e.buf = append(e.buf, metaMagic[:]...)
+	e.uvarint(Version)
+	e.string(m.Mode)
+	e.uvarint(uint64(len(m.Files)))
+	for _, f := range m.Files {
+		e.string(f.Name)
+		e.uvarint(uint64(len(f.Blocks)))
+		for _, b := range f.Blocks {
+			e.uvarint(uint64(b.StartLine))
+			e.uvarint(uint64(b.StartCol))
+			e.uvarint(uint64(b.EndLine))
+			e.uvarint(uint64(b.EndCol))
+			e.uvarint(uint64(b.NumStmt))
+		}
+	}
+	return e.buf
+}
+
+// HashMetaData returns the hash identifying the meta-data file
+// with the given contents.
+func HashMetaData(data []byte) Hash {
+	return md5.Sum(data)
+}
+
+// UnmarshalMetaData decodes the contents of a meta-data file.
+func UnmarshalMetaData(data []byte) (*MetaData, error) {
+	d := decoder{buf: data}
+	if err := d.header(metaMagic); err != nil {
+		return nil, err
+	}
+	m := new(MetaData)
+	m.Mode = d.string()
+	if d.err == nil && m.Mode != "set" && m.Mode != "count" && m.Mode != "atomic" {
+		return nil, fmt.Errorf("invalid coverage mode %q", m.Mode)
+	}
+	nfiles := d.count()
+	for i := 0; i < nfiles && d.err == nil; i++ {
+		f := File{Name: d.string()}
+		nblocks := d.count()
+		for j := 0; j < nblocks && d.err == nil; j++ {
+			f.Blocks = append(f.Blocks, Block{
+				StartLine: d.uint32(),
+				StartCol:  d.uint32(),
+				EndLine:   d.uint32(),
+				EndCol:    d.uint32(),
+				NumStmt:   d.uint32(),
+			})
+		}
+		m.Files = append(m.Files, f)
+	}
+	if err := d.finish(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CounterData holds the counter values of one run of a program.
+type CounterData struct {
+	MetaHash Hash     // hash of the program's meta-data file
+	Counters []uint32 // one per block, in meta-data order
+}
+
+// Marshal returns the encoding of c as a counter data file.
+func (c *CounterData) Marshal() []byte {
+	var e encoder
+	e.buf = append(e.buf, counterMagic[:]...)
+	e.uvarint(Version)
+	e.buf = append(e.buf, c.MetaHash[:]...)
+	e.uvarint(uint64(len(c.Counters)))
+	for _, v := range c.Counters {
+		e.uvarint(uint64(v))
+	}
+	return e.buf
+}
+
+// UnmarshalCounterData decodes the contents of a counter data file.
+func UnmarshalCounterData(data []byte) (*CounterData, error) {
+	d := decoder{buf: data}
+	if err := d.header(counterMagic); err != nil {
+		return nil, err
+	}
+	c := new(CounterData)
+	if len(d.buf) < len(c.MetaHash) {
+		return nil, errCorrupt
+	}
+	copy(c.MetaHash[:], d.buf)
+	d.buf = d.buf[len(c.MetaHash):]
+	n := d.count()
+	if d.err == nil {
+		c.Counters = make([]uint32, n)
+	}
+	for i := 0; i < n && d.err == nil; i++ {
+		c.Counters[i] = d.uint32()
+	}
+	if err := d.finish(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// MetaFileName returns the name of the meta-data file with hash h.
+func MetaFileName(h Hash) string {
+	return MetaFilePref + "." + h.String()
+}
+
+// CounterFileName returns the name of the counter data file
+// written at time nanotime by process pid for meta-data hash h.
+func CounterFileName(h Hash, pid int, nanotime int64) string {
+	return fmt.Sprintf("%s.%s.%d.%d", CounterFilePref, h, pid, nanotime)
+}
+
+// ParseMetaFileName reports whether name is the name of a meta-data file,
+// and if so returns its hash.
+func ParseMetaFileName(name string) (h Hash, ok bool) {
+	if !strings.HasPrefix(name, MetaFilePref+".") {
+		return h, false
+	}
+	return parseHash(name[len(MetaFilePref)+1:])
+}
+
+// ParseCounterFileName reports whether name is the name of a counter data
+// file, and if so returns the hash of its meta-data file.
+func ParseCounterFileName(name string) (h Hash, ok bool) {
+	if !strings.HasPrefix(name, CounterFilePref+".") {
+		return h, false
+	}
+	f := strings.Split(name[len(CounterFilePref)+1:], ".")
+	if len(f) != 3 {
+		return h, false
+	}
+	for _, s := range f[1:] {
+		if _, err := strconv.ParseUint(s, 10, 64); err != nil {
+			return h, false
+		}
+	}
+	return parseHash(f[0])
+}
+
+func parseHash(s string) (h Hash, ok bool) {
+	if len(s) != 2*len(h) {
+		return h, false
+	}
+	for i := range h {
+		b, err := strconv.ParseUint(s[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return h, false
+		}
+		h[i] = byte(b)
+	}
+	return h, true
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) uvarint(x uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], x)
+	e.buf = append(e.buf, tmp[:n]...)
+}
+
+func (e *encoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+var errCorrupt = errors.New("corrupt coverage data file")
+
+// A decoder decodes the contents of a file. Once an error occurs,
+// the decoder returns zero values, and finish reports the error.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) header(magic [4]byte) error {
+	if len(d.buf) < len(magic) || string(d.buf[:len(magic)]) != string(magic[:]) {
+		return errors.New("not a coverage data file")
+	}
+	d.buf = d.buf[len(magic):]
+	if v := d.uvarint(); d.err == nil && v != Version {
+		return fmt.Errorf("unsupported coverage data file version %d", v)
+	}
+	return d.err
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errCorrupt
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return x
+}
+
+func (d *decoder) uint32() uint32 {
+	x := d.uvarint()
+	if x > 1<<32-1 {
+		d.err = errCorrupt
+		return 0
+	}
+	return uint32(x)
+}
+
+// count decodes a number of items, each of which
+// occupies at least one byte of the remaining input.
+func (d *decoder) count() int {
+	x := d.uvarint()
+	if x > uint64(len(d.buf)) {
+		d.err = errCorrupt
+		return 0
+	}
+	return int(x)
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	s := string(d.buf[:n])
+	d.buf = d.buf[n:]
+	return s
+}
+
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.buf) != 0 {
+		d.err = errCorrupt
+	}
+	return d.err
+}
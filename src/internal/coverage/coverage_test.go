@@ -0,0 +1,113 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testMeta = &MetaData{
+	Mode: "count",
+	Files: []File{
+		{
+			Name: "example.com/m/a.go",
+			Blocks: []Block{
+				{StartLine: 3, StartCol: 14, EndLine: 5, EndCol: 2, NumStmt: 1},
+				{StartLine: 7, StartCol: 9, EndLine: 300, EndCol: 3, NumStmt: 200},
+			},
+		},
+		{Name: "example.com/m/empty.go"},
+		{
+			Name: "example.com/m/internal/b.go",
+			Blocks: []Block{
+				{StartLine: 1 << 20, StartCol: 1, EndLine: 1<<20 + 1, EndCol: 80, NumStmt: 2},
+			},
+		},
+	},
+}
+
+func TestMetaDataRoundTrip(t *testing.T) {
+	data := testMeta.Marshal()
+	m, err := UnmarshalMetaData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, testMeta) {
+		t.Errorf("UnmarshalMetaData(Marshal(m)) = %+v, want %+v", m, testMeta)
+	}
+	if n := m.NumBlocks(); n != 3 {
+		t.Errorf("NumBlocks() = %d, want 3", n)
+	}
+
+	for i := 0; i < len(data); i++ {
+		if _, err := UnmarshalMetaData(data[:i]); err == nil {
+			t.Errorf("UnmarshalMetaData(data[:%d]) succeeded, want error", i)
+		}
+	}
+	if _, err := UnmarshalMetaData(append(data, 0)); err == nil {
+		t.Errorf("UnmarshalMetaData with trailing data succeeded, want error")
+	}
+}
+
+func TestCounterDataRoundTrip(t *testing.T) {
+	c := &CounterData{
+		MetaHash: HashMetaData(testMeta.Marshal()),
+		Counters: []uint32{0, 1, 1<<32 - 1},
+	}
+	data := c.Marshal()
+	c2, err := UnmarshalCounterData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c2, c) {
+		t.Errorf("UnmarshalCounterData(Marshal(c)) = %+v, want %+v", c2, c)
+	}
+	if _, err := UnmarshalMetaData(data); err == nil {
+		t.Errorf("UnmarshalMetaData(counter data) succeeded, want error")
+	}
+	for i := 0; i < len(data); i++ {
+		if _, err := UnmarshalCounterData(data[:i]); err == nil {
+			t.Errorf("UnmarshalCounterData(data[:%d]) succeeded, want error", i)
+		}
+	}
+}
+
+func TestFileNames(t *testing.T) {
+	h := HashMetaData(testMeta.Marshal())
+
+	name := MetaFileName(h)
+	if h2, ok := ParseMetaFileName(name); !ok || h2 != h {
+		t.Errorf("ParseMetaFileName(%q) = %v, %v, want %v, true", name, h2, ok, h)
+	}
+	if _, ok := ParseCounterFileName(name); ok {
+		t.Errorf("ParseCounterFileName(%q) succeeded, want failure", name)
+	}
+
+	name = CounterFileName(h, 1234, 1656000000123456789)
+	if h2, ok := ParseCounterFileName(name); !ok || h2 != h {
+		t.Errorf("ParseCounterFileName(%q) = %v, %v, want %v, true", name, h2, ok, h)
+	}
+	if _, ok := ParseMetaFileName(name); ok {
+		t.Errorf("ParseMetaFileName(%q) succeeded, want failure", name)
+	}
+
+	for _, bad := range []string{
+		"covmeta",
+		"covmeta.",
+		"covmeta.0123",
+		"covmeta." + h.String() + "0",
+		"covcounters." + h.String(),
+		"covcounters." + h.String() + ".12.x",
+		"covcounters." + h.String() + ".12.34.56",
+	} {
+		if _, ok := ParseMetaFileName(bad); ok {
+			t.Errorf("ParseMetaFileName(%q) succeeded, want failure", bad)
+		}
+		if _, ok := ParseCounterFileName(bad); ok {
+			t.Errorf("ParseCounterFileName(%q) succeeded, want failure", bad)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fuzz
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestUnmarshalMarshal(t *testing.T) {
+	var tests = []struct {
+		in string
+		ok bool
+	}{
+		{
+			in: "int(1234)",
+			ok: false, // missing version
+		},
+		{
+			in: `go test fuzz v1
+string("a"bcad")`,
+			ok: false, // malformed
+		},
+		{
+			in: `go test fuzz v1
+int()`,
+			ok: false, // empty value
+		},
+		{
+			in: `go test fuzz v1
+uint(-32)`,
+			ok: false, // invalid negative uint
+		},
+		{
+			in: `go test fuzz v1
+int8(1234456)`,
+			ok: false, // int8 too large
+		},
+		{
+			in: `go test fuzz v1
+int(20*5)`,
+			ok: false, // expression in int value
+		},
+		{
+			in: `go test fuzz v1
+int(--5)`,
+			ok: false, // expression in int value
+		},
+		{
+			in: `go test fuzz v1
+bool(0)`,
+			ok: false, // malformed bool
+		},
+		{
+			in: `go test fuzz v1
+byte('aa)`,
+			ok: false, // malformed byte
+		},
+		{
+			in: `go test fuzz v1
+byte('☃')`,
+			ok: false, // byte out of range
+		},
+		{
+			in: `go test fuzz v1
+string("extra")
+[]byte("spacing")
+    `,
+			ok: true,
+		},
+		{
+			in: `go test fuzz v1
+float64(0)
+float32(0)`,
+			ok: true, // will be an integer literal since there is no decimal
+		},
+		{
+			in: `go test fuzz v1
+int(-23)
+int8(-2)
+int64(2342425)
+uint(1)
+uint16(234)
+uint32(352342)
+uint64(123)
+rune('œ')
+byte('K')
+byte('ÿ')
+[]byte("hello¿")
+[]byte("a")
+bool(true)
+string("hello\\xbd\\xb2=\\xbc ⌘")
+float64(-12.5)
+float32(2.5)`,
+			ok: true,
+		},
+		{
+			in: `go test fuzz v1
+float32(-0)
+float64(-0)
+math.Float64frombits(0x7ff0000000000000)
+math.Float64frombits(0xfff0000000000000)
+math.Float32frombits(0x7fc00000)
+math.Float64frombits(0x7ff8000000000001)`,
+			ok: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			vals, err := unmarshalCorpusFile([]byte(test.in))
+			if test.ok && err != nil {
+				t.Fatalf("unmarshal unexpected error: %v", err)
+			} else if !test.ok && err == nil {
+				t.Fatalf("unmarshal unexpected success")
+			}
+			if !test.ok {
+				return // skip the rest of the test
+			}
+			newB := marshalCorpusFile(vals...)
+			want := string(newB)
+			// Marshaling the decoded values must be stable.
+			vals2, err := unmarshalCorpusFile(newB)
+			if err != nil {
+				t.Fatalf("unmarshal of marshaled values failed: %v", err)
+			}
+			if got := string(marshalCorpusFile(vals2...)); got != want {
+				t.Errorf("unstable marshaling:\nfirst:\n%s\nsecond:\n%s", want, got)
+			}
+		})
+	}
+}
+
+// TestMarshalUnmarshalFloat checks that floating point values, including
+// NaNs with arbitrary payloads, survive a round trip through the corpus
+// encoding bit for bit.
+func TestMarshalUnmarshalFloat(t *testing.T) {
+	for _, bits := range []uint64{
+		0,
+		math.Float64bits(math.Copysign(0, -1)),
+		math.Float64bits(1.0 / 3),
+		math.Float64bits(math.Inf(1)),
+		math.Float64bits(math.Inf(-1)),
+		math.Float64bits(math.NaN()),
+		0x7ff8000000000001,
+		math.Float64bits(math.MaxFloat64),
+		math.Float64bits(math.SmallestNonzeroFloat64),
+	} {
+		t.Run(strconv.FormatUint(bits, 16), func(t *testing.T) {
+			f64 := math.Float64frombits(bits)
+			f32 := float32(f64)
+			vals, err := unmarshalCorpusFile(marshalCorpusFile(f64, f32))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := math.Float64bits(vals[0].(float64)); got != bits {
+				t.Errorf("float64: got %#x, want %#x", got, bits)
+			}
+			if got, want := math.Float32bits(vals[1].(float32)), math.Float32bits(f32); got != want {
+				t.Errorf("float32: got %#x, want %#x", got, want)
+			}
+		})
+	}
+}
+
+func TestReadCorpusDataTypes(t *testing.T) {
+	data := []byte("go test fuzz v1\n[]byte(\"x\")\nint(3)\n")
+	types := []reflect.Type{reflect.TypeOf([]byte(nil)), reflect.TypeOf(0)}
+	vals, err := readCorpusData(data, types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vals, []interface{}{[]byte("x"), 3}) {
+		t.Errorf("got %#v", vals)
+	}
+	if _, err := readCorpusData(data, types[:1]); err == nil {
+		t.Error("wrong number of values: unexpected success")
+	}
+	if _, err := readCorpusData(data, []reflect.Type{types[1], types[0]}); err == nil {
+		t.Error("mismatched types: unexpected success")
+	}
+}
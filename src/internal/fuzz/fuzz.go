@@ -0,0 +1,488 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fuzz provides common fuzzing functionality for tests built with
+// "go test" and for programs that use fuzzing functionality in the testing
+// package.
+package fuzz
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CoordinateFuzzingOpts is a set of arguments for CoordinateFuzzing.
+// The zero value is valid for each field unless specified otherwise.
+type CoordinateFuzzingOpts struct {
+	// Log is a writer for logging progress messages and warnings.
+	// If nil, io.Discard will be used instead.
+	Log io.Writer
+
+	// Timeout is the amount of wall clock time to spend fuzzing after the corpus
+	// has loaded. If zero, there will be no time limit.
+	Timeout time.Duration
+
+	// Limit is the number of random values to generate and test. If zero,
+	// there will be no limit on the number of generated values.
+	Limit int64
+
+	// MinimizeTimeout is the amount of wall clock time to spend minimizing
+	// after discovering a crasher. If zero, there will be no time limit. If
+	// MinimizeTimeout and MinimizeLimit are both zero, then minimization will
+	// be disabled.
+	MinimizeTimeout time.Duration
+
+	// MinimizeLimit is the maximum number of calls to the fuzz function to be
+	// made while minimizing after finding a crash. If zero, there will be no
+	// limit. Calls to the fuzz function made when minimizing also count toward
+	// Limit. If MinimizeTimeout and MinimizeLimit are both zero, then
+	// minimization will be disabled.
+	MinimizeLimit int64
+
+	// Seed is a list of seed values added by the fuzz target with testing.F.Add
+	// and in testdata.
+	Seed []CorpusEntry
+
+	// Types is the list of types which make up a corpus entry.
+	// Types must be set and must match values in Seed.
+	Types []reflect.Type
+
+	// CorpusDir is a directory where files containing values that crash the
+	// code being tested may be written. CorpusDir must be set.
+	CorpusDir string
+
+	// CacheDir is a directory containing additional "interesting" values.
+	// The fuzzer may derive new values from these, and may write new values here.
+	CacheDir string
+}
+
+// CoordinateFuzzing repeatedly mutates the values in the corpus, passing each
+// new set of values to fn. It stops when ctx is done, when opts.Timeout or
+// opts.Limit is reached, or when fn returns an error.
+//
+// Values that cause fn to cover new edges of the instrumented code are added
+// to the corpus and written to opts.CacheDir, so that later runs can start
+// from them. When fn fails, CoordinateFuzzing tries to minimize the failing
+// values, writes them to opts.CorpusDir and returns an error that describes
+// the failure; the error implements a CrashPath method reporting the file
+// written.
+//
+// If ctx is cancelled, CoordinateFuzzing returns nil: interrupting a fuzzing
+// run is not itself a failure.
+func CoordinateFuzzing(ctx context.Context, opts CoordinateFuzzingOpts, fn func(CorpusEntry) error) (err error) {
+	if err := ctx.Err(); err != nil {
+		return nil
+	}
+	if opts.Log == nil {
+		opts.Log = io.Discard
+	}
+	if len(opts.Types) == 0 {
+		return errors.New("fuzz: no types specified for the fuzz target")
+	}
+	if opts.CorpusDir == "" {
+		return errors.New("fuzz: no corpus directory specified")
+	}
+
+	c := &coordinator{
+		opts:      opts,
+		fn:        fn,
+		startTime: time.Now(),
+		m:         newMutator(time.Now().UnixNano()),
+	}
+	if err := c.readCache(); err != nil {
+		return err
+	}
+	if len(c.corpus) == 0 {
+		// Start from zero values when there is nothing else to go on.
+		vals := make([]interface{}, len(opts.Types))
+		for i, t := range opts.Types {
+			vals[i] = zeroValue(t)
+		}
+		c.corpus = append(c.corpus, CorpusEntry{Values: vals})
+	}
+
+	fmt.Fprintf(c.opts.Log, "fuzz: elapsed: 0s, gathering baseline coverage: 0/%d completed\n", len(c.corpus))
+	if err := c.gatherBaseline(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.opts.Log, "fuzz: elapsed: %s, gathering baseline coverage: %d/%d completed, now fuzzing with %d coverage bits\n",
+		c.elapsed(), len(c.corpus), len(c.corpus), countBits(c.coverageMask))
+
+	var deadline <-chan time.Time
+	if opts.Timeout > 0 {
+		t := time.NewTimer(opts.Timeout)
+		defer t.Stop()
+		deadline = t.C
+	}
+	ticker := time.NewTicker(3 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logStats()
+			return nil
+		case <-deadline:
+			c.logStats()
+			return nil
+		case <-ticker.C:
+			c.logStats()
+		default:
+		}
+		if opts.Limit > 0 && c.count >= opts.Limit {
+			c.logStats()
+			return nil
+		}
+
+		parent := c.corpus[c.m.rand(len(c.corpus))]
+		vals := make([]interface{}, len(parent.Values))
+		copy(vals, parent.Values)
+		c.m.mutate(vals, maxMutatedBytes)
+		e := CorpusEntry{Values: vals}
+
+		if err := c.run(e); err != nil {
+			return c.crash(ctx, e, err)
+		}
+		if c.coverageMask != nil && diffCoverage(c.coverageMask, coverageSnapshot) != nil {
+			c.addInteresting(e)
+		}
+	}
+}
+
+// coordinator holds the state of a single fuzzing run.
+type coordinator struct {
+	opts      CoordinateFuzzingOpts
+	fn        func(CorpusEntry) error
+	startTime time.Time
+	m         *mutator
+
+	// corpus is the set of interesting values that mutations start from.
+	corpus []CorpusEntry
+
+	// coverageMask aggregates coverage that was found for all inputs in
+	// the corpus. Each byte represents a single basic execution block. Each
+	// set bit within the byte indicates that an input has triggered that
+	// block at least 1 << n times, where n is the position of the bit in
+	// the byte. For example, a value of 12 indicates that separate inputs
+	// have triggered this block between 4-7 times and 8-15 times.
+	coverageMask []byte
+
+	// count is the number of values tested so far, including those tested
+	// while minimizing.
+	count int64
+
+	// interestingCount is the number of unique interesting values which
+	// have been found this execution.
+	interestingCount int
+}
+
+func (c *coordinator) elapsed() time.Duration {
+	return time.Since(c.startTime).Round(1 * time.Second)
+}
+
+func (c *coordinator) logStats() {
+	elapsed := c.elapsed()
+	rate := float64(c.count) / time.Since(c.startTime).Seconds()
+	fmt.Fprintf(c.opts.Log, "fuzz: elapsed: %s, execs: %d (%.0f/sec), new interesting: %d (total: %d)\n",
+		elapsed, c.count, rate, c.interestingCount, len(c.corpus))
+}
+
+// run calls the fuzz function with the values in e, recording the coverage
+// it produces in coverageSnapshot.
+func (c *coordinator) run(e CorpusEntry) error {
+	c.count++
+	ResetCoverage()
+	err := c.fn(e)
+	SnapshotCoverage()
+	return err
+}
+
+// readCache loads the seed corpus and the cached interesting values into
+// c.corpus. Cached files that cannot be decoded are reported and skipped;
+// they were written by an earlier version of the program under test or
+// corrupted, and should not prevent fuzzing.
+func (c *coordinator) readCache() error {
+	c.corpus = append(c.corpus, c.opts.Seed...)
+	for _, e := range c.corpus {
+		if err := CheckCorpus(e.Values, c.opts.Types); err != nil {
+			return err
+		}
+	}
+	if c.opts.CacheDir == "" {
+		return nil
+	}
+	entries, err := ReadCorpus(c.opts.CacheDir, c.opts.Types)
+	var mce *MalformedCorpusError
+	if errors.As(err, &mce) {
+		fmt.Fprintf(c.opts.Log, "fuzz: warning: skipping cached inputs:\n%v\n", err)
+	} else if err != nil {
+		return err
+	}
+	c.corpus = append(c.corpus, entries...)
+	return nil
+}
+
+// gatherBaseline runs every value in the corpus once, to establish the
+// coverage that has already been reached.
+func (c *coordinator) gatherBaseline(ctx context.Context) error {
+	for _, e := range c.corpus {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := c.run(e); err != nil {
+			if e.IsSeed {
+				// The seed corpus is already in testdata or in the test
+				// itself; report the failing entry rather than writing a
+				// copy of it. Inputs from the cache are treated like new
+				// crashers, since the cache is not visible to the user.
+				return fmt.Errorf("seed corpus entry %s failed: %w", e.Path, err)
+			}
+			return c.crash(ctx, e, err)
+		}
+		if c.coverageMask == nil {
+			c.coverageMask = make([]byte, len(coverageSnapshot))
+		}
+		for i, b := range coverageSnapshot {
+			c.coverageMask[i] |= b
+		}
+	}
+	if len(c.coverageMask) == 0 {
+		// The package under test was not instrumented; fuzzing proceeds
+		// with random mutations alone.
+		c.coverageMask = nil
+	}
+	return nil
+}
+
+// addInteresting adds e to the corpus and records the new coverage it
+// reached. The values are also written to the cache directory, if any, so
+// that future runs may start with them.
+func (c *coordinator) addInteresting(e CorpusEntry) {
+	newBits := countNewCoverageBits(c.coverageMask, coverageSnapshot)
+	for i, b := range coverageSnapshot {
+		c.coverageMask[i] |= b
+	}
+	if c.opts.CacheDir != "" {
+		data := marshalCorpusFile(e.Values...)
+		if path, err := writeToCorpus(data, c.opts.CacheDir); err == nil {
+			e.Path = path
+		} else {
+			fmt.Fprintf(c.opts.Log, "fuzz: warning: %v\n", err)
+		}
+	}
+	c.corpus = append(c.corpus, e)
+	c.interestingCount++
+	if c.opts.Log != io.Discard {
+		fmt.Fprintf(c.opts.Log, "fuzz: elapsed: %s, new interesting input added to corpus (%d new coverage bits, total: %d)\n",
+			c.elapsed(), newBits, len(c.corpus))
+	}
+}
+
+// crash minimizes the values in e, which made fn return err, and writes the
+// result to the corpus directory.
+func (c *coordinator) crash(ctx context.Context, e CorpusEntry, err error) error {
+	vals := make([]interface{}, len(e.Values))
+	copy(vals, e.Values)
+	if c.opts.MinimizeTimeout > 0 || c.opts.MinimizeLimit > 0 {
+		fmt.Fprintf(c.opts.Log, "fuzz: elapsed: %s, minimizing\n", c.elapsed())
+		var deadline time.Time
+		if c.opts.MinimizeTimeout > 0 {
+			deadline = time.Now().Add(c.opts.MinimizeTimeout)
+		}
+		var tries int64
+		shouldStop := func() bool {
+			return ctx.Err() != nil ||
+				(!deadline.IsZero() && time.Now().After(deadline)) ||
+				(c.opts.MinimizeLimit > 0 && tries >= c.opts.MinimizeLimit) ||
+				(c.opts.Limit > 0 && c.count >= c.opts.Limit)
+		}
+		minimizeInput(vals, func(candidate []interface{}) bool {
+			tries++
+			cerr := c.run(CorpusEntry{Values: candidate})
+			if cerr != nil {
+				err = cerr
+			}
+			return cerr != nil
+		}, shouldStop)
+	}
+	data := marshalCorpusFile(vals...)
+	path, werr := writeToCorpus(data, c.opts.CorpusDir)
+	if werr != nil {
+		return fmt.Errorf("%v\nfuzz: could not write failing input: %v", err, werr)
+	}
+	return &crashError{path: path, err: err}
+}
+
+// crashError wraps a crasher written to the seed corpus. It saves the name
+// of the file where the input causing the crasher was saved. The testing
+// framework uses this to report a command to re-run that specific input.
+type crashError struct {
+	path string
+	err  error
+}
+
+func (e *crashError) Error() string {
+	return e.err.Error()
+}
+
+func (e *crashError) Unwrap() error {
+	return e.err
+}
+
+func (e *crashError) CrashPath() string {
+	return e.path
+}
+
+// CorpusEntry represents an individual input for fuzzing.
+//
+// We must use an equivalent type in the testing and testing/internal/testdeps
+// packages, but testing can't import this package directly, and we don't want
+// to export this type from testing. Instead, we use the same struct type and
+// use a type alias (not a defined type) for convenience.
+type CorpusEntry = struct {
+	// Path is the path of the corpus file, if the entry was loaded from disk.
+	// For other entries, including seed values provided by f.Add, Path is
+	// the name of the test, e.g. seed#0 or its hash.
+	Path string
+
+	// Values is the unmarshaled values from a corpus file.
+	Values []interface{}
+
+	// IsSeed indicates whether this entry is part of the seed corpus.
+	IsSeed bool
+}
+
+// MalformedCorpusError is an error found while reading the corpus from the
+// filesystem. All of the errors are stored in the errs list. The testing
+// framework uses this to report malformed files in testdata.
+type MalformedCorpusError struct {
+	errs []error
+}
+
+func (e *MalformedCorpusError) Error() string {
+	var msgs []string
+	for _, s := range e.errs {
+		msgs = append(msgs, s.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// ReadCorpus reads the corpus from the provided dir. The returned corpus
+// entries are guaranteed to match the given types. Any malformed files will
+// be saved in a MalformedCorpusError and returned, along with the most recent
+// error.
+func ReadCorpus(dir string, types []reflect.Type) ([]CorpusEntry, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil // No corpus to read
+	} else if err != nil {
+		return nil, fmt.Errorf("reading seed corpus from testdata: %v", err)
+	}
+	var corpus []CorpusEntry
+	var errs []error
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		filename := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read corpus file: %v", err)
+		}
+		var vals []interface{}
+		vals, err = readCorpusData(data, types)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%q: %v", filename, err))
+			continue
+		}
+		corpus = append(corpus, CorpusEntry{Path: filename, Values: vals})
+	}
+	if len(errs) > 0 {
+		return corpus, &MalformedCorpusError{errs: errs}
+	}
+	return corpus, nil
+}
+
+func readCorpusData(data []byte, types []reflect.Type) ([]interface{}, error) {
+	vals, err := unmarshalCorpusFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal: %v", err)
+	}
+	if err = CheckCorpus(vals, types); err != nil {
+		return nil, err
+	}
+	return vals, nil
+}
+
+// CheckCorpus verifies that the types in vals match the expected types
+// provided.
+func CheckCorpus(vals []interface{}, types []reflect.Type) error {
+	if len(vals) != len(types) {
+		return fmt.Errorf("wrong number of values in corpus entry: %d, want %d", len(vals), len(types))
+	}
+	valsT := make([]reflect.Type, len(vals))
+	for valsI, v := range vals {
+		valsT[valsI] = reflect.TypeOf(v)
+	}
+	for i := range types {
+		if valsT[i] != types[i] {
+			return fmt.Errorf("mismatched types in corpus entry: %v, want %v", valsT, types)
+		}
+	}
+	return nil
+}
+
+// writeToCorpus atomically writes the given bytes to a new file in testdata.
+// If the directory does not exist, it will create one. If the file already
+// exists, writeToCorpus will not rewrite it. writeToCorpus returns the
+// file's name, or an error if it failed.
+func writeToCorpus(b []byte, dir string) (name string, err error) {
+	sum := fmt.Sprintf("%x", sha256.Sum256(b))[:16]
+	name = filepath.Join(dir, sum)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(name, b, 0666); err != nil {
+		os.Remove(name) // remove partially written file
+		return "", err
+	}
+	return name, nil
+}
+
+func zeroValue(t reflect.Type) interface{} {
+	for _, v := range zeroVals {
+		if reflect.TypeOf(v) == t {
+			return v
+		}
+	}
+	panic(fmt.Sprintf("unsupported type: %v", t))
+}
+
+var zeroVals []interface{} = []interface{}{
+	[]byte(""),
+	string(""),
+	false,
+	byte(0),
+	rune(0),
+	float32(0),
+	float64(0),
+	int(0),
+	int8(0),
+	int16(0),
+	int32(0),
+	int64(0),
+	uint(0),
+	uint8(0),
+	uint16(0),
+	uint32(0),
+	uint64(0),
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fuzz
+
+import (
+	"math"
+)
+
+// minimizeInput attempts to find a smaller set of values that still causes
+// fails to return true. vals is modified in place; on return it holds the
+// smallest failing values found. shouldStop is consulted before each
+// attempt so that minimization can be bounded in time or executions.
+func minimizeInput(vals []interface{}, fails func([]interface{}) bool, shouldStop func() bool) {
+	candidate := make([]interface{}, len(vals))
+	tryVal := func(i int, v interface{}) bool {
+		copy(candidate, vals)
+		candidate[i] = v
+		if !fails(candidate) {
+			return false
+		}
+		vals[i] = v
+		return true
+	}
+
+	for i, v := range vals {
+		switch v := v.(type) {
+		case []byte:
+			b := append([]byte(nil), v...)
+			minimizeBytes(b, func(c []byte) bool {
+				return tryVal(i, append([]byte(nil), c...))
+			}, shouldStop)
+		case string:
+			minimizeBytes([]byte(v), func(c []byte) bool {
+				return tryVal(i, string(c))
+			}, shouldStop)
+		case int:
+			minimizeInteger(uint64(v), true, func(u uint64) bool { return tryVal(i, int(u)) }, shouldStop)
+		case int8:
+			minimizeInteger(uint64(v), true, func(u uint64) bool { return tryVal(i, int8(u)) }, shouldStop)
+		case int16:
+			minimizeInteger(uint64(v), true, func(u uint64) bool { return tryVal(i, int16(u)) }, shouldStop)
+		case int32:
+			minimizeInteger(uint64(v), true, func(u uint64) bool { return tryVal(i, int32(u)) }, shouldStop)
+		case int64:
+			minimizeInteger(uint64(v), true, func(u uint64) bool { return tryVal(i, int64(u)) }, shouldStop)
+		case uint:
+			minimizeInteger(uint64(v), false, func(u uint64) bool { return tryVal(i, uint(u)) }, shouldStop)
+		case uint8:
+			minimizeInteger(uint64(v), false, func(u uint64) bool { return tryVal(i, uint8(u)) }, shouldStop)
+		case uint16:
+			minimizeInteger(uint64(v), false, func(u uint64) bool { return tryVal(i, uint16(u)) }, shouldStop)
+		case uint32:
+			minimizeInteger(uint64(v), false, func(u uint64) bool { return tryVal(i, uint32(u)) }, shouldStop)
+		case uint64:
+			minimizeInteger(v, false, func(u uint64) bool { return tryVal(i, u) }, shouldStop)
+		case float32:
+			minimizeFloat(float64(v), func(f float64) bool { return tryVal(i, float32(f)) }, shouldStop)
+		case float64:
+			minimizeFloat(v, func(f float64) bool { return tryVal(i, f) }, shouldStop)
+		}
+	}
+}
+
+// minimizeBytes shrinks v while try reports that the candidate still fails.
+// try must not retain the slice passed to it.
+func minimizeBytes(v []byte, try func([]byte) bool, shouldStop func() bool) {
+	tmp := make([]byte, len(v))
+
+	// First, try to cut the tail.
+	for n := 1024; n != 0; n /= 2 {
+		for len(v) > n {
+			if shouldStop() {
+				return
+			}
+			candidate := v[:len(v)-n]
+			if !try(candidate) {
+				break
+			}
+			// Set v to the new value to continue iterating.
+			v = candidate
+		}
+	}
+
+	// Then, try to remove each individual byte.
+	for i := 0; i < len(v)-1; i++ {
+		if shouldStop() {
+			return
+		}
+		candidate := tmp[:len(v)-1]
+		copy(candidate[:i], v[:i])
+		copy(candidate[i:], v[i+1:])
+		if !try(candidate) {
+			continue
+		}
+		// Update v to delete the value at index i.
+		copy(v[i:], v[i+1:])
+		v = v[:len(candidate)]
+		// v[i] is now different, so decrement i to redo this iteration
+		// of the loop with the new value.
+		i--
+	}
+
+	// Then, try to remove each possible subset of bytes.
+	for i := 0; i < len(v)-1; i++ {
+		copy(tmp, v[:i])
+		for j := len(v); j > i+1; j-- {
+			if shouldStop() {
+				return
+			}
+			candidate := tmp[:len(v)-j+i]
+			copy(candidate[i:], v[j:])
+			if !try(candidate) {
+				continue
+			}
+			// Update v and reset the loop with the new length.
+			copy(v[i:], v[j:])
+			v = v[:len(candidate)]
+			j = len(v)
+		}
+	}
+
+	// Then, try to make it more simplified and human-readable by trying to
+	// replace each byte with a printable character.
+	printableChars := []byte("012789ABCXYZabcxyz !\"#$%&'()*+,.")
+	for i, b := range v {
+		if shouldStop() {
+			return
+		}
+		for _, pc := range printableChars {
+			if pc == b {
+				break
+			}
+			v[i] = pc
+			if try(v) {
+				// Successful. Move on to the next byte in v.
+				break
+			}
+			// Unsuccessful. Revert v[i] back to original value.
+			v[i] = b
+		}
+	}
+}
+
+// minimizeInteger tries zero and then successively smaller magnitudes of v,
+// keeping each candidate for which try reports that it still fails. The
+// value is passed around as a uint64 so that a single implementation covers
+// every integer width; callers convert back to the original type.
+func minimizeInteger(v uint64, signed bool, try func(uint64) bool, shouldStop func() bool) {
+	if v == 0 || shouldStop() {
+		return
+	}
+	if try(0) {
+		return
+	}
+	// Try removing digits from the end, in the decimal representation a
+	// human would write.
+	neg := signed && int64(v) < 0
+	for {
+		if shouldStop() {
+			return
+		}
+		var next uint64
+		if neg {
+			next = uint64(int64(v) / 10)
+		} else {
+			next = v / 10
+		}
+		if next == 0 || next == v || !try(next) {
+			return
+		}
+		v = next
+	}
+}
+
+// minimizeFloat tries zero and then truncations of v to successively more
+// decimal places, keeping the first one that still fails.
+func minimizeFloat(v float64, try func(float64) bool, shouldStop func() bool) {
+	if v == 0 || math.IsNaN(v) || shouldStop() {
+		return
+	}
+	if try(0) {
+		return
+	}
+	for div := 1.0; div <= math.MaxInt32; div *= 10 {
+		if shouldStop() {
+			return
+		}
+		next := math.Trunc(v*div) / div
+		if next == v {
+			return
+		}
+		if try(next) {
+			return
+		}
+	}
+}
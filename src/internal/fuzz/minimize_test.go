@@ -0,0 +1,107 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fuzz
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMinimizeInput(t *testing.T) {
+	never := func() bool { return false }
+	cases := []struct {
+		name  string
+		input []interface{}
+		fails func([]interface{}) bool
+		want  []interface{}
+	}{
+		{
+			name:  "ones_byte",
+			input: []interface{}{[]byte{0, 1, 1, 0, 1, 0, 0, 1, 1, 1}},
+			fails: func(v []interface{}) bool {
+				return bytes.Count(v[0].([]byte), []byte{1}) >= 3
+			},
+			want: []interface{}{[]byte{1, 1, 1}},
+		},
+		{
+			name:  "single_bytes",
+			input: []interface{}{[]byte{1, 2, 3, 4, 5}},
+			fails: func(v []interface{}) bool {
+				b := v[0].([]byte)
+				return len(b) >= 2
+			},
+			want: []interface{}{[]byte("00")},
+		},
+		{
+			name:  "ones_string",
+			input: []interface{}{"001010001000000000000000000"},
+			fails: func(v []interface{}) bool {
+				return bytes.Count([]byte(v[0].(string)), []byte("1")) >= 3
+			},
+			// Can't make the string smaller since the check requires
+			// three 1s, but every other byte can be removed.
+			want: []interface{}{"111"},
+		},
+		{
+			name:  "int",
+			input: []interface{}{123456},
+			fails: func(v []interface{}) bool {
+				return v[0].(int) > 100
+			},
+			want: []interface{}{123},
+		},
+		{
+			name:  "negative_int",
+			input: []interface{}{int64(-98765)},
+			fails: func(v []interface{}) bool {
+				return v[0].(int64) < -50
+			},
+			want: []interface{}{int64(-98)},
+		},
+		{
+			name:  "float",
+			input: []interface{}{3.14159},
+			fails: func(v []interface{}) bool {
+				return v[0].(float64) > 3
+			},
+			want: []interface{}{3.1},
+		},
+		{
+			name:  "multiple",
+			input: []interface{}{[]byte("abcdef"), uint16(500)},
+			fails: func(v []interface{}) bool {
+				return len(v[0].([]byte)) > 0 && v[1].(uint16) != 0
+			},
+			want: []interface{}{[]byte("0"), uint16(5)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vals := append([]interface{}(nil), tc.input...)
+			minimizeInput(vals, tc.fails, never)
+			if !reflect.DeepEqual(vals, tc.want) {
+				t.Errorf("got %#v, want %#v", vals, tc.want)
+			}
+		})
+	}
+}
+
+// TestMinimizeInputStop checks that minimization stops as soon as
+// shouldStop reports true and leaves the input untouched.
+func TestMinimizeInputStop(t *testing.T) {
+	vals := []interface{}{[]byte("0123456789")}
+	calls := 0
+	minimizeInput(vals, func([]interface{}) bool {
+		calls++
+		return true
+	}, func() bool { return true })
+	if calls != 0 {
+		t.Errorf("fails called %d times, want 0", calls)
+	}
+	if got := string(vals[0].([]byte)); got != "0123456789" {
+		t.Errorf("input changed to %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fuzz
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkMutatorBytes(b *testing.B) {
+	for _, size := range []int{1, 10, 100, 1000, 10000, 100000} {
+		size := size
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			buf := make([]byte, size)
+			m := newMutator(1)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				vals := []interface{}{buf}
+				m.mutate(vals, 2*size+1000)
+			}
+		})
+	}
+}
+
+func TestByteSliceMutators(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		mut   func(*mutator, []byte) []byte
+		input []byte
+	}{
+		{"RemoveBytes", byteSliceRemoveBytes, []byte{1, 2, 3, 4}},
+		{"InsertRandomBytes", byteSliceInsertRandomBytes, make([]byte, 4, 8)},
+		{"DuplicateBytes", byteSliceDuplicateBytes, append(make([]byte, 0, 13), []byte{1, 2, 3, 4}...)},
+		{"OverwriteBytes", byteSliceOverwriteBytes, []byte{1, 1, 1, 1}},
+		{"BitFlip", byteSliceBitFlip, []byte{0, 0, 0, 0}},
+		{"XORByte", byteSliceXORByte, []byte{0, 0, 0, 0}},
+		{"SwapByte", byteSliceSwapByte, []byte{0, 1, 2, 3}},
+		{"ArithmeticUint8", byteSliceArithmeticUint8, []byte{0, 0, 0, 0}},
+		{"ArithmeticUint16", byteSliceArithmeticUint16, []byte{0, 0, 0, 0}},
+		{"ArithmeticUint32", byteSliceArithmeticUint32, []byte{0, 0, 0, 0}},
+		{"ArithmeticUint64", byteSliceArithmeticUint64, []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{"OverwriteInterestingUint8", byteSliceOverwriteInterestingUint8, []byte{0, 0, 0, 0}},
+		{"OverwriteInterestingUint16", byteSliceOverwriteInterestingUint16, []byte{0, 0, 0, 0}},
+		{"OverwriteInterestingUint32", byteSliceOverwriteInterestingUint32, []byte{0, 0, 0, 0}},
+		{"InsertConstantBytes", byteSliceInsertConstantBytes, append(make([]byte, 0, 20), []byte{1, 2, 3, 4}...)},
+		{"OverwriteConstantBytes", byteSliceOverwriteConstantBytes, []byte{1, 2, 3, 4}},
+		{"ShuffleBytes", byteSliceShuffleBytes, []byte{1, 2, 3, 4}},
+		{"SwapBytes", byteSliceSwapBytes, append(make([]byte, 0, 13), []byte{1, 2, 3, 4}...)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newMutator(1)
+			for i := 0; i < 1000; i++ {
+				in := append(make([]byte, 0, cap(tc.input)), tc.input...)
+				out := tc.mut(m, in)
+				if out == nil {
+					continue
+				}
+				if cap(out) > cap(tc.input) {
+					t.Fatalf("mutator grew capacity from %d to %d", cap(tc.input), cap(out))
+				}
+			}
+		})
+	}
+}
+
+// TestMutateStaysInBounds checks that repeated mutation of every supported
+// type never exceeds the byte budget or panics.
+func TestMutateStaysInBounds(t *testing.T) {
+	const maxBytes = 1 << 10
+	m := newMutator(2)
+	vals := []interface{}{
+		[]byte("hello"), "world", int(1), int8(2), int16(3), int32(4), int64(5),
+		uint(6), uint8(7), uint16(8), uint32(9), uint64(10),
+		float32(1.5), float64(2.5), true,
+	}
+	for i := 0; i < 100000; i++ {
+		m.mutate(vals, maxBytes*len(vals))
+		for _, v := range vals {
+			switch v := v.(type) {
+			case []byte:
+				if len(v) > maxBytes {
+					t.Fatalf("[]byte grew to %d bytes", len(v))
+				}
+			case string:
+				if len(v) > maxBytes {
+					t.Fatalf("string grew to %d bytes", len(v))
+				}
+			}
+		}
+	}
+}
@@ -2,15 +2,15 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build linux && (arm64 || riscv64)
+//go:build linux && (arm64 || loong64 || riscv64)
 // +build linux
-// +build arm64 riscv64
+// +build arm64 loong64 riscv64
 
 package unix
 
 // This file is named "generic" because at a certain point Linux started
 // standardizing on system call numbers across architectures. So far this
-// means only arm64 and riscv64 use the standard numbers.
+// means only arm64, loong64 and riscv64 use the standard numbers.
 
 const (
 	getrandomTrap     uintptr = 278
@@ -176,3 +176,31 @@ func (fd *netFD) dup() (*os.File, error) {
 	// TODO: Implement this
 	return nil, syscall.EWINDOWS
 }
+
+// The address-family specific variants below go through the generic
+// Sockaddr-based calls; unlike on Unix systems they are not
+// allocation free.
+
+func (fd *netFD) readFromInet4(p []byte, from *syscall.SockaddrInet4) (n int, err error) {
+	n, sa, err := fd.readFrom(p)
+	if sa, ok := sa.(*syscall.SockaddrInet4); ok {
+		*from = *sa
+	}
+	return n, err
+}
+
+func (fd *netFD) readFromInet6(p []byte, from *syscall.SockaddrInet6) (n int, err error) {
+	n, sa, err := fd.readFrom(p)
+	if sa, ok := sa.(*syscall.SockaddrInet6); ok {
+		*from = *sa
+	}
+	return n, err
+}
+
+func (fd *netFD) writeToInet4(p []byte, sa *syscall.SockaddrInet4) (n int, err error) {
+	return fd.writeTo(p, sa)
+}
+
+func (fd *netFD) writeToInet6(p []byte, sa *syscall.SockaddrInet6) (n int, err error) {
+	return fd.writeTo(p, sa)
+}
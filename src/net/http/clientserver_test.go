@@ -7,6 +7,7 @@
 package http_test
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"crypto/rand"
@@ -1582,3 +1583,147 @@ func TestH12_WebSocketUpgrade(t *testing.T) {
 		},
 	}.run(t)
 }
+
+func TestUnencryptedHTTP2PriorKnowledge(t *testing.T) {
+	CondSkipHTTP2(t)
+	defer afterTest(t)
+	ts := httptest.NewUnstartedServer(HandlerFunc(func(w ResponseWriter, r *Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s", r.Proto, body)
+	}))
+	ts.Config.Protocols = new(Protocols)
+	ts.Config.Protocols.SetHTTP1(true)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	tr := &Transport{Protocols: new(Protocols)}
+	tr.Protocols.SetUnencryptedHTTP2(true)
+	defer tr.CloseIdleConnections()
+	c := &Client{Transport: tr}
+
+	for _, tt := range []struct {
+		c    *Client
+		want string
+	}{
+		{c, "HTTP/2.0 hello"},
+		{ts.Client(), "HTTP/1.1 hello"},
+	} {
+		res, err := tt.c.Post(ts.URL, "text/plain", strings.NewReader("hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("got %q; want %q", got, tt.want)
+		}
+	}
+}
+
+// Unencrypted HTTP/2 connections are shared between requests and
+// closed by CloseIdleConnections, like "h2" connections over TLS.
+func TestUnencryptedHTTP2ConnReuse(t *testing.T) {
+	CondSkipHTTP2(t)
+	defer afterTest(t)
+	ts := httptest.NewUnstartedServer(HandlerFunc(func(w ResponseWriter, r *Request) {
+		io.WriteString(w, r.RemoteAddr)
+	}))
+	ts.Config.Protocols = new(Protocols)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	tr := &Transport{Protocols: new(Protocols)}
+	tr.Protocols.SetUnencryptedHTTP2(true)
+	defer tr.CloseIdleConnections()
+	c := &Client{Transport: tr}
+
+	get := func() string {
+		t.Helper()
+		res, err := c.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		if res.ProtoMajor != 2 {
+			t.Fatalf("got %v; want HTTP/2", res.Proto)
+		}
+		addr, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(addr)
+	}
+	addr1 := get()
+	if addr2 := get(); addr2 != addr1 {
+		t.Errorf("second request came from %v; want reused connection from %v", addr2, addr1)
+	}
+	tr.CloseIdleConnections()
+	if addr3 := get(); addr3 == addr1 {
+		t.Errorf("request after CloseIdleConnections reused connection from %v", addr1)
+	}
+}
+
+func TestUnencryptedHTTP2Upgrade(t *testing.T) {
+	CondSkipHTTP2(t)
+	defer afterTest(t)
+	ts := httptest.NewUnstartedServer(HandlerFunc(func(w ResponseWriter, r *Request) {
+		if r.Header.Get("Upgrade") != "" || r.Header.Get("Http2-Settings") != "" {
+			t.Errorf("upgrade headers not removed: %v", r.Header)
+		}
+		fmt.Fprintf(w, "%s %s", r.Proto, r.URL.Path)
+	}))
+	ts.Config.Protocols = new(Protocols)
+	ts.Config.Protocols.SetHTTP1(true)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	// SETTINGS_MAX_CONCURRENT_STREAMS = 100.
+	io.WriteString(conn, "GET /upgraded HTTP/1.1\r\nHost: example.com\r\n"+
+		"Connection: Upgrade, HTTP2-Settings\r\nUpgrade: h2c\r\n"+
+		"HTTP2-Settings: AAMAAABk\r\n\r\n")
+	br := bufio.NewReader(conn)
+	res, err := ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != StatusSwitchingProtocols || res.Header.Get("Upgrade") != "h2c" {
+		t.Fatalf("got response %v %v; want 101 with Upgrade: h2c", res.Status, res.Header)
+	}
+
+	// Client connection preface, followed by an empty SETTINGS frame.
+	io.WriteString(conn, "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+	conn.Write([]byte{0, 0, 0, 0x4, 0, 0, 0, 0, 0})
+
+	// Read frames until the DATA frame of the upgraded request's stream.
+	var hdr [9]byte
+	for {
+		if _, err := io.ReadFull(br, hdr[:]); err != nil {
+			t.Fatal(err)
+		}
+		payload := make([]byte, int(hdr[0])<<16|int(hdr[1])<<8|int(hdr[2]))
+		if _, err := io.ReadFull(br, payload); err != nil {
+			t.Fatal(err)
+		}
+		const frameData = 0x0
+		streamID := uint32(hdr[5]&0x7f)<<24 | uint32(hdr[6])<<16 | uint32(hdr[7])<<8 | uint32(hdr[8])
+		if hdr[3] == frameData && streamID == 1 {
+			if got, want := string(payload), "HTTP/2.0 /upgraded"; got != want {
+				t.Errorf("got body %q; want %q", got, want)
+			}
+			return
+		}
+	}
+}
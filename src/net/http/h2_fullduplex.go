@@ -0,0 +1,15 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !nethttpomithttp2
+// +build !nethttpomithttp2
+
+package http
+
+// EnableFullDuplex reports success: HTTP/2 handlers may always read
+// the request body while writing the response.
+// It implements ResponseController.EnableFullDuplex.
+func (w *http2responseWriter) EnableFullDuplex() error {
+	return nil
+}
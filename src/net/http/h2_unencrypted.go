@@ -0,0 +1,38 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !nethttpomithttp2
+// +build !nethttpomithttp2
+
+package http
+
+import (
+	"errors"
+	"net"
+)
+
+// addUnencryptedHTTP2Conn starts an HTTP/2 client connection on c,
+// which speaks HTTP/2 without TLS ("prior knowledge") to authority.
+// The connection is added to t2's pool, as the upgrade function
+// installed by http2configureTransports does for connections that
+// negotiated "h2" over TLS, and t2 is returned as the RoundTripper
+// to use for it.
+//
+// If c cannot be used, it is closed and an error is returned.
+func addUnencryptedHTTP2Conn(t2 *http2Transport, authority string, c net.Conn) (RoundTripper, error) {
+	p, ok := t2.ConnPool.(http2noDialClientConnPool)
+	if !ok {
+		c.Close()
+		return nil, errors.New("net/http: unencrypted HTTP/2 requires the default HTTP/2 connection pool")
+	}
+	cc, err := t2.NewClientConn(c)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	p.mu.Lock()
+	p.addConnLocked(http2authorityAddr("http", authority), cc)
+	p.mu.Unlock()
+	return t2, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !nethttpomithttp2
+// +build !nethttpomithttp2
+
+package http
+
+import (
+	"compress/zstd"
+	"io"
+
+	"net/http/internal/ascii"
+)
+
+// offerZstdHTTP2 returns rt, an HTTP/2 RoundTripper, wrapped so that
+// its requests offer zstd compression if t.EnableZstdCompression is set.
+//
+// The HTTP/2 transport only knows how to ask for gzip, so in that case
+// the wrapper sends "Accept-Encoding: zstd, gzip" itself and decodes
+// either encoding in the response, as the HTTP/1 transport does.
+func (t *Transport) offerZstdHTTP2(rt RoundTripper) RoundTripper {
+	if !t.EnableZstdCompression || t.DisableCompression {
+		return rt
+	}
+	return http2ZstdRoundTripper{rt}
+}
+
+type http2ZstdRoundTripper struct {
+	rt RoundTripper
+}
+
+func (z http2ZstdRoundTripper) RoundTrip(req *Request) (*Response, error) {
+	// Same conditions as for the transparent gzip in
+	// persistConn.roundTrip and the HTTP/2 transport.
+	if req.Header.Get("Accept-Encoding") != "" ||
+		req.Header.Get("Range") != "" ||
+		req.Method == "HEAD" {
+		return z.rt.RoundTrip(req)
+	}
+	r2 := new(Request)
+	*r2 = *req
+	r2.Header = req.Header.Clone()
+	if r2.Header == nil {
+		r2.Header = make(Header)
+	}
+	r2.Header.Set("Accept-Encoding", "zstd, gzip")
+
+	res, err := z.rt.RoundTrip(r2)
+	if err != nil {
+		return nil, err
+	}
+	switch ce := res.Header.Get("Content-Encoding"); {
+	case ascii.EqualFold(ce, "gzip"):
+		res.Body = &http2gzipReader{body: res.Body}
+	case ascii.EqualFold(ce, "zstd"):
+		res.Body = &http2ZstdReader{body: res.Body}
+	default:
+		return res, nil
+	}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return res, nil
+}
+
+// http2ZstdReader wraps an HTTP/2 response body so it can lazily
+// call zstd.NewReader on the first call to Read.
+type http2ZstdReader struct {
+	_    incomparable
+	body io.ReadCloser // underlying Response.Body
+	zr   *zstd.Reader  // lazily-initialized zstd reader
+	zerr error         // sticky error
+}
+
+func (zs *http2ZstdReader) Read(p []byte) (n int, err error) {
+	if zs.zerr != nil {
+		return 0, zs.zerr
+	}
+	if zs.zr == nil {
+		zs.zr, err = zstd.NewReader(zs.body)
+		if err != nil {
+			zs.zerr = err
+			return 0, err
+		}
+	}
+	return zs.zr.Read(p)
+}
+
+func (zs *http2ZstdReader) Close() error {
+	return zs.body.Close()
+}
@@ -0,0 +1,575 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP/3 (RFC 9114) framing shared by the client and server.
+//
+// See h3_transport.go for the client, h3_server.go for the server and
+// h3_qpack.go for field compression. The QUIC transport is in
+// net/http/internal/quic.
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/internal/ascii"
+	"net/http/internal/quic"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/http2/hpack"
+)
+
+const h3NextProtoTLS = "h3"
+
+// HTTP/3 frame types.
+const (
+	h3FrameData        = 0x00
+	h3FrameHeaders     = 0x01
+	h3FrameCancelPush  = 0x03
+	h3FrameSettings    = 0x04
+	h3FramePushPromise = 0x05
+	h3FrameGoAway      = 0x07
+	h3FrameMaxPushID   = 0x0d
+)
+
+// HTTP/3 unidirectional stream types.
+const (
+	h3StreamControl      = 0x00
+	h3StreamPush         = 0x01
+	h3StreamQPACKEncoder = 0x02
+	h3StreamQPACKDecoder = 0x03
+)
+
+// HTTP/3 settings.
+const (
+	h3SettingQPACKMaxTableCapacity = 0x01
+	h3SettingMaxFieldSectionSize   = 0x06
+	h3SettingQPACKBlockedStreams   = 0x07
+)
+
+// HTTP/3 error codes (RFC 9114, Section 8.1, and RFC 9204, Section 6).
+const (
+	h3NoError              = 0x100
+	h3GeneralProtocolError = 0x101
+	h3InternalError        = 0x102
+	h3StreamCreationError  = 0x103
+	h3ClosedCriticalStream = 0x104
+	h3FrameUnexpected      = 0x105
+	h3FrameError           = 0x106
+	h3ExcessiveLoad        = 0x107
+	h3IDError              = 0x108
+	h3SettingsError        = 0x109
+	h3MissingSettings      = 0x10a
+	h3RequestRejected      = 0x10b
+	h3RequestCancelled     = 0x10c
+	h3RequestIncomplete    = 0x10d
+	h3MessageError         = 0x10e
+	qpackDecompressionFail = 0x200
+)
+
+// An h3ConnError is a protocol error that closes the whole connection.
+type h3ConnError struct {
+	code   uint64
+	reason string
+}
+
+func (e *h3ConnError) Error() string {
+	return fmt.Sprintf("http3: connection error %#x: %s", e.code, e.reason)
+}
+
+// An h3StreamError is a protocol error that aborts one request stream.
+type h3StreamError struct {
+	code   uint64
+	reason string
+}
+
+func (e *h3StreamError) Error() string {
+	return fmt.Sprintf("http3: stream error %#x: %s", e.code, e.reason)
+}
+
+// h3Abort resets both directions of s after err, and closes the
+// connection if err is a connection error.
+func h3Abort(qc *quic.Conn, s *quic.Stream, err error) {
+	code := uint64(h3InternalError)
+	var ce *h3ConnError
+	var se *h3StreamError
+	switch {
+	case errors.As(err, &ce):
+		qc.CloseWithError(ce.code, ce.reason)
+		return
+	case errors.As(err, &se):
+		code = se.code
+	}
+	s.Reset(code)
+	s.CloseRead(code)
+}
+
+// h3AppendFrameHeader appends the type and length of a frame to b.
+func h3AppendFrameHeader(b []byte, typ uint64, length int) []byte {
+	b = quic.AppendVarint(b, typ)
+	return quic.AppendVarint(b, uint64(length))
+}
+
+// h3WriteFrame writes a frame with the given payload to w.
+func h3WriteFrame(w io.Writer, typ uint64, payload []byte) error {
+	b := make([]byte, 0, 16+len(payload))
+	b = h3AppendFrameHeader(b, typ, len(payload))
+	_, err := w.Write(append(b, payload...))
+	return err
+}
+
+// h3WriteData writes p to w as a DATA frame.
+func h3WriteData(w io.Writer, p []byte) error {
+	if _, err := w.Write(h3AppendFrameHeader(nil, h3FrameData, len(p))); err != nil {
+		return err
+	}
+	_, err := w.Write(p)
+	return err
+}
+
+// h3Settings is the payload of our SETTINGS frame. The QPACK dynamic
+// table capacity and blocked streams keep their default of zero.
+func h3Settings(maxFieldSectionSize int64) []byte {
+	b := quic.AppendVarint(nil, h3SettingMaxFieldSectionSize)
+	return quic.AppendVarint(b, uint64(maxFieldSectionSize))
+}
+
+// An h3FrameReader reads HTTP/3 frames from a stream.
+type h3FrameReader struct {
+	r *bufio.Reader
+}
+
+func newH3FrameReader(r io.Reader) *h3FrameReader {
+	return &h3FrameReader{r: bufio.NewReader(r)}
+}
+
+// next returns the type and length of the next frame, discarding
+// frames of unknown and reserved types (RFC 9114, Section 9). It
+// returns io.EOF at the end of the stream between frames.
+func (fr *h3FrameReader) next() (typ uint64, length int64, err error) {
+	for {
+		typ, err = quic.ReadVarint(fr.r)
+		if err != nil {
+			return 0, 0, err
+		}
+		n, err := quic.ReadVarint(fr.r)
+		if err != nil {
+			return 0, 0, h3Truncated(err)
+		}
+		length = int64(n)
+		switch typ {
+		case h3FrameData, h3FrameHeaders, h3FrameCancelPush, h3FrameSettings,
+			h3FramePushPromise, h3FrameGoAway, h3FrameMaxPushID:
+			return typ, length, nil
+		case 0x02, 0x06, 0x08, 0x09:
+			// Frame types used by HTTP/2 but not HTTP/3.
+			return 0, 0, &h3ConnError{h3FrameUnexpected, "HTTP/2 frame type"}
+		}
+		if _, err := fr.r.Discard(int(length)); err != nil {
+			return 0, 0, h3Truncated(err)
+		}
+	}
+}
+
+// payload reads a frame payload of length bytes, failing with
+// errH3HeaderTooLarge if it is longer than max.
+func (fr *h3FrameReader) payload(length, max int64) ([]byte, error) {
+	if length > max {
+		return nil, errH3HeaderTooLarge
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(fr.r, b); err != nil {
+		return nil, h3Truncated(err)
+	}
+	return b, nil
+}
+
+// h3Truncated maps the end of a stream in the middle of a frame to a
+// frame error.
+func h3Truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return &h3ConnError{h3FrameError, "truncated frame"}
+	}
+	return err
+}
+
+// readFields reads and decodes the HEADERS frame of the given length.
+func (fr *h3FrameReader) readFields(length, maxSize int64) ([]hpack.HeaderField, error) {
+	b, err := fr.payload(length, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	var fields []hpack.HeaderField
+	err = qpackDecode(b, maxSize, func(f hpack.HeaderField) {
+		fields = append(fields, f)
+	})
+	switch err {
+	case nil:
+	case errH3HeaderTooLarge:
+		return nil, err
+	default:
+		return nil, &h3ConnError{qpackDecompressionFail, err.Error()}
+	}
+	return fields, nil
+}
+
+// h3ConnHeaders are the connection-specific fields HTTP/3 forbids
+// (RFC 9114, Section 4.2).
+var h3ConnHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Connection",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// h3AppendHeader appends the fields of h to fields, in lower case and
+// without connection-specific fields or invalid fields.
+func h3AppendHeader(fields []hpack.HeaderField, h Header) []hpack.HeaderField {
+	for k, vv := range h {
+		if !httpguts.ValidHeaderFieldName(k) || strings.HasPrefix(k, TrailerPrefix) {
+			continue
+		}
+		skip := false
+		for _, ch := range h3ConnHeaders {
+			if ascii.EqualFold(k, ch) {
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+		name, ok := ascii.ToLower(k)
+		if !ok {
+			continue
+		}
+		for _, v := range vv {
+			if !httpguts.ValidHeaderFieldValue(v) {
+				continue
+			}
+			if name == "te" && v != "trailers" {
+				continue
+			}
+			fields = append(fields, hpack.HeaderField{Name: name, Value: v})
+		}
+	}
+	return fields
+}
+
+// h3ValidField reports whether f is acceptable in a received field
+// section: names are lower case and free of forbidden fields.
+func h3ValidField(f hpack.HeaderField) bool {
+	if !httpguts.ValidHeaderFieldName(f.Name) || !httpguts.ValidHeaderFieldValue(f.Value) {
+		return false
+	}
+	for i := 0; i < len(f.Name); i++ {
+		if c := f.Name[i]; 'A' <= c && c <= 'Z' {
+			return false
+		}
+	}
+	for _, ch := range h3ConnHeaders {
+		if ascii.EqualFold(f.Name, ch) {
+			return false
+		}
+	}
+	return f.Name != "te" || f.Value == "trailers"
+}
+
+// h3AddField adds the regular field f to h, joining cookie fields as
+// HTTP/1 does (RFC 9114, Section 4.2.1).
+func h3AddField(h Header, f hpack.HeaderField) {
+	key := CanonicalHeaderKey(f.Name)
+	if key == "Cookie" && len(h[key]) > 0 {
+		h[key][0] += "; " + f.Value
+		return
+	}
+	h[key] = append(h[key], f.Value)
+}
+
+// h3DeclaredTrailers returns a map holding the trailers announced by
+// the Trailer fields of h, or nil if there are none.
+func h3DeclaredTrailers(h Header) Header {
+	var t Header
+	for _, v := range h["Trailer"] {
+		for _, key := range strings.Split(v, ",") {
+			key = CanonicalHeaderKey(textproto.TrimString(key))
+			switch key {
+			case "", "Transfer-Encoding", "Trailer", "Content-Length":
+				continue
+			}
+			if t == nil {
+				t = make(Header)
+			}
+			t[key] = nil
+		}
+	}
+	return t
+}
+
+// An h3Body is a request or response body: the DATA frames of a
+// request stream, optionally followed by a HEADERS frame of trailers.
+type h3Body struct {
+	s             *quic.Stream
+	qc            *quic.Conn
+	fr            *h3FrameReader
+	trailer       *Header // where received trailers are stored
+	maxHeader     int64   // limit on the size of the trailers
+	contentLength int64   // declared length, or -1
+	onDone        func()  // called once at EOF, error or Close
+
+	mu     sync.Mutex
+	remain int64 // bytes left in the current DATA frame
+	n      int64 // bytes read so far
+	err    error // sticky read error
+	closed bool
+	done   bool
+}
+
+func (b *h3Body) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return 0, errReadOnClosedResBody
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	n, err := b.read(p)
+	b.n += int64(n)
+	if err != nil {
+		b.err = err
+		if err != io.EOF {
+			h3Abort(b.qc, b.s, err)
+		}
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *h3Body) read(p []byte) (int, error) {
+	for b.remain == 0 {
+		typ, length, err := b.fr.next()
+		if err == io.EOF {
+			if b.contentLength >= 0 && b.n != b.contentLength {
+				return 0, &h3StreamError{h3MessageError, "body length does not match Content-Length"}
+			}
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch typ {
+		case h3FrameData:
+			b.remain = length
+		case h3FrameHeaders:
+			return 0, b.readTrailers(length)
+		default:
+			return 0, &h3ConnError{h3FrameUnexpected, "unexpected frame on request stream"}
+		}
+	}
+	if int64(len(p)) > b.remain {
+		p = p[:b.remain]
+	}
+	if b.contentLength >= 0 && b.n+int64(len(p)) > b.contentLength {
+		return 0, &h3StreamError{h3MessageError, "body longer than Content-Length"}
+	}
+	n, err := b.fr.r.Read(p)
+	b.remain -= int64(n)
+	if err == io.EOF {
+		err = h3Truncated(err)
+	}
+	if n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// readTrailers reads the trailers, which end the stream.
+func (b *h3Body) readTrailers(length int64) error {
+	fields, err := b.fr.readFields(length, b.maxHeader)
+	if err != nil {
+		return err
+	}
+	t := *b.trailer
+	if t == nil {
+		t = make(Header)
+	}
+	for _, f := range fields {
+		if strings.HasPrefix(f.Name, ":") || !h3ValidField(f) {
+			return &h3StreamError{h3MessageError, "invalid trailer field"}
+		}
+		key := CanonicalHeaderKey(f.Name)
+		t[key] = append(t[key], f.Value)
+	}
+	*b.trailer = t
+	if _, _, err := b.fr.next(); err != io.EOF {
+		if err == nil {
+			err = &h3ConnError{h3FrameUnexpected, "frame after trailers"}
+		}
+		return err
+	}
+	if b.contentLength >= 0 && b.n != b.contentLength {
+		return &h3StreamError{h3MessageError, "body length does not match Content-Length"}
+	}
+	return io.EOF
+}
+
+func (b *h3Body) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	if b.err == nil {
+		b.s.CloseRead(h3RequestCancelled)
+	}
+	b.finish()
+	return nil
+}
+
+func (b *h3Body) finish() {
+	if !b.done {
+		b.done = true
+		if b.onDone != nil {
+			b.onDone()
+		}
+	}
+}
+
+// h3ControlStreams handles the unidirectional streams opened by the
+// peer of qc until the connection closes. Each control frame is passed
+// to onFrame, which reports an error to close the connection with.
+func h3ControlStreams(qc *quic.Conn, onFrame func(typ uint64, payload []byte) error) {
+	var mu sync.Mutex
+	sawControl := false
+	for {
+		s, err := qc.AcceptUniStream(context.Background())
+		if err != nil {
+			return
+		}
+		go func() {
+			br := bufio.NewReader(s)
+			typ, err := quic.ReadVarint(br)
+			if err != nil {
+				s.CloseRead(h3StreamCreationError)
+				return
+			}
+			switch typ {
+			case h3StreamControl:
+				mu.Lock()
+				dup := sawControl
+				sawControl = true
+				mu.Unlock()
+				if dup {
+					qc.CloseWithError(h3StreamCreationError, "second control stream")
+					return
+				}
+				err := h3ReadControl(&h3FrameReader{r: br}, onFrame)
+				var ce *h3ConnError
+				if !errors.As(err, &ce) {
+					ce = &h3ConnError{h3ClosedCriticalStream, "control stream closed"}
+				}
+				qc.CloseWithError(ce.code, ce.reason)
+			case h3StreamPush:
+				// We never send MAX_PUSH_ID, so no push is allowed.
+				qc.CloseWithError(h3IDError, "push stream without MAX_PUSH_ID")
+			case h3StreamQPACKEncoder, h3StreamQPACKDecoder:
+				// With a zero dynamic table capacity these streams
+				// carry nothing we need, but must stay open.
+				io.Copy(io.Discard, br)
+			default:
+				s.CloseRead(h3StreamCreationError)
+			}
+		}()
+	}
+}
+
+// h3ReadControl reads the peer's control stream, which starts with a
+// SETTINGS frame.
+func h3ReadControl(fr *h3FrameReader, onFrame func(typ uint64, payload []byte) error) error {
+	first := true
+	for {
+		typ, length, err := fr.next()
+		if err != nil {
+			return h3Truncated(err)
+		}
+		if first != (typ == h3FrameSettings) {
+			if first {
+				return &h3ConnError{h3MissingSettings, "control stream must start with SETTINGS"}
+			}
+			return &h3ConnError{h3FrameUnexpected, "second SETTINGS frame"}
+		}
+		switch typ {
+		case h3FrameData, h3FrameHeaders, h3FramePushPromise:
+			return &h3ConnError{h3FrameUnexpected, "request frame on control stream"}
+		}
+		payload, err := fr.payload(length, 1<<16)
+		if err != nil {
+			return &h3ConnError{h3ExcessiveLoad, "control frame too large"}
+		}
+		if typ == h3FrameSettings {
+			if err := h3CheckSettings(payload); err != nil {
+				return err
+			}
+		}
+		if err := onFrame(typ, payload); err != nil {
+			return err
+		}
+		first = false
+	}
+}
+
+// h3CheckSettings validates a SETTINGS frame payload.
+func h3CheckSettings(b []byte) error {
+	seen := make(map[uint64]bool)
+	for len(b) > 0 {
+		id, n := quic.ConsumeVarint(b)
+		if n < 0 {
+			return &h3ConnError{h3FrameError, "malformed SETTINGS"}
+		}
+		b = b[n:]
+		_, n = quic.ConsumeVarint(b)
+		if n < 0 {
+			return &h3ConnError{h3FrameError, "malformed SETTINGS"}
+		}
+		b = b[n:]
+		if seen[id] || (id >= 0x02 && id <= 0x05) {
+			return &h3ConnError{h3SettingsError, "duplicate or HTTP/2 setting"}
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// h3GoAwayID parses the payload of a GOAWAY frame.
+func h3GoAwayID(b []byte) (uint64, error) {
+	id, n := quic.ConsumeVarint(b)
+	if n < 0 || n != len(b) {
+		return 0, &h3ConnError{h3FrameError, "malformed GOAWAY"}
+	}
+	return id, nil
+}
+
+// h3OpenControlStream opens our control stream on qc and sends our
+// SETTINGS.
+func h3OpenControlStream(qc *quic.Conn, maxFieldSectionSize int64) (*quic.Stream, error) {
+	s, err := qc.OpenUniStream(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	b := quic.AppendVarint(nil, h3StreamControl)
+	settings := h3Settings(maxFieldSectionSize)
+	b = h3AppendFrameHeader(b, h3FrameSettings, len(settings))
+	if _, err := s.Write(append(b, settings...)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
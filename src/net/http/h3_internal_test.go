@@ -0,0 +1,101 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// White-box tests for the HTTP/3 field compression and Alt-Svc parsing
+// (in package http instead of http_test).
+
+package http
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2/hpack"
+)
+
+func TestQPACKRoundTrip(t *testing.T) {
+	fields := []hpack.HeaderField{
+		{Name: ":method", Value: "GET"},                                 // static table match
+		{Name: ":path", Value: "/index.html"},                           // static name reference
+		{Name: ":authority", Value: "example.com"},                      // static name, empty value
+		{Name: "x-custom", Value: "value"},                              // literal name
+		{Name: "x-long", Value: string(bytes.Repeat([]byte("a"), 300))}, // multi-byte length
+		{Name: "content-type", Value: "text/html; charset=utf-8"},       // static table match
+		{Name: "empty", Value: ""},
+	}
+	b := qpackEncode(nil, fields)
+	var got []hpack.HeaderField
+	if err := qpackDecode(b, 1<<20, func(f hpack.HeaderField) {
+		got = append(got, f)
+	}); err != nil {
+		t.Fatalf("qpackDecode: %v", err)
+	}
+	if !reflect.DeepEqual(got, fields) {
+		t.Errorf("round trip:\n got %q\nwant %q", got, fields)
+	}
+
+	if err := qpackDecode(b, 100, func(hpack.HeaderField) {}); err != errH3HeaderTooLarge {
+		t.Errorf("qpackDecode with a small limit = %v; want %v", err, errH3HeaderTooLarge)
+	}
+}
+
+func TestQPACKDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		b    []byte
+		want error
+	}{
+		{"empty", nil, errQPACKDecode},
+		{"required insert count", []byte{0x01, 0x00}, errQPACKDynamicTable},
+		{"dynamic indexed", []byte{0x00, 0x00, 0x80}, errQPACKDynamicTable},
+		{"dynamic name reference", []byte{0x00, 0x00, 0x40, 0x00}, errQPACKDynamicTable},
+		{"post-base index", []byte{0x00, 0x00, 0x10}, errQPACKDynamicTable},
+		{"static index out of range", []byte{0x00, 0x00, 0xff, 0x30}, errQPACKDecode},
+		{"truncated value", []byte{0x00, 0x00, 0x51, 0x05, 'a'}, errQPACKDecode},
+		{"truncated integer", []byte{0x00, 0x00, 0xff}, errQPACKDecode},
+	} {
+		err := qpackDecode(test.b, 1<<20, func(hpack.HeaderField) {})
+		if err != test.want {
+			t.Errorf("%s: qpackDecode(%x) = %v; want %v", test.name, test.b, err, test.want)
+		}
+	}
+}
+
+func TestParseAltSvc(t *testing.T) {
+	for _, test := range []struct {
+		in        []string
+		want      []altSvc
+		wantClear bool
+	}{{
+		in:   []string{`h3=":443"`},
+		want: []altSvc{{proto: "h3", authority: ":443", maxAge: 24 * time.Hour}},
+	}, {
+		in: []string{`h3="alt.example.com:8443"; ma=60; persist=1, h2=":443"`},
+		want: []altSvc{
+			{proto: "h3", authority: "alt.example.com:8443", maxAge: time.Minute},
+			{proto: "h2", authority: ":443", maxAge: 24 * time.Hour},
+		},
+	}, {
+		in:   []string{`w%3Dx=":80"`},
+		want: []altSvc{{proto: "w=x", authority: ":80", maxAge: 24 * time.Hour}},
+	}, {
+		in:   []string{`h3=":443";ma=3600`, `h3-29=":443"`},
+		want: []altSvc{{proto: "h3", authority: ":443", maxAge: time.Hour}, {proto: "h3-29", authority: ":443", maxAge: 24 * time.Hour}},
+	}, {
+		in:   []string{`h3="a,b:1"; ma=5`},
+		want: []altSvc{{proto: "h3", authority: "a,b:1", maxAge: 5 * time.Second}},
+	}, {
+		in: []string{`h3=":0"`, `h3=":99999"`, `h3="noport"`, `garbage`, `=":443"`},
+	}, {
+		in:        []string{"clear"},
+		wantClear: true,
+	}} {
+		got, clear := parseAltSvc(test.in)
+		if !reflect.DeepEqual(got, test.want) || clear != test.wantClear {
+			t.Errorf("parseAltSvc(%q) = %+v, %v; want %+v, %v", test.in, got, clear, test.want, test.wantClear)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// QPACK field compression for HTTP/3 (RFC 9204).
+//
+// Both endpoints advertise a dynamic table capacity of zero, so field
+// sections only refer to the static table and there is no state to
+// share on the QPACK encoder and decoder streams.
+
+package http
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/net/http2/hpack"
+)
+
+var (
+	errQPACKDecode       = errors.New("http3: QPACK decompression failed")
+	errH3HeaderTooLarge  = errors.New("http3: field section too large")
+	errQPACKDynamicTable = errors.New("http3: QPACK field section uses the dynamic table")
+)
+
+// qpackStaticTable is the QPACK static table (RFC 9204, Appendix A).
+var qpackStaticTable = [...]hpack.HeaderField{
+	{Name: ":authority"},
+	{Name: ":path", Value: "/"},
+	{Name: "age", Value: "0"},
+	{Name: "content-disposition"},
+	{Name: "content-length", Value: "0"},
+	{Name: "cookie"},
+	{Name: "date"},
+	{Name: "etag"},
+	{Name: "if-modified-since"},
+	{Name: "if-none-match"},
+	{Name: "last-modified"},
+	{Name: "link"},
+	{Name: "location"},
+	{Name: "referer"},
+	{Name: "set-cookie"},
+	{Name: ":method", Value: "CONNECT"},
+	{Name: ":method", Value: "DELETE"},
+	{Name: ":method", Value: "GET"},
+	{Name: ":method", Value: "HEAD"},
+	{Name: ":method", Value: "OPTIONS"},
+	{Name: ":method", Value: "POST"},
+	{Name: ":method", Value: "PUT"},
+	{Name: ":scheme", Value: "http"},
+	{Name: ":scheme", Value: "https"},
+	{Name: ":status", Value: "103"},
+	{Name: ":status", Value: "200"},
+	{Name: ":status", Value: "304"},
+	{Name: ":status", Value: "404"},
+	{Name: ":status", Value: "503"},
+	{Name: "accept", Value: "*/*"},
+	{Name: "accept", Value: "application/dns-message"},
+	{Name: "accept-encoding", Value: "gzip, deflate, br"},
+	{Name: "accept-ranges", Value: "bytes"},
+	{Name: "access-control-allow-headers", Value: "cache-control"},
+	{Name: "access-control-allow-headers", Value: "content-type"},
+	{Name: "access-control-allow-origin", Value: "*"},
+	{Name: "cache-control", Value: "max-age=0"},
+	{Name: "cache-control", Value: "max-age=2592000"},
+	{Name: "cache-control", Value: "max-age=604800"},
+	{Name: "cache-control", Value: "no-cache"},
+	{Name: "cache-control", Value: "no-store"},
+	{Name: "cache-control", Value: "public, max-age=31536000"},
+	{Name: "content-encoding", Value: "br"},
+	{Name: "content-encoding", Value: "gzip"},
+	{Name: "content-type", Value: "application/dns-message"},
+	{Name: "content-type", Value: "application/javascript"},
+	{Name: "content-type", Value: "application/json"},
+	{Name: "content-type", Value: "application/x-www-form-urlencoded"},
+	{Name: "content-type", Value: "image/gif"},
+	{Name: "content-type", Value: "image/jpeg"},
+	{Name: "content-type", Value: "image/png"},
+	{Name: "content-type", Value: "text/css"},
+	{Name: "content-type", Value: "text/html; charset=utf-8"},
+	{Name: "content-type", Value: "text/plain"},
+	{Name: "content-type", Value: "text/plain;charset=utf-8"},
+	{Name: "range", Value: "bytes=0-"},
+	{Name: "strict-transport-security", Value: "max-age=31536000"},
+	{Name: "strict-transport-security", Value: "max-age=31536000; includesubdomains"},
+	{Name: "strict-transport-security", Value: "max-age=31536000; includesubdomains; preload"},
+	{Name: "vary", Value: "accept-encoding"},
+	{Name: "vary", Value: "origin"},
+	{Name: "x-content-type-options", Value: "nosniff"},
+	{Name: "x-xss-protection", Value: "1; mode=block"},
+	{Name: ":status", Value: "100"},
+	{Name: ":status", Value: "204"},
+	{Name: ":status", Value: "206"},
+	{Name: ":status", Value: "302"},
+	{Name: ":status", Value: "400"},
+	{Name: ":status", Value: "403"},
+	{Name: ":status", Value: "421"},
+	{Name: ":status", Value: "425"},
+	{Name: ":status", Value: "500"},
+	{Name: "accept-language"},
+	{Name: "access-control-allow-credentials", Value: "FALSE"},
+	{Name: "access-control-allow-credentials", Value: "TRUE"},
+	{Name: "access-control-allow-headers", Value: "*"},
+	{Name: "access-control-allow-methods", Value: "get"},
+	{Name: "access-control-allow-methods", Value: "get, post, options"},
+	{Name: "access-control-allow-methods", Value: "options"},
+	{Name: "access-control-expose-headers", Value: "content-length"},
+	{Name: "access-control-request-headers", Value: "content-type"},
+	{Name: "access-control-request-method", Value: "get"},
+	{Name: "access-control-request-method", Value: "post"},
+	{Name: "alt-svc", Value: "clear"},
+	{Name: "authorization"},
+	{Name: "content-security-policy", Value: "script-src 'none'; object-src 'none'; base-uri 'none'"},
+	{Name: "early-data", Value: "1"},
+	{Name: "expect-ct"},
+	{Name: "forwarded"},
+	{Name: "if-range"},
+	{Name: "origin"},
+	{Name: "purpose", Value: "prefetch"},
+	{Name: "server"},
+	{Name: "timing-allow-origin", Value: "*"},
+	{Name: "upgrade-insecure-requests", Value: "1"},
+	{Name: "user-agent"},
+	{Name: "x-forwarded-for"},
+	{Name: "x-frame-options", Value: "deny"},
+	{Name: "x-frame-options", Value: "sameorigin"},
+}
+
+var (
+	qpackIndexOnce sync.Once
+	qpackFieldIdx  map[hpack.HeaderField]uint64 // exact matches
+	qpackNameIdx   map[string]uint64            // first entry with each name
+)
+
+func initQPACKIndex() {
+	qpackFieldIdx = make(map[hpack.HeaderField]uint64, len(qpackStaticTable))
+	qpackNameIdx = make(map[string]uint64)
+	for i, f := range qpackStaticTable {
+		qpackFieldIdx[f] = uint64(i)
+		if _, ok := qpackNameIdx[f.Name]; !ok {
+			qpackNameIdx[f.Name] = uint64(i)
+		}
+	}
+}
+
+// qpackAppendInt appends v as a prefix integer (RFC 7541, Section 5.1)
+// whose first byte has the flag bits first and an n-bit prefix.
+func qpackAppendInt(b []byte, first byte, n uint, v uint64) []byte {
+	max := uint64(1)<<n - 1
+	if v < max {
+		return append(b, first|byte(v))
+	}
+	b = append(b, first|byte(max))
+	v -= max
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// qpackReadInt reads a prefix integer with an n-bit prefix from the
+// start of b.
+func qpackReadInt(b []byte, n uint) (v uint64, rest []byte, err error) {
+	if len(b) == 0 {
+		return 0, nil, errQPACKDecode
+	}
+	max := uint64(1)<<n - 1
+	v = uint64(b[0]) & max
+	b = b[1:]
+	if v < max {
+		return v, b, nil
+	}
+	for shift := uint(0); shift < 63; shift += 7 {
+		if len(b) == 0 {
+			return 0, nil, errQPACKDecode
+		}
+		c := b[0]
+		b = b[1:]
+		v += uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			return v, b, nil
+		}
+	}
+	return 0, nil, errQPACKDecode
+}
+
+// qpackAppendString appends s as a string literal whose length has an
+// n-bit prefix, preceded by the Huffman flag. The literal is Huffman
+// coded when that is shorter.
+func qpackAppendString(b []byte, first byte, n uint, s string) []byte {
+	if l := hpack.HuffmanEncodeLength(s); l < uint64(len(s)) {
+		b = qpackAppendInt(b, first|1<<n, n, l)
+		return hpack.AppendHuffmanString(b, s)
+	}
+	b = qpackAppendInt(b, first, n, uint64(len(s)))
+	return append(b, s...)
+}
+
+// qpackReadString reads a string literal whose length has an n-bit
+// prefix, preceded by the Huffman flag.
+func qpackReadString(b []byte, n uint) (s string, rest []byte, err error) {
+	if len(b) == 0 {
+		return "", nil, errQPACKDecode
+	}
+	huffman := b[0]&(1<<n) != 0
+	l, b, err := qpackReadInt(b, n)
+	if err != nil {
+		return "", nil, err
+	}
+	if l > uint64(len(b)) {
+		return "", nil, errQPACKDecode
+	}
+	v := b[:l]
+	if !huffman {
+		return string(v), b[l:], nil
+	}
+	s, err = hpack.HuffmanDecodeToString(v)
+	if err != nil {
+		return "", nil, errQPACKDecode
+	}
+	return s, b[l:], nil
+}
+
+// qpackEncode appends the encoded field section for fields to b.
+// Field names must be lower case.
+func qpackEncode(b []byte, fields []hpack.HeaderField) []byte {
+	qpackIndexOnce.Do(initQPACKIndex)
+	// Required Insert Count and Delta Base are both zero.
+	b = append(b, 0, 0)
+	for _, f := range fields {
+		if i, ok := qpackFieldIdx[hpack.HeaderField{Name: f.Name, Value: f.Value}]; ok {
+			// Indexed field line, static table: 1 1 index(6+).
+			b = qpackAppendInt(b, 0xc0, 6, i)
+			continue
+		}
+		if i, ok := qpackNameIdx[f.Name]; ok {
+			// Literal field line with static name reference:
+			// 0 1 N 1 index(4+), then the value.
+			b = qpackAppendInt(b, 0x50, 4, i)
+		} else {
+			// Literal field line with literal name:
+			// 0 0 1 N H length(3+), then the name and the value.
+			b = qpackAppendString(b, 0x20, 3, f.Name)
+		}
+		b = qpackAppendString(b, 0, 7, f.Value)
+	}
+	return b
+}
+
+// qpackDecode decodes the field section b, calling f for each field.
+// It fails with errH3HeaderTooLarge if the decoded size of the section
+// (RFC 9114, Section 4.2.2) exceeds maxSize.
+func qpackDecode(b []byte, maxSize int64, f func(hpack.HeaderField)) error {
+	qpackIndexOnce.Do(initQPACKIndex)
+	ric, b, err := qpackReadInt(b, 8)
+	if err != nil {
+		return err
+	}
+	if ric != 0 {
+		return errQPACKDynamicTable
+	}
+	// With no dynamic table the Base is meaningless; skip it.
+	if _, b, err = qpackReadInt(b, 7); err != nil {
+		return err
+	}
+	var size int64
+	for len(b) > 0 {
+		var hf hpack.HeaderField
+		c := b[0]
+		switch {
+		case c&0x80 != 0:
+			// Indexed field line.
+			if c&0x40 == 0 {
+				return errQPACKDynamicTable
+			}
+			var i uint64
+			if i, b, err = qpackReadInt(b, 6); err != nil {
+				return err
+			}
+			if i >= uint64(len(qpackStaticTable)) {
+				return errQPACKDecode
+			}
+			hf = qpackStaticTable[i]
+		case c&0x40 != 0:
+			// Literal field line with name reference.
+			if c&0x10 == 0 {
+				return errQPACKDynamicTable
+			}
+			hf.Sensitive = c&0x20 != 0
+			var i uint64
+			if i, b, err = qpackReadInt(b, 4); err != nil {
+				return err
+			}
+			if i >= uint64(len(qpackStaticTable)) {
+				return errQPACKDecode
+			}
+			hf.Name = qpackStaticTable[i].Name
+			if hf.Value, b, err = qpackReadString(b, 7); err != nil {
+				return err
+			}
+		case c&0x20 != 0:
+			// Literal field line with literal name.
+			hf.Sensitive = c&0x10 != 0
+			if hf.Name, b, err = qpackReadString(b, 3); err != nil {
+				return err
+			}
+			if hf.Value, b, err = qpackReadString(b, 7); err != nil {
+				return err
+			}
+		default:
+			// Post-Base forms refer to the dynamic table.
+			return errQPACKDynamicTable
+		}
+		size += int64(len(hf.Name) + len(hf.Value) + 32)
+		if size > maxSize {
+			return errH3HeaderTooLarge
+		}
+		f(hf)
+	}
+	return nil
+}
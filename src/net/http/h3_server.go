@@ -0,0 +1,684 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP/3 server support.
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http/internal/quic"
+	"net/textproto"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2/hpack"
+)
+
+// ServeQUIC accepts HTTP/3 connections on the QUIC transport it runs
+// over pc, and serves their requests with srv.Handler. ServeQUIC takes
+// ownership of pc.
+//
+// Files containing a certificate and matching private key for the
+// server must be provided if neither the Server's
+// TLSConfig.Certificates nor TLSConfig.GetCertificate are populated,
+// as for ServeTLS. The server negotiates "h3" with ALPN, regardless of
+// TLSConfig.NextProtos.
+//
+// While ServeQUIC runs, responses the server sends over TLS carry an
+// Alt-Svc header advertising the HTTP/3 endpoint, so that clients can
+// switch to it. Handlers may replace or delete that header.
+//
+// HTTP/3 connections honor IdleTimeout and MaxHeaderBytes. They do not
+// use ConnState, ConnContext, BaseContext, or the read and write
+// timeouts.
+//
+// ServeQUIC always returns a non-nil error. After Shutdown or Close,
+// the returned error is ErrServerClosed. Shutdown sends each HTTP/3
+// connection a GOAWAY frame and waits for its active requests.
+func (srv *Server) ServeQUIC(pc net.PacketConn, certFile, keyFile string) error {
+	// Let Serve and ServeTLS finish initializing srv.TLSConfig
+	// before we clone it.
+	if err := srv.setupHTTP2_ServeTLS(); err != nil {
+		pc.Close()
+		return err
+	}
+
+	config := cloneTLSConfig(srv.TLSConfig)
+	config.NextProtos = []string{h3NextProtoTLS}
+	configHasCert := len(config.Certificates) > 0 || config.GetCertificate != nil
+	if !configHasCert || certFile != "" || keyFile != "" {
+		var err error
+		config.Certificates = make([]tls.Certificate, 1)
+		config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			pc.Close()
+			return err
+		}
+	}
+
+	idle := srv.idleTimeout()
+	if idle == 0 {
+		idle = -1 // no timeout
+	}
+	h := &h3Server{
+		srv: srv,
+		ep: quic.NewEndpoint(pc, &quic.Config{
+			TLSConfig:      config,
+			MaxIdleTimeout: idle,
+		}),
+		donec: make(chan struct{}),
+		conns: make(map[*h3ServerConn]struct{}),
+	}
+	if !srv.trackQUIC(h) {
+		h.ep.Close()
+		return ErrServerClosed
+	}
+	if port := udpPort(pc.LocalAddr()); port != 0 {
+		srv.h3AltSvc.Store(fmt.Sprintf(`h3=":%d"; ma=86400`, port))
+	}
+	go h.acceptLoop()
+	<-h.donec
+	return ErrServerClosed
+}
+
+func udpPort(a net.Addr) int {
+	if ua, ok := a.(*net.UDPAddr); ok {
+		return ua.Port
+	}
+	return 0
+}
+
+// trackQUIC adds h to the set of HTTP/3 servers, and reports whether
+// the server is still up.
+func (s *Server) trackQUIC(h *h3Server) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shuttingDown() {
+		return false
+	}
+	if s.h3Servers == nil {
+		s.h3Servers = make(map[*h3Server]struct{})
+	}
+	s.h3Servers[h] = struct{}{}
+	return true
+}
+
+// closeQUICLocked closes all HTTP/3 servers and their connections.
+// s.mu must be held.
+func (s *Server) closeQUICLocked() {
+	for h := range s.h3Servers {
+		h.close()
+		delete(s.h3Servers, h)
+	}
+	s.h3AltSvc.Store("")
+}
+
+// shutdownQUICLocked starts a graceful shutdown of all HTTP/3 servers.
+// s.mu must be held.
+func (s *Server) shutdownQUICLocked() {
+	for h := range s.h3Servers {
+		h.shutdown()
+	}
+	s.h3AltSvc.Store("")
+}
+
+// closeIdleQUICLocked closes the HTTP/3 connections with no active
+// requests, and the HTTP/3 servers left with no connections. It
+// reports whether all of them are closed. s.mu must be held.
+func (s *Server) closeIdleQUICLocked() bool {
+	for h := range s.h3Servers {
+		if h.closeIdle() {
+			h.close()
+			delete(s.h3Servers, h)
+		}
+	}
+	return len(s.h3Servers) == 0
+}
+
+// altSvcHeader returns the Alt-Svc value advertising the server's
+// HTTP/3 endpoint, if any.
+func (s *Server) altSvcHeader() string {
+	v, _ := s.h3AltSvc.Load().(string)
+	return v
+}
+
+// An h3Server serves HTTP/3 on one QUIC endpoint.
+type h3Server struct {
+	srv   *Server
+	ep    *quic.Endpoint
+	donec chan struct{} // closed on shutdown or close
+
+	mu       sync.Mutex
+	conns    map[*h3ServerConn]struct{}
+	stopping bool // no new connections or requests
+}
+
+func (h *h3Server) acceptLoop() {
+	for {
+		qc, err := h.ep.Accept(context.Background())
+		if err != nil {
+			return
+		}
+		c := &h3ServerConn{h: h, qc: qc}
+		h.mu.Lock()
+		stopping := h.stopping
+		if !stopping {
+			h.conns[c] = struct{}{}
+		}
+		h.mu.Unlock()
+		if stopping {
+			qc.CloseWithError(h3NoError, "")
+			continue
+		}
+		go c.serve()
+	}
+}
+
+// shutdown stops accepting connections and requests, and sends GOAWAY
+// on each connection.
+func (h *h3Server) shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.stopping {
+		h.stopping = true
+		close(h.donec)
+	}
+	for c := range h.conns {
+		c.goAway()
+	}
+}
+
+// closeIdle closes the connections with no active requests, and
+// reports whether none are left.
+func (h *h3Server) closeIdle() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		if c.closeIfIdle() {
+			delete(h.conns, c)
+		}
+	}
+	return len(h.conns) == 0
+}
+
+// close closes the endpoint and all its connections.
+func (h *h3Server) close() {
+	h.mu.Lock()
+	if !h.stopping {
+		h.stopping = true
+		close(h.donec)
+	}
+	h.mu.Unlock()
+	h.ep.Close()
+}
+
+func (h *h3Server) removeConn(c *h3ServerConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.conns, c)
+}
+
+// An h3ServerConn is an HTTP/3 connection accepted by a Server.
+type h3ServerConn struct {
+	h  *h3Server
+	qc *quic.Conn
+
+	mu        sync.Mutex
+	ctl       *quic.Stream // our control stream
+	active    int          // requests being handled
+	nextID    int64        // ID after the last request stream accepted
+	goingAway bool
+}
+
+func (c *h3ServerConn) serve() {
+	defer c.h.removeConn(c)
+	srv := c.h.srv
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = context.WithValue(ctx, ServerContextKey, srv)
+	ctx = context.WithValue(ctx, LocalAddrContextKey, c.qc.LocalAddr())
+	go func() {
+		<-c.qc.Done()
+		cancel()
+	}()
+
+	ctl, err := h3OpenControlStream(c.qc, int64(srv.maxHeaderBytes()))
+	if err != nil {
+		c.qc.CloseWithError(h3InternalError, "")
+		return
+	}
+	c.mu.Lock()
+	c.ctl = ctl
+	if c.goingAway {
+		c.writeGoAwayLocked()
+	}
+	c.mu.Unlock()
+	go h3ControlStreams(c.qc, c.onControlFrame)
+
+	for {
+		s, err := c.qc.AcceptStream(ctx)
+		if err != nil {
+			return
+		}
+		c.mu.Lock()
+		rejected := c.goingAway
+		if !rejected {
+			c.active++
+			c.nextID = s.ID() + 4
+		}
+		c.mu.Unlock()
+		if rejected {
+			s.Reset(h3RequestRejected)
+			s.CloseRead(h3RequestRejected)
+			continue
+		}
+		go c.serveStream(ctx, s)
+	}
+}
+
+func (c *h3ServerConn) onControlFrame(typ uint64, payload []byte) error {
+	switch typ {
+	case h3FrameGoAway:
+		// A client GOAWAY only limits server push, which is
+		// never used.
+		_, err := h3GoAwayID(payload)
+		return err
+	case h3FrameCancelPush:
+		return &h3ConnError{h3IDError, "CANCEL_PUSH for a push never promised"}
+	}
+	return nil
+}
+
+// goAway tells the client that requests after the ones already
+// accepted will not be processed.
+func (c *h3ServerConn) goAway() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.goingAway {
+		return
+	}
+	c.goingAway = true
+	if c.ctl != nil {
+		c.writeGoAwayLocked()
+	}
+}
+
+func (c *h3ServerConn) writeGoAwayLocked() {
+	h3WriteFrame(c.ctl, h3FrameGoAway, quic.AppendVarint(nil, uint64(c.nextID)))
+}
+
+func (c *h3ServerConn) closeIfIdle() bool {
+	c.mu.Lock()
+	idle := c.active == 0
+	c.mu.Unlock()
+	if idle {
+		c.qc.CloseWithError(h3NoError, "")
+	}
+	return idle
+}
+
+func (c *h3ServerConn) requestDone() {
+	c.mu.Lock()
+	c.active--
+	c.mu.Unlock()
+}
+
+func (c *h3ServerConn) serveStream(ctx context.Context, s *quic.Stream) {
+	defer c.requestDone()
+	srv := c.h.srv
+	maxHeader := int64(srv.maxHeaderBytes())
+	fr := newH3FrameReader(s)
+	typ, length, err := fr.next()
+	if err == nil && typ != h3FrameHeaders {
+		err = &h3ConnError{h3FrameUnexpected, "request does not start with HEADERS"}
+	}
+	var fields []hpack.HeaderField
+	if err == nil {
+		fields, err = fr.readFields(length, maxHeader)
+	}
+	if err == errH3HeaderTooLarge {
+		w := c.newResponseWriter(s, nil)
+		w.WriteHeader(StatusRequestHeaderFieldsTooLarge)
+		w.finish()
+		s.CloseRead(h3ExcessiveLoad)
+		return
+	}
+	var req *Request
+	if err == nil {
+		req, err = c.newRequest(ctx, s, fr, fields)
+	}
+	if err != nil {
+		if err == io.EOF {
+			err = &h3StreamError{h3RequestIncomplete, "stream ended before the request header"}
+		}
+		h3Abort(c.qc, s, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	req = req.WithContext(ctx)
+	w := c.newResponseWriter(s, req)
+	defer func() {
+		if e := recover(); e != nil {
+			if e != ErrAbortHandler {
+				const size = 64 << 10
+				buf := make([]byte, size)
+				buf = buf[:runtime.Stack(buf, false)]
+				srv.logf("http3: panic serving %v: %v\n%s", c.qc.RemoteAddr(), e, buf)
+			}
+			s.Reset(h3InternalError)
+			s.CloseRead(h3InternalError)
+			return
+		}
+		w.finish()
+		// The response is complete; the rest of the request body
+		// is not needed (RFC 9114, Section 4.1).
+		s.CloseRead(h3NoError)
+	}()
+	serverHandler{srv}.ServeHTTP(w, req)
+}
+
+// newRequest builds the Request for the header fields of a request
+// stream.
+func (c *h3ServerConn) newRequest(ctx context.Context, s *quic.Stream, fr *h3FrameReader, fields []hpack.HeaderField) (*Request, error) {
+	var method, scheme, authority, path string
+	header := make(Header)
+	regular := false
+	for _, f := range fields {
+		if !strings.HasPrefix(f.Name, ":") {
+			if !h3ValidField(f) {
+				return nil, &h3StreamError{h3MessageError, "invalid header field " + f.Name}
+			}
+			regular = true
+			h3AddField(header, f)
+			continue
+		}
+		var p *string
+		switch f.Name {
+		case ":method":
+			p = &method
+		case ":scheme":
+			p = &scheme
+		case ":authority":
+			p = &authority
+		case ":path":
+			p = &path
+		}
+		if p == nil || *p != "" || regular {
+			return nil, &h3StreamError{h3MessageError, "invalid pseudo-header " + f.Name}
+		}
+		*p = f.Value
+	}
+	if method == "CONNECT" {
+		// Neither CONNECT nor extended CONNECT is supported.
+		return nil, &h3StreamError{h3RequestRejected, "CONNECT not supported"}
+	}
+	if !validMethod(method) || scheme == "" || path == "" {
+		return nil, &h3StreamError{h3MessageError, "missing or invalid pseudo-header"}
+	}
+	if authority == "" {
+		authority = header.Get("Host")
+	}
+	header.Del("Host")
+	u, err := url.ParseRequestURI(path)
+	if path == "*" && method == "OPTIONS" {
+		u, err = &url.URL{Path: "*"}, nil
+	}
+	if err != nil {
+		return nil, &h3StreamError{h3MessageError, "invalid :path"}
+	}
+
+	cs := c.qc.ConnectionState()
+	req := &Request{
+		Method:        method,
+		URL:           u,
+		Proto:         "HTTP/3.0",
+		ProtoMajor:    3,
+		Header:        header,
+		ContentLength: -1,
+		Host:          authority,
+		RemoteAddr:    c.qc.RemoteAddr().String(),
+		RequestURI:    path,
+		TLS:           &cs,
+	}
+	if cl := header["Content-Length"]; len(cl) == 1 {
+		n, err := strconv.ParseUint(cl[0], 10, 63)
+		if err != nil {
+			return nil, &h3StreamError{h3MessageError, "malformed Content-Length"}
+		}
+		req.ContentLength = int64(n)
+	} else if len(cl) > 1 {
+		return nil, &h3StreamError{h3MessageError, "multiple Content-Length fields"}
+	}
+	req.Trailer = h3DeclaredTrailers(header)
+	req.Body = &h3Body{
+		s:             s,
+		qc:            c.qc,
+		fr:            fr,
+		trailer:       &req.Trailer,
+		maxHeader:     int64(c.h.srv.maxHeaderBytes()),
+		contentLength: req.ContentLength,
+	}
+	return req.WithContext(ctx), nil
+}
+
+// h3ResponseWriter is the ResponseWriter for an HTTP/3 request.
+type h3ResponseWriter struct {
+	c   *h3ServerConn
+	s   *quic.Stream
+	req *Request // nil for responses to malformed requests
+	bw  *bufio.Writer
+
+	handlerHeader Header
+	sentHeader    Header // snapshot of handlerHeader at WriteHeader
+	status        int
+	wroteHeader   bool // WriteHeader was called
+	sentHEADERS   bool // the HEADERS frame was sent
+	contentLength int64
+	written       int64
+	handlerDone   bool
+	err           error // sticky write error
+}
+
+func (c *h3ServerConn) newResponseWriter(s *quic.Stream, req *Request) *h3ResponseWriter {
+	w := &h3ResponseWriter{
+		c:             c,
+		s:             s,
+		req:           req,
+		handlerHeader: make(Header),
+		contentLength: -1,
+	}
+	w.bw = bufio.NewWriterSize(h3DataWriter{w}, 4<<10)
+	return w
+}
+
+func (w *h3ResponseWriter) Header() Header {
+	return w.handlerHeader
+}
+
+func (w *h3ResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	checkWriteHeaderCode(code)
+	if code >= 100 && code <= 199 && code != StatusSwitchingProtocols {
+		// Informational responses are sent right away, and the
+		// handler goes on to write the final response.
+		w.writeHEADERS(code, w.handlerHeader, nil)
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+	w.sentHeader = w.handlerHeader.Clone()
+	if cl := w.sentHeader.Get("Content-Length"); cl != "" {
+		if n, err := strconv.ParseInt(cl, 10, 64); err == nil && n >= 0 {
+			w.contentLength = n
+		} else {
+			w.sentHeader.Del("Content-Length")
+		}
+	}
+}
+
+func (w *h3ResponseWriter) bodyAllowed() bool {
+	return bodyAllowedForStatus(w.status)
+}
+
+func (w *h3ResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(StatusOK)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if !w.bodyAllowed() {
+		return 0, ErrBodyNotAllowed
+	}
+	if w.contentLength >= 0 && w.written+int64(len(p)) > w.contentLength {
+		return 0, ErrContentLength
+	}
+	w.written += int64(len(p))
+	if w.req != nil && w.req.Method == "HEAD" {
+		return len(p), nil
+	}
+	return w.bw.Write(p)
+}
+
+func (w *h3ResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends any buffered data to the client.
+func (w *h3ResponseWriter) Flush() {
+	w.FlushError()
+}
+
+// FlushError is like Flush, but reports an error.
+func (w *h3ResponseWriter) FlushError() error {
+	if !w.wroteHeader {
+		w.WriteHeader(StatusOK)
+	}
+	if err := w.bw.Flush(); err != nil {
+		return err
+	}
+	if !w.sentHEADERS {
+		w.sendHeader(nil)
+	}
+	return w.err
+}
+
+// h3DataWriter writes the response body as DATA frames, sending the
+// HEADERS frame first.
+type h3DataWriter struct {
+	w *h3ResponseWriter
+}
+
+func (dw h3DataWriter) Write(p []byte) (int, error) {
+	w := dw.w
+	if !w.sentHEADERS {
+		w.sendHeader(p)
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	if err := h3WriteData(w.s, p); err != nil {
+		w.err = err
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// sendHeader sends the final response header. first is the start of
+// the body, for content sniffing.
+func (w *h3ResponseWriter) sendHeader(first []byte) {
+	w.sentHEADERS = true
+	h := w.sentHeader
+	if w.bodyAllowed() {
+		if _, ok := h["Content-Type"]; !ok && len(first) > 0 {
+			h.Set("Content-Type", DetectContentType(first))
+		}
+		if w.handlerDone && w.contentLength < 0 && !h3HasTrailers(w.handlerHeader) &&
+			(w.req == nil || w.req.Method != "HEAD" || w.written > 0) {
+			h.Set("Content-Length", strconv.FormatInt(w.written, 10))
+		}
+	}
+	if _, ok := h["Date"]; !ok {
+		h.Set("Date", string(appendTime(nil, time.Now())))
+	}
+	w.writeHEADERS(w.status, h, nil)
+}
+
+func (w *h3ResponseWriter) writeHEADERS(status int, h Header, trailers []hpack.HeaderField) {
+	var fields []hpack.HeaderField
+	if trailers != nil {
+		fields = trailers
+	} else {
+		fields = append(fields, hpack.HeaderField{Name: ":status", Value: strconv.Itoa(status)})
+		fields = h3AppendHeader(fields, h)
+	}
+	if w.err == nil {
+		w.err = h3WriteFrame(w.s, h3FrameHeaders, qpackEncode(nil, fields))
+	}
+}
+
+func h3HasTrailers(h Header) bool {
+	if len(h["Trailer"]) > 0 {
+		return true
+	}
+	for k := range h {
+		if strings.HasPrefix(k, TrailerPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// finish completes the response after the handler returns.
+func (w *h3ResponseWriter) finish() {
+	w.handlerDone = true
+	if !w.wroteHeader {
+		w.WriteHeader(StatusOK)
+	}
+	// If nothing was flushed yet, the whole body is in bw, and
+	// sendHeader can give its length.
+	w.bw.Flush()
+	if !w.sentHEADERS {
+		w.sendHeader(nil)
+	}
+
+	var trailers []hpack.HeaderField
+	for _, v := range w.sentHeader["Trailer"] {
+		for _, k := range strings.Split(v, ",") {
+			k = CanonicalHeaderKey(textproto.TrimString(k))
+			if vv, ok := w.handlerHeader[k]; ok {
+				trailers = h3AppendHeader(trailers, Header{k: vv})
+			}
+		}
+	}
+	for k, vv := range w.handlerHeader {
+		if strings.HasPrefix(k, TrailerPrefix) {
+			k = CanonicalHeaderKey(strings.TrimPrefix(k, TrailerPrefix))
+			trailers = h3AppendHeader(trailers, Header{k: vv})
+		}
+	}
+	if len(trailers) > 0 {
+		w.writeHEADERS(0, nil, trailers)
+	}
+	if w.err == nil {
+		w.err = w.s.CloseWrite()
+	}
+	if w.err != nil {
+		w.s.Reset(h3InternalError)
+	}
+}
@@ -0,0 +1,462 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	. "net/http"
+	"net/http/httptest"
+	"net/http/internal/testcert"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// h3Test is a test server serving HTTP/1 over TLS and HTTP/3, with a
+// client that uses HTTP/3 once the server advertises it.
+type h3Test struct {
+	t        *testing.T
+	ts       *httptest.Server
+	tr       *Transport
+	c        *Client
+	quicAddr net.Addr
+	serveErr chan error
+}
+
+func newH3Test(t *testing.T, h Handler) *h3Test {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP loopback: %v", err)
+	}
+	cert, err := tls.X509KeyPair(testcert.LocalhostCert, testcert.LocalhostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewUnstartedServer(h)
+	ts.Config.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	ts.StartTLS()
+	st := &h3Test{
+		t:        t,
+		ts:       ts,
+		quicAddr: pc.LocalAddr(),
+		serveErr: make(chan error, 1),
+	}
+	go func() {
+		st.serveErr <- ts.Config.ServeQUIC(pc, "", "")
+	}()
+
+	st.c = ts.Client()
+	st.tr = st.c.Transport.(*Transport)
+	p := new(Protocols)
+	p.SetHTTP1(true)
+	p.SetHTTP3(true)
+	st.tr.Protocols = p
+	t.Cleanup(func() {
+		st.tr.CloseIdleConnections()
+		ts.Close()
+		ts.Config.Close()
+	})
+	return st
+}
+
+// upgrade sends requests over TCP until the server has advertised
+// HTTP/3, so that the next request uses it. It returns the Alt-Svc
+// value the server sent.
+func (st *h3Test) upgrade() string {
+	st.t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		res, err := st.c.Get(st.ts.URL + "/upgrade")
+		if err != nil {
+			st.t.Fatal(err)
+		}
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		if res.ProtoMajor == 3 {
+			st.t.Fatal("request used HTTP/3 before the server advertised it")
+		}
+		if v := res.Header.Get("Alt-Svc"); v != "" {
+			return v
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	st.t.Fatal("server never advertised HTTP/3")
+	return ""
+}
+
+func (st *h3Test) get(path string) *Response {
+	st.t.Helper()
+	res, err := st.c.Get(st.ts.URL + path)
+	if err != nil {
+		st.t.Fatal(err)
+	}
+	return res
+}
+
+func readBody(t *testing.T, res *Response) string {
+	t.Helper()
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	return string(b)
+}
+
+func TestH3AltSvcUpgrade(t *testing.T) {
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		fmt.Fprintf(w, "%s %s", r.Proto, r.URL.Path)
+	}))
+	res := st.get("/first")
+	altSvc := res.Header.Get("Alt-Svc")
+	if got := readBody(t, res); got != "HTTP/1.1 /first" {
+		t.Errorf("first response body = %q; want it over HTTP/1.1", got)
+	}
+	if altSvc == "" {
+		// ServeQUIC has not started yet.
+		altSvc = st.upgrade()
+	}
+	want := fmt.Sprintf(`h3=":%d"; ma=86400`, st.quicAddr.(*net.UDPAddr).Port)
+	if got := altSvc; got != want {
+		t.Errorf("Alt-Svc = %q; want %q", got, want)
+	}
+
+	res = st.get("/second")
+	if res.Proto != "HTTP/3.0" || res.ProtoMajor != 3 {
+		t.Errorf("second response Proto = %q (%d); want HTTP/3.0", res.Proto, res.ProtoMajor)
+	}
+	if res.TLS == nil || res.TLS.NegotiatedProtocol != "h3" {
+		t.Errorf("second response TLS = %+v; want ALPN h3", res.TLS)
+	}
+	if res.Header.Get("Alt-Svc") != "" {
+		t.Errorf("HTTP/3 response carries Alt-Svc %q", res.Header.Get("Alt-Svc"))
+	}
+	if got := readBody(t, res); got != "HTTP/3.0 /second" {
+		t.Errorf("second response body = %q; want it over HTTP/3.0", got)
+	}
+}
+
+func TestH3Headers(t *testing.T) {
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		switch r.URL.Path {
+		case "/headers":
+			w.Header().Set("X-Host", r.Host)
+			w.Header().Set("X-Cookie", r.Header.Get("Cookie"))
+			w.Header().Set("X-Foo", r.Header.Get("X-Foo"))
+			w.Header().Set("X-UA", r.UserAgent())
+			w.Header().Add("X-Multi", "a")
+			w.Header().Add("X-Multi", "b")
+			w.WriteHeader(StatusTeapot)
+		case "/html":
+			io.WriteString(w, "<html><body>hello</body></html>")
+		case "/nocontent":
+			w.WriteHeader(StatusNoContent)
+		}
+	}))
+	st.upgrade()
+
+	req, _ := NewRequest("GET", st.ts.URL+"/headers", nil)
+	req.Header.Set("X-Foo", "bar")
+	req.Header.Add("Cookie", "a=1")
+	req.Header.Add("Cookie", "b=2")
+	res, err := st.c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readBody(t, res)
+	if res.ProtoMajor != 3 {
+		t.Fatalf("Proto = %q; want HTTP/3.0", res.Proto)
+	}
+	if res.StatusCode != StatusTeapot || res.Status != "418 I'm a teapot" {
+		t.Errorf("Status = %q", res.Status)
+	}
+	for k, want := range map[string]string{
+		"X-Host":   strings.TrimPrefix(st.ts.URL, "https://"),
+		"X-Foo":    "bar",
+		"X-Cookie": "a=1; b=2",
+		"X-Ua":     "Go-http-client/3",
+	} {
+		if got := res.Header.Get(k); got != want {
+			t.Errorf("%s = %q; want %q", k, got, want)
+		}
+	}
+	if got := res.Header["X-Multi"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("X-Multi = %q; want [a b]", got)
+	}
+	if res.Header.Get("Date") == "" {
+		t.Error("missing Date header")
+	}
+
+	res = st.get("/html")
+	if got := res.Header.Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("sniffed Content-Type = %q", got)
+	}
+	if res.ContentLength != 31 {
+		t.Errorf("ContentLength = %d; want 31", res.ContentLength)
+	}
+	readBody(t, res)
+
+	res = st.get("/nocontent")
+	if res.StatusCode != StatusNoContent || res.Body != NoBody {
+		t.Errorf("204 response: status %d, body %T", res.StatusCode, res.Body)
+	}
+
+	res, err = st.c.Head(st.ts.URL + "/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ProtoMajor != 3 || res.Body != NoBody {
+		t.Errorf("HEAD response: Proto %q, body %T", res.Proto, res.Body)
+	}
+}
+
+func TestH3LargeBodies(t *testing.T) {
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		w.Header().Set("X-Content-Length", fmt.Sprint(r.ContentLength))
+		io.Copy(w, r.Body)
+	}))
+	st.upgrade()
+
+	body := make([]byte, 4<<20)
+	rand.New(rand.NewSource(1)).Read(body)
+	res, err := st.c.Post(st.ts.URL, "application/octet-stream", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := readBody(t, res)
+	if res.ProtoMajor != 3 {
+		t.Fatalf("Proto = %q; want HTTP/3.0", res.Proto)
+	}
+	if got != string(body) {
+		t.Fatalf("echoed %d bytes; want the %d bytes sent", len(got), len(body))
+	}
+	if cl := res.Header.Get("X-Content-Length"); cl != fmt.Sprint(len(body)) {
+		t.Errorf("server saw ContentLength %s; want %d", cl, len(body))
+	}
+}
+
+func TestH3Concurrent(t *testing.T) {
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		io.WriteString(w, r.URL.Path)
+	}))
+	st.upgrade()
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := fmt.Sprintf("/req%d", i)
+			res, err := st.c.Get(st.ts.URL + path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			b, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			if err != nil || string(b) != path || res.ProtoMajor != 3 {
+				t.Errorf("%s: body %q, err %v, proto %q", path, b, err, res.Proto)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestH3Trailers(t *testing.T) {
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Trailer", "X-Declared")
+		w.Header().Set("X-Request-Trailer", r.Trailer.Get("X-Client"))
+		w.WriteHeader(StatusOK)
+		io.WriteString(w, "body")
+		w.Header().Set("X-Declared", "declared value")
+		w.Header().Set(TrailerPrefix+"X-Undeclared", "undeclared value")
+	}))
+	st.upgrade()
+
+	req, _ := NewRequest("POST", st.ts.URL, strings.NewReader("request body"))
+	req.Trailer = Header{"X-Client": {"client value"}}
+	res, err := st.c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ProtoMajor != 3 {
+		t.Fatalf("Proto = %q; want HTTP/3.0", res.Proto)
+	}
+	if _, ok := res.Trailer["X-Declared"]; !ok {
+		t.Errorf("declared trailer missing before body is read: %v", res.Trailer)
+	}
+	if got := readBody(t, res); got != "body" {
+		t.Errorf("body = %q", got)
+	}
+	if got := res.Header.Get("X-Request-Trailer"); got != "client value" {
+		t.Errorf("server saw request trailer %q; want %q", got, "client value")
+	}
+	want := Header{
+		"X-Declared":   {"declared value"},
+		"X-Undeclared": {"undeclared value"},
+	}
+	for k, v := range want {
+		if got := res.Trailer[k]; len(got) != 1 || got[0] != v[0] {
+			t.Errorf("Trailer[%q] = %q; want %q", k, got, v)
+		}
+	}
+}
+
+func TestH3Gzip(t *testing.T) {
+	const text = "compressible compressible compressible compressible"
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			io.WriteString(w, text)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		io.WriteString(zw, text)
+		zw.Close()
+	}))
+	st.upgrade()
+	res := st.get("/")
+	if res.ProtoMajor != 3 || !res.Uncompressed {
+		t.Errorf("Proto %q, Uncompressed %v; want HTTP/3.0, true", res.Proto, res.Uncompressed)
+	}
+	if got := readBody(t, res); got != text {
+		t.Errorf("body = %q; want %q", got, text)
+	}
+}
+
+func TestH3Cancel(t *testing.T) {
+	unblock := make(chan struct{})
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		if r.URL.Path == "/upgrade" {
+			return
+		}
+		if r.URL.Path == "/body" {
+			io.WriteString(w, "partial")
+			w.(Flusher).Flush()
+		}
+		<-unblock
+	}))
+	defer close(unblock)
+	st.upgrade()
+
+	// Cancelled while waiting for the response header.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := NewRequestWithContext(ctx, "GET", st.ts.URL+"/header", nil)
+	_, err := st.c.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("request error = %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	// Cancelled while reading the body.
+	ctx, cancel = context.WithCancel(context.Background())
+	req, _ = NewRequestWithContext(ctx, "GET", st.ts.URL+"/body", nil)
+	res, err := st.c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ProtoMajor != 3 {
+		t.Fatalf("Proto = %q; want HTTP/3.0", res.Proto)
+	}
+	buf := make([]byte, len("partial"))
+	if _, err := io.ReadFull(res.Body, buf); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := res.Body.Read(buf); err == nil {
+		t.Error("body Read succeeded after the request was cancelled")
+	}
+	res.Body.Close()
+}
+
+func TestH3Fallback(t *testing.T) {
+	// Advertise an HTTP/3 endpoint where nothing answers.
+	dead, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP loopback: %v", err)
+	}
+	defer dead.Close()
+	altSvc := fmt.Sprintf(`h3=":%d"`, dead.LocalAddr().(*net.UDPAddr).Port)
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		w.Header().Set("Alt-Svc", altSvc)
+		io.WriteString(w, r.Proto)
+	}))
+	st.tr.TLSHandshakeTimeout = 200 * time.Millisecond
+	st.upgrade()
+	for i := 0; i < 2; i++ {
+		res := st.get("/")
+		if got := readBody(t, res); got != "HTTP/1.1" {
+			t.Errorf("request %d: body %q; want HTTP/1.1 after falling back", i, got)
+		}
+	}
+}
+
+func TestH3Shutdown(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	st := newH3Test(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			<-unblock
+		}
+		io.WriteString(w, "done")
+	}))
+	st.upgrade()
+
+	resc := make(chan string, 1)
+	go func() {
+		res, err := st.c.Get(st.ts.URL + "/slow")
+		if err != nil {
+			resc <- err.Error()
+			return
+		}
+		b, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		resc <- res.Proto + " " + string(b)
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- st.ts.Config.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-st.serveErr:
+		if err != ErrServerClosed {
+			t.Errorf("ServeQUIC = %v; want %v", err, ErrServerClosed)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("ServeQUIC did not return after Shutdown")
+	}
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("Shutdown returned %v with a request in progress", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(unblock)
+	if got := <-resc; got != "HTTP/3.0 done" {
+		t.Errorf("in-flight request got %q; want %q", got, "HTTP/3.0 done")
+	}
+	select {
+	case err := <-shutdownErr:
+		if err != nil {
+			t.Errorf("Shutdown = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Shutdown did not return after the request completed")
+	}
+}
@@ -0,0 +1,721 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP/3 client support for Transport.
+//
+// A Transport whose Protocols include HTTP3 learns of HTTP/3 endpoints
+// from the Alt-Svc headers (RFC 7838) of responses it receives over
+// TCP, and sends later requests to the same origin over HTTP/3. If the
+// HTTP/3 endpoint cannot be reached, it is marked broken for a while
+// and requests go back to TCP.
+
+package http
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http/httptrace"
+	"net/http/internal/ascii"
+	"net/http/internal/quic"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	h3DefaultUserAgent = "Go-http-client/3"
+
+	// h3BrokenDuration is how long an HTTP/3 endpoint that failed
+	// to connect is left unused.
+	h3BrokenDuration = 5 * time.Minute
+)
+
+// errH3Skip reports that a request was not sent over HTTP/3, and should
+// be sent over TCP instead.
+var errH3Skip = errors.New("net/http: HTTP/3 not used")
+
+// h3Transport is the HTTP/3 state of a Transport.
+type h3Transport struct {
+	mu      sync.Mutex
+	altSvc  map[string]h3AltSvc          // by origin host:port
+	conns   map[string]*h3ClientConn     // by h3ConnKey
+	dialing map[string]*h3DialInProgress // by h3ConnKey
+}
+
+// An h3AltSvc is an HTTP/3 alternative service for an origin.
+type h3AltSvc struct {
+	addr    string    // host:port of the HTTP/3 endpoint
+	expires time.Time // end of the advertised lifetime
+	broken  time.Time // don't use before this time
+}
+
+type h3DialInProgress struct {
+	done chan struct{}
+	cc   *h3ClientConn
+	err  error
+}
+
+func h3ConnKey(origin, addr string) string {
+	return origin + " " + addr
+}
+
+// useHTTP3 reports whether req may be sent over HTTP/3.
+func (t *Transport) useHTTP3(req *Request) bool {
+	return req.URL.Scheme == "https" && t.protocols().HTTP3() &&
+		!req.requiresHTTP1() && req.Method != "CONNECT"
+}
+
+// roundTripHTTP3 sends req over HTTP/3 if its origin advertised an
+// HTTP/3 endpoint. It returns errH3Skip if it did not send req.
+func (t *Transport) roundTripHTTP3(req *Request) (*Response, error) {
+	if !t.useHTTP3(req) {
+		return nil, errH3Skip
+	}
+	origin := canonicalAddr(req.URL)
+	svc, ok := t.h3.lookup(origin, time.Now())
+	if !ok {
+		return nil, errH3Skip
+	}
+	if t.Proxy != nil {
+		// Requests through proxies stay on TCP. An error from
+		// Proxy is reported by the TCP path.
+		if u, err := t.Proxy(req); err != nil || u != nil {
+			return nil, errH3Skip
+		}
+	}
+	ctx := req.Context()
+	cc, err := t.h3GetConn(ctx, origin, svc.addr, req.URL.Hostname())
+	if err != nil {
+		if ctx.Err() != nil {
+			req.closeBody()
+			return nil, ctx.Err()
+		}
+		t.h3.markBroken(origin)
+		return nil, errH3Skip
+	}
+	return cc.roundTrip(req)
+}
+
+// lookup returns the usable HTTP/3 endpoint of origin.
+func (h *h3Transport) lookup(origin string, now time.Time) (h3AltSvc, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	svc, ok := h.altSvc[origin]
+	if !ok {
+		return h3AltSvc{}, false
+	}
+	if now.After(svc.expires) {
+		delete(h.altSvc, origin)
+		return h3AltSvc{}, false
+	}
+	return svc, now.After(svc.broken)
+}
+
+func (h *h3Transport) markBroken(origin string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if svc, ok := h.altSvc[origin]; ok {
+		svc.broken = time.Now().Add(h3BrokenDuration)
+		h.altSvc[origin] = svc
+	}
+}
+
+// noteAltSvc records the HTTP/3 endpoint advertised by the Alt-Svc
+// header of resp, a response to req received over TCP.
+func (t *Transport) noteAltSvc(req *Request, resp *Response) {
+	vv := resp.Header["Alt-Svc"]
+	if len(vv) == 0 || !t.useHTTP3(req) {
+		return
+	}
+	origin := canonicalAddr(req.URL)
+	svcs, clear := parseAltSvc(vv)
+	h := &t.h3
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	old, hadOld := h.altSvc[origin]
+	if clear {
+		delete(h.altSvc, origin)
+		return
+	}
+	for _, svc := range svcs {
+		if svc.proto != h3NextProtoTLS {
+			continue
+		}
+		host, port, _ := net.SplitHostPort(svc.authority)
+		if host == "" {
+			host = req.URL.Hostname()
+		}
+		addr := net.JoinHostPort(host, port)
+		s := h3AltSvc{addr: addr, expires: time.Now().Add(svc.maxAge)}
+		if hadOld && old.addr == addr {
+			s.broken = old.broken
+		}
+		if h.altSvc == nil {
+			h.altSvc = make(map[string]h3AltSvc)
+		}
+		h.altSvc[origin] = s
+		return
+	}
+}
+
+// An altSvc is one alternative service from an Alt-Svc header.
+type altSvc struct {
+	proto     string // ALPN protocol ID
+	authority string // host:port; the host may be empty
+	maxAge    time.Duration
+}
+
+// parseAltSvc parses the values of Alt-Svc header fields (RFC 7838,
+// Section 3). It skips malformed entries.
+func parseAltSvc(vv []string) (svcs []altSvc, clear bool) {
+	for _, v := range vv {
+		v = textproto.TrimString(v)
+		if v == "clear" {
+			return nil, true
+		}
+		for v != "" {
+			var entry string
+			entry, v = altSvcSplit(v, ',')
+			params := strings.Split(entry, ";")
+			proto, authority, ok := altSvcParam(params[0])
+			if !ok {
+				continue
+			}
+			proto, err := url.PathUnescape(proto)
+			if err != nil {
+				continue
+			}
+			if _, port, err := net.SplitHostPort(authority); err != nil || !altSvcValidPort(port) {
+				continue
+			}
+			svc := altSvc{proto: proto, authority: authority, maxAge: 24 * time.Hour}
+			for _, p := range params[1:] {
+				k, val, ok := altSvcParam(p)
+				if ok && k == "ma" {
+					if n, err := strconv.ParseUint(val, 10, 32); err == nil {
+						svc.maxAge = time.Duration(n) * time.Second
+					}
+				}
+			}
+			svcs = append(svcs, svc)
+		}
+	}
+	return svcs, false
+}
+
+// altSvcSplit splits s at the first sep outside a quoted string.
+func altSvcSplit(s string, sep byte) (before, after string) {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\' && quoted:
+			i++
+		case c == sep && !quoted:
+			return s[:i], s[i+1:]
+		}
+	}
+	return s, ""
+}
+
+// altSvcParam parses a key=value pair whose value is a token or a
+// quoted string.
+func altSvcParam(s string) (key, value string, ok bool) {
+	s = textproto.TrimString(s)
+	i := strings.IndexByte(s, '=')
+	if i <= 0 {
+		return "", "", false
+	}
+	key, value = textproto.TrimString(s[:i]), textproto.TrimString(s[i+1:])
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		v, err := strconv.Unquote(value)
+		if err != nil {
+			return "", "", false
+		}
+		value = v
+	}
+	return key, value, value != ""
+}
+
+func altSvcValidPort(port string) bool {
+	n, err := strconv.ParseUint(port, 10, 16)
+	return err == nil && n > 0
+}
+
+// h3GetConn returns a connection to the HTTP/3 endpoint addr of origin,
+// dialing one if needed.
+func (t *Transport) h3GetConn(ctx context.Context, origin, addr, serverName string) (*h3ClientConn, error) {
+	key := h3ConnKey(origin, addr)
+	h := &t.h3
+	h.mu.Lock()
+	if cc := h.conns[key]; cc != nil && cc.reserve() {
+		h.mu.Unlock()
+		return cc, nil
+	}
+	d := h.dialing[key]
+	if d == nil {
+		d = &h3DialInProgress{done: make(chan struct{})}
+		if h.dialing == nil {
+			h.dialing = make(map[string]*h3DialInProgress)
+		}
+		h.dialing[key] = d
+		go t.h3Dial(d, key, addr, serverName)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-d.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if !d.cc.reserve() {
+		return nil, errors.New("net/http: HTTP/3 connection closed")
+	}
+	return d.cc, nil
+}
+
+// h3Dial dials a connection for d. The dial is not tied to any one
+// request, so that requests waiting for it can give up independently.
+func (t *Transport) h3Dial(d *h3DialInProgress, key, addr, serverName string) {
+	timeout := t.TLSHandshakeTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	d.cc, d.err = t.dialHTTP3(ctx, key, addr, serverName)
+
+	h := &t.h3
+	h.mu.Lock()
+	delete(h.dialing, key)
+	if d.err == nil {
+		if h.conns == nil {
+			h.conns = make(map[string]*h3ClientConn)
+		}
+		h.conns[key] = d.cc
+	}
+	h.mu.Unlock()
+	close(d.done)
+}
+
+func (t *Transport) dialHTTP3(ctx context.Context, key, addr, serverName string) (*h3ClientConn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	portNum, err := net.DefaultResolver.LookupPort(ctx, "udp", port)
+	if err != nil {
+		return nil, err
+	}
+	raddr := &net.UDPAddr{IP: ips[0].IP, Port: portNum, Zone: ips[0].Zone}
+	network := "udp4"
+	if raddr.IP.To4() == nil {
+		network = "udp6"
+	}
+	pc, err := net.ListenUDP(network, nil)
+	if err != nil {
+		return nil, err
+	}
+	ep := quic.NewEndpoint(pc, nil)
+
+	cfg := cloneTLSConfig(t.TLSClientConfig)
+	if cfg.ServerName == "" {
+		cfg.ServerName = serverName
+	}
+	cfg.NextProtos = []string{h3NextProtoTLS}
+	qc, err := ep.Dial(ctx, raddr, cfg)
+	if err != nil {
+		ep.Close()
+		return nil, err
+	}
+	cc := &h3ClientConn{t: t, key: key, qc: qc}
+	if cc.ctl, err = h3OpenControlStream(qc, t.h3MaxHeaderResponseSize()); err != nil {
+		ep.Close()
+		return nil, err
+	}
+	go h3ControlStreams(qc, cc.onControlFrame)
+	go func() {
+		<-qc.Done()
+		t.h3.removeConn(cc)
+		ep.Close()
+	}()
+	return cc, nil
+}
+
+func (t *Transport) h3MaxHeaderResponseSize() int64 {
+	if v := t.MaxResponseHeaderBytes; v != 0 {
+		return v
+	}
+	return 10 << 20 // same as HTTP/1 and HTTP/2
+}
+
+func (h *h3Transport) removeConn(cc *h3ClientConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conns[cc.key] == cc {
+		delete(h.conns, cc.key)
+	}
+}
+
+// closeIdle closes the HTTP/3 connections with no active requests.
+func (h *h3Transport) closeIdle() {
+	h.mu.Lock()
+	var idle []*h3ClientConn
+	for key, cc := range h.conns {
+		if cc.closeIfIdle() {
+			delete(h.conns, key)
+			idle = append(idle, cc)
+		}
+	}
+	h.mu.Unlock()
+	for _, cc := range idle {
+		cc.qc.CloseWithError(h3NoError, "")
+	}
+}
+
+// An h3ClientConn is an HTTP/3 connection from a Transport.
+type h3ClientConn struct {
+	t   *Transport
+	key string
+	qc  *quic.Conn
+	ctl *quic.Stream
+
+	mu      sync.Mutex
+	active  int  // requests in progress
+	goAway  bool // the server sent GOAWAY
+	closing bool // closed by closeIdle
+}
+
+// reserve reserves the connection for a new request.
+func (cc *h3ClientConn) reserve() bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.goAway || cc.closing || cc.qc.Err() != nil {
+		return false
+	}
+	cc.active++
+	return true
+}
+
+func (cc *h3ClientConn) release() {
+	cc.mu.Lock()
+	cc.active--
+	done := cc.active == 0 && cc.goAway
+	cc.mu.Unlock()
+	if done {
+		cc.qc.CloseWithError(h3NoError, "")
+	}
+}
+
+func (cc *h3ClientConn) closeIfIdle() bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.active > 0 {
+		return false
+	}
+	cc.closing = true
+	return true
+}
+
+func (cc *h3ClientConn) onControlFrame(typ uint64, payload []byte) error {
+	switch typ {
+	case h3FrameGoAway:
+		if _, err := h3GoAwayID(payload); err != nil {
+			return err
+		}
+		cc.mu.Lock()
+		cc.goAway = true
+		idle := cc.active == 0
+		cc.mu.Unlock()
+		cc.t.h3.removeConn(cc)
+		if idle {
+			cc.qc.CloseWithError(h3NoError, "")
+		}
+	case h3FrameMaxPushID:
+		return &h3ConnError{h3FrameUnexpected, "MAX_PUSH_ID from server"}
+	}
+	return nil
+}
+
+// roundTrip sends req on a new request stream. The caller has reserved
+// the connection.
+func (cc *h3ClientConn) roundTrip(req *Request) (*Response, error) {
+	ctx := req.Context()
+	trace := httptrace.ContextClientTrace(ctx)
+	s, err := cc.qc.OpenStream(ctx)
+	if err != nil {
+		cc.release()
+		req.closeBody()
+		return nil, err
+	}
+	// Cancelling the request resets the stream in both directions.
+	stop := context.AfterFunc(ctx, func() {
+		s.Reset(h3RequestCancelled)
+		s.CloseRead(h3RequestCancelled)
+	})
+	var once sync.Once
+	done := func() {
+		once.Do(func() {
+			stop()
+			cc.release()
+		})
+	}
+
+	requestedGzip := !cc.t.DisableCompression &&
+		req.Header.Get("Accept-Encoding") == "" &&
+		req.Header.Get("Range") == "" &&
+		req.Method != "HEAD"
+	if err := h3WriteFrame(s, h3FrameHeaders, cc.encodeRequestHeaders(req, requestedGzip)); err != nil {
+		done()
+		req.closeBody()
+		return nil, h3CtxErr(ctx, err)
+	}
+	if trace != nil && trace.WroteHeaders != nil {
+		trace.WroteHeaders()
+	}
+	go cc.writeRequestBody(s, req, trace)
+
+	resp, err := cc.readResponse(s, req, trace, requestedGzip, done)
+	if err != nil {
+		h3Abort(cc.qc, s, err)
+		done()
+		return nil, h3CtxErr(ctx, err)
+	}
+	return resp, nil
+}
+
+// h3CtxErr returns the context's error in place of the stream errors
+// that cancellation causes.
+func h3CtxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (cc *h3ClientConn) encodeRequestHeaders(req *Request, requestedGzip bool) []byte {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	method := req.Method
+	if method == "" {
+		method = "GET"
+	}
+	fields := []hpack.HeaderField{
+		{Name: ":method", Value: method},
+		{Name: ":scheme", Value: "https"},
+		{Name: ":authority", Value: removeZone(host)},
+		{Name: ":path", Value: req.URL.RequestURI()},
+	}
+	fields = h3AppendHeader(fields, req.Header)
+	if _, ok := req.Header["User-Agent"]; !ok {
+		fields = append(fields, hpack.HeaderField{Name: "user-agent", Value: h3DefaultUserAgent})
+	}
+	if requestedGzip {
+		fields = append(fields, hpack.HeaderField{Name: "accept-encoding", Value: "gzip"})
+	}
+	if n := req.outgoingLength(); n > 0 || (n == 0 && (method == "POST" || method == "PUT")) {
+		if _, ok := req.Header["Content-Length"]; !ok {
+			fields = append(fields, hpack.HeaderField{Name: "content-length", Value: strconv.FormatInt(n, 10)})
+		}
+	}
+	if len(req.Trailer) > 0 {
+		keys := make([]string, 0, len(req.Trailer))
+		for k := range req.Trailer {
+			keys = append(keys, CanonicalHeaderKey(k))
+		}
+		fields = append(fields, hpack.HeaderField{Name: "trailer", Value: strings.Join(keys, ",")})
+	}
+	return qpackEncode(nil, fields)
+}
+
+// writeRequestBody sends the body and trailers of req, and then ends
+// the stream. A failure to read the body resets the stream.
+func (cc *h3ClientConn) writeRequestBody(s *quic.Stream, req *Request, trace *httptrace.ClientTrace) {
+	err := func() error {
+		if req.Body == nil || req.Body == NoBody {
+			return nil
+		}
+		defer req.closeBody()
+		buf := make([]byte, 16<<10)
+		for {
+			n, err := req.Body.Read(buf)
+			if n > 0 {
+				if werr := h3WriteData(s, buf[:n]); werr != nil {
+					return werr
+				}
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}()
+	if err == nil && len(req.Trailer) > 0 {
+		fields := h3AppendHeader(nil, req.Trailer)
+		err = h3WriteFrame(s, h3FrameHeaders, qpackEncode(nil, fields))
+	}
+	if err == nil {
+		err = s.CloseWrite()
+	} else {
+		s.Reset(h3RequestCancelled)
+	}
+	if trace != nil && trace.WroteRequest != nil {
+		trace.WroteRequest(httptrace.WroteRequestInfo{Err: err})
+	}
+}
+
+// readResponse reads the response header from s. done is called once
+// the response body has been consumed or closed.
+func (cc *h3ClientConn) readResponse(s *quic.Stream, req *Request, trace *httptrace.ClientTrace, requestedGzip bool, done func()) (*Response, error) {
+	fr := newH3FrameReader(s)
+	maxHeader := cc.t.h3MaxHeaderResponseSize()
+	for {
+		typ, length, err := fr.next()
+		if err == io.EOF {
+			return nil, &h3StreamError{h3RequestIncomplete, "stream ended before the response header"}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if typ != h3FrameHeaders {
+			return nil, &h3ConnError{h3FrameUnexpected, "response does not start with HEADERS"}
+		}
+		fields, err := fr.readFields(length, maxHeader)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := h3NewResponse(fields)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 200 {
+			if resp.StatusCode == 101 {
+				return nil, &h3StreamError{h3MessageError, "101 Switching Protocols over HTTP/3"}
+			}
+			if trace != nil && trace.Got1xxResponse != nil {
+				if err := trace.Got1xxResponse(resp.StatusCode, textproto.MIMEHeader(resp.Header)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if trace != nil && trace.GotFirstResponseByte != nil {
+			trace.GotFirstResponseByte()
+		}
+		resp.Request = req
+		resp.Trailer = h3DeclaredTrailers(resp.Header)
+		cs := cc.qc.ConnectionState()
+		resp.TLS = &cs
+
+		if req.Method == "HEAD" || !bodyAllowedForStatus(resp.StatusCode) {
+			resp.Body = NoBody
+			s.CloseRead(h3NoError)
+			done()
+			return resp, nil
+		}
+		body := &h3Body{
+			s:             s,
+			qc:            cc.qc,
+			fr:            fr,
+			trailer:       &resp.Trailer,
+			maxHeader:     maxHeader,
+			contentLength: resp.ContentLength,
+			onDone:        done,
+		}
+		resp.Body = body
+		if requestedGzip && ascii.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
+			resp.Body = &h3GzipReader{body: body}
+		}
+		return resp, nil
+	}
+}
+
+// h3NewResponse builds a Response from the fields of a response header.
+func h3NewResponse(fields []hpack.HeaderField) (*Response, error) {
+	resp := &Response{
+		Proto:         "HTTP/3.0",
+		ProtoMajor:    3,
+		Header:        make(Header),
+		ContentLength: -1,
+	}
+	status := ""
+	regular := false
+	for _, f := range fields {
+		if strings.HasPrefix(f.Name, ":") {
+			if regular || f.Name != ":status" || status != "" {
+				return nil, &h3StreamError{h3MessageError, "invalid pseudo-header " + f.Name}
+			}
+			status = f.Value
+			continue
+		}
+		if !h3ValidField(f) {
+			return nil, &h3StreamError{h3MessageError, "invalid response header field " + f.Name}
+		}
+		regular = true
+		h3AddField(resp.Header, f)
+	}
+	code, err := strconv.Atoi(status)
+	if len(status) != 3 || err != nil || code < 100 {
+		return nil, &h3StreamError{h3MessageError, "malformed :status " + strconv.Quote(status)}
+	}
+	resp.StatusCode = code
+	resp.Status = status + " " + StatusText(code)
+	if cl := resp.Header["Content-Length"]; len(cl) == 1 {
+		n, err := strconv.ParseUint(cl[0], 10, 63)
+		if err != nil {
+			return nil, &h3StreamError{h3MessageError, "malformed Content-Length"}
+		}
+		resp.ContentLength = int64(n)
+	} else if len(cl) > 1 {
+		return nil, &h3StreamError{h3MessageError, "multiple Content-Length fields"}
+	}
+	return resp, nil
+}
+
+// h3GzipReader decompresses a gzip-encoded response body.
+type h3GzipReader struct {
+	body io.ReadCloser
+	zr   *gzip.Reader // lazily initialized
+	zerr error        // sticky error from gzip.NewReader
+}
+
+func (gz *h3GzipReader) Read(p []byte) (int, error) {
+	if gz.zr == nil {
+		if gz.zerr == nil {
+			gz.zr, gz.zerr = gzip.NewReader(gz.body)
+		}
+		if gz.zerr != nil {
+			return 0, gz.zerr
+		}
+	}
+	return gz.zr.Read(p)
+}
+
+func (gz *h3GzipReader) Close() error {
+	return gz.body.Close()
+}
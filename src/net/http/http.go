@@ -34,6 +34,84 @@ var aLongTimeAgo = time.Unix(1, 0)
 // shouldn't try to use it.
 var omitBundledHTTP2 bool
 
+// Protocols is a set of HTTP protocols.
+// The zero value is an empty set of protocols.
+//
+// The supported protocols are:
+//
+//   - HTTP1 is the HTTP/1.0 and HTTP/1.1 protocols.
+//     HTTP1 is supported on both unsecured TCP and secured TLS connections.
+//
+//   - HTTP2 is the HTTP/2 protocol over a TLS connection,
+//     negotiated with ALPN.
+//
+//   - UnencryptedHTTP2 is the HTTP/2 protocol over an unsecured TCP
+//     connection, also known as h2c.
+//
+//   - HTTP3 is the HTTP/3 protocol over QUIC. A Transport uses it
+//     for servers that advertise it in an Alt-Svc header. Servers
+//     serve HTTP/3 with Server.ServeQUIC, and ignore this protocol
+//     in Server.Protocols.
+type Protocols struct {
+	bits uint8
+}
+
+const (
+	protoHTTP1 = 1 << iota
+	protoHTTP2
+	protoUnencryptedHTTP2
+	protoHTTP3
+)
+
+// HTTP1 reports whether p includes HTTP/1.
+func (p Protocols) HTTP1() bool { return p.bits&protoHTTP1 != 0 }
+
+// SetHTTP1 adds or removes HTTP/1 from p.
+func (p *Protocols) SetHTTP1(ok bool) { p.setBit(protoHTTP1, ok) }
+
+// HTTP2 reports whether p includes HTTP/2.
+func (p Protocols) HTTP2() bool { return p.bits&protoHTTP2 != 0 }
+
+// SetHTTP2 adds or removes HTTP/2 from p.
+func (p *Protocols) SetHTTP2(ok bool) { p.setBit(protoHTTP2, ok) }
+
+// UnencryptedHTTP2 reports whether p includes unencrypted HTTP/2.
+func (p Protocols) UnencryptedHTTP2() bool { return p.bits&protoUnencryptedHTTP2 != 0 }
+
+// SetUnencryptedHTTP2 adds or removes unencrypted HTTP/2 from p.
+func (p *Protocols) SetUnencryptedHTTP2(ok bool) { p.setBit(protoUnencryptedHTTP2, ok) }
+
+// HTTP3 reports whether p includes HTTP/3.
+func (p Protocols) HTTP3() bool { return p.bits&protoHTTP3 != 0 }
+
+// SetHTTP3 adds or removes HTTP/3 from p.
+func (p *Protocols) SetHTTP3(ok bool) { p.setBit(protoHTTP3, ok) }
+
+func (p *Protocols) setBit(bit uint8, ok bool) {
+	if ok {
+		p.bits |= bit
+	} else {
+		p.bits &^= bit
+	}
+}
+
+func (p Protocols) String() string {
+	var s []string
+	if p.HTTP1() {
+		s = append(s, "HTTP1")
+	}
+	if p.HTTP2() {
+		s = append(s, "HTTP2")
+	}
+	if p.UnencryptedHTTP2() {
+		s = append(s, "UnencryptedHTTP2")
+	}
+	if p.HTTP3() {
+		s = append(s, "HTTP3")
+	}
+	return "{" + strings.Join(s, ",") + "}"
+}
+
 // TODO(bradfitz): move common stuff here. The other files have accumulated
 // generic http stuff in random places.
 
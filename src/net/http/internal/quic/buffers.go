@@ -0,0 +1,293 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+// A span is the half-open interval [start, end).
+type span struct {
+	start, end int64
+}
+
+// A rangeset is a set of int64s, stored as sorted, non-overlapping,
+// non-adjacent spans. It tracks received packet numbers and the parts
+// of a stream that have been received, acknowledged or lost.
+type rangeset []span
+
+func (s rangeset) contains(v int64) bool {
+	for _, r := range s {
+		if v < r.start {
+			return false
+		}
+		if v < r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds [start, end) to s.
+func (s *rangeset) add(start, end int64) {
+	if start >= end {
+		return
+	}
+	r := *s
+	i := 0
+	for i < len(r) && r[i].end < start {
+		i++
+	}
+	j := i
+	for j < len(r) && r[j].start <= end {
+		j++
+	}
+	if i == j {
+		r = append(r, span{})
+		copy(r[i+1:], r[i:])
+		r[i] = span{start, end}
+		*s = r
+		return
+	}
+	if r[i].start < start {
+		start = r[i].start
+	}
+	if r[j-1].end > end {
+		end = r[j-1].end
+	}
+	r[i] = span{start, end}
+	*s = append(r[:i+1], r[j:]...)
+}
+
+// sub removes [start, end) from s.
+func (s *rangeset) sub(start, end int64) {
+	if start >= end {
+		return
+	}
+	var out rangeset
+	for i, r := range *s {
+		if r.end <= start || r.start >= end {
+			if out != nil {
+				out = append(out, r)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make(rangeset, 0, len(*s)+1), (*s)[:i]...)
+		}
+		if r.start < start {
+			out = append(out, span{r.start, start})
+		}
+		if r.end > end {
+			out = append(out, span{end, r.end})
+		}
+	}
+	if out != nil {
+		*s = out
+	}
+}
+
+// A sendBuffer holds the data written to one direction of a stream, or
+// to the crypto stream of one number space, until the peer acknowledges
+// it. Data is sent once, and again after being declared lost.
+type sendBuffer struct {
+	buf   []byte // data at offsets [base, base+len(buf))
+	base  int64  // all data before base has been acknowledged
+	next  int64  // first offset that has never been sent
+	acked rangeset
+	lost  rangeset
+
+	fin      bool // no more data will be written
+	finSent  bool
+	finLost  bool
+	finAcked bool
+}
+
+func (b *sendBuffer) end() int64 {
+	return b.base + int64(len(b.buf))
+}
+
+func (b *sendBuffer) write(p []byte) {
+	b.buf = append(b.buf, p...)
+}
+
+// unacked returns the number of bytes written but not yet acknowledged.
+func (b *sendBuffer) unacked() int64 {
+	return int64(len(b.buf))
+}
+
+// hasData reports whether there is data or a FIN to (re)send, not
+// counting new data beyond the flow control limit.
+func (b *sendBuffer) hasData(limit int64) bool {
+	if len(b.lost) > 0 {
+		return true
+	}
+	if b.next < b.end() && b.next < limit {
+		return true
+	}
+	return b.fin && b.next == b.end() && (!b.finSent || b.finLost) && !b.finAcked
+}
+
+// take returns the next chunk of at most max bytes to send, preferring
+// retransmissions. New data is not sent beyond offset limit. fin
+// reports whether the chunk ends the stream. isNew reports whether the
+// chunk is new data, which counts against connection flow control.
+func (b *sendBuffer) take(max, limit int64) (off int64, data []byte, fin, isNew bool) {
+	if len(b.lost) > 0 {
+		r := b.lost[0]
+		n := r.end - r.start
+		if n > max {
+			n = max
+		}
+		off = r.start
+		data = b.buf[off-b.base : off-b.base+n]
+		b.lost.sub(off, off+n)
+		if off+n == b.end() && b.fin && b.finLost {
+			fin = true
+			b.finLost = false
+		}
+		return off, data, fin, false
+	}
+	if limit > b.end() {
+		limit = b.end()
+	}
+	n := limit - b.next
+	if n > max {
+		n = max
+	}
+	if n < 0 {
+		n = 0
+	}
+	off = b.next
+	data = b.buf[off-b.base : off-b.base+n]
+	b.next += n
+	if b.fin && b.next == b.end() && (!b.finSent || b.finLost) {
+		fin = true
+		b.finSent = true
+		b.finLost = false
+	}
+	return off, data, fin, n > 0
+}
+
+// onAck records the acknowledgement of [off, off+n) and, if fin is set,
+// of the end of the stream.
+func (b *sendBuffer) onAck(off, n int64, fin bool) {
+	if fin {
+		b.finAcked = true
+	}
+	b.lost.sub(off, off+n)
+	if off+n <= b.base {
+		return
+	}
+	b.acked.add(off, off+n)
+	if len(b.acked) > 0 && b.acked[0].start <= b.base {
+		newBase := b.acked[0].end
+		b.buf = b.buf[newBase-b.base:]
+		b.base = newBase
+		b.acked = b.acked[1:]
+	}
+}
+
+// onLost records that [off, off+n) and, if fin is set, the end of the
+// stream, must be sent again.
+func (b *sendBuffer) onLost(off, n int64, fin bool) {
+	if fin && !b.finAcked {
+		b.finLost = true
+	}
+	if off < b.base {
+		n -= b.base - off
+		off = b.base
+	}
+	if n <= 0 {
+		return
+	}
+	b.lost.add(off, off+n)
+	for _, r := range b.acked {
+		b.lost.sub(r.start, r.end)
+	}
+}
+
+// done reports whether all data and the FIN have been acknowledged.
+func (b *sendBuffer) done() bool {
+	return b.fin && b.finAcked && len(b.buf) == 0
+}
+
+// A recvBuffer reassembles the data received on one direction of a
+// stream, or on the crypto stream of one number space.
+type recvBuffer struct {
+	buf       []byte // data at offsets [readOff, readOff+len(buf))
+	readOff   int64  // data before readOff has been consumed
+	recvd     rangeset
+	maxOff    int64 // largest offset received
+	finalSize int64 // -1 until the final size is known
+}
+
+func newRecvBuffer() recvBuffer {
+	return recvBuffer{finalSize: -1}
+}
+
+// write records the receipt of data at offset off. fin reports whether
+// off+len(data) is the final size of the stream.
+func (b *recvBuffer) write(off int64, data []byte, fin bool) error {
+	end := off + int64(len(data))
+	if b.finalSize >= 0 && (end > b.finalSize || fin && end != b.finalSize) {
+		return errFinalSize
+	}
+	if fin {
+		if end < b.maxOff {
+			return errFinalSize
+		}
+		b.finalSize = end
+	}
+	if end > b.maxOff {
+		b.maxOff = end
+	}
+	if end <= b.readOff {
+		return nil
+	}
+	if off < b.readOff {
+		data = data[b.readOff-off:]
+		off = b.readOff
+	}
+	if need := int(end - b.readOff); need > len(b.buf) {
+		b.buf = append(b.buf, make([]byte, need-len(b.buf))...)
+	}
+	copy(b.buf[off-b.readOff:], data)
+	b.recvd.add(off, end)
+	return nil
+}
+
+// readable returns the number of contiguous bytes available to read.
+func (b *recvBuffer) readable() int {
+	if len(b.recvd) == 0 || b.recvd[0].start > b.readOff {
+		return 0
+	}
+	return int(b.recvd[0].end - b.readOff)
+}
+
+// peek returns the contiguous bytes available to read.
+func (b *recvBuffer) peek() []byte {
+	return b.buf[:b.readable()]
+}
+
+// discard consumes n bytes returned by peek.
+func (b *recvBuffer) discard(n int) {
+	b.buf = b.buf[n:]
+	b.readOff += int64(n)
+	if b.readOff == b.recvd[0].end {
+		b.recvd = b.recvd[1:]
+	} else {
+		b.recvd[0].start = b.readOff
+	}
+}
+
+func (b *recvBuffer) read(p []byte) int {
+	n := copy(p, b.peek())
+	if n > 0 {
+		b.discard(n)
+	}
+	return n
+}
+
+// eof reports whether the stream has been read up to its final size.
+func (b *recvBuffer) eof() bool {
+	return b.finalSize >= 0 && b.readOff == b.finalSize
+}
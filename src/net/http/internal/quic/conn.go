@@ -0,0 +1,764 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// A Config configures an Endpoint and the connections it creates.
+// A Config must not be modified after it has been passed to NewEndpoint.
+type Config struct {
+	// TLSConfig is the TLS configuration used for incoming connections.
+	// If nil, the Endpoint does not accept connections.
+	TLSConfig *tls.Config
+
+	// MaxIdleTimeout is the duration after which an inactive
+	// connection is closed. If zero, 30 seconds is used.
+	// If negative, connections never time out.
+	MaxIdleTimeout time.Duration
+
+	// MaxBidiRemoteStreams and MaxUniRemoteStreams limit the number of
+	// bidirectional and unidirectional streams the peer may have open
+	// at once. If zero, 100 and 10 are used.
+	MaxBidiRemoteStreams int64
+	MaxUniRemoteStreams  int64
+
+	// MaxStreamReadBufferSize is the amount of data the peer may send
+	// on a stream before the application reads it. If zero, 1MB is used.
+	MaxStreamReadBufferSize int64
+
+	// MaxStreamWriteBufferSize is the amount of data buffered by a
+	// stream until the peer acknowledges it. Writes block when the
+	// buffer is full. If zero, 1MB is used.
+	MaxStreamWriteBufferSize int64
+
+	// MaxConnReadBufferSize is the amount of data the peer may send
+	// on all streams of a connection before the application reads it.
+	// If zero, 4MB is used.
+	MaxConnReadBufferSize int64
+}
+
+func (c *Config) maxIdleTimeout() time.Duration {
+	switch {
+	case c.MaxIdleTimeout == 0:
+		return 30 * time.Second
+	case c.MaxIdleTimeout < 0:
+		return 0
+	}
+	return c.MaxIdleTimeout
+}
+
+func (c *Config) maxRemoteStreams(uni bool) int64 {
+	if uni {
+		return configDefault(c.MaxUniRemoteStreams, 10)
+	}
+	return configDefault(c.MaxBidiRemoteStreams, 100)
+}
+
+func (c *Config) maxStreamReadBufferSize() int64 {
+	return configDefault(c.MaxStreamReadBufferSize, 1<<20)
+}
+
+func (c *Config) maxStreamWriteBufferSize() int64 {
+	return configDefault(c.MaxStreamWriteBufferSize, 1<<20)
+}
+
+func (c *Config) maxConnReadBufferSize() int64 {
+	return configDefault(c.MaxConnReadBufferSize, 4<<20)
+}
+
+func configDefault(v, def int64) int64 {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// A numberSpace is a packet number space (RFC 9000, Section 12.3).
+// Each space has its own encryption level.
+type numberSpace int
+
+const (
+	initialSpace numberSpace = iota
+	handshakeSpace
+	appDataSpace
+	numSpaces
+)
+
+func levelSpace(l tls.QUICEncryptionLevel) (numberSpace, bool) {
+	switch l {
+	case tls.QUICEncryptionLevelInitial:
+		return initialSpace, true
+	case tls.QUICEncryptionLevelHandshake:
+		return handshakeSpace, true
+	case tls.QUICEncryptionLevelApplication:
+		return appDataSpace, true
+	}
+	return 0, false // 0-RTT is not supported
+}
+
+func (sp numberSpace) level() tls.QUICEncryptionLevel {
+	switch sp {
+	case initialSpace:
+		return tls.QUICEncryptionLevelInitial
+	case handshakeSpace:
+		return tls.QUICEncryptionLevelHandshake
+	}
+	return tls.QUICEncryptionLevelApplication
+}
+
+// A space holds the state of one packet number space.
+type space struct {
+	read, write *packetKeys
+	discarded   bool
+
+	// Sending.
+	nextPN               int64
+	largestAcked         int64 // -1 until the peer acknowledges a packet
+	sent                 []*sentPacket
+	ackElicitingInFlight int
+	lastAckElicitingSent time.Time
+	lossTime             time.Time
+
+	// Receiving.
+	recvd            rangeset // packet numbers received
+	ackFloor         int64    // packets below ackFloor are treated as duplicates
+	largestRecvd     int64    // -1 until a packet is received
+	largestRecvdTime time.Time
+	ackPending       bool      // send an ACK frame as soon as possible
+	ackDeadline      time.Time // send an ACK frame at this time
+	unackedElicited  int       // ack-eliciting packets received since the last ACK
+
+	cryptoSend sendBuffer
+	cryptoRecv recvBuffer
+}
+
+// maxAckRanges limits the number of ranges of received packet numbers
+// we remember, and so can acknowledge.
+const maxAckRanges = 32
+
+// streamCounts tracks the streams of one type (bidirectional or
+// unidirectional) opened by one side of the connection.
+type streamCounts struct {
+	opened int64 // streams opened so far
+	max    int64 // limit from MAX_STREAMS or the transport parameters
+	closed int64 // remote streams only: streams fully closed
+
+	sendMaxStreams bool // remote streams only: a MAX_STREAMS frame is due
+}
+
+// A Conn is a QUIC connection.
+//
+// Its state is owned by a goroutine running the connection loop, which
+// processes received datagrams, timers and requests from the methods of
+// Conn and Stream, and sends packets. All state is guarded by mu.
+type Conn struct {
+	ep         *Endpoint
+	config     *Config
+	isClient   bool
+	remoteAddr net.Addr
+	tls        *tls.QUICConn
+
+	recvc         chan []byte
+	wakec         chan struct{}
+	donec         chan struct{} // closed when closeErr is set
+	handshakeDone chan struct{} // closed when the handshake completes or the conn closes
+
+	mu   sync.Mutex
+	cond sync.Cond
+
+	spaces [numSpaces]space
+
+	srcConnID     []byte // our connection ID
+	dstConnID     []byte // the peer's connection ID
+	origDstConnID []byte // the destination connection ID of the client's first Initial
+	peerParams    transportParameters
+	gotPeerSrcID  bool // the client has switched to the server's connection ID
+
+	handshakeComplete  bool
+	handshakeConfirmed bool
+	sendHandshakeDone  bool
+	keyPhase           bool
+	nextReadKeys       *packetKeys
+
+	// Connection-level flow control.
+	sendMaxData  int64 // limit on stream data we may send
+	sentData     int64 // new stream data sent so far
+	recvMaxData  int64 // limit on stream data the peer may send
+	recvData     int64 // stream data received, by highest offset
+	readData     int64 // stream data consumed by the application
+	sendMaxDataF bool  // a MAX_DATA frame is due
+
+	streams       map[int64]*Stream
+	localStreams  [2]streamCounts // indexed by uni
+	remoteStreams [2]streamCounts
+	acceptq       [2][]*Stream
+	sendq         []*Stream // streams with frames to send
+
+	pathResponses [][8]byte
+
+	rtt       rttState
+	cc        newReno
+	ptoCount  int
+	ptoProbes int
+	ptoSpace  numberSpace
+	lastSent  time.Time
+
+	// Anti-amplification limit, for servers (RFC 9000, Section 8).
+	addrValidated bool
+	bytesRecvd    int64
+	bytesSent     int64
+
+	idleTimeout   time.Duration
+	idleDeadline  time.Time
+	idleRestarted bool // the idle timer was restarted by a send since the last receive
+
+	// Closing (RFC 9000, Section 10.2).
+	closeErr      error // set once the connection is closed
+	closing       bool  // we sent CONNECTION_CLOSE
+	draining      bool  // the peer sent CONNECTION_CLOSE
+	closeApp      bool  // the close frame carries an application error
+	closeCode     uint64
+	closeReason   string
+	sendClose     bool // a CONNECTION_CLOSE frame is due
+	closeSends    int  // CONNECTION_CLOSE frames sent
+	closeDeadline time.Time
+	exitNow       bool // exit the loop once sendClose is done
+	exit          bool
+}
+
+func newConn(ep *Endpoint, isClient bool, remoteAddr net.Addr, tlsConfig *tls.Config, origDstConnID, peerSrcConnID []byte) (*Conn, error) {
+	c := &Conn{
+		ep:            ep,
+		config:        ep.config,
+		isClient:      isClient,
+		remoteAddr:    remoteAddr,
+		recvc:         make(chan []byte, 128),
+		wakec:         make(chan struct{}, 1),
+		donec:         make(chan struct{}),
+		handshakeDone: make(chan struct{}),
+		srcConnID:     newConnID(),
+		origDstConnID: origDstConnID,
+		streams:       make(map[int64]*Stream),
+		rtt:           newRTTState(),
+		cc:            newNewReno(),
+		addrValidated: isClient,
+		idleTimeout:   ep.config.maxIdleTimeout(),
+	}
+	c.cond.L = &c.mu
+	if isClient {
+		c.dstConnID = origDstConnID
+	} else {
+		c.dstConnID = peerSrcConnID
+	}
+	for i := range c.spaces {
+		s := &c.spaces[i]
+		s.largestAcked = -1
+		s.largestRecvd = -1
+		s.cryptoRecv = newRecvBuffer()
+	}
+	c.spaces[initialSpace].read, c.spaces[initialSpace].write = initialKeys(origDstConnID, isClient)
+
+	c.recvMaxData = c.config.maxConnReadBufferSize()
+	c.remoteStreams[0].max = c.config.maxRemoteStreams(false)
+	c.remoteStreams[1].max = c.config.maxRemoteStreams(true)
+	window := c.config.maxStreamReadBufferSize()
+	params := transportParameters{
+		originalDstConnID:     origDstConnID,
+		initialSrcConnID:      c.srcConnID,
+		maxIdleTimeout:        c.idleTimeout,
+		maxUDPPayloadSize:     maxRecvDatagramSize - 8,
+		initialMaxData:        c.recvMaxData,
+		maxStreamDataBidiLoc:  window,
+		maxStreamDataBidiRem:  window,
+		maxStreamDataUni:      window,
+		initialMaxStreamsBidi: c.remoteStreams[0].max,
+		initialMaxStreamsUni:  c.remoteStreams[1].max,
+		ackDelayExponent:      ackDelayExponent,
+		maxAckDelay:           maxAckDelay,
+		activeConnIDLimit:     2,
+	}
+
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.MinVersion = tls.VersionTLS13
+	qconf := &tls.QUICConfig{TLSConfig: tlsConfig}
+	if isClient {
+		c.tls = tls.QUICClient(qconf)
+	} else {
+		c.tls = tls.QUICServer(qconf)
+	}
+	c.tls.SetTransportParameters(params.marshal(isClient))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.tls.Start(context.Background()); err != nil {
+		c.tls.Close()
+		return nil, err
+	}
+	if err := c.handleTLSEvents(time.Now()); err != nil {
+		c.tls.Close()
+		return nil, err
+	}
+	c.idleDeadline = c.nextIdleDeadline(time.Now())
+	return c, nil
+}
+
+func newConnID() []byte {
+	id := make([]byte, connIDLen)
+	if _, err := rand.Read(id); err != nil {
+		panic("quic: reading random connection ID: " + err.Error())
+	}
+	return id
+}
+
+// wake notifies the connection loop that it has work to do.
+func (c *Conn) wake() {
+	select {
+	case c.wakec <- struct{}{}:
+	default:
+	}
+}
+
+// deliver passes a datagram received by the endpoint to the connection.
+func (c *Conn) deliver(d []byte) {
+	select {
+	case c.recvc <- d:
+	default:
+		// The connection loop is falling behind; drop the datagram.
+	}
+}
+
+func (c *Conn) loop() {
+	defer c.exitLoop()
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		now := time.Now()
+		c.handleTimers(now)
+		c.sendPackets(now)
+		exit := c.exit
+		next := c.nextTimer()
+		c.mu.Unlock()
+		if exit {
+			return
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+		select {
+		case d := <-c.recvc:
+			c.mu.Lock()
+			now := time.Now()
+			c.handleDatagram(now, d)
+			// Process a batch of queued datagrams before sending, so
+			// that one ACK covers all of them.
+			for i := 0; i < 16; i++ {
+				select {
+				case d = <-c.recvc:
+					c.handleDatagram(now, d)
+					continue
+				default:
+				}
+				break
+			}
+			c.mu.Unlock()
+		case <-c.wakec:
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Conn) exitLoop() {
+	c.mu.Lock()
+	c.setClosed(ErrClosed)
+	c.mu.Unlock()
+	c.tls.Close()
+	c.ep.removeConn(c)
+}
+
+// handleTLSEvents processes the events produced by the TLS handshake.
+func (c *Conn) handleTLSEvents(now time.Time) error {
+	for {
+		e := c.tls.NextEvent()
+		switch e.Kind {
+		case tls.QUICNoEvent:
+			return nil
+		case tls.QUICSetReadSecret, tls.QUICSetWriteSecret:
+			sp, ok := levelSpace(e.Level)
+			if !ok {
+				continue
+			}
+			st, err := suiteByID(e.Suite)
+			if err != nil {
+				return &connCloseError{code: errInternal, reason: err.Error()}
+			}
+			k := st.newKeys(append([]byte(nil), e.Data...))
+			if e.Kind == tls.QUICSetReadSecret {
+				c.spaces[sp].read = k
+			} else {
+				c.spaces[sp].write = k
+			}
+		case tls.QUICWriteData:
+			if sp, ok := levelSpace(e.Level); ok {
+				c.spaces[sp].cryptoSend.write(e.Data)
+			}
+		case tls.QUICTransportParameters:
+			p, err := parseTransportParameters(e.Data, !c.isClient)
+			if err != nil {
+				return err
+			}
+			if err := p.checkConnIDs(c.dstConnID, c.origDstConnID, !c.isClient); err != nil {
+				return err
+			}
+			c.setPeerParams(p)
+		case tls.QUICHandshakeDone:
+			c.handshakeComplete = true
+			if !c.isClient {
+				// The server's handshake is confirmed as soon as it
+				// completes (RFC 9001, Section 4.1.2).
+				c.handshakeConfirmed = true
+				c.sendHandshakeDone = true
+				c.discardKeys(handshakeSpace)
+				c.ep.handshakeComplete(c)
+			}
+			c.closeHandshakeDone()
+			c.cond.Broadcast()
+		}
+	}
+}
+
+func (c *Conn) setPeerParams(p transportParameters) {
+	c.peerParams = p
+	c.sendMaxData = p.initialMaxData
+	c.localStreams[0].max = p.initialMaxStreamsBidi
+	c.localStreams[1].max = p.initialMaxStreamsUni
+	if p.maxIdleTimeout > 0 && (c.idleTimeout == 0 || p.maxIdleTimeout < c.idleTimeout) {
+		c.idleTimeout = p.maxIdleTimeout
+	}
+}
+
+// handleCryptoData passes the handshake data received in space sp to TLS.
+func (c *Conn) handleCryptoData(now time.Time, sp numberSpace) {
+	s := &c.spaces[sp]
+	b := s.cryptoRecv.peek()
+	if len(b) == 0 {
+		return
+	}
+	err := c.tls.HandleData(sp.level(), b)
+	s.cryptoRecv.discard(len(b))
+	if err == nil {
+		err = c.handleTLSEvents(now)
+	}
+	if err != nil {
+		c.abortErr(now, err)
+	}
+}
+
+// discardKeys discards the keys of a number space, and with them all
+// state about packets in flight in it (RFC 9002, Section 6.4).
+func (c *Conn) discardKeys(sp numberSpace) {
+	s := &c.spaces[sp]
+	if s.discarded {
+		return
+	}
+	for _, p := range s.sent {
+		c.cc.bytesInFlight -= int64(p.size)
+	}
+	s.read, s.write = nil, nil
+	s.discarded = true
+	s.sent = nil
+	s.ackElicitingInFlight = 0
+	s.lossTime = time.Time{}
+	s.ackPending = false
+	s.ackDeadline = time.Time{}
+	c.ptoCount = 0
+}
+
+func (c *Conn) nextIdleDeadline(now time.Time) time.Time {
+	if c.idleTimeout == 0 {
+		return time.Time{}
+	}
+	// The timeout is at least three times the current probe timeout
+	// (RFC 9000, Section 10.1).
+	d := c.idleTimeout
+	if pto := 3 * c.pto(); d < pto {
+		d = pto
+	}
+	return now.Add(d)
+}
+
+// handleTimers runs the actions of all timers that have expired.
+func (c *Conn) handleTimers(now time.Time) {
+	if c.closing || c.draining {
+		if !now.Before(c.closeDeadline) {
+			c.exit = true
+		}
+		return
+	}
+	if !c.idleDeadline.IsZero() && !now.Before(c.idleDeadline) {
+		c.setClosed(ErrIdleTimeout)
+		c.exit = true
+		return
+	}
+	for sp := initialSpace; sp < numSpaces; sp++ {
+		s := &c.spaces[sp]
+		if !s.ackDeadline.IsZero() && !now.Before(s.ackDeadline) {
+			s.ackPending = true
+		}
+	}
+	if t, sp := c.lossDetectionTimer(); !t.IsZero() && !now.Before(t) {
+		if !c.spaces[sp].lossTime.IsZero() {
+			c.detectLoss(now, sp)
+		} else {
+			c.onPTO(sp)
+		}
+	}
+}
+
+// nextTimer returns the time at which handleTimers next has work to do.
+func (c *Conn) nextTimer() time.Time {
+	if c.closing || c.draining {
+		return c.closeDeadline
+	}
+	next := c.idleDeadline
+	earliest := func(t time.Time) {
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	for sp := range c.spaces {
+		earliest(c.spaces[sp].ackDeadline)
+	}
+	t, _ := c.lossDetectionTimer()
+	earliest(t)
+	return next
+}
+
+// setClosed records that the connection is closed with err, and wakes
+// everything waiting on it.
+func (c *Conn) setClosed(err error) {
+	if c.closeErr != nil {
+		return
+	}
+	c.closeErr = err
+	close(c.donec)
+	c.closeHandshakeDone()
+	c.cond.Broadcast()
+}
+
+func (c *Conn) closeHandshakeDone() {
+	select {
+	case <-c.handshakeDone:
+	default:
+		close(c.handshakeDone)
+	}
+}
+
+// abort closes the connection with a transport error detected locally.
+func (c *Conn) abort(now time.Time, code TransportError, reason string) {
+	c.abortErr(now, &connCloseError{code: code, reason: reason})
+}
+
+func (c *Conn) abortErr(now time.Time, err error) {
+	var code TransportError
+	var ae tls.AlertError
+	var ce *connCloseError
+	switch {
+	case errors.As(err, &ce):
+		code = ce.code
+	case errors.As(err, &ae):
+		code = errCryptoBase + TransportError(ae)
+		err = &connCloseError{code: code, reason: err.Error()}
+	default:
+		code = errInternal
+		err = &connCloseError{code: code, reason: err.Error()}
+	}
+	c.enterClosing(now, err, false, uint64(code), "")
+}
+
+// enterClosing closes the connection and starts sending a
+// CONNECTION_CLOSE frame in response to incoming packets.
+func (c *Conn) enterClosing(now time.Time, err error, app bool, code uint64, reason string) {
+	if c.closeErr != nil {
+		return
+	}
+	c.setClosed(err)
+	c.closing = true
+	c.closeApp = app
+	c.closeCode = code
+	c.closeReason = reason
+	c.sendClose = true
+	c.closeDeadline = now.Add(3 * c.pto())
+	c.wake()
+}
+
+// enterDraining closes the connection after the peer closed it.
+func (c *Conn) enterDraining(now time.Time, err error) {
+	if c.closing {
+		c.closing = false
+		c.sendClose = false
+	}
+	c.setClosed(err)
+	c.draining = true
+	c.closeDeadline = now.Add(3 * c.pto())
+}
+
+// closeNow closes the connection with a NO_ERROR CONNECTION_CLOSE and
+// stops the loop as soon as the frame has been sent. A connection that
+// is already closed stops without waiting out its closing period.
+func (c *Conn) closeNow() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.closeErr == nil:
+		c.enterClosing(time.Now(), ErrClosed, false, uint64(errNo), "")
+	case !c.closing:
+		c.exit = true
+	}
+	c.exitNow = true
+	c.wake()
+}
+
+// wait waits until ready reports true, ctx is done, or the connection
+// is closed. c.mu must be held.
+func (c *Conn) wait(ctx context.Context, ready func() bool) error {
+	if ctx.Done() != nil {
+		stop := context.AfterFunc(ctx, func() {
+			c.mu.Lock()
+			c.cond.Broadcast()
+			c.mu.Unlock()
+		})
+		defer stop()
+	}
+	for !ready() {
+		if c.closeErr != nil {
+			return c.closeErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.cond.Wait()
+	}
+	return nil
+}
+
+// OpenStream opens a new bidirectional stream, blocking until the peer
+// allows it.
+func (c *Conn) OpenStream(ctx context.Context) (*Stream, error) {
+	return c.openStream(ctx, false)
+}
+
+// OpenUniStream opens a new unidirectional stream, blocking until the
+// peer allows it.
+func (c *Conn) OpenUniStream(ctx context.Context) (*Stream, error) {
+	return c.openStream(ctx, true)
+}
+
+func (c *Conn) openStream(ctx context.Context, uni bool) (*Stream, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := &c.localStreams[b2i(uni)]
+	err := c.wait(ctx, func() bool {
+		return c.handshakeComplete && n.opened < n.max && c.closeErr == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	id := n.opened<<2 | int64(b2i(uni))<<1
+	if !c.isClient {
+		id |= 1
+	}
+	n.opened++
+	return c.newStream(id), nil
+}
+
+// AcceptStream waits for and returns the next bidirectional stream
+// opened by the peer.
+func (c *Conn) AcceptStream(ctx context.Context) (*Stream, error) {
+	return c.acceptStream(ctx, false)
+}
+
+// AcceptUniStream waits for and returns the next unidirectional stream
+// opened by the peer.
+func (c *Conn) AcceptUniStream(ctx context.Context) (*Stream, error) {
+	return c.acceptStream(ctx, true)
+}
+
+func (c *Conn) acceptStream(ctx context.Context, uni bool) (*Stream, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q := &c.acceptq[b2i(uni)]
+	if err := c.wait(ctx, func() bool { return len(*q) > 0 }); err != nil {
+		return nil, err
+	}
+	s := (*q)[0]
+	*q = (*q)[1:]
+	return s, nil
+}
+
+// CloseWithError closes the connection, sending the peer the given
+// application error code and reason. Streams and blocked operations
+// fail with ErrClosed.
+func (c *Conn) CloseWithError(code uint64, reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closeErr != nil {
+		return nil
+	}
+	c.enterClosing(time.Now(), ErrClosed, true, code, reason)
+	return nil
+}
+
+// ConnectionState returns the state of the TLS handshake.
+func (c *Conn) ConnectionState() tls.ConnectionState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tls.ConnectionState()
+}
+
+// LocalAddr returns the local address of the connection's endpoint.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.ep.LocalAddr()
+}
+
+// RemoteAddr returns the peer's address.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// Done returns a channel that is closed when the connection closes.
+func (c *Conn) Done() <-chan struct{} {
+	return c.donec
+}
+
+// Err returns the error the connection closed with, or nil if it is
+// still open.
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeErr
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
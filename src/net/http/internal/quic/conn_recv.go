@@ -0,0 +1,394 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"bytes"
+	"time"
+)
+
+// handleDatagram processes a datagram received from the peer, which
+// may contain several coalesced packets (RFC 9000, Section 12.2).
+func (c *Conn) handleDatagram(now time.Time, d []byte) {
+	if c.draining || c.exit {
+		return
+	}
+	if c.closing {
+		// Respond to incoming packets with another CONNECTION_CLOSE,
+		// but not without limit (RFC 9000, Section 10.2.1).
+		if c.closeSends < 16 {
+			c.sendClose = true
+		}
+		return
+	}
+	c.bytesRecvd += int64(len(d))
+	for len(d) > 0 && c.closeErr == nil {
+		n := c.handlePacket(now, d)
+		if n <= 0 {
+			return
+		}
+		d = d[n:]
+	}
+}
+
+// handlePacket processes the packet at the start of b, and returns its
+// length, or -1 if the rest of the datagram should be dropped.
+func (c *Conn) handlePacket(now time.Time, b []byte) int {
+	if b[0]&headerFormLong == 0 {
+		c.handleShortPacket(now, b)
+		return len(b)
+	}
+	h, ok := parseLongHeader(b)
+	if !ok || h.version != quicVersion1 {
+		// We send no Version Negotiation packets and act on none.
+		return -1
+	}
+	var sp numberSpace
+	switch h.typ {
+	case longTypeInitial:
+		sp = initialSpace
+	case longTypeHandshake:
+		sp = handshakeSpace
+	default:
+		// 0-RTT is not accepted, and Retry is not supported.
+		return h.end
+	}
+	if b[0]&fixedBit == 0 {
+		return h.end
+	}
+	if !bytes.Equal(h.dstID, c.srcConnID) &&
+		!(!c.isClient && sp == initialSpace && bytes.Equal(h.dstID, c.origDstConnID)) {
+		return h.end
+	}
+	if c.isClient && sp == initialSpace && len(h.token) > 0 {
+		// A server never sends a token in an Initial packet.
+		return h.end
+	}
+	s := &c.spaces[sp]
+	if s.read == nil {
+		return h.end
+	}
+	pkt := b[:h.end]
+	pn, hdrLen, ok := s.read.unprotectHeader(pkt, h.pnOff, s.largestRecvd)
+	if !ok {
+		return h.end
+	}
+	payload, err := s.read.open(pkt, hdrLen, pn)
+	if err != nil {
+		return h.end
+	}
+	if pkt[0]&reservedLongBits != 0 {
+		c.abort(now, errProtocolViolation, "reserved header bits set")
+		return -1
+	}
+	if c.isClient && sp == initialSpace && !c.gotPeerSrcID {
+		// Use the connection ID the server chose from now on
+		// (RFC 9000, Section 7.2).
+		c.dstConnID = append([]byte(nil), h.srcID...)
+		c.gotPeerSrcID = true
+	}
+	if !c.isClient && sp == handshakeSpace {
+		// Receiving a Handshake packet validates the client's address
+		// and ends the use of Initial packets (RFC 9001, Section 4.9.1).
+		c.addrValidated = true
+		c.discardKeys(initialSpace)
+	}
+	c.handleFrames(now, sp, pn, payload)
+	return h.end
+}
+
+func (c *Conn) handleShortPacket(now time.Time, b []byte) {
+	s := &c.spaces[appDataSpace]
+	if s.read == nil || len(b) < 1+connIDLen || b[0]&fixedBit == 0 ||
+		!bytes.Equal(b[1:1+connIDLen], c.srcConnID) {
+		return
+	}
+	pn, hdrLen, ok := s.read.unprotectHeader(b, 1+connIDLen, s.largestRecvd)
+	if !ok {
+		return
+	}
+	keys := s.read
+	phase := b[0]&keyPhaseBit != 0
+	if phase != c.keyPhase {
+		if c.nextReadKeys == nil {
+			c.nextReadKeys = s.read.next()
+		}
+		keys = c.nextReadKeys
+	}
+	payload, err := keys.open(b, hdrLen, pn)
+	if err != nil {
+		return
+	}
+	if b[0]&reservedShortBits != 0 {
+		c.abort(now, errProtocolViolation, "reserved header bits set")
+		return
+	}
+	if phase != c.keyPhase {
+		// The peer started a key update. Follow it by updating our
+		// own keys too (RFC 9001, Section 6.2). Reordered packets
+		// protected with the old keys are dropped.
+		if !c.handshakeConfirmed {
+			c.abort(now, errKeyUpdate, "key update before handshake confirmed")
+			return
+		}
+		c.keyPhase = phase
+		s.read = keys
+		s.write = s.write.next()
+		c.nextReadKeys = nil
+	}
+	c.handleFrames(now, appDataSpace, pn, payload)
+}
+
+// handleFrames processes the frames in the payload of packet pn,
+// received in number space sp.
+func (c *Conn) handleFrames(now time.Time, sp numberSpace, pn int64, payload []byte) {
+	s := &c.spaces[sp]
+	if pn < s.ackFloor || s.recvd.contains(pn) {
+		return // duplicate
+	}
+	if len(payload) == 0 {
+		c.abort(now, errProtocolViolation, "packet with no frames")
+		return
+	}
+	ackEliciting := false
+	r := wireReader{b: payload}
+	for len(r.b) > 0 && c.closeErr == nil {
+		typ := r.varint()
+		if r.err != nil {
+			break
+		}
+		if sp != appDataSpace && !frameAllowedInHandshake(typ) {
+			c.abort(now, errProtocolViolation, "frame not allowed in handshake packet")
+			return
+		}
+		if isAckEliciting(typ) {
+			ackEliciting = true
+		}
+		c.handleFrame(now, sp, typ, &r)
+		if r.err != nil {
+			c.abort(now, errFrameEncoding, "malformed frame")
+			return
+		}
+	}
+	if r.err != nil {
+		c.abort(now, errFrameEncoding, "malformed frame type")
+		return
+	}
+	if c.closeErr != nil {
+		return
+	}
+
+	s.recvd.add(pn, pn+1)
+	if len(s.recvd) > maxAckRanges {
+		s.recvd = s.recvd[len(s.recvd)-maxAckRanges:]
+		s.ackFloor = s.recvd[0].start
+	}
+	outOfOrder := pn != s.largestRecvd+1
+	if pn > s.largestRecvd {
+		s.largestRecvd = pn
+		s.largestRecvdTime = now
+	}
+	if ackEliciting {
+		// Acknowledge Initial and Handshake packets, reordered packets
+		// and every second packet immediately, and others within
+		// max_ack_delay (RFC 9000, Section 13.2).
+		s.unackedElicited++
+		if sp != appDataSpace || outOfOrder || s.unackedElicited >= 2 {
+			s.ackPending = true
+		} else if s.ackDeadline.IsZero() {
+			s.ackDeadline = now.Add(maxAckDelay - timerGranularity)
+		}
+	}
+	c.idleDeadline = c.nextIdleDeadline(now)
+	c.idleRestarted = false
+}
+
+// handleFrame processes a frame of type typ, whose contents follow in r.
+func (c *Conn) handleFrame(now time.Time, sp numberSpace, typ uint64, r *wireReader) {
+	switch {
+	case typ == frameTypePadding:
+		for len(r.b) > 0 && r.b[0] == 0 {
+			r.b = r.b[1:]
+		}
+	case typ == frameTypePing:
+	case typ == frameTypeAck || typ == frameTypeAckECN:
+		c.handleAckFrame(now, sp, typ, r)
+	case typ == frameTypeResetStream:
+		id, code, size := int64(r.varint()), r.varint(), int64(r.varint())
+		if r.err == nil {
+			c.handleResetStream(now, id, code, size)
+		}
+	case typ == frameTypeStopSending:
+		id, code := int64(r.varint()), r.varint()
+		if r.err == nil {
+			c.handleStopSending(now, id, code)
+		}
+	case typ == frameTypeCrypto:
+		off := int64(r.varint())
+		data := r.varintBytes()
+		if r.err != nil {
+			return
+		}
+		s := &c.spaces[sp]
+		if err := s.cryptoRecv.write(off, data, false); err != nil {
+			c.abortErr(now, err)
+			return
+		}
+		if s.cryptoRecv.maxOff-s.cryptoRecv.readOff > 1<<16 {
+			c.abort(now, errCryptoBufferExceeded, "")
+			return
+		}
+		c.handleCryptoData(now, sp)
+	case typ == frameTypeNewToken:
+		r.varintBytes()
+		if !c.isClient {
+			c.abort(now, errProtocolViolation, "NEW_TOKEN from client")
+		}
+	case typ >= frameTypeStreamBase && typ <= frameTypeStreamBase|0x07:
+		id := int64(r.varint())
+		var off int64
+		if typ&streamFlagOff != 0 {
+			off = int64(r.varint())
+		}
+		var data []byte
+		if typ&streamFlagLen != 0 {
+			data = r.varintBytes()
+		} else {
+			data, r.b = r.b, nil
+		}
+		if r.err != nil {
+			return
+		}
+		if off+int64(len(data)) > maxVarint {
+			c.abort(now, errFrameEncoding, "stream data beyond maximum offset")
+			return
+		}
+		c.handleStreamFrame(now, id, off, data, typ&streamFlagFin != 0)
+	case typ == frameTypeMaxData:
+		if v := int64(r.varint()); v > c.sendMaxData {
+			c.sendMaxData = v
+			c.queueAllStreams()
+		}
+	case typ == frameTypeMaxStreamData:
+		id, v := int64(r.varint()), int64(r.varint())
+		if r.err != nil {
+			return
+		}
+		st, err := c.streamForFrame(id, false)
+		if err != nil {
+			c.abortErr(now, err)
+			return
+		}
+		if st != nil && v > st.sendMax {
+			st.sendMax = v
+			c.queueStream(st)
+		}
+	case typ == frameTypeMaxStreamsBidi || typ == frameTypeMaxStreamsUni:
+		v := int64(r.varint())
+		if v > 1<<60 {
+			c.abort(now, errFrameEncoding, "MAX_STREAMS too large")
+			return
+		}
+		n := &c.localStreams[b2i(typ == frameTypeMaxStreamsUni)]
+		if v > n.max {
+			n.max = v
+			c.cond.Broadcast()
+		}
+	case typ == frameTypeDataBlocked:
+		r.varint()
+	case typ == frameTypeStreamDataBlocked:
+		r.varint()
+		r.varint()
+	case typ == frameTypeStreamsBlockedBidi || typ == frameTypeStreamsBlockedUni:
+		r.varint()
+	case typ == frameTypeNewConnectionID:
+		// We keep using the peer's first connection ID, since we
+		// never migrate, so additional IDs are only validated.
+		seq, retirePriorTo := r.varint(), r.varint()
+		n := r.byte()
+		r.bytes(uint64(n))
+		r.bytes(16) // stateless reset token
+		if r.err == nil && (n < 1 || n > maxConnIDLen || retirePriorTo > seq) {
+			c.abort(now, errFrameEncoding, "invalid NEW_CONNECTION_ID")
+		}
+		if len(c.dstConnID) == 0 {
+			c.abort(now, errProtocolViolation, "NEW_CONNECTION_ID with zero-length connection ID")
+		}
+	case typ == frameTypeRetireConnectionID:
+		if seq := r.varint(); seq > 0 {
+			c.abort(now, errProtocolViolation, "retired unknown connection ID")
+		}
+	case typ == frameTypePathChallenge:
+		var data [8]byte
+		copy(data[:], r.bytes(8))
+		if r.err == nil {
+			c.pathResponses = append(c.pathResponses, data)
+		}
+	case typ == frameTypePathResponse:
+		// We never send PATH_CHALLENGE.
+		r.bytes(8)
+	case typ == frameTypeConnectionClose:
+		code := TransportError(r.varint())
+		r.varint() // frame type
+		reason := r.varintBytes()
+		if r.err == nil {
+			c.enterDraining(now, &connCloseError{code: code, reason: string(reason), remote: true})
+		}
+	case typ == frameTypeConnectionCloseApp:
+		code := r.varint()
+		reason := r.varintBytes()
+		if r.err == nil {
+			c.enterDraining(now, &ApplicationError{Code: code, Reason: string(reason)})
+		}
+	case typ == frameTypeHandshakeDone:
+		if !c.isClient {
+			c.abort(now, errProtocolViolation, "HANDSHAKE_DONE from client")
+			return
+		}
+		if !c.handshakeConfirmed {
+			c.handshakeConfirmed = true
+			c.discardKeys(handshakeSpace)
+		}
+	default:
+		c.abort(now, errFrameEncoding, "unknown frame type")
+	}
+}
+
+func (c *Conn) handleAckFrame(now time.Time, sp numberSpace, typ uint64, r *wireReader) {
+	largest := int64(r.varint())
+	delay := r.varint()
+	count := r.varint()
+	first := int64(r.varint())
+	if r.err != nil {
+		return
+	}
+	if first > largest {
+		c.abort(now, errFrameEncoding, "invalid ACK range")
+		return
+	}
+	var arr [8]span
+	ranges := append(arr[:0], span{largest - first, largest + 1})
+	smallest := largest - first
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		gap, n := int64(r.varint()), int64(r.varint())
+		hi := smallest - gap - 2
+		lo := hi - n
+		if lo < 0 {
+			c.abort(now, errFrameEncoding, "invalid ACK range")
+			return
+		}
+		ranges = append(ranges, span{lo, hi + 1})
+		smallest = lo
+	}
+	if typ == frameTypeAckECN {
+		r.varint()
+		r.varint()
+		r.varint()
+	}
+	if r.err != nil {
+		return
+	}
+	c.handleAck(now, sp, ranges, delay)
+}
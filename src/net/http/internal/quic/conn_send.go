@@ -0,0 +1,397 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import "time"
+
+// An outPacket is a packet under construction, before protection.
+type outPacket struct {
+	sp           numberSpace
+	b            []byte // header followed by the plaintext payload
+	pn           int64
+	pnOff        int
+	pnLen        int
+	lenOff       int // offset of the Length field of a long header, or 0
+	ackEliciting bool
+	padded       bool
+	frames       []sentFrame
+}
+
+// sendPackets sends all the packets the connection can send now.
+func (c *Conn) sendPackets(now time.Time) {
+	if c.draining {
+		return
+	}
+	if c.closing {
+		if c.sendClose {
+			c.sendClose = false
+			c.closeSends++
+			if d := c.closeDatagram(now); d != nil {
+				c.ep.writeTo(d, c.remoteAddr)
+			}
+		}
+		if c.exitNow {
+			c.exit = true
+		}
+		return
+	}
+	for i := 0; i < 64; i++ {
+		d := c.nextDatagram(now)
+		if d == nil {
+			return
+		}
+		c.ep.writeTo(d, c.remoteAddr)
+	}
+	// There may be more to send; let the loop look at incoming
+	// datagrams first.
+	c.wake()
+}
+
+// sendLimit returns the largest datagram the anti-amplification limit
+// allows us to send (RFC 9000, Section 8.1).
+func (c *Conn) sendLimit() int {
+	if c.addrValidated {
+		return maxDatagramSize
+	}
+	budget := 3*c.bytesRecvd - c.bytesSent
+	if budget < maxDatagramSize {
+		return int(budget)
+	}
+	return maxDatagramSize
+}
+
+// nextDatagram assembles the next datagram to send, coalescing packets
+// from each number space with something to send, or returns nil.
+func (c *Conn) nextDatagram(now time.Time) []byte {
+	limit := c.sendLimit()
+	canSend := c.cc.canSend() || c.ptoProbes > 0
+	var pkts []*outPacket
+	size := 0
+	pad := false
+	for sp := initialSpace; sp < numSpaces; sp++ {
+		p := c.buildPacket(now, sp, limit-size, canSend)
+		if p == nil {
+			continue
+		}
+		pkts = append(pkts, p)
+		size += len(p.b) + aeadOverhead
+		// Datagrams carrying Initial packets from clients, and
+		// ack-eliciting Initial packets from servers, are padded
+		// (RFC 9000, Section 14.1).
+		if sp == initialSpace && (c.isClient || p.ackEliciting) {
+			pad = true
+		}
+	}
+	if len(pkts) == 0 {
+		return nil
+	}
+	if pad && size < limit {
+		last := pkts[len(pkts)-1]
+		last.b = append(last.b, make([]byte, limit-size)...)
+		last.padded = true
+	}
+	d := c.sealDatagram(pkts)
+
+	discardInitial := false
+	for _, p := range pkts {
+		s := &c.spaces[p.sp]
+		if p.ackEliciting || p.padded {
+			s.sent = append(s.sent, &sentPacket{
+				num:          p.pn,
+				time:         now,
+				size:         len(p.b) + aeadOverhead,
+				ackEliciting: p.ackEliciting,
+				frames:       p.frames,
+			})
+			c.cc.bytesInFlight += int64(len(p.b) + aeadOverhead)
+		}
+		if p.ackEliciting {
+			s.ackElicitingInFlight++
+			s.lastAckElicitingSent = now
+			if c.ptoProbes > 0 {
+				c.ptoProbes--
+			}
+			if !c.idleRestarted {
+				c.idleDeadline = c.nextIdleDeadline(now)
+				c.idleRestarted = true
+			}
+		}
+		if c.isClient && p.sp == handshakeSpace {
+			// A client stops using Initial packets once it sends a
+			// Handshake packet (RFC 9001, Section 4.9.1).
+			discardInitial = true
+		}
+	}
+	if discardInitial {
+		c.discardKeys(initialSpace)
+	}
+	c.bytesSent += int64(len(d))
+	c.lastSent = now
+	return d
+}
+
+// startPacket writes the header of the next packet in number space sp,
+// and returns a frameBuilder for its payload, limited so that the
+// protected packet is at most room bytes long. It returns nil if
+// there are no keys for sp or no room.
+func (c *Conn) startPacket(sp numberSpace, room int) (*outPacket, *frameBuilder) {
+	s := &c.spaces[sp]
+	if s.write == nil || room < 64 {
+		return nil, nil
+	}
+	p := &outPacket{
+		sp:    sp,
+		pn:    s.nextPN,
+		pnLen: packetNumberLength(s.nextPN, s.largestAcked),
+	}
+	b := make([]byte, 0, maxDatagramSize)
+	if sp == appDataSpace {
+		first := byte(fixedBit | (p.pnLen - 1))
+		if c.keyPhase {
+			first |= keyPhaseBit
+		}
+		b = append(b, first)
+		b = append(b, c.dstConnID...)
+	} else {
+		typ := byte(longTypeInitial)
+		if sp == handshakeSpace {
+			typ = longTypeHandshake
+		}
+		b = append(b, headerFormLong|fixedBit|typ<<4|byte(p.pnLen-1))
+		b = append(b, 0, 0, 0, quicVersion1)
+		b = append(b, byte(len(c.dstConnID)))
+		b = append(b, c.dstConnID...)
+		b = append(b, byte(len(c.srcConnID)))
+		b = append(b, c.srcConnID...)
+		if sp == initialSpace {
+			b = append(b, 0) // token length
+		}
+		p.lenOff = len(b)
+		b = append(b, 0x40, 0) // two-byte Length, filled in by sealDatagram
+	}
+	p.pnOff = len(b)
+	b = appendPacketNumber(b, p.pn, p.pnLen)
+	return p, &frameBuilder{b: b, limit: room - aeadOverhead}
+}
+
+// finishPacket completes p with the frames in fb, and consumes its
+// packet number.
+func (c *Conn) finishPacket(p *outPacket, fb *frameBuilder) {
+	// Header protection samples 16 bytes starting 4 bytes after the
+	// start of the packet number (RFC 9001, Section 5.4.2).
+	for len(fb.b)-p.pnOff < 4 {
+		fb.b = append(fb.b, frameTypePadding)
+	}
+	p.b = fb.b
+	p.ackEliciting = fb.ackEliciting
+	p.frames = fb.frames
+	c.spaces[p.sp].nextPN++
+}
+
+// buildPacket assembles the next packet in number space sp, if there is
+// anything to send in it. Only acknowledgements are sent unless canSend
+// is set.
+func (c *Conn) buildPacket(now time.Time, sp numberSpace, room int, canSend bool) *outPacket {
+	s := &c.spaces[sp]
+	p, fb := c.startPacket(sp, room)
+	if p == nil {
+		return nil
+	}
+	hdrLen := len(fb.b)
+	ackWanted := s.ackPending || s.unackedElicited > 0
+	if ackWanted && !c.appendAckFrame(fb, now, s) {
+		return nil
+	}
+	if canSend {
+		c.appendFrames(fb, now, sp)
+	}
+	if len(fb.b) == hdrLen || !fb.ackEliciting && !s.ackPending {
+		// Nothing to send, or only an ACK that can wait.
+		return nil
+	}
+	if ackWanted {
+		s.ackPending = false
+		s.unackedElicited = 0
+		s.ackDeadline = time.Time{}
+	}
+	c.finishPacket(p, fb)
+	return p
+}
+
+// appendFrames appends everything but acknowledgements that is waiting
+// to be sent in number space sp.
+func (c *Conn) appendFrames(fb *frameBuilder, now time.Time, sp numberSpace) {
+	s := &c.spaces[sp]
+	if sp == appDataSpace {
+		if c.sendHandshakeDone {
+			if !fb.appendVarints(frameTypeHandshakeDone) {
+				return
+			}
+			fb.record(sentFrame{typ: frameTypeHandshakeDone})
+			c.sendHandshakeDone = false
+		}
+		for len(c.pathResponses) > 0 && fb.room() >= 9 {
+			fb.b = append(fb.b, frameTypePathResponse)
+			fb.b = append(fb.b, c.pathResponses[0][:]...)
+			fb.ackEliciting = true
+			c.pathResponses = c.pathResponses[1:]
+		}
+		if c.sendMaxDataF {
+			if !fb.appendVarints(frameTypeMaxData, uint64(c.recvMaxData)) {
+				return
+			}
+			fb.record(sentFrame{typ: frameTypeMaxData})
+			c.sendMaxDataF = false
+		}
+		for i := range c.remoteStreams {
+			n := &c.remoteStreams[i]
+			if !n.sendMaxStreams {
+				continue
+			}
+			typ := byte(frameTypeMaxStreamsBidi + i)
+			if !fb.appendVarints(uint64(typ), uint64(n.max)) {
+				return
+			}
+			fb.record(sentFrame{typ: typ})
+			n.sendMaxStreams = false
+		}
+	}
+	for s.cryptoSend.hasData(maxVarint) {
+		if _, _, ok := fb.appendDataFrame(nil, &s.cryptoSend, maxVarint); !ok {
+			return
+		}
+	}
+	if sp == appDataSpace {
+		for len(c.sendq) > 0 {
+			st := c.sendq[0]
+			c.sendq = c.sendq[1:]
+			st.inSendq = false
+			if st.closed || !st.wantsToSend(c.sendMaxData-c.sentData) {
+				continue
+			}
+			full := !c.appendStreamFrames(fb, st)
+			if st.wantsToSend(c.sendMaxData - c.sentData) {
+				c.queueStream(st)
+			}
+			if full {
+				break
+			}
+		}
+	}
+	if c.ptoProbes > 0 && c.ptoSpace == sp && !fb.ackEliciting {
+		if fb.appendVarints(frameTypePing) {
+			fb.ackEliciting = true
+		}
+	}
+}
+
+// appendAckFrame appends an ACK frame for the packets received in s,
+// acknowledging as many ranges as fit.
+func (c *Conn) appendAckFrame(fb *frameBuilder, now time.Time, s *space) bool {
+	r := s.recvd
+	if len(r) == 0 {
+		return true
+	}
+	last := r[len(r)-1]
+	largest := last.end - 1
+	delay := uint64(now.Sub(s.largestRecvdTime) / time.Microsecond >> ackDelayExponent)
+	hdr := 1 + SizeVarint(uint64(largest)) + SizeVarint(delay) + SizeVarint(uint64(last.end-1-last.start))
+
+	var rb []byte
+	count := 0
+	prev := last.start
+	for i := len(r) - 2; i >= 0; i-- {
+		gap := prev - r[i].end - 1
+		n := r[i].end - 1 - r[i].start
+		next := AppendVarint(AppendVarint(rb, uint64(gap)), uint64(n))
+		if hdr+SizeVarint(uint64(count+1))+len(next) > fb.room() {
+			break
+		}
+		rb = next
+		count++
+		prev = r[i].start
+	}
+	if hdr+SizeVarint(uint64(count))+len(rb) > fb.room() {
+		return false
+	}
+	fb.b = append(fb.b, frameTypeAck)
+	fb.b = AppendVarint(fb.b, uint64(largest))
+	fb.b = AppendVarint(fb.b, delay)
+	fb.b = AppendVarint(fb.b, uint64(count))
+	fb.b = AppendVarint(fb.b, uint64(last.end-1-last.start))
+	fb.b = append(fb.b, rb...)
+	return true
+}
+
+// closeDatagram builds a datagram carrying CONNECTION_CLOSE frames in
+// every number space the peer may be able to read
+// (RFC 9000, Section 10.2.3).
+func (c *Conn) closeDatagram(now time.Time) []byte {
+	limit := c.sendLimit()
+	var pkts []*outPacket
+	size := 0
+	for sp := initialSpace; sp < numSpaces; sp++ {
+		if c.handshakeConfirmed && sp != appDataSpace {
+			continue
+		}
+		p, fb := c.startPacket(sp, limit-size)
+		if p == nil {
+			continue
+		}
+		reason := c.closeReason
+		if len(reason) > 256 {
+			reason = reason[:256]
+		}
+		switch {
+		case c.closeApp && sp == appDataSpace:
+			fb.appendVarints(frameTypeConnectionCloseApp, c.closeCode, uint64(len(reason)))
+		case c.closeApp:
+			// Application errors are not revealed before the
+			// handshake is complete.
+			reason = ""
+			fb.appendVarints(frameTypeConnectionClose, uint64(errApplication), 0, 0)
+		default:
+			fb.appendVarints(frameTypeConnectionClose, c.closeCode, 0, uint64(len(reason)))
+		}
+		if len(reason) > fb.room() {
+			return nil
+		}
+		fb.b = append(fb.b, reason...)
+		c.finishPacket(p, fb)
+		pkts = append(pkts, p)
+		size += len(p.b) + aeadOverhead
+	}
+	if len(pkts) == 0 {
+		return nil
+	}
+	if c.isClient && pkts[0].sp == initialSpace && size < limit {
+		last := pkts[len(pkts)-1]
+		last.b = append(last.b, make([]byte, limit-size)...)
+	}
+	d := c.sealDatagram(pkts)
+	c.bytesSent += int64(len(d))
+	return d
+}
+
+// sealDatagram protects the packets and concatenates them.
+func (c *Conn) sealDatagram(pkts []*outPacket) []byte {
+	n := 0
+	for _, p := range pkts {
+		n += len(p.b) + aeadOverhead
+	}
+	d := make([]byte, 0, n)
+	for _, p := range pkts {
+		if p.lenOff > 0 {
+			length := len(p.b) - p.pnOff + aeadOverhead
+			p.b[p.lenOff] = 0x40 | byte(length>>8)
+			p.b[p.lenOff+1] = byte(length)
+		}
+		start := len(d)
+		d = append(d, p.b...)
+		sealed := c.spaces[p.sp].write.protect(d[start:], p.pnOff, p.pnLen, p.pn)
+		d = d[:start+len(sealed)]
+	}
+	return d
+}
@@ -0,0 +1,366 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http/internal/testcert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lossyConn drops a deterministic pseudo-random fraction of the
+// datagrams written to it.
+type lossyConn struct {
+	net.PacketConn
+	mu   sync.Mutex
+	rand *rand.Rand
+	loss float64
+}
+
+func (c *lossyConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	c.mu.Lock()
+	drop := c.rand.Float64() < c.loss
+	c.mu.Unlock()
+	if drop {
+		return len(b), nil
+	}
+	return c.PacketConn.WriteTo(b, addr)
+}
+
+type testPair struct {
+	server, client *Endpoint
+	sconn, cconn   *Conn
+}
+
+func listenUDP(t *testing.T) net.PacketConn {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP loopback: %v", err)
+	}
+	return pc
+}
+
+func testTLSConfigs(t *testing.T) (server, client *tls.Config) {
+	cert, err := tls.X509KeyPair(testcert.LocalhostCert, testcert.LocalhostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	server = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"test"},
+	}
+	client = &tls.Config{
+		RootCAs:    roots,
+		ServerName: "example.com",
+		NextProtos: []string{"test"},
+	}
+	return server, client
+}
+
+// newTestPair returns a connected client and server. If loss is
+// non-zero, both endpoints drop that fraction of outgoing datagrams.
+func newTestPair(t *testing.T, config *Config, loss float64) *testPair {
+	t.Helper()
+	if config == nil {
+		config = &Config{}
+	}
+	stls, ctls := testTLSConfigs(t)
+	sconfig := *config
+	sconfig.TLSConfig = stls
+	cconfig := *config
+	cconfig.TLSConfig = nil
+
+	spc, cpc := listenUDP(t), listenUDP(t)
+	if loss > 0 {
+		spc = &lossyConn{PacketConn: spc, rand: rand.New(rand.NewSource(1)), loss: loss}
+		cpc = &lossyConn{PacketConn: cpc, rand: rand.New(rand.NewSource(2)), loss: loss}
+	}
+	p := &testPair{
+		server: NewEndpoint(spc, &sconfig),
+		client: NewEndpoint(cpc, &cconfig),
+	}
+	t.Cleanup(func() {
+		p.client.Close()
+		p.server.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	var err error
+	p.cconn, err = p.client.Dial(ctx, p.server.LocalAddr(), ctls)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	p.sconn, err = p.server.Accept(ctx)
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	return p
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// echo serves streams on c, copying each stream's data back to it.
+func echo(ctx context.Context, c *Conn) {
+	for {
+		s, err := c.AcceptStream(ctx)
+		if err != nil {
+			return
+		}
+		go func() {
+			io.Copy(s, s)
+			s.CloseWrite()
+		}()
+	}
+}
+
+func roundTrip(s *Stream, msg []byte) ([]byte, error) {
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.Write(msg)
+		if err == nil {
+			err = s.CloseWrite()
+		}
+		errc <- err
+	}()
+	got, err := io.ReadAll(s)
+	if err != nil {
+		return nil, err
+	}
+	return got, <-errc
+}
+
+func TestHandshake(t *testing.T) {
+	p := newTestPair(t, nil, 0)
+	for _, c := range []*Conn{p.cconn, p.sconn} {
+		cs := c.ConnectionState()
+		if cs.Version != tls.VersionTLS13 || !cs.HandshakeComplete {
+			t.Errorf("ConnectionState = %+v", cs)
+		}
+		if cs.NegotiatedProtocol != "test" {
+			t.Errorf("NegotiatedProtocol = %q; want %q", cs.NegotiatedProtocol, "test")
+		}
+	}
+	if p.sconn.RemoteAddr().String() != p.client.LocalAddr().String() {
+		t.Errorf("server RemoteAddr = %v; want %v", p.sconn.RemoteAddr(), p.client.LocalAddr())
+	}
+}
+
+func TestALPNRequired(t *testing.T) {
+	stls, ctls := testTLSConfigs(t)
+	ctls.NextProtos = nil
+	server := NewEndpoint(listenUDP(t), &Config{TLSConfig: stls})
+	defer server.Close()
+	client := NewEndpoint(listenUDP(t), nil)
+	defer client.Close()
+	ctx := testContext(t)
+	_, err := client.Dial(ctx, server.LocalAddr(), ctls)
+	if err == nil {
+		t.Fatal("Dial without ALPN succeeded")
+	}
+	var ce *connCloseError
+	if !errors.As(err, &ce) || !ce.remote || ce.code != errCryptoBase+120 {
+		t.Errorf("Dial error = %v; want no_application_protocol from the server", err)
+	}
+}
+
+func TestEcho(t *testing.T) {
+	p := newTestPair(t, nil, 0)
+	ctx := testContext(t)
+	go echo(ctx, p.sconn)
+	s, err := p.cconn.OpenStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := roundTrip(s, []byte("hello"))
+	if err != nil || string(got) != "hello" {
+		t.Fatalf("round trip = %q, %v; want %q", got, err, "hello")
+	}
+}
+
+func TestManyStreams(t *testing.T) {
+	// More streams than the peer allows at once, so that stream
+	// credit has to be returned with MAX_STREAMS.
+	p := newTestPair(t, &Config{MaxBidiRemoteStreams: 8}, 0)
+	ctx := testContext(t)
+	go echo(ctx, p.sconn)
+	const n = 50
+	var wg sync.WaitGroup
+	errc := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s, err := p.cconn.OpenStream(ctx)
+			if err != nil {
+				errc <- err
+				return
+			}
+			msg := []byte(fmt.Sprintf("stream %d", i))
+			got, err := roundTrip(s, msg)
+			if err == nil && !bytes.Equal(got, msg) {
+				err = fmt.Errorf("got %q; want %q", got, msg)
+			}
+			if err != nil {
+				errc <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errc)
+	for err := range errc {
+		t.Error(err)
+	}
+}
+
+func testTransfer(t *testing.T, p *testPair, size int) {
+	ctx := testContext(t)
+	go echo(ctx, p.sconn)
+	s, err := p.cconn.OpenStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := make([]byte, size)
+	rand.New(rand.NewSource(3)).Read(msg)
+	got, err := roundTrip(s, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, msg) {
+		t.Fatalf("got %d bytes back, want the %d bytes sent", len(got), len(msg))
+	}
+}
+
+func TestLargeTransfer(t *testing.T) {
+	p := newTestPair(t, nil, 0)
+	testTransfer(t, p, 8<<20)
+}
+
+func TestSmallFlowControlWindows(t *testing.T) {
+	p := newTestPair(t, &Config{
+		MaxStreamReadBufferSize:  1000,
+		MaxStreamWriteBufferSize: 1500,
+		MaxConnReadBufferSize:    3000,
+	}, 0)
+	testTransfer(t, p, 100<<10)
+}
+
+func TestLossyTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	p := newTestPair(t, nil, 0.1)
+	testTransfer(t, p, 1<<20)
+}
+
+func TestStreamReset(t *testing.T) {
+	p := newTestPair(t, nil, 0)
+	ctx := testContext(t)
+	s, err := p.cconn.OpenStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write([]byte("partial")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Reset(42); err != nil {
+		t.Fatal(err)
+	}
+	ss, err := p.sconn.AcceptStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = io.ReadAll(ss)
+	var se *StreamError
+	if !errors.As(err, &se) || se.Code != 42 || se.StreamID != s.ID() {
+		t.Fatalf("Read error = %v; want StreamError with code 42", err)
+	}
+}
+
+func TestStopSending(t *testing.T) {
+	p := newTestPair(t, nil, 0)
+	ctx := testContext(t)
+	s, err := p.cconn.OpenStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write([]byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	ss, err := p.sconn.AcceptStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.CloseRead(7); err != nil {
+		t.Fatal(err)
+	}
+	// Writes fail once the STOP_SENDING frame arrives.
+	buf := make([]byte, 1000)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, err = s.Write(buf)
+		if err != nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	var se *StreamError
+	if !errors.As(err, &se) || se.Code != 7 {
+		t.Fatalf("Write error = %v; want StreamError with code 7", err)
+	}
+}
+
+func TestCloseWithError(t *testing.T) {
+	p := newTestPair(t, nil, 0)
+	ctx := testContext(t)
+	if err := p.sconn.CloseWithError(0x10c, "going away"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := p.cconn.AcceptStream(ctx)
+	var ae *ApplicationError
+	if !errors.As(err, &ae) || ae.Code != 0x10c || ae.Reason != "going away" {
+		t.Fatalf("AcceptStream error = %v; want ApplicationError 0x10c", err)
+	}
+	select {
+	case <-p.cconn.Done():
+	case <-ctx.Done():
+		t.Fatal("client connection not done after the peer closed it")
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	p := newTestPair(t, &Config{MaxIdleTimeout: 100 * time.Millisecond}, 0)
+	ctx := testContext(t)
+	for _, c := range []*Conn{p.cconn, p.sconn} {
+		select {
+		case <-c.Done():
+		case <-ctx.Done():
+			t.Fatal("connection did not time out")
+		}
+		if err := c.Err(); err != ErrIdleTimeout {
+			t.Errorf("Err() = %v; want %v", err, ErrIdleTimeout)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// An Endpoint sends and receives QUIC packets on a net.PacketConn, and
+// demultiplexes them to connections by connection ID.
+type Endpoint struct {
+	pc     net.PacketConn
+	config *Config
+
+	acceptq  chan *Conn
+	closec   chan struct{}
+	readDone chan struct{}
+	wg       sync.WaitGroup // connection loops
+
+	mu     sync.Mutex
+	conns  map[string]*Conn // by connection ID
+	closed bool
+}
+
+// NewEndpoint returns an Endpoint using pc, which it takes ownership of.
+// If config has a TLSConfig, the endpoint accepts incoming connections,
+// which are returned by Accept. Any endpoint may dial.
+func NewEndpoint(pc net.PacketConn, config *Config) *Endpoint {
+	if config == nil {
+		config = &Config{}
+	}
+	e := &Endpoint{
+		pc:       pc,
+		config:   config,
+		acceptq:  make(chan *Conn, 16),
+		closec:   make(chan struct{}),
+		readDone: make(chan struct{}),
+		conns:    make(map[string]*Conn),
+	}
+	go e.readLoop()
+	return e
+}
+
+// LocalAddr returns the local address of the endpoint.
+func (e *Endpoint) LocalAddr() net.Addr {
+	return e.pc.LocalAddr()
+}
+
+// Dial opens a connection to addr, and returns once the handshake has
+// completed.
+func (e *Endpoint) Dial(ctx context.Context, addr net.Addr, tlsConfig *tls.Config) (*Conn, error) {
+	c, err := newConn(e, true, addr, tlsConfig, newConnID(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.addConn(c); err != nil {
+		c.tls.Close()
+		return nil, err
+	}
+	select {
+	case <-c.handshakeDone:
+	case <-ctx.Done():
+		c.closeNow()
+		return nil, ctx.Err()
+	}
+	c.mu.Lock()
+	err = c.closeErr
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Accept waits for and returns the next incoming connection, after its
+// handshake has completed.
+func (e *Endpoint) Accept(ctx context.Context) (*Conn, error) {
+	select {
+	case c := <-e.acceptq:
+		return c, nil
+	case <-e.closec:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes all connections, sending each a CONNECTION_CLOSE frame,
+// and then the endpoint's net.PacketConn.
+func (e *Endpoint) Close() error {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	close(e.closec)
+	conns := make(map[*Conn]bool)
+	for _, c := range e.conns {
+		conns[c] = true
+	}
+	e.mu.Unlock()
+	for c := range conns {
+		c.closeNow()
+	}
+	e.wg.Wait()
+	err := e.pc.Close()
+	<-e.readDone
+	return err
+}
+
+// addConn registers c under its connection IDs and starts its loop.
+func (e *Endpoint) addConn(c *Conn) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return ErrClosed
+	}
+	e.conns[string(c.srcConnID)] = c
+	if !c.isClient {
+		// The client keeps using the ID it chose until it sees ours.
+		e.conns[string(c.origDstConnID)] = c
+	}
+	e.wg.Add(1)
+	go c.loop()
+	return nil
+}
+
+func (e *Endpoint) removeConn(c *Conn) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, id := range [][]byte{c.srcConnID, c.origDstConnID} {
+		if e.conns[string(id)] == c {
+			delete(e.conns, string(id))
+		}
+	}
+	e.wg.Done()
+}
+
+// handshakeComplete queues a server connection for Accept, or refuses
+// it if too many are waiting.
+func (e *Endpoint) handshakeComplete(c *Conn) {
+	select {
+	case e.acceptq <- c:
+	default:
+		c.enterClosing(time.Now(), &connCloseError{code: errConnectionRefused}, false, uint64(errConnectionRefused), "")
+	}
+}
+
+func (e *Endpoint) writeTo(d []byte, addr net.Addr) {
+	// Errors are treated as packet loss.
+	e.pc.WriteTo(d, addr)
+}
+
+func (e *Endpoint) readLoop() {
+	defer close(e.readDone)
+	buf := make([]byte, maxRecvDatagramSize)
+	for {
+		n, addr, err := e.pc.ReadFrom(buf)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Temporary() {
+				continue
+			}
+			return
+		}
+		e.handleDatagram(append([]byte(nil), buf[:n]...), addr)
+	}
+}
+
+func (e *Endpoint) handleDatagram(d []byte, addr net.Addr) {
+	id, ok := dstConnID(d)
+	if !ok {
+		return
+	}
+	e.mu.Lock()
+	c := e.conns[string(id)]
+	closed := e.closed
+	e.mu.Unlock()
+	if c != nil {
+		c.deliver(d)
+		return
+	}
+	if closed || e.config.TLSConfig == nil || d[0]&headerFormLong == 0 {
+		return
+	}
+	// A new connection starts with an Initial packet from the client,
+	// in a datagram padded to at least 1200 bytes, with a destination
+	// connection ID of at least 8 bytes (RFC 9000, Section 7.2).
+	h, ok := parseLongHeader(d)
+	if !ok || h.version != quicVersion1 || h.typ != longTypeInitial ||
+		len(d) < maxDatagramSize || len(h.dstID) < 8 {
+		return
+	}
+	c, err := newConn(e, false, addr, e.config.TLSConfig,
+		append([]byte(nil), h.dstID...), append([]byte(nil), h.srcID...))
+	if err != nil {
+		return
+	}
+	if err := e.addConn(c); err != nil {
+		c.tls.Close()
+		return
+	}
+	c.deliver(d)
+}
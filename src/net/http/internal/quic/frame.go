@@ -0,0 +1,157 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import "time"
+
+// Frame types (RFC 9000, Section 19).
+const (
+	frameTypePadding            = 0x00
+	frameTypePing               = 0x01
+	frameTypeAck                = 0x02
+	frameTypeAckECN             = 0x03
+	frameTypeResetStream        = 0x04
+	frameTypeStopSending        = 0x05
+	frameTypeCrypto             = 0x06
+	frameTypeNewToken           = 0x07
+	frameTypeStreamBase         = 0x08 // through 0x0f
+	frameTypeMaxData            = 0x10
+	frameTypeMaxStreamData      = 0x11
+	frameTypeMaxStreamsBidi     = 0x12
+	frameTypeMaxStreamsUni      = 0x13
+	frameTypeDataBlocked        = 0x14
+	frameTypeStreamDataBlocked  = 0x15
+	frameTypeStreamsBlockedBidi = 0x16
+	frameTypeStreamsBlockedUni  = 0x17
+	frameTypeNewConnectionID    = 0x18
+	frameTypeRetireConnectionID = 0x19
+	frameTypePathChallenge      = 0x1a
+	frameTypePathResponse       = 0x1b
+	frameTypeConnectionClose    = 0x1c
+	frameTypeConnectionCloseApp = 0x1d
+	frameTypeHandshakeDone      = 0x1e
+
+	// Flags in the type of a STREAM frame.
+	streamFlagOff = 0x04
+	streamFlagLen = 0x02
+	streamFlagFin = 0x01
+)
+
+// frameAllowedInHandshake reports whether a frame of type typ may be
+// sent in an Initial or Handshake packet (RFC 9000, Section 12.4).
+func frameAllowedInHandshake(typ uint64) bool {
+	switch typ {
+	case frameTypePadding, frameTypePing, frameTypeAck, frameTypeAckECN,
+		frameTypeCrypto, frameTypeConnectionClose:
+		return true
+	}
+	return false
+}
+
+// isAckEliciting reports whether a frame of type typ requires the
+// receiver to acknowledge the packet carrying it.
+func isAckEliciting(typ uint64) bool {
+	switch typ {
+	case frameTypePadding, frameTypeAck, frameTypeAckECN,
+		frameTypeConnectionClose, frameTypeConnectionCloseApp:
+		return false
+	}
+	return true
+}
+
+// A sentFrame records a frame that must be retransmitted, or whose
+// acknowledgement changes state, if the packet carrying it is lost or
+// acknowledged.
+type sentFrame struct {
+	typ    byte
+	stream *Stream
+	off    int64
+	n      int64
+	fin    bool
+}
+
+// A sentPacket is a packet that counts toward bytes in flight, kept
+// until it is acknowledged or declared lost.
+type sentPacket struct {
+	num          int64
+	time         time.Time
+	size         int
+	ackEliciting bool
+	frames       []sentFrame
+}
+
+// A frameBuilder appends frames to the plaintext payload of a packet
+// under construction.
+type frameBuilder struct {
+	b            []byte
+	limit        int // maximum length of b
+	ackEliciting bool
+	frames       []sentFrame
+}
+
+func (fb *frameBuilder) room() int {
+	return fb.limit - len(fb.b)
+}
+
+func (fb *frameBuilder) record(f sentFrame) {
+	fb.ackEliciting = true
+	fb.frames = append(fb.frames, f)
+}
+
+// appendVarints appends a frame consisting of the given varints if it
+// fits, and reports whether it did.
+func (fb *frameBuilder) appendVarints(v ...uint64) bool {
+	n := 0
+	for _, x := range v {
+		n += SizeVarint(x)
+	}
+	if n > fb.room() {
+		return false
+	}
+	for _, x := range v {
+		fb.b = AppendVarint(fb.b, x)
+	}
+	return true
+}
+
+// appendDataFrame appends a STREAM frame for s, or a CRYPTO frame if s
+// is nil, carrying as much of the pending data in b as fits. New data
+// is not taken beyond offset limit. It reports false if the packet has
+// no room left for a frame.
+func (fb *frameBuilder) appendDataFrame(s *Stream, b *sendBuffer, limit int64) (f sentFrame, isNew, ok bool) {
+	typ := byte(frameTypeCrypto)
+	hdr := 1 + SizeVarint(uint64(b.end())) + SizeVarint(uint64(fb.room()))
+	if s != nil {
+		typ = frameTypeStreamBase
+		hdr += SizeVarint(uint64(s.id))
+	}
+	room := int64(fb.room() - hdr)
+	if room < 1 {
+		return sentFrame{}, false, false
+	}
+	off, data, fin, isNew := b.take(room, limit)
+	if typ == frameTypeCrypto {
+		fb.b = append(fb.b, frameTypeCrypto)
+		fb.b = AppendVarint(fb.b, uint64(off))
+	} else {
+		t := byte(frameTypeStreamBase | streamFlagLen)
+		if off > 0 {
+			t |= streamFlagOff
+		}
+		if fin {
+			t |= streamFlagFin
+		}
+		fb.b = append(fb.b, t)
+		fb.b = AppendVarint(fb.b, uint64(s.id))
+		if off > 0 {
+			fb.b = AppendVarint(fb.b, uint64(off))
+		}
+	}
+	fb.b = AppendVarint(fb.b, uint64(len(data)))
+	fb.b = append(fb.b, data...)
+	f = sentFrame{typ: typ, stream: s, off: off, n: int64(len(data)), fin: fin}
+	fb.record(f)
+	return f, isNew, true
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+const (
+	headerFormLong = 0x80
+	fixedBit       = 0x40
+
+	// Long header packet types (RFC 9000, Section 17.2).
+	longTypeInitial   = 0x0
+	longTypeZeroRTT   = 0x1
+	longTypeHandshake = 0x2
+	longTypeRetry     = 0x3
+
+	// reservedLongBits and reservedShortBits are the bits of the first
+	// byte that must be zero once header protection is removed.
+	reservedLongBits  = 0x0c
+	reservedShortBits = 0x18
+
+	keyPhaseBit = 0x04
+)
+
+// A longHeader is the parsed, still header-protected, long header of a
+// packet (RFC 9000, Section 17.2).
+type longHeader struct {
+	typ     byte
+	version uint32
+	dstID   []byte
+	srcID   []byte
+	token   []byte // Initial packets only
+	pnOff   int    // offset of the packet number
+	end     int    // offset just past the end of the packet
+}
+
+// parseLongHeader parses the long header at the start of b. It reports
+// false if the header is malformed. For Version Negotiation and Retry
+// packets, which have no length field, end is len(b).
+func parseLongHeader(b []byte) (h longHeader, ok bool) {
+	r := wireReader{b: b}
+	first := r.byte()
+	h.typ = (first >> 4) & 0x03
+	h.version = r.uint32()
+	h.dstID = r.bytes(uint64(r.byte()))
+	h.srcID = r.bytes(uint64(r.byte()))
+	if r.err != nil || len(h.dstID) > maxConnIDLen || len(h.srcID) > maxConnIDLen {
+		return h, false
+	}
+	if h.version != quicVersion1 || h.typ == longTypeRetry {
+		h.end = len(b)
+		return h, true
+	}
+	if h.typ == longTypeInitial {
+		h.token = r.varintBytes()
+	}
+	length := r.varint()
+	if r.err != nil || length > uint64(len(r.b)) {
+		return h, false
+	}
+	h.pnOff = len(b) - len(r.b)
+	h.end = h.pnOff + int(length)
+	return h, true
+}
+
+// dstConnID returns the destination connection ID of the packet at the
+// start of datagram b, using the length of our own connection IDs for
+// short header packets.
+func dstConnID(b []byte) ([]byte, bool) {
+	if len(b) < 1 {
+		return nil, false
+	}
+	if b[0]&headerFormLong == 0 {
+		if len(b) < 1+connIDLen {
+			return nil, false
+		}
+		return b[1 : 1+connIDLen], true
+	}
+	if len(b) < 6 {
+		return nil, false
+	}
+	n := int(b[5])
+	if n > maxConnIDLen || len(b) < 6+n {
+		return nil, false
+	}
+	return b[6 : 6+n], true
+}
+
+// packetNumberLength returns the number of bytes to encode packet
+// number pn with, given the largest packet number acknowledged by the
+// peer in its number space (RFC 9000, Appendix A.2).
+func packetNumberLength(pn, largestAcked int64) int {
+	d := pn - largestAcked // largestAcked is -1 if nothing was acknowledged
+	switch {
+	case d < 1<<7:
+		return 1
+	case d < 1<<15:
+		return 2
+	case d < 1<<23:
+		return 3
+	}
+	return 4
+}
+
+// appendPacketNumber appends the pnLen least significant bytes of pn.
+func appendPacketNumber(b []byte, pn int64, pnLen int) []byte {
+	for i := pnLen - 1; i >= 0; i-- {
+		b = append(b, byte(pn>>(8*i)))
+	}
+	return b
+}
+
+// decodePacketNumber reconstructs a full packet number from its
+// truncated pnLen-byte encoding and the largest packet number
+// received so far in the number space (RFC 9000, Appendix A.3).
+func decodePacketNumber(largest, truncated int64, pnLen int) int64 {
+	expected := largest + 1
+	win := int64(1) << (8 * pnLen)
+	hwin := win / 2
+	mask := win - 1
+	candidate := (expected &^ mask) | truncated
+	switch {
+	case candidate <= expected-hwin && candidate < (1<<62)-win:
+		return candidate + win
+	case candidate > expected+hwin && candidate >= win:
+		return candidate - win
+	}
+	return candidate
+}
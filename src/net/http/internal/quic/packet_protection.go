@@ -0,0 +1,237 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	_ "crypto/sha512" // for crypto.SHA384
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// initialSalt is the salt used to derive Initial packet protection
+// keys in QUIC version 1 (RFC 9001, Section 5.2).
+var initialSalt = []byte{
+	0x38, 0x76, 0x2c, 0xf7, 0xf5, 0x59, 0x34, 0xb3, 0x4d, 0x17,
+	0x9a, 0xe6, 0xa4, 0xc8, 0x0c, 0xad, 0xcc, 0xbb, 0x7f, 0x0a,
+}
+
+var errDecrypt = errors.New("quic: packet decryption failed")
+
+// hkdfExpandLabel implements HKDF-Expand-Label from RFC 8446,
+// Section 7.1, with an empty context.
+func hkdfExpandLabel(hash crypto.Hash, secret []byte, label string, length int) []byte {
+	const prefix = "tls13 "
+	info := make([]byte, 0, 4+len(prefix)+len(label))
+	info = append(info, byte(length>>8), byte(length))
+	info = append(info, byte(len(prefix)+len(label)))
+	info = append(info, prefix...)
+	info = append(info, label...)
+	info = append(info, 0) // context
+	out := make([]byte, length)
+	if _, err := hkdf.Expand(hash.New, secret, info).Read(out); err != nil {
+		panic("quic: HKDF-Expand-Label failed: " + err.Error())
+	}
+	return out
+}
+
+// A suite is a TLS 1.3 cipher suite, as used for QUIC packet protection.
+type suite struct {
+	id     uint16
+	hash   crypto.Hash
+	keyLen int
+}
+
+func suiteByID(id uint16) (*suite, error) {
+	switch id {
+	case tls.TLS_AES_128_GCM_SHA256:
+		return &suite{id, crypto.SHA256, 16}, nil
+	case tls.TLS_AES_256_GCM_SHA384:
+		return &suite{id, crypto.SHA384, 32}, nil
+	case tls.TLS_CHACHA20_POLY1305_SHA256:
+		return &suite{id, crypto.SHA256, 32}, nil
+	}
+	return nil, fmt.Errorf("quic: unsupported cipher suite %#04x", id)
+}
+
+// newKeys derives packet protection keys from a traffic secret
+// (RFC 9001, Section 5.1).
+func (s *suite) newKeys(secret []byte) *packetKeys {
+	hpKey := hkdfExpandLabel(s.hash, secret, "quic hp", s.keyLen)
+	var hp headerProtection
+	if s.id == tls.TLS_CHACHA20_POLY1305_SHA256 {
+		h := new(chachaHeaderProtection)
+		copy(h.key[:], hpKey)
+		hp = h
+	} else {
+		block, err := aes.NewCipher(hpKey)
+		if err != nil {
+			panic(err)
+		}
+		hp = aesHeaderProtection{block}
+	}
+	return s.newKeysWithHP(secret, hp)
+}
+
+// newKeysWithHP derives the AEAD key and IV from secret, and pairs
+// them with an existing header protection key. Key updates change the
+// former but not the latter (RFC 9001, Section 6).
+func (s *suite) newKeysWithHP(secret []byte, hp headerProtection) *packetKeys {
+	key := hkdfExpandLabel(s.hash, secret, "quic key", s.keyLen)
+	k := &packetKeys{
+		suite:  s,
+		secret: secret,
+		hp:     hp,
+	}
+	copy(k.iv[:], hkdfExpandLabel(s.hash, secret, "quic iv", len(k.iv)))
+	var err error
+	if s.id == tls.TLS_CHACHA20_POLY1305_SHA256 {
+		k.aead, err = chacha20poly1305.New(key)
+	} else {
+		var block cipher.Block
+		if block, err = aes.NewCipher(key); err == nil {
+			k.aead, err = cipher.NewGCM(block)
+		}
+	}
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// initialKeys returns the Initial packet protection keys for the
+// connection whose client chose the destination connection ID cid.
+func initialKeys(cid []byte, isClient bool) (read, write *packetKeys) {
+	s := &suite{tls.TLS_AES_128_GCM_SHA256, crypto.SHA256, 16}
+	initialSecret := hkdf.Extract(sha256.New, cid, initialSalt)
+	clientSecret := hkdfExpandLabel(crypto.SHA256, initialSecret, "client in", sha256.Size)
+	serverSecret := hkdfExpandLabel(crypto.SHA256, initialSecret, "server in", sha256.Size)
+	if isClient {
+		return s.newKeys(serverSecret), s.newKeys(clientSecret)
+	}
+	return s.newKeys(clientSecret), s.newKeys(serverSecret)
+}
+
+// packetKeys protects or unprotects packets at one encryption level
+// in one direction.
+type packetKeys struct {
+	suite  *suite
+	secret []byte // traffic secret, to derive the next keys on update
+	aead   cipher.AEAD
+	iv     [12]byte
+	hp     headerProtection
+}
+
+// next returns the keys for the next key phase.
+func (k *packetKeys) next() *packetKeys {
+	secret := hkdfExpandLabel(k.suite.hash, k.secret, "quic ku", k.suite.hash.Size())
+	return k.suite.newKeysWithHP(secret, k.hp)
+}
+
+func (k *packetKeys) nonce(pn int64) []byte {
+	nonce := k.iv
+	for i := 0; i < 8; i++ {
+		nonce[len(nonce)-1-i] ^= byte(pn >> (8 * i))
+	}
+	return nonce[:]
+}
+
+// protect encrypts the payload of pkt in place and applies header
+// protection. pkt holds the header, including the pnLen-byte packet
+// number at pnOff, followed by the plaintext payload. The capacity of
+// pkt must leave room for the AEAD tag. protect returns the protected
+// packet.
+func (k *packetKeys) protect(pkt []byte, pnOff, pnLen int, pn int64) []byte {
+	hdrLen := pnOff + pnLen
+	payload := pkt[hdrLen:]
+	sealed := k.aead.Seal(payload[:0], k.nonce(pn), payload, pkt[:hdrLen])
+	pkt = pkt[:hdrLen+len(sealed)]
+	mask := k.hp.mask(pkt[pnOff+4 : pnOff+4+16])
+	if pkt[0]&headerFormLong != 0 {
+		pkt[0] ^= mask[0] & 0x0f
+	} else {
+		pkt[0] ^= mask[0] & 0x1f
+	}
+	for i := 0; i < pnLen; i++ {
+		pkt[pnOff+i] ^= mask[1+i]
+	}
+	return pkt
+}
+
+// unprotectHeader removes header protection from pkt in place, given
+// the offset of its packet number field. It returns the decoded packet
+// number and the length of the header.
+func (k *packetKeys) unprotectHeader(pkt []byte, pnOff int, largest int64) (pn int64, hdrLen int, ok bool) {
+	if len(pkt) < pnOff+4+16 {
+		return 0, 0, false
+	}
+	mask := k.hp.mask(pkt[pnOff+4 : pnOff+4+16])
+	if pkt[0]&headerFormLong != 0 {
+		pkt[0] ^= mask[0] & 0x0f
+	} else {
+		pkt[0] ^= mask[0] & 0x1f
+	}
+	pnLen := int(pkt[0]&0x03) + 1
+	var truncated int64
+	for i := 0; i < pnLen; i++ {
+		pkt[pnOff+i] ^= mask[1+i]
+		truncated = truncated<<8 | int64(pkt[pnOff+i])
+	}
+	return decodePacketNumber(largest, truncated, pnLen), pnOff + pnLen, true
+}
+
+// open decrypts the payload of pkt, whose header (of length hdrLen) has
+// already had its protection removed. The plaintext overwrites the
+// ciphertext.
+func (k *packetKeys) open(pkt []byte, hdrLen int, pn int64) ([]byte, error) {
+	if len(pkt) < hdrLen+aeadOverhead {
+		return nil, errDecrypt
+	}
+	payload, err := k.aead.Open(pkt[hdrLen:hdrLen], k.nonce(pn), pkt[hdrLen:], pkt[:hdrLen])
+	if err != nil {
+		return nil, errDecrypt
+	}
+	return payload, nil
+}
+
+// headerProtection computes the header protection mask for a sample
+// of a protected packet (RFC 9001, Section 5.4).
+type headerProtection interface {
+	mask(sample []byte) [5]byte
+}
+
+type aesHeaderProtection struct {
+	block cipher.Block
+}
+
+func (h aesHeaderProtection) mask(sample []byte) (m [5]byte) {
+	var out [aes.BlockSize]byte
+	h.block.Encrypt(out[:], sample[:aes.BlockSize])
+	copy(m[:], out[:])
+	return m
+}
+
+type chachaHeaderProtection struct {
+	key [chacha20.KeySize]byte
+}
+
+func (h *chachaHeaderProtection) mask(sample []byte) (m [5]byte) {
+	c, err := chacha20.NewUnauthenticatedCipher(h.key[:], sample[4:16])
+	if err != nil {
+		panic(err)
+	}
+	c.SetCounter(binary.LittleEndian.Uint32(sample[:4]))
+	c.XORKeyStream(m[:], m[:])
+	return m
+}
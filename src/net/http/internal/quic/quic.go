@@ -0,0 +1,184 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package quic implements the QUIC transport protocol (RFC 9000) used by
+// the HTTP/3 client and server in net/http.
+//
+// The TLS 1.3 handshake is run by crypto/tls through its QUICConn API
+// (RFC 9001). Loss recovery and congestion control follow RFC 9002,
+// using NewReno.
+//
+// The implementation covers what HTTP/3 needs and no more. It speaks
+// QUIC version 1 only and uses fixed-length connection IDs. It does not
+// support 0-RTT data, Retry packets, version negotiation, connection
+// migration, path MTU discovery or initiating key updates. It does
+// follow key updates initiated by the peer.
+package quic
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// quicVersion1 is the version number of QUIC version 1.
+	quicVersion1 = 0x00000001
+
+	// connIDLen is the length of the connection IDs chosen by this
+	// implementation. Peers may choose IDs of any valid length.
+	connIDLen = 8
+
+	// maxConnIDLen is the maximum length of a connection ID in QUIC
+	// version 1 (RFC 9000, Section 17.2).
+	maxConnIDLen = 20
+
+	// maxDatagramSize is the size of the largest UDP payload we send.
+	// Every IPv4 and IPv6 path that carries QUIC supports it, so there
+	// is no need for path MTU discovery.
+	maxDatagramSize = 1200
+
+	// maxRecvDatagramSize is the size of the buffer datagrams are
+	// read into.
+	maxRecvDatagramSize = 65536
+
+	// aeadOverhead is the size of the authentication tag added by
+	// every AEAD used in QUIC version 1.
+	aeadOverhead = 16
+
+	// maxVarint is the largest value a variable-length integer holds.
+	maxVarint = 1<<62 - 1
+
+	// ackDelayExponent and maxAckDelay are the values of the
+	// ack_delay_exponent and max_ack_delay transport parameters we send.
+	ackDelayExponent = 3
+	maxAckDelay      = 25 * time.Millisecond
+
+	// timerGranularity is the minimum duration of the loss and
+	// probe timers (RFC 9002, Section 6.1.2).
+	timerGranularity = time.Millisecond
+)
+
+// A TransportError is a QUIC transport error code
+// (RFC 9000, Section 20.1).
+type TransportError uint64
+
+const (
+	errNo                   = TransportError(0x0)
+	errInternal             = TransportError(0x1)
+	errConnectionRefused    = TransportError(0x2)
+	errFlowControl          = TransportError(0x3)
+	errStreamLimit          = TransportError(0x4)
+	errStreamState          = TransportError(0x5)
+	errFinalSize            = TransportError(0x6)
+	errFrameEncoding        = TransportError(0x7)
+	errTransportParameter   = TransportError(0x8)
+	errConnectionIDLimit    = TransportError(0x9)
+	errProtocolViolation    = TransportError(0xa)
+	errInvalidToken         = TransportError(0xb)
+	errApplication          = TransportError(0xc)
+	errCryptoBufferExceeded = TransportError(0xd)
+	errKeyUpdate            = TransportError(0xe)
+	errAEADLimitReached     = TransportError(0xf)
+	errNoViablePath         = TransportError(0x10)
+
+	// errCryptoBase is added to a TLS alert to form the
+	// CRYPTO_ERROR transport error code carrying it.
+	errCryptoBase = TransportError(0x100)
+)
+
+var transportErrorNames = [...]string{
+	errNo:                   "NO_ERROR",
+	errInternal:             "INTERNAL_ERROR",
+	errConnectionRefused:    "CONNECTION_REFUSED",
+	errFlowControl:          "FLOW_CONTROL_ERROR",
+	errStreamLimit:          "STREAM_LIMIT_ERROR",
+	errStreamState:          "STREAM_STATE_ERROR",
+	errFinalSize:            "FINAL_SIZE_ERROR",
+	errFrameEncoding:        "FRAME_ENCODING_ERROR",
+	errTransportParameter:   "TRANSPORT_PARAMETER_ERROR",
+	errConnectionIDLimit:    "CONNECTION_ID_LIMIT_ERROR",
+	errProtocolViolation:    "PROTOCOL_VIOLATION",
+	errInvalidToken:         "INVALID_TOKEN",
+	errApplication:          "APPLICATION_ERROR",
+	errCryptoBufferExceeded: "CRYPTO_BUFFER_EXCEEDED",
+	errKeyUpdate:            "KEY_UPDATE_ERROR",
+	errAEADLimitReached:     "AEAD_LIMIT_REACHED",
+	errNoViablePath:         "NO_VIABLE_PATH",
+}
+
+func (e TransportError) String() string {
+	if int(e) < len(transportErrorNames) {
+		return transportErrorNames[e]
+	}
+	if e >= errCryptoBase && e <= errCryptoBase+0xff {
+		return fmt.Sprintf("CRYPTO_ERROR(%d)", uint64(e-errCryptoBase))
+	}
+	return fmt.Sprintf("TransportError(%#x)", uint64(e))
+}
+
+func (e TransportError) Error() string {
+	return "quic: " + e.String()
+}
+
+// A connCloseError is the error a connection closed with because of a
+// transport error, detected either locally or by the peer.
+type connCloseError struct {
+	code   TransportError
+	reason string
+	remote bool
+}
+
+func (e *connCloseError) Error() string {
+	who := "local"
+	if e.remote {
+		who = "peer"
+	}
+	if e.reason == "" {
+		return fmt.Sprintf("quic: %s (%s)", e.code.String(), who)
+	}
+	return fmt.Sprintf("quic: %s (%s): %s", e.code.String(), who, e.reason)
+}
+
+func (e *connCloseError) Unwrap() error { return e.code }
+
+// An ApplicationError is an application protocol error received from
+// the peer in a CONNECTION_CLOSE frame.
+type ApplicationError struct {
+	Code   uint64
+	Reason string
+}
+
+func (e *ApplicationError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("quic: peer closed connection with application error %#x", e.Code)
+	}
+	return fmt.Sprintf("quic: peer closed connection with application error %#x: %s", e.Code, e.Reason)
+}
+
+// A StreamError is returned by Stream operations when the peer aborts
+// its side of the stream. Read returns it after the peer resets the
+// stream with RESET_STREAM; Write returns it after the peer asks us to
+// stop sending with STOP_SENDING.
+type StreamError struct {
+	StreamID int64
+	Code     uint64
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("quic: stream %d aborted by peer with error %#x", e.StreamID, e.Code)
+}
+
+var (
+	// ErrClosed is returned by operations on a connection or endpoint
+	// that has been closed locally.
+	ErrClosed = errors.New("quic: closed")
+
+	// ErrIdleTimeout is returned by operations on a connection that
+	// was closed because the idle timeout expired.
+	ErrIdleTimeout = errors.New("quic: idle timeout")
+
+	errStreamClosed = errors.New("quic: use of closed stream")
+	errLocalReset   = errors.New("quic: stream was reset")
+)
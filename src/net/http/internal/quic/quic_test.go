@@ -0,0 +1,277 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/tls"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Examples from RFC 9000, Appendix A.1.
+var varintTests = []struct {
+	enc string
+	v   uint64
+}{
+	{"c2197c5eff14e88c", 151288809941952652},
+	{"9d7f3e7d", 494878333},
+	{"7bbd", 15293},
+	{"25", 37},
+}
+
+func TestVarint(t *testing.T) {
+	for _, tt := range varintTests {
+		enc := unhex(tt.enc)
+		v, n := ConsumeVarint(enc)
+		if v != tt.v || n != len(enc) {
+			t.Errorf("ConsumeVarint(%s) = %d, %d; want %d, %d", tt.enc, v, n, tt.v, len(enc))
+		}
+		if got := AppendVarint(nil, tt.v); !bytes.Equal(got, enc) {
+			t.Errorf("AppendVarint(%d) = %x; want %s", tt.v, got, tt.enc)
+		}
+		if got := SizeVarint(tt.v); got != len(enc) {
+			t.Errorf("SizeVarint(%d) = %d; want %d", tt.v, got, len(enc))
+		}
+		if v, err := ReadVarint(bytes.NewReader(enc)); v != tt.v || err != nil {
+			t.Errorf("ReadVarint(%s) = %d, %v; want %d, nil", tt.enc, v, err, tt.v)
+		}
+		if _, n := ConsumeVarint(enc[:len(enc)-1]); len(enc) > 1 && n >= 0 {
+			t.Errorf("ConsumeVarint(%x) succeeded on truncated input", enc[:len(enc)-1])
+		}
+	}
+	// The two-byte encoding of 37 is also valid.
+	if v, n := ConsumeVarint(unhex("4025")); v != 37 || n != 2 {
+		t.Errorf("ConsumeVarint(4025) = %d, %d; want 37, 2", v, n)
+	}
+}
+
+func TestDecodePacketNumber(t *testing.T) {
+	// RFC 9000, Appendix A.3.
+	if got := decodePacketNumber(0xa82f30ea, 0x9b32, 2); got != 0xa82f9b32 {
+		t.Errorf("decodePacketNumber = %#x; want 0xa82f9b32", got)
+	}
+	for _, pn := range []int64{0, 1, 255, 256, 1000, 65535, 1 << 20} {
+		for _, largest := range []int64{pn - 1, pn - 100, pn - 1000} {
+			if largest < -1 {
+				continue
+			}
+			n := packetNumberLength(pn, largest)
+			b := appendPacketNumber(nil, pn, n)
+			var truncated int64
+			for _, c := range b {
+				truncated = truncated<<8 | int64(c)
+			}
+			if got := decodePacketNumber(largest, truncated, n); got != pn {
+				t.Errorf("pn %d, largest %d: decoded %d", pn, largest, got)
+			}
+		}
+	}
+}
+
+// TestInitialSecrets checks the key derivation of RFC 9001, Appendix A.1.
+func TestInitialSecrets(t *testing.T) {
+	const dcid = "8394c8f03e515708"
+	// Check that Initial keys protect and unprotect, then check the
+	// derived values directly.
+	cread, cwrite := initialKeys(unhex(dcid), true)
+	sread, swrite := initialKeys(unhex(dcid), false)
+	for _, pair := range [][2]*packetKeys{{cwrite, sread}, {swrite, cread}} {
+		hdr := []byte{0xc0, 0, 0, 0, 1, 0, 0, 0x40, 0x15, 0}
+		pkt := append(append(make([]byte, 0, 64), hdr...), "ping plaintext"...)
+		pkt = pair[0].protect(pkt, len(hdr)-1, 1, 0)
+		pn, hdrLen, ok := pair[1].unprotectHeader(pkt, len(hdr)-1, -1)
+		if !ok || pn != 0 || hdrLen != len(hdr) {
+			t.Fatalf("unprotectHeader = %d, %d, %v", pn, hdrLen, ok)
+		}
+		payload, err := pair[1].open(pkt, hdrLen, pn)
+		if err != nil || string(payload) != "ping plaintext" {
+			t.Fatalf("open = %q, %v", payload, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		secret, key, iv, hp string
+	}{
+		{
+			secret: "c00cf151ca5be075ed0ebfb5c80323c42d6b7db67881289af4008f1f6c357aea",
+			key:    "1f369613dd76d5467730efcbe3b1a22d",
+			iv:     "fa044b2f42a3fd3b46fb255c",
+			hp:     "9f50449e04a0e810283a1e9933adedd2",
+		},
+		{
+			secret: "3c199828fd139efd216c155ad844cc81fb82fa8d7446fa7d78be803acdda951b",
+			key:    "cf3a5331653c364c88f0f379b6067e37",
+			iv:     "0ac1493ca1905853b0bba03e",
+			hp:     "c206b8d9b9f0f37644430b490eeaa314",
+		},
+	} {
+		secret := unhex(tt.secret)
+		for _, f := range []struct {
+			label, want string
+		}{
+			{"quic key", tt.key},
+			{"quic iv", tt.iv},
+			{"quic hp", tt.hp},
+		} {
+			got := hkdfExpandLabel(crypto.SHA256, secret, f.label, len(f.want)/2)
+			if hex.EncodeToString(got) != f.want {
+				t.Errorf("%s from %s = %x; want %s", f.label, tt.secret[:8], got, f.want)
+			}
+		}
+	}
+	if got := hex.EncodeToString(cwrite.iv[:]); got != "fa044b2f42a3fd3b46fb255c" {
+		t.Errorf("client Initial IV = %s", got)
+	}
+	if got := hex.EncodeToString(swrite.iv[:]); got != "0ac1493ca1905853b0bba03e" {
+		t.Errorf("server Initial IV = %s", got)
+	}
+	if got := hex.EncodeToString(cwrite.secret); got != "c00cf151ca5be075ed0ebfb5c80323c42d6b7db67881289af4008f1f6c357aea" {
+		t.Errorf("client Initial secret = %s", got)
+	}
+}
+
+// TestChaCha20ShortHeader checks the ChaCha20-Poly1305 short header
+// packet of RFC 9001, Appendix A.5, and the key update derivation.
+func TestChaCha20ShortHeader(t *testing.T) {
+	s, err := suiteByID(tls.TLS_CHACHA20_POLY1305_SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := s.newKeys(unhex("9ac312a7f877468ebe69422748ad00a15443f18203a07d6060f688f30f21632b"))
+	const pn = 654360564
+	pkt := append(make([]byte, 0, 32), 0x42, 0x00, 0xbf, 0xf4, 0x01)
+	pkt = k.protect(pkt, 1, 3, pn)
+	want := unhex("4cfe4189655e5cd55c41f69080575d7999c25a5bfb")
+	if !bytes.Equal(pkt, want) {
+		t.Fatalf("protected packet:\ngot  %x\nwant %x", pkt, want)
+	}
+
+	got, hdrLen, ok := k.unprotectHeader(pkt, 1, pn-1)
+	if !ok || got != pn || hdrLen != 4 {
+		t.Fatalf("unprotectHeader = %d, %d, %v; want %d, 4, true", got, hdrLen, ok, pn)
+	}
+	payload, err := k.open(pkt, hdrLen, pn)
+	if err != nil || !bytes.Equal(payload, []byte{0x01}) {
+		t.Fatalf("open = %x, %v; want 01", payload, err)
+	}
+
+	next := k.next()
+	if got := hex.EncodeToString(next.secret); got != "1223504755036d556342ee9361d253421a826c9ecdf3c7148684b36b714881f9" {
+		t.Errorf("next secret = %s", got)
+	}
+}
+
+func TestRangeset(t *testing.T) {
+	var s rangeset
+	s.add(10, 20)
+	s.add(30, 40)
+	s.add(20, 25)
+	s.add(0, 5)
+	want := rangeset{{0, 5}, {10, 25}, {30, 40}}
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("after adds: %v; want %v", s, want)
+	}
+	s.add(4, 31)
+	if want := (rangeset{{0, 40}}); !reflect.DeepEqual(s, want) {
+		t.Fatalf("after merging add: %v; want %v", s, want)
+	}
+	s.sub(10, 20)
+	s.sub(35, 50)
+	if want := (rangeset{{0, 10}, {20, 35}}); !reflect.DeepEqual(s, want) {
+		t.Fatalf("after subs: %v; want %v", s, want)
+	}
+	for v, want := range map[int64]bool{0: true, 9: true, 10: false, 20: true, 34: true, 35: false} {
+		if got := s.contains(v); got != want {
+			t.Errorf("contains(%d) = %v; want %v", v, got, want)
+		}
+	}
+}
+
+func TestSendBuffer(t *testing.T) {
+	var b sendBuffer
+	b.write([]byte("hello, world"))
+	b.fin = true
+	off, data, fin, isNew := b.take(5, 100)
+	if off != 0 || string(data) != "hello" || fin || !isNew {
+		t.Fatalf("take = %d, %q, %v, %v", off, data, fin, isNew)
+	}
+	off, data, fin, _ = b.take(100, 100)
+	if off != 5 || string(data) != ", world" || !fin {
+		t.Fatalf("take = %d, %q, %v", off, data, fin)
+	}
+	b.onLost(0, 5, false)
+	b.onAck(5, 7, true)
+	if !b.hasData(100) {
+		t.Fatal("lost data not pending")
+	}
+	off, data, fin, isNew = b.take(100, 100)
+	if off != 0 || string(data) != "hello" || fin || isNew {
+		t.Fatalf("retransmission = %d, %q, %v, %v", off, data, fin, isNew)
+	}
+	b.onAck(0, 5, false)
+	if !b.done() || b.hasData(100) {
+		t.Fatalf("buffer not done after all data was acknowledged")
+	}
+}
+
+func TestRecvBuffer(t *testing.T) {
+	b := newRecvBuffer()
+	if err := b.write(6, []byte("world"), true); err != nil {
+		t.Fatal(err)
+	}
+	if b.readable() != 0 {
+		t.Fatalf("readable = %d before the start of the stream arrived", b.readable())
+	}
+	if err := b.write(0, []byte("hello "), false); err != nil {
+		t.Fatal(err)
+	}
+	p := make([]byte, 20)
+	n := b.read(p)
+	if string(p[:n]) != "hello world" || !b.eof() {
+		t.Fatalf("read %q, eof %v", p[:n], b.eof())
+	}
+	if err := b.write(0, []byte("hello world!"), false); err != errFinalSize {
+		t.Fatalf("data beyond the final size: err = %v; want %v", err, errFinalSize)
+	}
+}
+
+func TestTransportParameters(t *testing.T) {
+	p := defaultTransportParameters()
+	p.originalDstConnID = []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	p.initialSrcConnID = []byte{9, 9}
+	p.initialMaxData = 1 << 20
+	p.initialMaxStreamsBidi = 100
+	b := p.marshal(false)
+	got, err := parseTransportParameters(b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.checkConnIDs([]byte{9, 9}, p.originalDstConnID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got.initialMaxData != 1<<20 || got.initialMaxStreamsBidi != 100 {
+		t.Errorf("parsed %+v", got)
+	}
+	if err := got.checkConnIDs([]byte{9, 8}, p.originalDstConnID, false); err == nil {
+		t.Error("checkConnIDs accepted the wrong initial_source_connection_id")
+	}
+	if _, err := parseTransportParameters(b, true); err == nil {
+		t.Error("server-only parameters accepted from a client")
+	}
+	if _, err := parseTransportParameters(append(b, b[:3]...), false); err == nil {
+		t.Error("duplicate parameter accepted")
+	}
+}
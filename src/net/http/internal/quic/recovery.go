@@ -0,0 +1,366 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import "time"
+
+// Loss detection and congestion control, following RFC 9002.
+
+const (
+	// initialRTT is the RTT assumed before the first sample
+	// (RFC 9002, Section 6.2.2).
+	initialRTT = 333 * time.Millisecond
+
+	// packetThreshold and timeThreshold (as a fraction) decide when
+	// a packet is declared lost (RFC 9002, Section 6.1).
+	packetThreshold    = 3
+	timeThresholdNum   = 9
+	timeThresholdDenom = 8
+
+	initialWindow = 10 * maxDatagramSize
+	minimumWindow = 2 * maxDatagramSize
+)
+
+// rttState holds the RTT estimates of a connection
+// (RFC 9002, Section 5).
+type rttState struct {
+	latest   time.Duration
+	min      time.Duration
+	smoothed time.Duration
+	variance time.Duration
+	sampled  bool
+}
+
+func newRTTState() rttState {
+	return rttState{
+		smoothed: initialRTT,
+		variance: initialRTT / 2,
+	}
+}
+
+func (r *rttState) update(latest, ackDelay time.Duration) {
+	r.latest = latest
+	if !r.sampled {
+		r.sampled = true
+		r.min = latest
+		r.smoothed = latest
+		r.variance = latest / 2
+		return
+	}
+	if latest < r.min {
+		r.min = latest
+	}
+	adjusted := latest
+	if latest >= r.min+ackDelay {
+		adjusted = latest - ackDelay
+	}
+	d := r.smoothed - adjusted
+	if d < 0 {
+		d = -d
+	}
+	r.variance = (3*r.variance + d) / 4
+	r.smoothed = (7*r.smoothed + adjusted) / 8
+}
+
+// newReno is the NewReno congestion controller of
+// RFC 9002, Section 7.
+type newReno struct {
+	cwnd          int64
+	ssthresh      int64
+	bytesInFlight int64
+	recoveryStart time.Time
+}
+
+func newNewReno() newReno {
+	return newReno{
+		cwnd:     initialWindow,
+		ssthresh: 1<<63 - 1,
+	}
+}
+
+func (cc *newReno) canSend() bool {
+	return cc.bytesInFlight+maxDatagramSize <= cc.cwnd
+}
+
+func (cc *newReno) onAcked(p *sentPacket) {
+	cc.bytesInFlight -= int64(p.size)
+	if !p.time.After(cc.recoveryStart) {
+		return
+	}
+	if cc.cwnd < cc.ssthresh {
+		cc.cwnd += int64(p.size)
+	} else {
+		cc.cwnd += maxDatagramSize * int64(p.size) / cc.cwnd
+	}
+}
+
+// onCongestion reduces the window after the loss of a packet sent at
+// sentTime, unless that packet was sent before the current recovery
+// period began.
+func (cc *newReno) onCongestion(now, sentTime time.Time) {
+	if !sentTime.After(cc.recoveryStart) {
+		return
+	}
+	cc.recoveryStart = now
+	cc.ssthresh = cc.cwnd / 2
+	if cc.ssthresh < minimumWindow {
+		cc.ssthresh = minimumWindow
+	}
+	cc.cwnd = cc.ssthresh
+}
+
+// pto returns the probe timeout, without backoff or max_ack_delay
+// (RFC 9002, Section 6.2.1).
+func (c *Conn) pto() time.Duration {
+	v := 4 * c.rtt.variance
+	if v < timerGranularity {
+		v = timerGranularity
+	}
+	return c.rtt.smoothed + v
+}
+
+// lossDetectionTimer returns the time the loss detection timer fires
+// at, and the number space it fires for, or the zero time if it is
+// not armed (RFC 9002, Appendix A.8).
+func (c *Conn) lossDetectionTimer() (time.Time, numberSpace) {
+	var t time.Time
+	var tsp numberSpace
+	for sp := initialSpace; sp < numSpaces; sp++ {
+		lt := c.spaces[sp].lossTime
+		if !lt.IsZero() && (t.IsZero() || lt.Before(t)) {
+			t, tsp = lt, sp
+		}
+	}
+	if !t.IsZero() {
+		return t, tsp
+	}
+
+	inFlight := false
+	for sp := range c.spaces {
+		if c.spaces[sp].ackElicitingInFlight > 0 {
+			inFlight = true
+		}
+	}
+	d := c.pto() << c.ptoCount
+	if !inFlight {
+		// A client probes until the server has validated its address,
+		// which we take to be when the handshake is confirmed, so that
+		// an amplification-limited server does not deadlock.
+		if !c.isClient || c.handshakeConfirmed || c.lastSent.IsZero() {
+			return time.Time{}, 0
+		}
+		if c.spaces[handshakeSpace].write != nil {
+			return c.lastSent.Add(d), handshakeSpace
+		}
+		return c.lastSent.Add(d), initialSpace
+	}
+	for sp := initialSpace; sp < numSpaces; sp++ {
+		s := &c.spaces[sp]
+		if s.ackElicitingInFlight == 0 {
+			continue
+		}
+		sd := d
+		if sp == appDataSpace {
+			if !c.handshakeConfirmed {
+				continue
+			}
+			sd += c.peerParams.maxAckDelay << c.ptoCount
+		}
+		pt := s.lastAckElicitingSent.Add(sd)
+		if t.IsZero() || pt.Before(t) {
+			t, tsp = pt, sp
+		}
+	}
+	return t, tsp
+}
+
+// onPTO handles the expiry of the probe timeout for number space sp:
+// the data in unacknowledged packets is queued for retransmission, and
+// up to two probe packets may be sent regardless of the congestion
+// window (RFC 9002, Section 6.2.4).
+func (c *Conn) onPTO(sp numberSpace) {
+	c.ptoCount++
+	c.ptoSpace = sp
+	c.ptoProbes = 2
+	s := &c.spaces[sp]
+	for _, p := range s.sent {
+		if p.ackEliciting {
+			c.requeueFrames(sp, p)
+		}
+	}
+}
+
+// handleAck processes the ranges of an ACK frame received in number
+// space sp. ranges are in descending order.
+func (c *Conn) handleAck(now time.Time, sp numberSpace, ranges []span, ackDelay uint64) {
+	s := &c.spaces[sp]
+	largest := ranges[0].end - 1
+	if largest >= s.nextPN {
+		c.abort(now, errProtocolViolation, "acknowledgement of unsent packet")
+		return
+	}
+	if largest > s.largestAcked {
+		s.largestAcked = largest
+	}
+
+	var newlyAcked []*sentPacket
+	kept := s.sent[:0]
+	ri := len(ranges) - 1
+	for _, p := range s.sent {
+		for ri >= 0 && ranges[ri].end <= p.num {
+			ri--
+		}
+		if ri >= 0 && ranges[ri].start <= p.num {
+			newlyAcked = append(newlyAcked, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	for i := len(kept); i < len(s.sent); i++ {
+		s.sent[i] = nil
+	}
+	s.sent = kept
+	if len(newlyAcked) == 0 {
+		return
+	}
+
+	last := newlyAcked[len(newlyAcked)-1]
+	ackEliciting := false
+	for _, p := range newlyAcked {
+		ackEliciting = ackEliciting || p.ackEliciting
+	}
+	if last.num == largest && ackEliciting {
+		var delay time.Duration
+		if sp == appDataSpace {
+			delay = time.Duration(ackDelay<<c.peerParams.ackDelayExponent) * time.Microsecond
+			if c.handshakeConfirmed && delay > c.peerParams.maxAckDelay {
+				delay = c.peerParams.maxAckDelay
+			}
+		}
+		c.rtt.update(now.Sub(last.time), delay)
+	}
+	for _, p := range newlyAcked {
+		c.onPacketAcked(sp, p)
+	}
+	c.detectLoss(now, sp)
+	c.ptoCount = 0
+	c.cond.Broadcast()
+}
+
+func (c *Conn) onPacketAcked(sp numberSpace, p *sentPacket) {
+	s := &c.spaces[sp]
+	c.cc.onAcked(p)
+	if p.ackEliciting {
+		s.ackElicitingInFlight--
+	}
+	for _, f := range p.frames {
+		switch f.typ {
+		case frameTypeCrypto:
+			s.cryptoSend.onAck(f.off, f.n, false)
+		case frameTypeStreamBase:
+			f.stream.send.onAck(f.off, f.n, f.fin)
+			c.maybeFinishStream(f.stream)
+		case frameTypeResetStream:
+			f.stream.resetAcked = true
+			c.maybeFinishStream(f.stream)
+		}
+	}
+}
+
+// detectLoss declares packets in number space sp lost by the packet
+// and time thresholds (RFC 9002, Section 6.1), and arms the loss timer
+// for packets that will cross the time threshold.
+func (c *Conn) detectLoss(now time.Time, sp numberSpace) {
+	s := &c.spaces[sp]
+	s.lossTime = time.Time{}
+	lossDelay := c.rtt.latest
+	if c.rtt.smoothed > lossDelay {
+		lossDelay = c.rtt.smoothed
+	}
+	lossDelay = lossDelay * timeThresholdNum / timeThresholdDenom
+	if lossDelay < timerGranularity {
+		lossDelay = timerGranularity
+	}
+	lostSendTime := now.Add(-lossDelay)
+
+	var lastLost time.Time
+	kept := s.sent[:0]
+	for _, p := range s.sent {
+		switch {
+		case p.num > s.largestAcked:
+			kept = append(kept, p)
+		case !p.time.After(lostSendTime) || s.largestAcked >= p.num+packetThreshold:
+			c.onPacketLost(sp, p)
+			if p.time.After(lastLost) {
+				lastLost = p.time
+			}
+		default:
+			kept = append(kept, p)
+			if t := p.time.Add(lossDelay); s.lossTime.IsZero() || t.Before(s.lossTime) {
+				s.lossTime = t
+			}
+		}
+	}
+	for i := len(kept); i < len(s.sent); i++ {
+		s.sent[i] = nil
+	}
+	s.sent = kept
+	if !lastLost.IsZero() {
+		c.cc.onCongestion(now, lastLost)
+	}
+}
+
+func (c *Conn) onPacketLost(sp numberSpace, p *sentPacket) {
+	s := &c.spaces[sp]
+	c.cc.bytesInFlight -= int64(p.size)
+	if p.ackEliciting {
+		s.ackElicitingInFlight--
+	}
+	c.requeueFrames(sp, p)
+}
+
+// requeueFrames arranges for the frames in p to be sent again, in their
+// current form where that differs.
+func (c *Conn) requeueFrames(sp numberSpace, p *sentPacket) {
+	s := &c.spaces[sp]
+	for _, f := range p.frames {
+		st := f.stream
+		switch f.typ {
+		case frameTypeCrypto:
+			s.cryptoSend.onLost(f.off, f.n, false)
+		case frameTypeStreamBase:
+			if !st.reset {
+				st.send.onLost(f.off, f.n, f.fin)
+				c.queueStream(st)
+			}
+		case frameTypeResetStream:
+			if !st.resetAcked {
+				st.sendReset = true
+				c.queueStream(st)
+			}
+		case frameTypeStopSending:
+			if st.recv.finalSize < 0 && st.peerReset == nil {
+				st.sendStopSending = true
+				c.queueStream(st)
+			}
+		case frameTypeMaxStreamData:
+			if st.recv.finalSize < 0 && !st.readClosed {
+				st.sendMaxStreamData = true
+				c.queueStream(st)
+			}
+		case frameTypeMaxData:
+			c.sendMaxDataF = true
+		case frameTypeMaxStreamsBidi:
+			c.remoteStreams[0].sendMaxStreams = true
+		case frameTypeMaxStreamsUni:
+			c.remoteStreams[1].sendMaxStreams = true
+		case frameTypeHandshakeDone:
+			c.sendHandshakeDone = true
+		}
+	}
+	// The frames are either queued for retransmission or obsolete;
+	// an acknowledgement of p arriving later must not act on them.
+	p.frames = nil
+}
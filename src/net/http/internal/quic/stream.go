@@ -0,0 +1,489 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"io"
+	"time"
+)
+
+// A Stream is a QUIC stream. Unidirectional streams opened locally
+// can only be written to, and those opened by the peer can only be
+// read from.
+//
+// It is safe to call Read concurrently with Write, CloseWrite and Reset,
+// and CloseRead concurrently with all of them.
+type Stream struct {
+	id   int64
+	conn *Conn
+
+	// The fields below are guarded by conn.mu.
+
+	// Send side.
+	hasSend     bool
+	send        sendBuffer
+	sendMax     int64 // limit from MAX_STREAM_DATA or the transport parameters
+	writeClosed bool  // CloseWrite or Reset was called
+	reset       bool  // Reset was called or the peer sent STOP_SENDING
+	resetCode   uint64
+	sendReset   bool // a RESET_STREAM frame is due
+	resetAcked  bool
+	stopped     *StreamError // the peer sent STOP_SENDING
+
+	// Receive side.
+	hasRecv           bool
+	recv              recvBuffer
+	recvMax           int64 // the limit we advertised
+	sendMaxStreamData bool  // a MAX_STREAM_DATA frame is due
+	readClosed        bool  // CloseRead was called
+	stopCode          uint64
+	sendStopSending   bool         // a STOP_SENDING frame is due
+	peerReset         *StreamError // the peer sent RESET_STREAM
+
+	inSendq bool
+	closed  bool // both sides are done and the stream has been forgotten
+}
+
+// ID returns the stream ID.
+func (s *Stream) ID() int64 {
+	return s.id
+}
+
+// isLocal reports whether the stream with the given id was opened by
+// this side of the connection.
+func (c *Conn) isLocal(id int64) bool {
+	return (id&1 == 0) == c.isClient
+}
+
+func (c *Conn) newStream(id int64) *Stream {
+	s := &Stream{
+		id:      id,
+		conn:    c,
+		recv:    newRecvBuffer(),
+		recvMax: c.config.maxStreamReadBufferSize(),
+	}
+	uni := id&2 != 0
+	local := c.isLocal(id)
+	s.hasSend = !uni || local
+	s.hasRecv = !uni || !local
+	switch {
+	case uni:
+		s.sendMax = c.peerParams.maxStreamDataUni
+	case local:
+		s.sendMax = c.peerParams.maxStreamDataBidiRem
+	default:
+		s.sendMax = c.peerParams.maxStreamDataBidiLoc
+	}
+	c.streams[id] = s
+	return s
+}
+
+// streamForFrame returns the stream a received frame refers to,
+// opening peer streams implicitly (RFC 9000, Section 3.2). forRecv
+// reports whether the frame concerns the receive side of the stream.
+// It returns nil if the stream has already been closed.
+func (c *Conn) streamForFrame(id int64, forRecv bool) (*Stream, error) {
+	uni := id&2 != 0
+	idx := id >> 2
+	if c.isLocal(id) {
+		if uni && forRecv {
+			return nil, &connCloseError{code: errStreamState, reason: "frame for receive side of send-only stream"}
+		}
+		if idx >= c.localStreams[b2i(uni)].opened {
+			return nil, &connCloseError{code: errStreamState, reason: "frame for unopened stream"}
+		}
+		return c.streams[id], nil
+	}
+	if uni && !forRecv {
+		return nil, &connCloseError{code: errStreamState, reason: "frame for send side of receive-only stream"}
+	}
+	n := &c.remoteStreams[b2i(uni)]
+	if idx >= n.max {
+		return nil, &connCloseError{code: errStreamLimit, reason: "stream limit exceeded"}
+	}
+	for n.opened <= idx {
+		nid := n.opened<<2 | id&3
+		n.opened++
+		c.acceptq[b2i(uni)] = append(c.acceptq[b2i(uni)], c.newStream(nid))
+		c.cond.Broadcast()
+	}
+	return c.streams[id], nil
+}
+
+func (c *Conn) handleStreamFrame(now time.Time, id, off int64, data []byte, fin bool) {
+	s, err := c.streamForFrame(id, true)
+	if err != nil {
+		c.abortErr(now, err)
+		return
+	}
+	if s == nil || s.peerReset != nil {
+		return
+	}
+	end := off + int64(len(data))
+	if end > s.recvMax {
+		c.abort(now, errFlowControl, "stream flow control limit exceeded")
+		return
+	}
+	prev := s.recv.maxOff
+	if err := s.recv.write(off, data, fin); err != nil {
+		c.abortErr(now, err)
+		return
+	}
+	if !c.addRecvData(now, s.recv.maxOff-prev) {
+		return
+	}
+	if s.readClosed {
+		c.discardRecv(s)
+	}
+	c.maybeFinishStream(s)
+	c.cond.Broadcast()
+}
+
+// addRecvData counts n more bytes of stream data against connection
+// flow control, and reports whether the limit was respected.
+func (c *Conn) addRecvData(now time.Time, n int64) bool {
+	c.recvData += n
+	if c.recvData > c.recvMaxData {
+		c.abort(now, errFlowControl, "connection flow control limit exceeded")
+		return false
+	}
+	return true
+}
+
+func (c *Conn) handleResetStream(now time.Time, id int64, code uint64, size int64) {
+	s, err := c.streamForFrame(id, true)
+	if err != nil {
+		c.abortErr(now, err)
+		return
+	}
+	if s == nil {
+		return
+	}
+	if size < s.recv.maxOff || s.recv.finalSize >= 0 && size != s.recv.finalSize {
+		c.abort(now, errFinalSize, "RESET_STREAM final size mismatch")
+		return
+	}
+	if size > s.recvMax {
+		c.abort(now, errFlowControl, "stream flow control limit exceeded")
+		return
+	}
+	if !c.addRecvData(now, size-s.recv.maxOff) {
+		return
+	}
+	s.recv.maxOff = size
+	s.recv.finalSize = size
+	if s.peerReset == nil {
+		s.peerReset = &StreamError{StreamID: s.id, Code: code}
+		s.sendMaxStreamData = false
+		s.sendStopSending = false
+		// Data that will never be read no longer counts against
+		// the connection's flow control window.
+		c.consumed(size - s.recv.readOff)
+		s.recv.buf = nil
+	}
+	c.maybeFinishStream(s)
+	c.cond.Broadcast()
+}
+
+func (c *Conn) handleStopSending(now time.Time, id int64, code uint64) {
+	s, err := c.streamForFrame(id, false)
+	if err != nil {
+		c.abortErr(now, err)
+		return
+	}
+	if s == nil || s.stopped != nil {
+		return
+	}
+	s.stopped = &StreamError{StreamID: s.id, Code: code}
+	// Respond with RESET_STREAM (RFC 9000, Section 3.5).
+	c.resetStream(s, code)
+	c.cond.Broadcast()
+}
+
+// resetStream abandons the send side of s with a RESET_STREAM frame,
+// unless all data was already sent and acknowledged.
+func (c *Conn) resetStream(s *Stream, code uint64) {
+	s.writeClosed = true
+	if s.reset || s.send.done() {
+		return
+	}
+	s.reset = true
+	s.resetCode = code
+	s.sendReset = true
+	s.send.lost = nil
+	c.queueStream(s)
+}
+
+// discardRecv drops data received on a stream the application no
+// longer reads.
+func (c *Conn) discardRecv(s *Stream) {
+	if n := s.recv.readable(); n > 0 {
+		s.recv.discard(n)
+		c.consumed(int64(n))
+	}
+}
+
+// consumed records that the application consumed n bytes of stream
+// data, and extends the connection flow control limit once half the
+// window has been used.
+func (c *Conn) consumed(n int64) {
+	c.readData += n
+	window := c.config.maxConnReadBufferSize()
+	if c.recvMaxData-c.readData < window/2 {
+		c.recvMaxData = c.readData + window
+		c.sendMaxDataF = true
+		c.wake()
+	}
+}
+
+// queueStream adds s to the streams with frames to send.
+func (c *Conn) queueStream(s *Stream) {
+	if !s.inSendq && !s.closed {
+		s.inSendq = true
+		c.sendq = append(c.sendq, s)
+		c.wake()
+	}
+}
+
+// queueAllStreams queues every stream with data, after the connection
+// flow control limit increased.
+func (c *Conn) queueAllStreams() {
+	for _, s := range c.streams {
+		if s.hasSend && !s.reset && s.send.next < s.send.end() {
+			c.queueStream(s)
+		}
+	}
+}
+
+// maybeFinishStream forgets s once both of its sides are done, and
+// allows the peer to open another stream if s was the peer's.
+func (c *Conn) maybeFinishStream(s *Stream) {
+	if s.closed {
+		return
+	}
+	sendDone := !s.hasSend || s.send.done() || s.resetAcked
+	recvDone := !s.hasRecv || s.recv.eof() || s.peerReset != nil ||
+		s.readClosed && s.recv.finalSize >= 0
+	if !sendDone || !recvDone {
+		return
+	}
+	s.closed = true
+	delete(c.streams, s.id)
+	if !c.isLocal(s.id) {
+		uni := s.id&2 != 0
+		n := &c.remoteStreams[b2i(uni)]
+		n.closed++
+		n.max = n.closed + c.config.maxRemoteStreams(uni)
+		n.sendMaxStreams = true
+		c.wake()
+	}
+}
+
+// wantsToSend reports whether s has frames to send.
+func (s *Stream) wantsToSend(connCredit int64) bool {
+	if s.sendStopSending || s.sendMaxStreamData || s.sendReset {
+		return true
+	}
+	if !s.hasSend || s.reset {
+		return false
+	}
+	limit := s.sendMax
+	if l := s.send.next + connCredit; l < limit {
+		limit = l
+	}
+	return s.send.hasData(limit)
+}
+
+// appendFrames appends the frames s has to send to fb. It reports false
+// if fb ran out of room.
+func (c *Conn) appendStreamFrames(fb *frameBuilder, s *Stream) bool {
+	if s.sendStopSending {
+		if !fb.appendVarints(frameTypeStopSending, uint64(s.id), s.stopCode) {
+			return false
+		}
+		fb.record(sentFrame{typ: frameTypeStopSending, stream: s})
+		s.sendStopSending = false
+	}
+	if s.sendMaxStreamData {
+		if !fb.appendVarints(frameTypeMaxStreamData, uint64(s.id), uint64(s.recvMax)) {
+			return false
+		}
+		fb.record(sentFrame{typ: frameTypeMaxStreamData, stream: s})
+		s.sendMaxStreamData = false
+	}
+	if s.reset {
+		if s.sendReset {
+			if !fb.appendVarints(frameTypeResetStream, uint64(s.id), s.resetCode, uint64(s.send.next)) {
+				return false
+			}
+			fb.record(sentFrame{typ: frameTypeResetStream, stream: s})
+			s.sendReset = false
+		}
+		return true
+	}
+	for s.wantsToSend(c.sendMaxData - c.sentData) {
+		limit := s.sendMax
+		if l := s.send.next + c.sendMaxData - c.sentData; l < limit {
+			limit = l
+		}
+		f, isNew, ok := fb.appendDataFrame(s, &s.send, limit)
+		if !ok {
+			return false
+		}
+		if isNew {
+			c.sentData += f.n
+		}
+	}
+	return true
+}
+
+// Read reads data from the stream. It returns io.EOF at the end of the
+// stream, and a *StreamError if the peer reset it.
+func (s *Stream) Read(p []byte) (int, error) {
+	c := s.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !s.hasRecv {
+		return 0, errStreamClosed
+	}
+	for {
+		if s.readClosed {
+			return 0, errStreamClosed
+		}
+		if s.peerReset != nil {
+			return 0, s.peerReset
+		}
+		if len(p) == 0 {
+			return 0, nil
+		}
+		if n := s.recv.read(p); n > 0 {
+			s.onRead(n)
+			return n, nil
+		}
+		if s.recv.eof() {
+			c.maybeFinishStream(s)
+			return 0, io.EOF
+		}
+		if c.closeErr != nil {
+			return 0, c.closeErr
+		}
+		c.cond.Wait()
+	}
+}
+
+// onRead extends the stream and connection flow control limits after
+// the application read n bytes.
+func (s *Stream) onRead(n int) {
+	c := s.conn
+	c.consumed(int64(n))
+	window := c.config.maxStreamReadBufferSize()
+	if s.recv.finalSize < 0 && s.recvMax-s.recv.readOff < window/2 {
+		s.recvMax = s.recv.readOff + window
+		s.sendMaxStreamData = true
+		c.queueStream(s)
+	}
+	if s.recv.eof() {
+		c.maybeFinishStream(s)
+	}
+}
+
+// Write writes data to the stream, blocking while the stream's write
+// buffer is full. It returns a *StreamError if the peer asked us to
+// stop sending.
+func (s *Stream) Write(p []byte) (int, error) {
+	c := s.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !s.hasSend {
+		return 0, errStreamClosed
+	}
+	max := c.config.maxStreamWriteBufferSize()
+	n := 0
+	for len(p) > 0 {
+		switch {
+		case s.stopped != nil:
+			return n, s.stopped
+		case s.reset:
+			return n, errLocalReset
+		case s.writeClosed:
+			return n, errStreamClosed
+		case c.closeErr != nil:
+			return n, c.closeErr
+		}
+		room := max - s.send.unacked()
+		if room <= 0 {
+			c.cond.Wait()
+			continue
+		}
+		if int64(len(p)) < room {
+			room = int64(len(p))
+		}
+		s.send.write(p[:room])
+		p = p[room:]
+		n += int(room)
+		c.queueStream(s)
+	}
+	return n, nil
+}
+
+// CloseWrite ends the send side of the stream. Data already written is
+// still delivered.
+func (s *Stream) CloseWrite() error {
+	c := s.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !s.hasSend {
+		return errStreamClosed
+	}
+	if s.writeClosed {
+		return nil
+	}
+	s.writeClosed = true
+	s.send.fin = true
+	c.queueStream(s)
+	c.cond.Broadcast()
+	return nil
+}
+
+// Reset abandons the send side of the stream, telling the peer the
+// given application error code. Data not yet delivered is discarded.
+func (s *Stream) Reset(code uint64) error {
+	c := s.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !s.hasSend {
+		return errStreamClosed
+	}
+	c.resetStream(s, code)
+	c.maybeFinishStream(s)
+	c.cond.Broadcast()
+	return nil
+}
+
+// CloseRead abandons the receive side of the stream. Unless the stream
+// has already ended, the peer is asked to stop sending with the given
+// application error code.
+func (s *Stream) CloseRead(code uint64) error {
+	c := s.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !s.hasRecv {
+		return errStreamClosed
+	}
+	if s.readClosed {
+		return nil
+	}
+	s.readClosed = true
+	s.sendMaxStreamData = false
+	if s.recv.finalSize < 0 && s.peerReset == nil {
+		s.stopCode = code
+		s.sendStopSending = true
+		c.queueStream(s)
+	}
+	c.discardRecv(s)
+	c.maybeFinishStream(s)
+	c.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"bytes"
+	"time"
+)
+
+// Transport parameter IDs (RFC 9000, Section 18.2).
+const (
+	paramOriginalDestinationConnectionID = 0x00
+	paramMaxIdleTimeout                  = 0x01
+	paramStatelessResetToken             = 0x02
+	paramMaxUDPPayloadSize               = 0x03
+	paramInitialMaxData                  = 0x04
+	paramInitialMaxStreamDataBidiLocal   = 0x05
+	paramInitialMaxStreamDataBidiRemote  = 0x06
+	paramInitialMaxStreamDataUni         = 0x07
+	paramInitialMaxStreamsBidi           = 0x08
+	paramInitialMaxStreamsUni            = 0x09
+	paramAckDelayExponent                = 0x0a
+	paramMaxAckDelay                     = 0x0b
+	paramDisableActiveMigration          = 0x0c
+	paramPreferredAddress                = 0x0d
+	paramActiveConnectionIDLimit         = 0x0e
+	paramInitialSourceConnectionID       = 0x0f
+	paramRetrySourceConnectionID         = 0x10
+)
+
+// transportParameters are the QUIC transport parameters sent in the
+// quic_transport_parameters TLS extension.
+type transportParameters struct {
+	originalDstConnID     []byte // server only
+	initialSrcConnID      []byte
+	hasOriginalDstConnID  bool
+	hasInitialSrcConnID   bool
+	hasRetrySrcConnID     bool
+	maxIdleTimeout        time.Duration
+	maxUDPPayloadSize     int64
+	initialMaxData        int64
+	maxStreamDataBidiLoc  int64
+	maxStreamDataBidiRem  int64
+	maxStreamDataUni      int64
+	initialMaxStreamsBidi int64
+	initialMaxStreamsUni  int64
+	ackDelayExponent      int64
+	maxAckDelay           time.Duration
+	activeConnIDLimit     int64
+}
+
+func defaultTransportParameters() transportParameters {
+	return transportParameters{
+		maxUDPPayloadSize: 65527,
+		ackDelayExponent:  3,
+		maxAckDelay:       25 * time.Millisecond,
+		activeConnIDLimit: 2,
+	}
+}
+
+func (p *transportParameters) marshal(isClient bool) []byte {
+	var b []byte
+	appendInt := func(id, v uint64) {
+		b = AppendVarint(b, id)
+		b = AppendVarint(b, uint64(SizeVarint(v)))
+		b = AppendVarint(b, v)
+	}
+	appendBytes := func(id uint64, v []byte) {
+		b = AppendVarint(b, id)
+		b = AppendVarint(b, uint64(len(v)))
+		b = append(b, v...)
+	}
+	if !isClient {
+		appendBytes(paramOriginalDestinationConnectionID, p.originalDstConnID)
+	}
+	appendBytes(paramInitialSourceConnectionID, p.initialSrcConnID)
+	if p.maxIdleTimeout > 0 {
+		appendInt(paramMaxIdleTimeout, uint64(p.maxIdleTimeout/time.Millisecond))
+	}
+	appendInt(paramMaxUDPPayloadSize, uint64(p.maxUDPPayloadSize))
+	appendInt(paramInitialMaxData, uint64(p.initialMaxData))
+	appendInt(paramInitialMaxStreamDataBidiLocal, uint64(p.maxStreamDataBidiLoc))
+	appendInt(paramInitialMaxStreamDataBidiRemote, uint64(p.maxStreamDataBidiRem))
+	appendInt(paramInitialMaxStreamDataUni, uint64(p.maxStreamDataUni))
+	appendInt(paramInitialMaxStreamsBidi, uint64(p.initialMaxStreamsBidi))
+	appendInt(paramInitialMaxStreamsUni, uint64(p.initialMaxStreamsUni))
+	appendInt(paramAckDelayExponent, uint64(p.ackDelayExponent))
+	appendInt(paramMaxAckDelay, uint64(p.maxAckDelay/time.Millisecond))
+	appendInt(paramActiveConnectionIDLimit, uint64(p.activeConnIDLimit))
+	// We never change the address we send from, and do not support
+	// the peer changing its own.
+	b = AppendVarint(b, paramDisableActiveMigration)
+	b = AppendVarint(b, 0)
+	return b
+}
+
+// parseTransportParameters parses the transport parameters sent by the
+// peer. fromClient reports whether the peer is a client, which may not
+// send the parameters reserved for servers.
+func parseTransportParameters(b []byte, fromClient bool) (transportParameters, error) {
+	p := defaultTransportParameters()
+	seen := make(map[uint64]bool)
+	r := wireReader{b: b}
+	for len(r.b) > 0 {
+		id := r.varint()
+		val := wireReader{b: r.varintBytes()}
+		if r.err != nil {
+			return p, &connCloseError{code: errTransportParameter, reason: "malformed transport parameters"}
+		}
+		if seen[id] {
+			return p, &connCloseError{code: errTransportParameter, reason: "duplicate transport parameter"}
+		}
+		seen[id] = true
+		var v uint64
+		switch id {
+		case paramOriginalDestinationConnectionID,
+			paramInitialSourceConnectionID,
+			paramRetrySourceConnectionID,
+			paramStatelessResetToken,
+			paramPreferredAddress,
+			paramDisableActiveMigration:
+		default:
+			v = val.varint()
+			if len(val.b) != 0 {
+				val.fail()
+			}
+		}
+		switch id {
+		case paramOriginalDestinationConnectionID:
+			p.originalDstConnID = append([]byte(nil), val.b...)
+			p.hasOriginalDstConnID = true
+		case paramInitialSourceConnectionID:
+			p.initialSrcConnID = append([]byte(nil), val.b...)
+			p.hasInitialSrcConnID = true
+		case paramRetrySourceConnectionID:
+			p.hasRetrySrcConnID = true
+		case paramStatelessResetToken:
+			if len(val.b) != 16 {
+				val.fail()
+			}
+		case paramMaxIdleTimeout:
+			if v > uint64(maxVarint/time.Millisecond) {
+				v = uint64(maxVarint / time.Millisecond)
+			}
+			p.maxIdleTimeout = time.Duration(v) * time.Millisecond
+		case paramMaxUDPPayloadSize:
+			if v < 1200 {
+				val.fail()
+			}
+			p.maxUDPPayloadSize = int64(v)
+		case paramInitialMaxData:
+			p.initialMaxData = int64(v)
+		case paramInitialMaxStreamDataBidiLocal:
+			p.maxStreamDataBidiLoc = int64(v)
+		case paramInitialMaxStreamDataBidiRemote:
+			p.maxStreamDataBidiRem = int64(v)
+		case paramInitialMaxStreamDataUni:
+			p.maxStreamDataUni = int64(v)
+		case paramInitialMaxStreamsBidi:
+			if v > 1<<60 {
+				val.fail()
+			}
+			p.initialMaxStreamsBidi = int64(v)
+		case paramInitialMaxStreamsUni:
+			if v > 1<<60 {
+				val.fail()
+			}
+			p.initialMaxStreamsUni = int64(v)
+		case paramAckDelayExponent:
+			if v > 20 {
+				val.fail()
+			}
+			p.ackDelayExponent = int64(v)
+		case paramMaxAckDelay:
+			if v >= 1<<14 {
+				val.fail()
+			}
+			p.maxAckDelay = time.Duration(v) * time.Millisecond
+		case paramActiveConnectionIDLimit:
+			if v < 2 {
+				val.fail()
+			}
+			p.activeConnIDLimit = int64(v)
+		case paramDisableActiveMigration:
+			if len(val.b) != 0 {
+				val.fail()
+			}
+		}
+		if val.err != nil {
+			return p, &connCloseError{code: errTransportParameter, reason: "invalid transport parameter"}
+		}
+		if fromClient {
+			switch id {
+			case paramOriginalDestinationConnectionID,
+				paramStatelessResetToken,
+				paramPreferredAddress,
+				paramRetrySourceConnectionID:
+				return p, &connCloseError{code: errTransportParameter, reason: "client sent server-only transport parameter"}
+			}
+		}
+	}
+	return p, nil
+}
+
+// checkConnIDs validates the connection ID transport parameters sent by
+// the peer against the connection IDs seen in the handshake packets
+// (RFC 9000, Section 7.3). Retry is not supported, so the server must
+// not send a retry_source_connection_id.
+func (p *transportParameters) checkConnIDs(peerSrcID, origDstID []byte, fromClient bool) error {
+	if !p.hasInitialSrcConnID || !bytes.Equal(p.initialSrcConnID, peerSrcID) {
+		return &connCloseError{code: errTransportParameter, reason: "initial_source_connection_id mismatch"}
+	}
+	if !fromClient && (!p.hasOriginalDstConnID || !bytes.Equal(p.originalDstConnID, origDstID)) {
+		return &connCloseError{code: errTransportParameter, reason: "original_destination_connection_id mismatch"}
+	}
+	if p.hasRetrySrcConnID {
+		return &connCloseError{code: errTransportParameter, reason: "unexpected retry_source_connection_id"}
+	}
+	return nil
+}
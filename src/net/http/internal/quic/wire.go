@@ -0,0 +1,140 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quic
+
+import (
+	"errors"
+	"io"
+)
+
+// AppendVarint appends v to b as a variable-length integer
+// (RFC 9000, Section 16). It panics if v is larger than 2^62-1.
+func AppendVarint(b []byte, v uint64) []byte {
+	switch {
+	case v < 1<<6:
+		return append(b, byte(v))
+	case v < 1<<14:
+		return append(b, 0x40|byte(v>>8), byte(v))
+	case v < 1<<30:
+		return append(b, 0x80|byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	case v <= maxVarint:
+		return append(b, 0xc0|byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	panic("quic: varint too large")
+}
+
+// SizeVarint returns the number of bytes AppendVarint uses to encode v.
+func SizeVarint(v uint64) int {
+	switch {
+	case v < 1<<6:
+		return 1
+	case v < 1<<14:
+		return 2
+	case v < 1<<30:
+		return 4
+	}
+	return 8
+}
+
+// ConsumeVarint parses a variable-length integer at the start of b.
+// It returns the value and the number of bytes consumed, or a negative
+// length if b does not contain a complete integer.
+func ConsumeVarint(b []byte) (uint64, int) {
+	if len(b) < 1 {
+		return 0, -1
+	}
+	n := 1 << (b[0] >> 6)
+	if len(b) < n {
+		return 0, -1
+	}
+	v := uint64(b[0] & 0x3f)
+	for i := 1; i < n; i++ {
+		v = v<<8 | uint64(b[i])
+	}
+	return v, n
+}
+
+// ReadVarint reads a variable-length integer from r. It returns io.EOF
+// only if no bytes were read, and io.ErrUnexpectedEOF if r ends in the
+// middle of the integer.
+func ReadVarint(r io.ByteReader) (uint64, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	n := 1 << (c >> 6)
+	v := uint64(c & 0x3f)
+	for i := 1; i < n; i++ {
+		c, err = r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+var errTruncated = errors.New("quic: truncated")
+
+// A wireReader consumes fields from a buffer. The first failure sets
+// err; later calls return zero values.
+type wireReader struct {
+	b   []byte
+	err error
+}
+
+func (r *wireReader) fail() {
+	if r.err == nil {
+		r.err = errTruncated
+	}
+	r.b = nil
+}
+
+func (r *wireReader) varint() uint64 {
+	v, n := ConsumeVarint(r.b)
+	if n < 0 {
+		r.fail()
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *wireReader) byte() byte {
+	if len(r.b) < 1 {
+		r.fail()
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *wireReader) bytes(n uint64) []byte {
+	if uint64(len(r.b)) < n {
+		r.fail()
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+// varintBytes reads a length-prefixed byte string.
+func (r *wireReader) varintBytes() []byte {
+	return r.bytes(r.varint())
+}
+
+func (r *wireReader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
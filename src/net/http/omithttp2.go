@@ -8,7 +8,9 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"net"
 	"sync"
 	"time"
 )
@@ -28,11 +30,16 @@ const http2NextProtoTLS = "h2"
 type http2Transport struct {
 	MaxHeaderListSize uint32
 	ConnPool          interface{}
+	AllowHTTP         bool
 }
 
 func (*http2Transport) RoundTrip(*Request) (*Response, error) { panic(noHTTP2) }
 func (*http2Transport) CloseIdleConnections()                 {}
 
+func addUnencryptedHTTP2Conn(*http2Transport, string, net.Conn) (RoundTripper, error) { panic(noHTTP2) }
+
+func (t *Transport) offerZstdHTTP2(rt RoundTripper) RoundTripper { return rt }
+
 type http2noDialH2RoundTripper struct{}
 
 func (http2noDialH2RoundTripper) RoundTrip(*Request) (*Response, error) { panic(noHTTP2) }
@@ -57,6 +64,18 @@ type http2Server struct {
 	NewWriteScheduler func() http2WriteScheduler
 }
 
+func (*http2Server) ServeConn(net.Conn, *http2ServeConnOpts) { panic(noHTTP2) }
+
+type http2ServeConnOpts struct {
+	Context        context.Context
+	BaseConfig     *Server
+	Handler        Handler
+	UpgradeRequest *Request
+	Settings       []byte
+}
+
+const http2ClientPreface = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+
 type http2WriteScheduler interface{}
 
 func http2NewPriorityWriteScheduler(interface{}) http2WriteScheduler { panic(noHTTP2) }
@@ -0,0 +1,407 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	. "net/http"
+	"net/http/httptrace"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResponseControllerFlush_h1(t *testing.T) { testResponseControllerFlush(t, h1Mode) }
+func TestResponseControllerFlush_h2(t *testing.T) { testResponseControllerFlush(t, h2Mode) }
+func testResponseControllerFlush(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	continuec := make(chan struct{})
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		ctl := NewResponseController(w)
+		w.Write([]byte("one"))
+		if err := ctl.Flush(); err != nil {
+			t.Errorf("ctl.Flush() = %v, want nil", err)
+			return
+		}
+		<-continuec
+		w.Write([]byte("two"))
+	}))
+	defer cst.close()
+
+	res, err := cst.c.Get(cst.ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected connection error: %v", err)
+	}
+	defer res.Body.Close()
+
+	buf := make([]byte, 16)
+	n, err := res.Body.Read(buf)
+	close(continuec)
+	if err != nil || string(buf[:n]) != "one" {
+		t.Fatalf("Body.Read = %q, %v, want %q, nil", string(buf[:n]), err, "one")
+	}
+
+	got, err := io.ReadAll(res.Body)
+	if err != nil || string(got) != "two" {
+		t.Fatalf("Body.Read = %q, %v, want %q, nil", string(got), err, "two")
+	}
+}
+
+func TestResponseControllerHijack_h1(t *testing.T) { testResponseControllerHijack(t, h1Mode) }
+func TestResponseControllerHijack_h2(t *testing.T) { testResponseControllerHijack(t, h2Mode) }
+func testResponseControllerHijack(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	const header = "X-Header"
+	const value = "set"
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		ctl := NewResponseController(w)
+		c, _, err := ctl.Hijack()
+		if h2 {
+			if err == nil || !errors.Is(err, ErrNotSupported) {
+				t.Errorf("ctl.Hijack = nil, %v, want error", err)
+			}
+			w.Header().Set(header, value)
+			return
+		}
+		if err != nil {
+			t.Errorf("ctl.Hijack = _, _, %v, want _, _, nil", err)
+			return
+		}
+		fmt.Fprintf(c, "HTTP/1.0 200 OK\r\n%v: %v\r\nContent-Length: 0\r\n\r\n", header, value)
+	}))
+	defer cst.close()
+	res, err := cst.c.Get(cst.ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got, want := res.Header.Get(header), value; got != want {
+		t.Errorf("response header %q = %q, want %q", header, got, want)
+	}
+}
+
+func TestResponseControllerSetPastWriteDeadline_h1(t *testing.T) {
+	testResponseControllerSetPastWriteDeadline(t, h1Mode)
+}
+func TestResponseControllerSetPastWriteDeadline_h2(t *testing.T) {
+	testResponseControllerSetPastWriteDeadline(t, h2Mode)
+}
+func testResponseControllerSetPastWriteDeadline(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		ctl := NewResponseController(w)
+		w.Write([]byte("one"))
+		if err := ctl.Flush(); err != nil {
+			t.Errorf("before setting deadline: ctl.Flush() = %v, want nil", err)
+		}
+		if err := ctl.SetWriteDeadline(time.Now().Add(-10 * time.Second)); err != nil {
+			t.Errorf("ctl.SetWriteDeadline() = %v, want nil", err)
+		}
+
+		w.Write([]byte("two"))
+		if err := ctl.Flush(); err == nil {
+			t.Errorf("after setting deadline: ctl.Flush() = nil, want non-nil")
+		}
+		// Connection errors are sticky, so resetting the deadline does not permit
+		// making more progress. We might want to change this in the future, but verify
+		// the current behavior for now. If we do change this, we'll want to make sure
+		// to do so only for writing the response body, not headers.
+		if err := ctl.SetWriteDeadline(time.Now().Add(1 * time.Hour)); err != nil {
+			t.Errorf("ctl.SetWriteDeadline() = %v, want nil", err)
+		}
+		w.Write([]byte("three"))
+		if err := ctl.Flush(); err == nil {
+			t.Errorf("after resetting deadline: ctl.Flush() = nil, want non-nil")
+		}
+	}))
+	defer cst.close()
+
+	res, err := cst.c.Get(cst.ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected connection error: %v", err)
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	if string(b) != "one" {
+		t.Errorf("unexpected body: %q", string(b))
+	}
+}
+
+func TestResponseControllerSetFutureWriteDeadline_h1(t *testing.T) {
+	testResponseControllerSetFutureWriteDeadline(t, h1Mode)
+}
+func TestResponseControllerSetFutureWriteDeadline_h2(t *testing.T) {
+	testResponseControllerSetFutureWriteDeadline(t, h2Mode)
+}
+func testResponseControllerSetFutureWriteDeadline(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	errc := make(chan error, 1)
+	startwrites := make(chan struct{})
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		ctl := NewResponseController(w)
+		w.WriteHeader(200)
+		if err := ctl.Flush(); err != nil {
+			t.Errorf("ctl.Flush() = %v, want nil", err)
+		}
+		<-startwrites
+		// Set a deadline in the future and write until it expires.
+		ctl.SetWriteDeadline(time.Now().Add(1 * time.Millisecond))
+		for {
+			if _, err := w.Write([]byte("a")); err != nil {
+				errc <- err
+				break
+			}
+			if err := ctl.Flush(); err != nil {
+				errc <- err
+				break
+			}
+		}
+	}))
+	defer cst.close()
+
+	res, err := cst.c.Get(cst.ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected connection error: %v", err)
+	}
+	defer res.Body.Close()
+	close(startwrites)
+	if err := <-errc; err == nil {
+		t.Errorf("server write did not fail after the write deadline passed")
+	}
+}
+
+func TestResponseControllerSetPastReadDeadline_h1(t *testing.T) {
+	testResponseControllerSetPastReadDeadline(t, h1Mode)
+}
+func TestResponseControllerSetPastReadDeadline_h2(t *testing.T) {
+	testResponseControllerSetPastReadDeadline(t, h2Mode)
+}
+func testResponseControllerSetPastReadDeadline(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	readc := make(chan struct{})
+	donec := make(chan struct{})
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		defer close(donec)
+		ctl := NewResponseController(w)
+		b := make([]byte, 3)
+		n, err := io.ReadFull(r.Body, b)
+		b = b[:n]
+		if err != nil || string(b) != "one" {
+			t.Errorf("before setting read deadline: Read = %v, %q, want nil, %q", err, string(b), "one")
+			return
+		}
+		if err := ctl.SetReadDeadline(time.Now()); err != nil {
+			t.Errorf("ctl.SetReadDeadline() = %v, want nil", err)
+			return
+		}
+		b, err = io.ReadAll(r.Body)
+		if err == nil || string(b) != "" {
+			t.Errorf("after setting read deadline: Read = %q, nil, want error", string(b))
+		}
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("after setting read deadline: Read = %v, want os.ErrDeadlineExceeded", err)
+		}
+		close(readc)
+		// Connection errors are sticky, so resetting the deadline does not permit
+		// making more progress.
+		if err := ctl.SetReadDeadline(time.Time{}); err != nil {
+			t.Errorf("ctl.SetReadDeadline() = %v, want nil", err)
+			return
+		}
+		b, err = io.ReadAll(r.Body)
+		if err == nil {
+			t.Errorf("after resetting read deadline: Read = %q, nil, want error", string(b))
+		}
+	}))
+	defer cst.close()
+
+	pr, pw := io.Pipe()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer pw.Close()
+		pw.Write([]byte("one"))
+		select {
+		case <-readc:
+		case <-donec:
+			select {
+			case <-readc:
+			default:
+				t.Errorf("server handler unexpectedly exited without closing readc")
+				return
+			}
+		}
+		pw.Write([]byte("two"))
+	}()
+	defer wg.Wait()
+	res, err := cst.c.Post(cst.ts.URL, "text/foo", pr)
+	if err == nil {
+		defer res.Body.Close()
+	}
+}
+
+func TestResponseControllerWriteDeadlineNotReused_h1(t *testing.T) {
+	testResponseControllerWriteDeadlineNotReused(t, h1Mode)
+}
+func TestResponseControllerWriteDeadlineNotReused_h2(t *testing.T) {
+	testResponseControllerWriteDeadlineNotReused(t, h2Mode)
+}
+func testResponseControllerWriteDeadlineNotReused(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	deadlinec := make(chan time.Time, 1)
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		ctl := NewResponseController(w)
+		if r.URL.Path == "/first" {
+			// Set a deadline that expires before the next request is handled.
+			deadline := time.Now().Add(50 * time.Millisecond)
+			if err := ctl.SetWriteDeadline(deadline); err != nil {
+				t.Errorf("ctl.SetWriteDeadline() = %v, want nil", err)
+			}
+			w.Write([]byte("one"))
+			deadlinec <- deadline
+			return
+		}
+		time.Sleep(time.Until(<-deadlinec) + 10*time.Millisecond)
+		w.Write([]byte("two"))
+		if err := ctl.Flush(); err != nil {
+			t.Errorf("ctl.Flush() after the previous request's deadline = %v, want nil", err)
+		}
+	}))
+	defer cst.close()
+
+	var addrs []string
+	for _, path := range []string{"/first", "/second"} {
+		var addr string
+		trace := &httptrace.ClientTrace{
+			GotConn: func(info httptrace.GotConnInfo) {
+				addr = info.Conn.LocalAddr().String()
+			},
+		}
+		req, _ := NewRequest("GET", cst.ts.URL+path, nil)
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+		res, err := cst.c.Do(req)
+		if err != nil {
+			t.Fatalf("GET %v: %v", path, err)
+		}
+		if _, err := io.ReadAll(res.Body); err != nil {
+			t.Fatalf("GET %v: reading body: %v", path, err)
+		}
+		res.Body.Close()
+		addrs = append(addrs, addr)
+	}
+	if addrs[0] != addrs[1] {
+		t.Fatalf("requests used different connections (%v and %v); want one keep-alive connection", addrs[0], addrs[1])
+	}
+}
+
+type wrapWriter struct {
+	ResponseWriter
+}
+
+func (w wrapWriter) Unwrap() ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestWrappedResponseController_h1(t *testing.T) { testWrappedResponseController(t, h1Mode) }
+func TestWrappedResponseController_h2(t *testing.T) { testWrappedResponseController(t, h2Mode) }
+func testWrappedResponseController(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		ctl := NewResponseController(wrapWriter{w})
+		if err := ctl.Flush(); err != nil {
+			t.Errorf("ctl.Flush() = %v, want nil", err)
+		}
+		if err := ctl.SetReadDeadline(time.Time{}); err != nil {
+			t.Errorf("ctl.SetReadDeadline() = %v, want nil", err)
+		}
+		if err := ctl.SetWriteDeadline(time.Time{}); err != nil {
+			t.Errorf("ctl.SetWriteDeadline() = %v, want nil", err)
+		}
+		if err := ctl.EnableFullDuplex(); err != nil {
+			t.Errorf("ctl.EnableFullDuplex() = %v, want nil", err)
+		}
+	}))
+	defer cst.close()
+	res, err := cst.c.Get(cst.ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected connection error: %v", err)
+	}
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
+
+func TestResponseControllerNotSupported(t *testing.T) {
+	// A ResponseWriter that hides the underlying writer behind a
+	// wrapper without an Unwrap method supports nothing.
+	type opaqueWriter struct{ ResponseWriter }
+	ctl := NewResponseController(opaqueWriter{})
+	if err := ctl.Flush(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ctl.Flush() = %v, want ErrNotSupported", err)
+	}
+	if _, _, err := ctl.Hijack(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ctl.Hijack() = %v, want ErrNotSupported", err)
+	}
+	if err := ctl.SetReadDeadline(time.Time{}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ctl.SetReadDeadline() = %v, want ErrNotSupported", err)
+	}
+	if err := ctl.SetWriteDeadline(time.Time{}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ctl.SetWriteDeadline() = %v, want ErrNotSupported", err)
+	}
+	if err := ctl.EnableFullDuplex(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ctl.EnableFullDuplex() = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestResponseControllerEnableFullDuplex_h1(t *testing.T) {
+	testResponseControllerEnableFullDuplex(t, h1Mode)
+}
+func TestResponseControllerEnableFullDuplex_h2(t *testing.T) {
+	testResponseControllerEnableFullDuplex(t, h2Mode)
+}
+func testResponseControllerEnableFullDuplex(t *testing.T, h2 bool) {
+	defer afterTest(t)
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, req *Request) {
+		ctl := NewResponseController(w)
+		if err := ctl.EnableFullDuplex(); err != nil {
+			t.Errorf("ctl.EnableFullDuplex() = %v, want nil", err)
+		}
+		w.WriteHeader(200)
+		ctl.Flush()
+		for {
+			var buf [1]byte
+			n, err := req.Body.Read(buf[:])
+			if n != 1 || err != nil {
+				break
+			}
+			w.Write(buf[:])
+			ctl.Flush()
+		}
+	}))
+	defer cst.close()
+	pr, pw := io.Pipe()
+	req, _ := NewRequest("POST", cst.ts.URL, pr)
+	res, err := cst.c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	for i := byte(0); i < 10; i++ {
+		if _, err := pw.Write([]byte{i}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		var buf [1]byte
+		if n, err := res.Body.Read(buf[:]); n != 1 || err != nil {
+			t.Fatalf("Read: %v, %v", n, err)
+		}
+		if buf[0] != i {
+			t.Fatalf("read byte %v, want %v", buf[0], i)
+		}
+	}
+	pw.Close()
+}
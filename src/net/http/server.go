@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -390,11 +391,11 @@ func (cw *chunkWriter) Write(p []byte) (n int, err error) {
 	return
 }
 
-func (cw *chunkWriter) flush() {
+func (cw *chunkWriter) flush() error {
 	if !cw.wroteHeader {
 		cw.writeHeader(nil)
 	}
-	cw.res.conn.bufw.Flush()
+	return cw.res.conn.bufw.Flush()
 }
 
 func (cw *chunkWriter) close() {
@@ -424,6 +425,7 @@ type response struct {
 	wroteContinue    bool               // 100 Continue response was written
 	wants10KeepAlive bool               // HTTP/1.0 w/ Connection "keep-alive"
 	wantsClose       bool               // HTTP request has Connection "close"
+	fullDuplex       bool               // ResponseController.EnableFullDuplex was called
 
 	// canWriteContinue is a boolean value accessed as an atomic int32
 	// that says whether or not a 100 Continue header can be written
@@ -1317,8 +1319,11 @@ func (cw *chunkWriter) writeHeader(p []byte) {
 	// DoS reasons, so we only try up to a threshold.
 	// TODO(bradfitz): where does RFC 2616 say that? See Issue 15527
 	// about HTTP/1.x Handlers concurrently reading and writing, like
-	// HTTP/2 handlers can do. Maybe this code should be relaxed?
-	if w.req.ContentLength != 0 && !w.closeAfterReply {
+	// HTTP/2 handlers can do.
+	//
+	// If full duplex mode has been enabled with
+	// ResponseController.EnableFullDuplex, leave the request body alone.
+	if w.req.ContentLength != 0 && !w.closeAfterReply && !w.fullDuplex {
 		var discard, tooBig bool
 
 		switch bdy := w.req.Body.(type) {
@@ -1651,12 +1656,33 @@ func (w *response) closedRequestBodyEarly() bool {
 	return ok && body.didEarlyClose()
 }
 
+func (w *response) SetReadDeadline(deadline time.Time) error {
+	return w.conn.rwc.SetReadDeadline(deadline)
+}
+
+func (w *response) SetWriteDeadline(deadline time.Time) error {
+	return w.conn.rwc.SetWriteDeadline(deadline)
+}
+
+func (w *response) EnableFullDuplex() error {
+	w.fullDuplex = true
+	return nil
+}
+
 func (w *response) Flush() {
+	w.FlushError()
+}
+
+func (w *response) FlushError() error {
 	if !w.wroteHeader {
 		w.WriteHeader(StatusOK)
 	}
-	w.w.Flush()
-	w.cw.flush()
+	err := w.w.Flush()
+	e2 := w.cw.flush()
+	if err == nil {
+		err = e2
+	}
+	return err
 }
 
 func (c *conn) finalFlush() {
@@ -1851,6 +1877,14 @@ func (c *conn) serve(ctx context.Context) {
 	c.bufr = newBufioReader(c.r)
 	c.bufw = newBufioWriterSize(checkConnErrorWriter{c}, 4<<10)
 
+	protos := c.server.protocols()
+	if c.tlsState == nil && protos.UnencryptedHTTP2() && c.maybeServeUnencryptedHTTP2(ctx) {
+		return
+	}
+	if !protos.HTTP1() {
+		return
+	}
+
 	for {
 		w, err := c.readRequest(ctx)
 		if c.r.remain != c.server.initialReadLimitSize() {
@@ -1898,8 +1932,15 @@ func (c *conn) serve(ctx context.Context) {
 			}
 		}
 
-		// Expect 100 Continue support
 		req := w.req
+		if c.tlsState == nil && protos.UnencryptedHTTP2() && c.server.h2 != nil {
+			if settings, ok := h2cUpgradeSettings(req); ok {
+				c.serveH2CUpgrade(ctx, w, settings)
+				return
+			}
+		}
+
+		// Expect 100 Continue support
 		if req.expectsContinue() {
 			if req.ProtoAtLeast(1, 1) && req.ContentLength != 0 {
 				// Wrap the Body reader with one that replies on the connection
@@ -1932,6 +1973,9 @@ func (c *conn) serve(ctx context.Context) {
 			return
 		}
 		w.finishRequest()
+		// The handler may have set a write deadline through a
+		// ResponseController. Don't let it apply to later requests.
+		c.rwc.SetWriteDeadline(time.Time{})
 		if !w.shouldReuseConnection() {
 			if w.requestBodyLimitHit || w.closedRequestBodyEarly() {
 				c.closeWriteAndWait()
@@ -1959,6 +2003,101 @@ func (c *conn) serve(ctx context.Context) {
 	}
 }
 
+// maybeServeUnencryptedHTTP2 serves c as an unencrypted HTTP/2
+// connection if the client starts by sending the HTTP/2 connection
+// preface, and reports whether it did so.
+func (c *conn) maybeServeUnencryptedHTTP2(ctx context.Context) bool {
+	if c.server.h2 == nil {
+		return false
+	}
+	if d := c.server.readHeaderTimeout(); d != 0 {
+		c.rwc.SetReadDeadline(time.Now().Add(d))
+	}
+	c.r.setReadLimit(c.server.initialReadLimitSize())
+	defer c.r.setInfiniteReadLimit()
+	// Peek one byte at a time, so that a short HTTP/1 request
+	// doesn't block waiting for bytes the client will never send.
+	for i := 1; i <= len(http2ClientPreface); i++ {
+		b, err := c.bufr.Peek(i)
+		if err != nil || b[i-1] != http2ClientPreface[i-1] {
+			return false
+		}
+	}
+	c.rwc.SetReadDeadline(time.Time{})
+	c.serveUnencryptedHTTP2(ctx, nil, nil)
+	return true
+}
+
+// h2cUpgradeSettings reports whether req asks to upgrade the connection
+// to unencrypted HTTP/2, per RFC 7540 Section 3.2, and returns the
+// decoded HTTP2-Settings header. Requests with a body are not upgraded,
+// as the body would have to be buffered before switching protocols.
+func h2cUpgradeSettings(req *Request) ([]byte, bool) {
+	if !req.ProtoAtLeast(1, 1) || req.Body != NoBody {
+		return nil, false
+	}
+	conn := req.Header["Connection"]
+	if !httpguts.HeaderValuesContainsToken(req.Header["Upgrade"], "h2c") ||
+		!httpguts.HeaderValuesContainsToken(conn, "Upgrade") ||
+		!httpguts.HeaderValuesContainsToken(conn, "HTTP2-Settings") {
+		return nil, false
+	}
+	vv := req.Header["Http2-Settings"]
+	if len(vv) != 1 {
+		return nil, false
+	}
+	settings, err := base64.RawURLEncoding.DecodeString(vv[0])
+	if err != nil || len(settings)%6 != 0 {
+		return nil, false
+	}
+	return settings, true
+}
+
+// serveH2CUpgrade accepts the h2c upgrade requested by w's request
+// and serves the connection as HTTP/2, with the request itself
+// handled as the first stream.
+func (c *conn) serveH2CUpgrade(ctx context.Context, w *response, settings []byte) {
+	c.bufw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: h2c\r\n\r\n")
+	if err := c.bufw.Flush(); err != nil {
+		return
+	}
+	req := w.req
+	for _, k := range []string{"Connection", "Upgrade", "Http2-Settings"} {
+		delete(req.Header, k)
+	}
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+	c.serveUnencryptedHTTP2(ctx, req, settings)
+}
+
+// serveUnencryptedHTTP2 hands c over to the bundled HTTP/2 server.
+// Any bytes already buffered by the HTTP/1 reader are replayed first.
+func (c *conn) serveUnencryptedHTTP2(ctx context.Context, upgrade *Request, settings []byte) {
+	// Like TLS connections negotiating HTTP/2, keep the connection
+	// away from closeIdleConns. See issue 39776.
+	c.setState(c.rwc, StateActive, skipHooks)
+	buffered, _ := c.bufr.Peek(c.bufr.Buffered())
+	nc := &bufferedConn{
+		Conn: c.rwc,
+		r:    io.MultiReader(bytes.NewReader(append([]byte(nil), buffered...)), c.rwc),
+	}
+	c.server.h2.ServeConn(nc, &http2ServeConnOpts{
+		Context:        ctx,
+		BaseConfig:     c.server,
+		Handler:        serverHandler{c.server},
+		UpgradeRequest: upgrade,
+		Settings:       settings,
+	})
+}
+
+// bufferedConn is a net.Conn whose reads come from r, which replays
+// data already read from Conn before reading from it again.
+type bufferedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) { return c.r.Read(p) }
+
 func (w *response) sendExpectationFailed() {
 	// TODO(bradfitz): let ServeHTTP handlers handle
 	// requests with non-standard expectation[s]? Seems
@@ -2197,46 +2336,110 @@ func RedirectHandler(url string, code int) Handler {
 // patterns and calls the handler for the pattern that
 // most closely matches the URL.
 //
-// Patterns name fixed, rooted paths, like "/favicon.ico",
-// or rooted subtrees, like "/images/" (note the trailing slash).
-// Longer patterns take precedence over shorter ones, so that
-// if there are handlers registered for both "/images/"
-// and "/images/thumbnails/", the latter handler will be
-// called for paths beginning "/images/thumbnails/" and the
-// former will receive requests for any other paths in the
-// "/images/" subtree.
-//
-// Note that since a pattern ending in a slash names a rooted subtree,
-// the pattern "/" matches all paths not matched by other registered
-// patterns, not just the URL with Path == "/".
-//
-// If a subtree has been registered and a request is received naming the
-// subtree root without its trailing slash, ServeMux redirects that
-// request to the subtree root (adding the trailing slash). This behavior can
-// be overridden with a separate registration for the path without
-// the trailing slash. For example, registering "/images/" causes ServeMux
+// Patterns
+//
+// Patterns can match the method, host and path of a request.
+// Some examples:
+//
+//   - "/index.html" matches the path "/index.html" for any host and method.
+//   - "GET /static/" matches a GET request whose path begins with "/static/".
+//   - "example.com/" matches any request to the host "example.com".
+//   - "example.com/{$}" matches requests with host "example.com" and path "/".
+//   - "/b/{bucket}/o/{objectname...}" matches paths whose first segment is "b"
+//     and whose third segment is "o". The name "bucket" denotes the second
+//     segment and "objectname" denotes the remainder of the path.
+//
+// In general, a pattern looks like
+//
+//	[METHOD ][HOST]/[PATH]
+//
+// All three parts are optional; "/" is a valid pattern.
+// If METHOD is present, it must be followed by at least one space or tab.
+//
+// Literal (that is, non-wildcard) parts of a pattern match
+// the corresponding parts of a request case-sensitively.
+//
+// A pattern with no method matches every method. A pattern
+// with the method GET matches both GET and HEAD requests.
+// Otherwise, the method must match exactly.
+//
+// A pattern with no host matches every host.
+// A pattern with a host matches URLs on that host only.
+//
+// A path can include wildcard segments of the form {NAME} or {NAME...}.
+// For example, "/b/{bucket}/o/{objectname...}".
+// The wildcard name must be a valid Go identifier.
+// Wildcards must be full path segments: they must be preceded by a slash and followed by
+// either a slash or the end of the string.
+// For example, "/b_{bucket}" is not a valid pattern.
+//
+// Normally a wildcard matches only a single path segment,
+// ending at the next literal slash (not %2F) in the request URL.
+// But if the "..." is present, then the wildcard matches the remainder of the URL path, including slashes.
+// (Therefore it is invalid for a "..." wildcard to appear anywhere but at the end of a pattern.)
+// The match for a wildcard can be obtained by calling Request.PathValue with the wildcard's name.
+// A trailing slash in a path acts as an anonymous "..." wildcard.
+//
+// The special wildcard {$} matches only the end of the URL.
+// For example, the pattern "/{$}" matches only the path "/",
+// whereas the pattern "/" matches every path.
+//
+// For matching, both pattern paths and incoming request paths are unescaped segment by segment.
+// So, for example, the path "/a%2Fb/100%25" is treated as having two segments, "a/b" and "100%".
+// The pattern "/a%2fb/" matches it, but the pattern "/a/b/" does not.
+//
+// Precedence
+//
+// If two or more patterns match a request, then the most specific pattern takes precedence.
+// A pattern P1 is more specific than P2 if P1 matches a strict subset of P2's requests;
+// that is, if P2 matches all the requests of P1 and more.
+// If neither is more specific, then the patterns conflict.
+// There is one exception to this rule, for backwards compatibility:
+// if two patterns would otherwise conflict and one has a host while the other does not,
+// then the pattern with the host takes precedence.
+// If a pattern passed to ServeMux.Handle or ServeMux.HandleFunc conflicts with
+// another pattern that is already registered, those functions panic.
+//
+// As an example of the general rule, "/images/thumbnails/" is more specific than "/images/",
+// so both can be registered.
+// The former matches paths beginning with "/images/thumbnails/"
+// and the latter will match any other path in the "/images/" subtree.
+//
+// As another example, consider the patterns "GET /" and "/index.html":
+// both match a GET request for "/index.html", but the former pattern
+// matches all other GET and HEAD requests, while the latter matches any
+// request for "/index.html" that uses a different method.
+// The patterns conflict.
+//
+// Trailing-slash redirection
+//
+// Consider a ServeMux with a handler for a subtree, registered using a trailing slash or "..." wildcard.
+// If the ServeMux receives a request for the subtree root without a trailing slash,
+// it redirects the request by adding the trailing slash.
+// This behavior can be overridden with a separate registration for the path without
+// the trailing slash or "..." wildcard. For example, registering "/images/" causes ServeMux
 // to redirect a request for "/images" to "/images/", unless "/images" has
 // been registered separately.
 //
-// Patterns may optionally begin with a host name, restricting matches to
-// URLs on that host only. Host-specific patterns take precedence over
-// general patterns, so that a handler might register for the two patterns
-// "/codesearch" and "codesearch.google.com/" without also taking over
-// requests for "http://www.google.com/".
+// Request sanitizing
 //
 // ServeMux also takes care of sanitizing the URL request path and the Host
 // header, stripping the port number and redirecting any request containing . or
-// .. elements or repeated slashes to an equivalent, cleaner URL.
+// .. segments or repeated slashes to an equivalent, cleaner URL.
+//
+// Compatibility
+//
+// The pattern syntax and matching behavior of ServeMux changed significantly
+// when methods and wildcards were added. Patterns that previously matched
+// paths containing braces literally, such as "/{x}", are now wildcards, and
+// registrations that used to be accepted may now be reported as conflicts.
+// Setting GODEBUG=httpmuxgo121=1 restores the old syntax and behavior:
+// patterns are matched literally and the request method is ignored.
 type ServeMux struct {
-	mu    sync.RWMutex
-	m     map[string]muxEntry
-	es    []muxEntry // slice of entries sorted from longest to shortest.
-	hosts bool       // whether any patterns contain hostnames
-}
-
-type muxEntry struct {
-	h       Handler
-	pattern string
+	mu       sync.RWMutex
+	tree     routingNode
+	patterns []*pattern  // registered patterns, for conflict detection
+	mux121   serveMux121 // used if GODEBUG=httpmuxgo121=1
 }
 
 // NewServeMux allocates and returns a new ServeMux.
@@ -2282,66 +2485,6 @@ func stripHostPort(h string) string {
 	return host
 }
 
-// Find a handler on a handler map given a path string.
-// Most-specific (longest) pattern wins.
-func (mux *ServeMux) match(path string) (h Handler, pattern string) {
-	// Check for exact match first.
-	v, ok := mux.m[path]
-	if ok {
-		return v.h, v.pattern
-	}
-
-	// Check for longest valid match.  mux.es contains all patterns
-	// that end in / sorted from longest to shortest.
-	for _, e := range mux.es {
-		if strings.HasPrefix(path, e.pattern) {
-			return e.h, e.pattern
-		}
-	}
-	return nil, ""
-}
-
-// redirectToPathSlash determines if the given path needs appending "/" to it.
-// This occurs when a handler for path + "/" was already registered, but
-// not for path itself. If the path needs appending to, it creates a new
-// URL, setting the path to u.Path + "/" and returning true to indicate so.
-func (mux *ServeMux) redirectToPathSlash(host, path string, u *url.URL) (*url.URL, bool) {
-	mux.mu.RLock()
-	shouldRedirect := mux.shouldRedirectRLocked(host, path)
-	mux.mu.RUnlock()
-	if !shouldRedirect {
-		return u, false
-	}
-	path = path + "/"
-	u = &url.URL{Path: path, RawQuery: u.RawQuery}
-	return u, true
-}
-
-// shouldRedirectRLocked reports whether the given path and host should be redirected to
-// path+"/". This should happen if a handler is registered for path+"/" but
-// not path -- see comments at ServeMux.
-func (mux *ServeMux) shouldRedirectRLocked(host, path string) bool {
-	p := []string{path, host + path}
-
-	for _, c := range p {
-		if _, exist := mux.m[c]; exist {
-			return false
-		}
-	}
-
-	n := len(path)
-	if n == 0 {
-		return false
-	}
-	for _, c := range p {
-		if _, exist := mux.m[c+"/"]; exist {
-			return path[n-1] != '/'
-		}
-	}
-
-	return false
-}
-
 // Handler returns the handler to use for the given request,
 // consulting r.Method, r.Host, and r.URL.Path. It always returns
 // a non-nil handler. If the path is not in its canonical form, the
@@ -2353,61 +2496,169 @@ func (mux *ServeMux) shouldRedirectRLocked(host, path string) bool {
 //
 // Handler also returns the registered pattern that matches the
 // request or, in the case of internally-generated redirects,
-// the pattern that will match after following the redirect.
+// the path that will match after following the redirect.
 //
 // If there is no registered handler that applies to the request,
 // Handler returns a ``page not found'' handler and an empty pattern.
+// If a handler is registered for the path but not for the request's
+// method, Handler returns a ``method not allowed'' handler, which
+// replies with the Allow header set, and an empty pattern.
 func (mux *ServeMux) Handler(r *Request) (h Handler, pattern string) {
+	if use121 {
+		return mux.mux121.findHandler(r)
+	}
+	h, pattern, _, _ = mux.findHandler(r)
+	return
+}
 
+// findHandler finds a handler for a request.
+// If there is a matching handler, it returns it and the pattern that matched.
+// Otherwise it returns a Redirect or NotFound handler with the path that would match
+// after the redirect.
+func (mux *ServeMux) findHandler(r *Request) (h Handler, patStr string, _ *pattern, matches []string) {
+	var n *routingNode
+	host := r.URL.Host
+	escapedPath := r.URL.EscapedPath()
+	path := escapedPath
 	// CONNECT requests are not canonicalized.
 	if r.Method == "CONNECT" {
 		// If r.URL.Path is /tree and its handler is not registered,
 		// the /tree -> /tree/ redirect applies to CONNECT requests
 		// but the path canonicalization does not.
-		if u, ok := mux.redirectToPathSlash(r.URL.Host, r.URL.Path, r.URL); ok {
-			return RedirectHandler(u.String(), StatusMovedPermanently), u.Path
+		_, _, u := mux.matchOrRedirect(host, r.Method, path, r.URL)
+		if u != nil {
+			return RedirectHandler(u.String(), StatusMovedPermanently), u.Path, nil, nil
+		}
+		// Redo the match, this time with r.Host instead of r.URL.Host.
+		// Pass a nil URL to skip the trailing-slash redirect logic.
+		host = r.Host
+		n, matches, _ = mux.matchOrRedirect(host, r.Method, path, nil)
+	} else {
+		// All other requests have any port stripped and path cleaned
+		// before passing to mux.handler.
+		host = stripHostPort(r.Host)
+		path = cleanPath(path)
+
+		// If the given path is /tree and its handler is not registered,
+		// redirect for /tree/.
+		var u *url.URL
+		n, matches, u = mux.matchOrRedirect(host, r.Method, path, r.URL)
+		if u != nil {
+			return RedirectHandler(u.String(), StatusMovedPermanently), u.Path, nil, nil
+		}
+		if path != escapedPath {
+			// Redirect to cleaned path.
+			patStr := ""
+			if n != nil {
+				patStr = n.pattern.String()
+			}
+			u := &url.URL{Path: cleanPath(r.URL.Path), RawQuery: r.URL.RawQuery}
+			return RedirectHandler(u.String(), StatusMovedPermanently), patStr, nil, nil
+		}
+	}
+	if n == nil {
+		// We didn't find a match with the request method. To distinguish between
+		// Not Found and Method Not Allowed, see if there is another pattern that
+		// matches except for the method.
+		allowedMethods := mux.matchingMethods(host, path)
+		if len(allowedMethods) > 0 {
+			return HandlerFunc(func(w ResponseWriter, r *Request) {
+				w.Header().Set("Allow", strings.Join(allowedMethods, ", "))
+				Error(w, StatusText(StatusMethodNotAllowed), StatusMethodNotAllowed)
+			}), "", nil, nil
 		}
+		return NotFoundHandler(), "", nil, nil
+	}
+	return n.handler, n.pattern.String(), n.pattern, matches
+}
+
+// matchOrRedirect looks up a node in the tree that matches the host, method and path.
+//
+// If the url argument is non-nil, matchOrRedirect also handles the
+// trailing-slash redirect: if there is no exact match for the path, but
+// the path with a slash appended would match exactly, it returns a URL
+// to redirect to instead of a node.
+func (mux *ServeMux) matchOrRedirect(host, method, path string, u *url.URL) (_ *routingNode, matches []string, redirectTo *url.URL) {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
 
-		return mux.handler(r.Host, r.URL.Path)
+	n, matches := mux.tree.match(host, method, path)
+	// If we have an exact match, or we were asked not to try trailing-slash redirection,
+	// or the URL already has a trailing slash, then we're done.
+	if !exactMatch(n, path) && u != nil && !strings.HasSuffix(path, "/") {
+		// If there is an exact match with a trailing slash, then redirect.
+		path += "/"
+		n2, _ := mux.tree.match(host, method, path)
+		if exactMatch(n2, path) {
+			return nil, nil, &url.URL{Path: cleanPath(u.Path) + "/", RawQuery: u.RawQuery}
+		}
 	}
+	return n, matches, nil
+}
 
-	// All other requests have any port stripped and path cleaned
-	// before passing to mux.handler.
-	host := stripHostPort(r.Host)
-	path := cleanPath(r.URL.Path)
+// match returns the handler and pattern that a GET request for path,
+// with no host, would be dispatched to.
+func (mux *ServeMux) match(path string) (h Handler, pattern string) {
+	if use121 {
+		mux.mux121.mu.RLock()
+		defer mux.mux121.mu.RUnlock()
+		return mux.mux121.match(path)
+	}
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+	n, _ := mux.tree.match("", "GET", path)
+	if n == nil {
+		return nil, ""
+	}
+	return n.handler, n.pattern.String()
+}
 
-	// If the given path is /tree and its handler is not registered,
-	// redirect for /tree/.
-	if u, ok := mux.redirectToPathSlash(host, path, r.URL); ok {
-		return RedirectHandler(u.String(), StatusMovedPermanently), u.Path
+// exactMatch reports whether the node n matches the path exactly.
+// That means that either n's pattern has no multi wildcard, or the
+// multi wildcard matched the empty string.
+func exactMatch(n *routingNode, path string) bool {
+	if n == nil {
+		return false
 	}
+	// We can't directly implement the definition (empty match for multi
+	// wildcard) because we don't record a match for anonymous multis.
 
-	if path != r.URL.Path {
-		_, pattern = mux.handler(host, path)
-		u := &url.URL{Path: path, RawQuery: r.URL.RawQuery}
-		return RedirectHandler(u.String(), StatusMovedPermanently), pattern
+	// If there is no multi, the match is exact.
+	if !n.pattern.lastSegment().multi {
+		return true
 	}
 
-	return mux.handler(host, r.URL.Path)
+	// If the path doesn't end in a trailing slash, then the multi match
+	// is non-empty.
+	if len(path) > 0 && path[len(path)-1] != '/' {
+		return false
+	}
+	// Only patterns ending in {$} or a multi wildcard can
+	// match a path with a trailing slash.
+	// For the match to be exact, the number of pattern
+	// segments should be the same as the number of slashes in the path.
+	// E.g. "/a/b/{$}" and "/a/b/{...}" exactly match "/a/b/", but "/a/" does not.
+	return len(n.pattern.segments) == strings.Count(path, "/")
 }
 
-// handler is the main implementation of Handler.
-// The path is known to be in canonical form, except for CONNECT methods.
-func (mux *ServeMux) handler(host, path string) (h Handler, pattern string) {
+// matchingMethods return a sorted list of all methods that would match with the given host and path.
+func (mux *ServeMux) matchingMethods(host, path string) []string {
+	// Hold the read lock for the entire method so that the two matches are done
+	// on the same set of registered patterns.
 	mux.mu.RLock()
 	defer mux.mu.RUnlock()
-
-	// Host-specific pattern takes precedence over generic ones
-	if mux.hosts {
-		h, pattern = mux.match(host + path)
-	}
-	if h == nil {
-		h, pattern = mux.match(path)
+	ms := map[string]bool{}
+	mux.tree.matchingMethods(host, path, ms)
+	// matchOrRedirect will try appending a trailing slash if there is no match.
+	if !strings.HasSuffix(path, "/") {
+		mux.tree.matchingMethods(host, path+"/", ms)
 	}
-	if h == nil {
-		h, pattern = NotFoundHandler(), ""
+	methods := make([]string, 0, len(ms))
+	for m := range ms {
+		methods = append(methods, m)
 	}
-	return
+	sort.Strings(methods)
+	return methods
 }
 
 // ServeHTTP dispatches the request to the handler whose
@@ -2420,73 +2671,89 @@ func (mux *ServeMux) ServeHTTP(w ResponseWriter, r *Request) {
 		w.WriteHeader(StatusBadRequest)
 		return
 	}
-	h, _ := mux.Handler(r)
+	var h Handler
+	if use121 {
+		h, _ = mux.mux121.findHandler(r)
+	} else {
+		h, _, r.pat, r.matches = mux.findHandler(r)
+	}
 	h.ServeHTTP(w, r)
 }
 
 // Handle registers the handler for the given pattern.
-// If a handler already exists for pattern, Handle panics.
+// If the given pattern conflicts with one that is already registered,
+// Handle panics.
 func (mux *ServeMux) Handle(pattern string, handler Handler) {
-	mux.mu.Lock()
-	defer mux.mu.Unlock()
+	mux.register(pattern, handler)
+}
 
-	if pattern == "" {
-		panic("http: invalid pattern")
-	}
+// HandleFunc registers the handler function for the given pattern.
+// If the given pattern conflicts with one that is already registered,
+// HandleFunc panics.
+func (mux *ServeMux) HandleFunc(pattern string, handler func(ResponseWriter, *Request)) {
 	if handler == nil {
 		panic("http: nil handler")
 	}
-	if _, exist := mux.m[pattern]; exist {
-		panic("http: multiple registrations for " + pattern)
-	}
+	mux.register(pattern, HandlerFunc(handler))
+}
 
-	if mux.m == nil {
-		mux.m = make(map[string]muxEntry)
+// register registers handler for pattern, panicking on error.
+// It must be called directly by Handle, HandleFunc, or their
+// package-level counterparts, so that it can report the location
+// of the caller's registration in conflict messages.
+func (mux *ServeMux) register(patstr string, handler Handler) {
+	if use121 {
+		mux.mux121.handle(patstr, handler)
+		return
 	}
-	e := muxEntry{h: handler, pattern: pattern}
-	mux.m[pattern] = e
-	if pattern[len(pattern)-1] == '/' {
-		mux.es = appendSorted(mux.es, e)
+	if patstr == "" {
+		panic("http: invalid pattern")
 	}
-
-	if pattern[0] != '/' {
-		mux.hosts = true
+	if handler == nil {
+		panic("http: nil handler")
+	}
+	pat, err := parsePattern(patstr)
+	if err != nil {
+		panic(fmt.Sprintf("http: invalid pattern %q: %v", patstr, err))
 	}
-}
 
-func appendSorted(es []muxEntry, e muxEntry) []muxEntry {
-	n := len(es)
-	i := sort.Search(n, func(i int) bool {
-		return len(es[i].pattern) < len(e.pattern)
-	})
-	if i == n {
-		return append(es, e)
+	// Get the caller's location, for better conflict error messages.
+	// Skip register and whatever calls it.
+	if _, file, line, ok := runtime.Caller(2); ok {
+		pat.loc = fmt.Sprintf("%s:%d", file, line)
+	} else {
+		pat.loc = "unknown location"
 	}
-	// we now know that i points at where we want to insert
-	es = append(es, muxEntry{}) // try to grow the slice in place, any entry works.
-	copy(es[i+1:], es[i:])      // Move shorter entries down
-	es[i] = e
-	return es
-}
 
-// HandleFunc registers the handler function for the given pattern.
-func (mux *ServeMux) HandleFunc(pattern string, handler func(ResponseWriter, *Request)) {
-	if handler == nil {
-		panic("http: nil handler")
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+	for _, pat2 := range mux.patterns {
+		if !pat.conflictsWith(pat2) {
+			continue
+		}
+		if pat.str == pat2.str {
+			panic("http: multiple registrations for " + patstr)
+		}
+		panic(fmt.Sprintf("http: pattern %q (registered at %s) conflicts with pattern %q (registered at %s):\n%s",
+			pat, pat.loc, pat2, pat2.loc, describeConflict(pat, pat2)))
 	}
-	mux.Handle(pattern, HandlerFunc(handler))
+	mux.tree.addPattern(pat, handler)
+	mux.patterns = append(mux.patterns, pat)
 }
 
 // Handle registers the handler for the given pattern
 // in the DefaultServeMux.
 // The documentation for ServeMux explains how patterns are matched.
-func Handle(pattern string, handler Handler) { DefaultServeMux.Handle(pattern, handler) }
+func Handle(pattern string, handler Handler) { DefaultServeMux.register(pattern, handler) }
 
 // HandleFunc registers the handler function for the given pattern
 // in the DefaultServeMux.
 // The documentation for ServeMux explains how patterns are matched.
 func HandleFunc(pattern string, handler func(ResponseWriter, *Request)) {
-	DefaultServeMux.HandleFunc(pattern, handler)
+	if handler == nil {
+		panic("http: nil handler")
+	}
+	DefaultServeMux.register(pattern, HandlerFunc(handler))
 }
 
 // Serve accepts incoming HTTP connections on the listener l,
@@ -2616,15 +2883,33 @@ type Server struct {
 	// value.
 	ConnContext func(ctx context.Context, c net.Conn) context.Context
 
+	// Protocols is the set of protocols accepted by the server.
+	//
+	// If Protocols includes UnencryptedHTTP2, the server accepts
+	// unencrypted HTTP/2 connections, both from clients that start
+	// with the HTTP/2 connection preface (prior knowledge) and from
+	// HTTP/1.1 requests carrying an "Upgrade: h2c" header. The
+	// server must use the bundled HTTP/2 implementation.
+	//
+	// If Protocols is nil, the default is usually HTTP/1 and HTTP/2.
+	// If TLSNextProto is non-nil and does not contain an "h2" entry,
+	// the default is HTTP/1 only.
+	Protocols *Protocols
+
 	inShutdown atomicBool // true when server is in shutdown
 
 	disableKeepAlives int32     // accessed atomically.
 	nextProtoOnce     sync.Once // guards setupHTTP2_* init
 	nextProtoErr      error     // result of http2.ConfigureServer if used
 
+	h2 *http2Server // bundled HTTP/2 server, if configured
+
+	h3AltSvc atomic.Value // of string; Alt-Svc value for HTTP/3, if served
+
 	mu         sync.Mutex
 	listeners  map[*net.Listener]struct{}
 	activeConn map[*conn]struct{}
+	h3Servers  map[*h3Server]struct{}
 	doneChan   chan struct{}
 	onShutdown []func()
 }
@@ -2673,6 +2958,7 @@ func (srv *Server) Close() error {
 		c.rwc.Close()
 		delete(srv.activeConn, c)
 	}
+	srv.closeQUICLocked()
 	return err
 }
 
@@ -2711,6 +2997,7 @@ func (srv *Server) Shutdown(ctx context.Context) error {
 	srv.mu.Lock()
 	lnerr := srv.closeListenersLocked()
 	srv.closeDoneChanLocked()
+	srv.shutdownQUICLocked()
 	for _, f := range srv.onShutdown {
 		go f()
 	}
@@ -2783,6 +3070,9 @@ func (s *Server) closeIdleConns() bool {
 		c.rwc.Close()
 		delete(s.activeConn, c)
 	}
+	if !s.closeIdleQUICLocked() {
+		quiescent = false
+	}
 	return quiescent
 }
 
@@ -2862,6 +3152,11 @@ func (sh serverHandler) ServeHTTP(rw ResponseWriter, req *Request) {
 	if req.RequestURI == "*" && req.Method == "OPTIONS" {
 		handler = globalOptionsHandler{}
 	}
+	if req.TLS != nil && req.ProtoMajor < 3 {
+		if v := sh.srv.altSvcHeader(); v != "" {
+			rw.Header().Set("Alt-Svc", v)
+		}
+	}
 
 	if req.URL != nil && strings.Contains(req.URL.RawQuery, ";") {
 		var allowQuerySemicolonsInUse int32
@@ -3055,7 +3350,13 @@ func (srv *Server) ServeTLS(l net.Listener, certFile, keyFile string) error {
 	}
 
 	config := cloneTLSConfig(srv.TLSConfig)
-	if !strSliceContains(config.NextProtos, "http/1.1") {
+	p := srv.protocols()
+	if !p.HTTP2() {
+		config.NextProtos = strSliceRemove(config.NextProtos, http2NextProtoTLS)
+	}
+	if !p.HTTP1() {
+		config.NextProtos = strSliceRemove(config.NextProtos, "http/1.1")
+	} else if !strSliceContains(config.NextProtos, "http/1.1") {
 		config.NextProtos = append(config.NextProtos, "http/1.1")
 	}
 
@@ -3250,28 +3551,66 @@ func (srv *Server) setupHTTP2_Serve() error {
 }
 
 func (srv *Server) onceSetNextProtoDefaults_Serve() {
-	if srv.shouldConfigureHTTP2ForServe() {
+	if srv.shouldConfigureHTTP2ForServe() || srv.protocols().UnencryptedHTTP2() {
 		srv.onceSetNextProtoDefaults()
 	}
 }
 
 // onceSetNextProtoDefaults configures HTTP/2, if the user hasn't
-// configured otherwise. (by setting srv.TLSNextProto non-nil)
+// configured otherwise. (by setting srv.TLSNextProto non-nil, or
+// by leaving HTTP/2 out of srv.Protocols)
 // It must only be called via srv.nextProtoOnce (use srv.setupHTTP2_*).
 func (srv *Server) onceSetNextProtoDefaults() {
-	if omitBundledHTTP2 || strings.Contains(os.Getenv("GODEBUG"), "http2server=0") {
+	if omitBundledHTTP2 {
+		return
+	}
+	p := srv.protocols()
+	if !p.HTTP2() && !p.UnencryptedHTTP2() {
 		return
 	}
 	// Enable HTTP/2 by default if the user hasn't otherwise
 	// configured their TLSNextProto map.
-	if srv.TLSNextProto == nil {
-		conf := &http2Server{
-			NewWriteScheduler: func() http2WriteScheduler { return http2NewPriorityWriteScheduler(nil) },
-		}
-		srv.nextProtoErr = http2ConfigureServer(srv, conf)
+	if _, ok := srv.TLSNextProto[http2NextProtoTLS]; ok {
+		return
+	}
+	if srv.TLSNextProto != nil && srv.Protocols == nil {
+		return
+	}
+	conf := &http2Server{
+		NewWriteScheduler: func() http2WriteScheduler { return http2NewPriorityWriteScheduler(nil) },
+	}
+	srv.nextProtoErr = http2ConfigureServer(srv, conf)
+	if srv.nextProtoErr != nil {
+		return
+	}
+	srv.h2 = conf
+	if !p.HTTP2() {
+		// Only unencrypted HTTP/2 was requested; don't
+		// negotiate HTTP/2 over TLS.
+		delete(srv.TLSNextProto, http2NextProtoTLS)
 	}
 }
 
+// protocols returns the set of protocols the server accepts.
+func (srv *Server) protocols() Protocols {
+	if srv.Protocols != nil {
+		return *srv.Protocols
+	}
+	var p Protocols
+	p.SetHTTP1(true)
+	// The historic way of disabling HTTP/2 is to set TLSNextProto
+	// to a non-nil map with no "h2" entry, or GODEBUG=http2server=0.
+	_, hasH2 := srv.TLSNextProto[http2NextProtoTLS]
+	switch {
+	case hasH2:
+		p.SetHTTP2(true)
+	case srv.TLSNextProto != nil, strings.Contains(os.Getenv("GODEBUG"), "http2server=0"):
+	default:
+		p.SetHTTP2(true)
+	}
+	return p
+}
+
 // TimeoutHandler returns a Handler that runs h with the given time limit.
 //
 // The new Handler calls h.ServeHTTP to handle each request, but if a
@@ -3558,6 +3897,17 @@ func strSliceContains(ss []string, s string) bool {
 	return false
 }
 
+// strSliceRemove returns a copy of ss without the elements equal to s.
+func strSliceRemove(ss []string, s string) []string {
+	var out []string
+	for _, v := range ss {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 // tlsRecordHeaderLooksLikeHTTP reports whether a TLS record header
 // looks like it might've been a misdirected plaintext HTTP request.
 func tlsRecordHeaderLooksLikeHTTP(hdr [5]byte) bool {
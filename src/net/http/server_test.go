@@ -8,6 +8,12 @@ package http
 
 import (
 	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -43,3 +49,341 @@ func BenchmarkServerMatch(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestServeMuxMatch(t *testing.T) {
+	mux := NewServeMux()
+	for _, pat := range []string{
+		"/",
+		"/a",
+		"/a/",
+		"/a/b",
+		"/a/{x}",
+		"/a/{x}/c",
+		"/a/{x}/{y...}",
+		"/b/{$}",
+		"GET /item/{id}",
+		"POST /item/{id}",
+		"DELETE /item/{id}/",
+		"example.com/",
+		"example.com/item/{id}",
+		"/files/{path...}",
+	} {
+		pat := pat
+		mux.HandleFunc(pat, func(w ResponseWriter, r *Request) {
+			fmt.Fprint(w, pat)
+		})
+	}
+
+	for _, test := range []struct {
+		method, host, path string
+		wantPat            string
+		wantMatches        []string
+	}{
+		{"GET", "", "/", "/", nil},
+		{"GET", "", "/x", "/", nil},
+		{"GET", "", "/a", "/a", nil},
+		{"GET", "", "/a/", "/a/", nil},
+		{"GET", "", "/a/b", "/a/b", nil},
+		{"GET", "", "/a/c", "/a/{x}", []string{"c"}},
+		{"GET", "", "/a/d/c", "/a/{x}/c", []string{"d"}},
+		{"GET", "", "/a/d/e/f", "/a/{x}/{y...}", []string{"d", "e/f"}},
+		{"GET", "", "/a/d/", "/a/{x}/{y...}", []string{"d", ""}},
+		{"GET", "", "/b/", "/b/{$}", nil},
+		{"GET", "", "/b/c", "/", nil},
+		{"GET", "", "/item/12", "GET /item/{id}", []string{"12"}},
+		{"HEAD", "", "/item/12", "GET /item/{id}", []string{"12"}},
+		{"POST", "", "/item/12", "POST /item/{id}", []string{"12"}},
+		{"DELETE", "", "/item/12/", "DELETE /item/{id}/", []string{"12"}},
+		{"GET", "example.com", "/x", "example.com/", nil},
+		{"GET", "example.com", "/item/3", "example.com/item/{id}", []string{"3"}},
+		{"GET", "other.com", "/item/3", "GET /item/{id}", []string{"3"}},
+		{"GET", "", "/files/a%2Fb/c", "/files/{path...}", []string{"a/b/c"}},
+		{"GET", "", "/a/x%2Fy/c", "/a/{x}/c", []string{"x/y"}},
+	} {
+		n, matches := mux.tree.match(test.host, test.method, test.path)
+		if n == nil {
+			t.Errorf("%s %s%s: no match", test.method, test.host, test.path)
+			continue
+		}
+		if got := n.pattern.String(); got != test.wantPat {
+			t.Errorf("%s %s%s: got pattern %q, want %q", test.method, test.host, test.path, got, test.wantPat)
+		}
+		if !reflect.DeepEqual(matches, test.wantMatches) {
+			t.Errorf("%s %s%s: got matches %q, want %q", test.method, test.host, test.path, matches, test.wantMatches)
+		}
+	}
+}
+
+func TestServeMuxPathValue(t *testing.T) {
+	mux := NewServeMux()
+	var got map[string]string
+	mux.HandleFunc("/b/{bucket}/o/{objectname...}", func(w ResponseWriter, r *Request) {
+		got = map[string]string{
+			"bucket":     r.PathValue("bucket"),
+			"objectname": r.PathValue("objectname"),
+			"missing":    r.PathValue("missing"),
+		}
+	})
+	req := &Request{Method: "GET", URL: &url.URL{Path: "/b/mybucket/o/a/b/c.txt"}, Host: "example.com"}
+	mux.ServeHTTP(&fakeResponseWriter{header: Header{}}, req)
+	want := map[string]string{"bucket": "mybucket", "objectname": "a/b/c.txt", "missing": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	req.SetPathValue("bucket", "other")
+	req.SetPathValue("extra", "value")
+	if got := req.PathValue("bucket"); got != "other" {
+		t.Errorf("after SetPathValue, PathValue(bucket) = %q, want %q", got, "other")
+	}
+	if got := req.PathValue("extra"); got != "value" {
+		t.Errorf("after SetPathValue, PathValue(extra) = %q, want %q", got, "value")
+	}
+	r2 := req.Clone(req.Context())
+	r2.SetPathValue("bucket", "clone")
+	if got := req.PathValue("bucket"); got != "other" {
+		t.Errorf("SetPathValue on clone changed original: got %q", got)
+	}
+}
+
+func TestServeMuxMethodNotAllowed(t *testing.T) {
+	mux := NewServeMux()
+	h := HandlerFunc(func(ResponseWriter, *Request) {})
+	mux.Handle("GET /item/{id}", h)
+	mux.Handle("PUT /item/{id}", h)
+	mux.Handle("/other", h)
+
+	req := &Request{Method: "POST", URL: &url.URL{Path: "/item/1"}}
+	w := &fakeResponseWriter{header: Header{}}
+	mux.ServeHTTP(w, req)
+	if w.code != StatusMethodNotAllowed {
+		t.Errorf("got status %d, want %d", w.code, StatusMethodNotAllowed)
+	}
+	if got, want := w.header.Get("Allow"), "GET, HEAD, PUT"; got != want {
+		t.Errorf("got Allow %q, want %q", got, want)
+	}
+
+	req = &Request{Method: "POST", URL: &url.URL{Path: "/nothing"}}
+	w = &fakeResponseWriter{header: Header{}}
+	mux.ServeHTTP(w, req)
+	if w.code != StatusNotFound {
+		t.Errorf("got status %d, want %d", w.code, StatusNotFound)
+	}
+}
+
+func TestServeMuxRegisterConflicts(t *testing.T) {
+	for _, test := range []struct {
+		pat1, pat2 string
+		want       string // regexp matching the panic, or "" for no panic
+	}{
+		{"/a", "/b", ""},
+		{"/a/", "/a/b", ""},
+		{"/a/{x}", "/a/b", ""},
+		{"GET /", "POST /", ""},
+		{"example.com/", "/", ""},
+		{"/a", "/a", "multiple registrations for /a"},
+		{"/{x}", "/{y}", `"/{y}".* conflicts with pattern "/{x}".*matches the same requests`},
+		{"/a/{x}", "/{y}/b", "both match some paths"},
+		{"GET /", "/index.html", "matches more methods|matches fewer methods"},
+		{"GET /a/{x}", "HEAD /a/{x}", ""},
+	} {
+		got := func() (msg string) {
+			defer func() {
+				if r := recover(); r != nil {
+					msg = fmt.Sprint(r)
+				}
+			}()
+			mux := NewServeMux()
+			h := HandlerFunc(func(ResponseWriter, *Request) {})
+			mux.Handle(test.pat1, h)
+			mux.Handle(test.pat2, h)
+			return ""
+		}()
+		if test.want == "" {
+			if got != "" {
+				t.Errorf("%q, %q: unexpected panic: %s", test.pat1, test.pat2, got)
+			}
+			continue
+		}
+		if !regexp.MustCompile(test.want).MatchString(strings.ReplaceAll(got, "\n", " ")) {
+			t.Errorf("%q, %q: got panic %q, want match for %q", test.pat1, test.pat2, got, test.want)
+		}
+	}
+}
+
+func TestServeMuxRegisterInvalid(t *testing.T) {
+	for _, pat := range []string{
+		"",
+		"/a/{",
+		"/a/b{x}",
+		"/{x}/{x}",
+		"/{1x}",
+		"/{x...}/a",
+		"/{$}/a",
+		"B@D /",
+		"example.com",
+		"GET /a/../b",
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Handle(%q) did not panic", pat)
+				}
+			}()
+			NewServeMux().Handle(pat, NotFoundHandler())
+		}()
+	}
+}
+
+func TestServeMuxTrailingSlashRedirect(t *testing.T) {
+	mux := NewServeMux()
+	h := HandlerFunc(func(ResponseWriter, *Request) {})
+	mux.Handle("/images/", h)
+	mux.Handle("/dir/{name...}", h)
+	mux.Handle("/exact", h)
+	mux.Handle("/exact/", h)
+
+	for _, test := range []struct {
+		path, wantPattern string
+		redirect          bool
+	}{
+		{"/images", "/images/", true},
+		{"/dir", "/dir/", true},
+		{"/exact", "/exact", false},
+		{"/images/x", "/images/", false},
+	} {
+		req := &Request{Method: "GET", URL: &url.URL{Path: test.path}}
+		_, pat := mux.Handler(req)
+		if pat != test.wantPattern {
+			t.Errorf("%s: got pattern %q, want %q", test.path, pat, test.wantPattern)
+		}
+		w := &fakeResponseWriter{header: Header{}}
+		h, _ := mux.Handler(req)
+		h.ServeHTTP(w, req)
+		if gotRedirect := w.code == StatusMovedPermanently; gotRedirect != test.redirect {
+			t.Errorf("%s: got redirect %v, want %v", test.path, gotRedirect, test.redirect)
+		}
+	}
+}
+
+func TestServeMux121(t *testing.T) {
+	defer func(old bool) { use121 = old }(use121)
+	use121 = true
+
+	mux := NewServeMux()
+	h := HandlerFunc(func(ResponseWriter, *Request) {})
+	// These would be wildcards, methods and conflicts with the
+	// current syntax.
+	mux.Handle("/{x}", h)
+	mux.Handle("/{y}", h)
+	mux.Handle("GET /a", h)
+	mux.Handle("/images/", h)
+
+	for _, test := range []struct {
+		method, path, wantPattern string
+	}{
+		{"GET", "/{x}", "/{x}"},
+		{"POST", "/{y}", "/{y}"},
+		{"GET", "/b", ""},
+		{"GET", "/a", ""},
+		{"GET", "/images/x", "/images/"},
+		{"POST", "/images", "/images/"},
+	} {
+		req := &Request{Method: test.method, URL: &url.URL{Path: test.path}}
+		if _, pat := mux.Handler(req); pat != test.wantPattern {
+			t.Errorf("%s %s: got pattern %q, want %q", test.method, test.path, pat, test.wantPattern)
+		}
+	}
+	if h, pat := mux.match("/images/y"); h == nil || pat != "/images/" {
+		t.Errorf(`match("/images/y") = %v, %q; want handler, "/images/"`, h, pat)
+	}
+
+	defer func() {
+		if r := recover(); r != "http: multiple registrations for /{x}" {
+			t.Errorf("registering /{x} again: got panic %v", r)
+		}
+	}()
+	mux.Handle("/{x}", h)
+}
+
+func TestPatternMatchingMethodsSorted(t *testing.T) {
+	mux := NewServeMux()
+	h := HandlerFunc(func(ResponseWriter, *Request) {})
+	for _, m := range []string{"PUT", "DELETE", "PATCH", "GET"} {
+		mux.Handle(m+" /x", h)
+	}
+	got := mux.matchingMethods("", "/x")
+	if !sort.StringsAreSorted(got) {
+		t.Errorf("matchingMethods not sorted: %q", got)
+	}
+	want := []string{"DELETE", "GET", "HEAD", "PATCH", "PUT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type fakeResponseWriter struct {
+	header Header
+	code   int
+}
+
+func (w *fakeResponseWriter) Header() Header { return w.header }
+
+func (w *fakeResponseWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = StatusOK
+	}
+	return len(b), nil
+}
+
+func (w *fakeResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func TestProtocolsString(t *testing.T) {
+	var p Protocols
+	if got, want := p.String(), "{}"; got != want {
+		t.Errorf("empty Protocols = %q; want %q", got, want)
+	}
+	p.SetHTTP1(true)
+	p.SetUnencryptedHTTP2(true)
+	if got, want := p.String(), "{HTTP1,UnencryptedHTTP2}"; got != want {
+		t.Errorf("Protocols = %q; want %q", got, want)
+	}
+	p.SetHTTP1(false)
+	if p.HTTP1() || p.HTTP2() || !p.UnencryptedHTTP2() {
+		t.Errorf("after SetHTTP1(false), Protocols = %v", p)
+	}
+}
+
+func TestH2CUpgradeSettings(t *testing.T) {
+	tests := []struct {
+		header Header
+		proto  int // minor version
+		body   bool
+		ok     bool
+	}{
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAABk"}}, 1, false, true},
+		{Header{"Connection": {"upgrade", "http2-settings"}, "Upgrade": {"websocket, h2c"}, "Http2-Settings": {""}}, 1, false, true},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAABk"}}, 0, false, false},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAABk"}}, 1, true, false},
+		{Header{"Connection": {"Upgrade"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAABk"}}, 1, false, false},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2"}, "Http2-Settings": {"AAMAAABk"}}, 1, false, false},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}}, 1, false, false},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAABk", "AAMAAABk"}}, 1, false, false},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAA"}}, 1, false, false},
+		{Header{"Connection": {"Upgrade, HTTP2-Settings"}, "Upgrade": {"h2c"}, "Http2-Settings": {"AAMAAABk=="}}, 1, false, false},
+	}
+	for i, tt := range tests {
+		req := &Request{Header: tt.header, ProtoMajor: 1, ProtoMinor: tt.proto, Body: NoBody}
+		if tt.body {
+			req.Body = io.NopCloser(strings.NewReader("x"))
+		}
+		_, ok := h2cUpgradeSettings(req)
+		if ok != tt.ok {
+			t.Errorf("%d. h2cUpgradeSettings(%v) = %v; want %v", i, tt.header, ok, tt.ok)
+		}
+	}
+}
@@ -12,6 +12,7 @@ package http
 import (
 	"bufio"
 	"compress/gzip"
+	"compress/zstd"
 	"container/list"
 	"context"
 	"crypto/tls"
@@ -189,6 +190,14 @@ type Transport struct {
 	// uncompressed.
 	DisableCompression bool
 
+	// EnableZstdCompression, if true, makes the Transport offer
+	// Zstandard as well as gzip when it adds its own Accept-Encoding
+	// header, sending "Accept-Encoding: zstd, gzip". A response with
+	// "Content-Encoding: zstd" to such a request is transparently
+	// decoded in the Response.Body, as for gzip.
+	// It has no effect if DisableCompression is true.
+	EnableZstdCompression bool
+
 	// MaxIdleConns controls the maximum number of idle (keep-alive)
 	// connections across all hosts. Zero means no limit.
 	MaxIdleConns int
@@ -275,12 +284,31 @@ type Transport struct {
 	h2transport        h2Transport // non-nil if http2 wired up
 	tlsNextProtoWasNil bool        // whether TLSNextProto was nil when the Once fired
 
+	h3 h3Transport // HTTP/3 alternative services and connections
+
 	// ForceAttemptHTTP2 controls whether HTTP/2 is enabled when a non-zero
 	// Dial, DialTLS, or DialContext func or TLSClientConfig is provided.
 	// By default, use of any those fields conservatively disables HTTP/2.
 	// To use a custom dialer or TLS config and still attempt HTTP/2
 	// upgrades, set this to true.
 	ForceAttemptHTTP2 bool
+
+	// Protocols is the set of protocols supported by the transport.
+	//
+	// If Protocols includes UnencryptedHTTP2 and does not include HTTP1,
+	// the transport will use unencrypted HTTP/2 (prior knowledge) for
+	// requests to http:// URLs that are not sent through a proxy.
+	//
+	// If Protocols includes HTTP3, the transport sends https requests
+	// that are not sent through a proxy over HTTP/3 once the server
+	// has advertised an HTTP/3 endpoint in an Alt-Svc header.
+	// Requests go back to TCP if that endpoint cannot be reached.
+	//
+	// If Protocols is nil, the default is usually HTTP/1 and HTTP/2.
+	// If TLSNextProto is non-nil and does not contain an "h2" entry,
+	// or if a custom dialer or TLSClientConfig is set and
+	// ForceAttemptHTTP2 is false, the default is HTTP/1 only.
+	Protocols *Protocols
 }
 
 // A cancelKey is the key of the reqCanceler map.
@@ -304,6 +332,31 @@ func (t *Transport) readBufferSize() int {
 	return 4 << 10
 }
 
+// protocols returns the set of protocols t uses, resolving the
+// historic defaults when t.Protocols is nil.
+func (t *Transport) protocols() Protocols {
+	if t.Protocols != nil {
+		return *t.Protocols
+	}
+	var p Protocols
+	p.SetHTTP1(true)
+	switch {
+	case t.TLSNextProto != nil:
+		// Setting TLSNextProto to a non-nil map is the
+		// documented way to disable http2.
+		if _, ok := t.TLSNextProto["h2"]; ok {
+			p.SetHTTP2(true)
+		}
+	case !t.ForceAttemptHTTP2 && (t.TLSClientConfig != nil || t.Dial != nil || t.DialContext != nil || t.hasCustomTLSDialer()):
+		// Custom dialers or TLS configs disable http2 by
+		// default. See onceSetNextProtoDefaults.
+	case strings.Contains(os.Getenv("GODEBUG"), "http2client=0"):
+	default:
+		p.SetHTTP2(true)
+	}
+	return p
+}
+
 // Clone returns a deep copy of t's exported fields.
 func (t *Transport) Clone() *Transport {
 	t.nextProtoOnce.Do(t.onceSetNextProtoDefaults)
@@ -316,6 +369,7 @@ func (t *Transport) Clone() *Transport {
 		TLSHandshakeTimeout:    t.TLSHandshakeTimeout,
 		DisableKeepAlives:      t.DisableKeepAlives,
 		DisableCompression:     t.DisableCompression,
+		EnableZstdCompression:  t.EnableZstdCompression,
 		MaxIdleConns:           t.MaxIdleConns,
 		MaxIdleConnsPerHost:    t.MaxIdleConnsPerHost,
 		MaxConnsPerHost:        t.MaxConnsPerHost,
@@ -329,6 +383,10 @@ func (t *Transport) Clone() *Transport {
 		WriteBufferSize:        t.WriteBufferSize,
 		ReadBufferSize:         t.ReadBufferSize,
 	}
+	if t.Protocols != nil {
+		t2.Protocols = new(Protocols)
+		*t2.Protocols = *t.Protocols
+	}
 	if t.TLSClientConfig != nil {
 		t2.TLSClientConfig = t.TLSClientConfig.Clone()
 	}
@@ -379,12 +437,16 @@ func (t *Transport) onceSetNextProtoDefaults() {
 		}
 	}
 
-	if t.TLSNextProto != nil {
+	p := t.protocols()
+	if !p.HTTP2() && !p.UnencryptedHTTP2() {
+		return
+	}
+	if t.TLSNextProto != nil && t.Protocols == nil {
 		// This is the documented way to disable http2 on a
 		// Transport.
 		return
 	}
-	if !t.ForceAttemptHTTP2 && (t.TLSClientConfig != nil || t.Dial != nil || t.DialContext != nil || t.hasCustomTLSDialer()) {
+	if !t.ForceAttemptHTTP2 && t.Protocols == nil && (t.TLSClientConfig != nil || t.Dial != nil || t.DialContext != nil || t.hasCustomTLSDialer()) {
 		// Be conservative and don't automatically enable
 		// http2 if they've specified a custom TLS config or
 		// custom dialers. Let them opt-in themselves via
@@ -402,6 +464,15 @@ func (t *Transport) onceSetNextProtoDefaults() {
 		return
 	}
 	t.h2transport = t2
+	if p.UnencryptedHTTP2() {
+		t2.AllowHTTP = true
+	}
+	if !p.HTTP2() {
+		// Only unencrypted HTTP/2 was requested: don't offer
+		// "h2" in TLS handshakes.
+		delete(t.TLSNextProto, "h2")
+		t.TLSClientConfig.NextProtos = strSliceRemove(t.TLSClientConfig.NextProtos, "h2")
+	}
 
 	// Auto-configure the http2.Transport's MaxHeaderListSize from
 	// the http.Transport's MaxResponseHeaderBytes. They don't
@@ -535,8 +606,19 @@ func (t *Transport) roundTrip(req *Request) (*Response, error) {
 	cancelKey := cancelKey{origReq}
 	req = setupRewindBody(req)
 
+	if isHTTP && req.URL.Host != "" {
+		if resp, err := t.roundTripHTTP3(req); err != errH3Skip {
+			if err == nil {
+				resp.Request = origReq
+			}
+			return resp, err
+		}
+	}
 	if altRT := t.alternateRoundTripper(req); altRT != nil {
 		if resp, err := altRT.RoundTrip(req); err != ErrSkipAltProtocol {
+			if err == nil {
+				t.noteAltSvc(req, resp)
+			}
 			return resp, err
 		}
 		var err error
@@ -595,6 +677,7 @@ func (t *Transport) roundTrip(req *Request) (*Response, error) {
 		}
 		if err == nil {
 			resp.Request = origReq
+			t.noteAltSvc(req, resp)
 			return resp, nil
 		}
 
@@ -773,6 +856,7 @@ func (t *Transport) CloseIdleConnections() {
 	if t2 := t.h2transport; t2 != nil {
 		t2.CloseIdleConnections()
 	}
+	t.h3.closeIdle()
 }
 
 // CancelRequest cancels an in-flight request by closing its connection.
@@ -1730,6 +1814,18 @@ func (t *Transport) dialConn(ctx context.Context, cm connectMethod) (pconn *pers
 		}
 	}
 
+	p := t.protocols()
+	if cm.proxyURL == nil && cm.scheme() == "http" && p.UnencryptedHTTP2() && !p.HTTP1() {
+		if t2, ok := t.h2transport.(*http2Transport); ok {
+			alt, err := addUnencryptedHTTP2Conn(t2, cm.targetAddr, pconn.conn)
+			if err != nil {
+				// pconn.conn was closed by addUnencryptedHTTP2Conn.
+				return nil, err
+			}
+			return &persistConn{t: t, cacheKey: pconn.cacheKey, alt: t.offerZstdHTTP2(alt)}, nil
+		}
+	}
+
 	if s := pconn.tlsState; s != nil && s.NegotiatedProtocolIsMutual && s.NegotiatedProtocol != "" {
 		if next, ok := t.TLSNextProto[s.NegotiatedProtocol]; ok {
 			alt := next(cm.targetAddr, pconn.conn.(*tls.Conn))
@@ -1737,10 +1833,18 @@ func (t *Transport) dialConn(ctx context.Context, cm connectMethod) (pconn *pers
 				// pconn.conn was closed by next (http2configureTransports.upgradeFn).
 				return nil, e.RoundTripErr()
 			}
+			if _, ok := alt.(*http2Transport); ok {
+				alt = t.offerZstdHTTP2(alt)
+			}
 			return &persistConn{t: t, cacheKey: pconn.cacheKey, alt: alt}, nil
 		}
 	}
 
+	if !p.HTTP1() {
+		pconn.conn.Close()
+		return nil, errors.New("net/http: HTTP/1.x transport disabled by Transport.Protocols")
+	}
+
 	pconn.br = bufio.NewReaderSize(pconn, t.readBufferSize())
 	pconn.bw = bufio.NewWriterSize(persistConnWriter{pconn}, t.writeBufferSize())
 
@@ -2193,6 +2297,12 @@ func (pc *persistConn) readLoop() {
 			resp.Header.Del("Content-Length")
 			resp.ContentLength = -1
 			resp.Uncompressed = true
+		} else if rc.addedZstd && ascii.EqualFold(resp.Header.Get("Content-Encoding"), "zstd") {
+			resp.Body = &zstdReader{body: body}
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
 		}
 
 		select {
@@ -2472,6 +2582,10 @@ type requestAndChan struct {
 	// set it, only then do we transparently decode the gzip.
 	addedGzip bool
 
+	// whether the Transport also offered zstd in the Accept-Encoding
+	// header it added.
+	addedZstd bool
+
 	// Optional blocking chan for Expect: 100-continue (for send).
 	// If the request has an "Expect: 100-continue" header and
 	// the server responds 100 Continue, readLoop send a value
@@ -2544,7 +2658,7 @@ func (pc *persistConn) roundTrip(req *transportRequest) (resp *Response, err err
 	// own value for Accept-Encoding. We only attempt to
 	// uncompress the gzip stream if we were the layer that
 	// requested it.
-	requestedGzip := false
+	requestedGzip, requestedZstd := false, false
 	if !pc.t.DisableCompression &&
 		req.Header.Get("Accept-Encoding") == "" &&
 		req.Header.Get("Range") == "" &&
@@ -2562,7 +2676,12 @@ func (pc *persistConn) roundTrip(req *transportRequest) (resp *Response, err err
 		// auto-decoding a portion of a gzipped document will just fail
 		// anyway. See https://golang.org/issue/8923
 		requestedGzip = true
-		req.extraHeaders().Set("Accept-Encoding", "gzip")
+		if pc.t.EnableZstdCompression {
+			requestedZstd = true
+			req.extraHeaders().Set("Accept-Encoding", "zstd, gzip")
+		} else {
+			req.extraHeaders().Set("Accept-Encoding", "gzip")
+		}
 	}
 
 	var continueCh chan struct{}
@@ -2600,6 +2719,7 @@ func (pc *persistConn) roundTrip(req *transportRequest) (resp *Response, err err
 		cancelKey:  req.cancelKey,
 		ch:         resc,
 		addedGzip:  requestedGzip,
+		addedZstd:  requestedZstd,
 		continueCh: continueCh,
 		callerGone: gone,
 	}
@@ -2836,6 +2956,41 @@ func (gz *gzipReader) Close() error {
 	return gz.body.Close()
 }
 
+// zstdReader wraps a response body so it can lazily
+// call zstd.NewReader on the first call to Read
+type zstdReader struct {
+	_    incomparable
+	body *bodyEOFSignal // underlying HTTP/1 response body framing
+	zr   *zstd.Reader   // lazily-initialized zstd reader
+	zerr error          // any error from zstd.NewReader; sticky
+}
+
+func (zs *zstdReader) Read(p []byte) (n int, err error) {
+	if zs.zr == nil {
+		if zs.zerr == nil {
+			zs.zr, zs.zerr = zstd.NewReader(zs.body)
+		}
+		if zs.zerr != nil {
+			return 0, zs.zerr
+		}
+	}
+
+	zs.body.mu.Lock()
+	if zs.body.closed {
+		err = errReadOnClosedResBody
+	}
+	zs.body.mu.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+	return zs.zr.Read(p)
+}
+
+func (zs *zstdReader) Close() error {
+	return zs.body.Close()
+}
+
 type tlsHandshakeTimeoutError struct{}
 
 func (tlsHandshakeTimeoutError) Timeout() bool   { return true }
@@ -13,6 +13,7 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"compress/zstd"
 	"context"
 	"crypto/rand"
 	"crypto/tls"
@@ -3998,6 +3999,46 @@ func TestTransportContentEncodingCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestTransportZstd_h1(t *testing.T) { testTransportZstd(t, h1Mode) }
+func TestTransportZstd_h2(t *testing.T) { testTransportZstd(t, h2Mode) }
+
+func testTransportZstd(t *testing.T, h2 bool) {
+	setParallel(t)
+	defer afterTest(t)
+	const encodedString = "Hello Gopher"
+	cst := newClientServerTest(t, h2, HandlerFunc(func(w ResponseWriter, r *Request) {
+		if g, e := r.Header.Get("Accept-Encoding"), "zstd, gzip"; g != e {
+			t.Errorf("Accept-Encoding = %q, want %q", g, e)
+		}
+		w.Header().Set("Content-Encoding", "zstd")
+		zw := zstd.NewWriter(w)
+		zw.Write([]byte(encodedString))
+		zw.Close()
+	}), func(tr *Transport) {
+		tr.EnableZstdCompression = true
+	})
+	defer cst.close()
+
+	res, err := cst.c.Get(cst.ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != encodedString {
+		t.Fatalf("Expected body %q, got: %q", encodedString, string(body))
+	}
+	if !res.Uncompressed {
+		t.Error("res.Uncompressed = false; want true")
+	}
+	if g := res.Header.Get("Content-Encoding"); g != "" {
+		t.Errorf("Content-Encoding = %q; want none", g)
+	}
+}
+
 func TestTransportDialCancelRace(t *testing.T) {
 	defer afterTest(t)
 
@@ -5887,6 +5928,7 @@ func TestTransportClone(t *testing.T) {
 		TLSHandshakeTimeout:    time.Second,
 		DisableKeepAlives:      true,
 		DisableCompression:     true,
+		EnableZstdCompression:  true,
 		MaxIdleConns:           1,
 		MaxIdleConnsPerHost:    1,
 		MaxConnsPerHost:        1,
@@ -5902,6 +5944,7 @@ func TestTransportClone(t *testing.T) {
 		},
 		ReadBufferSize:  1,
 		WriteBufferSize: 1,
+		Protocols:       &Protocols{},
 	}
 	tr2 := tr.Clone()
 	rv := reflect.ValueOf(tr2).Elem()
@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"internal/testenv"
+	"net/netip"
 	"reflect"
 	"runtime"
 	"sort"
@@ -1267,3 +1268,30 @@ func TestResolverLookupIP(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupNetIP(t *testing.T) {
+	origTestHookLookupIP := testHookLookupIP
+	defer func() { testHookLookupIP = origTestHookLookupIP }()
+	testHookLookupIP = func(ctx context.Context, fn func(context.Context, string, string) ([]IPAddr, error), network, host string) ([]IPAddr, error) {
+		return []IPAddr{
+			{IP: IPv4(127, 0, 0, 1)},
+			{IP: ParseIP("fe80::1"), Zone: "eth0"},
+		}, nil
+	}
+
+	got, err := DefaultResolver.LookupNetIP(context.Background(), "ip", "golang.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Addr{
+		netip.MustParseAddr("127.0.0.1"),
+		netip.MustParseAddr("fe80::1%eth0"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LookupNetIP = %v; want %v", got, want)
+	}
+
+	if _, err := DefaultResolver.LookupNetIP(context.Background(), "tcp", "golang.org"); err == nil {
+		t.Error("LookupNetIP with network tcp succeeded; want error")
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netip
+
+import "internal/intern"
+
+var ParseAddrSlow = parseAddrSlow
+
+func Mk128(hi, lo uint64) uint128 {
+	return uint128{hi, lo}
+}
+
+func MkAddr(u uint128, z *intern.Value) Addr {
+	return Addr{u, z}
+}
+
+func ZoneOf(zone string) *intern.Value {
+	return intern.GetByString(zone)
+}
+
+var (
+	Z4    = z4
+	Z6noz = z6noz
+)
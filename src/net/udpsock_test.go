@@ -10,6 +10,7 @@ package net
 import (
 	"errors"
 	"internal/testenv"
+	"net/netip"
 	"os"
 	"reflect"
 	"runtime"
@@ -495,3 +496,145 @@ func BenchmarkWriteToReadFromUDP(b *testing.B) {
 		}
 	}
 }
+
+func TestUDPAddrPortRoundTrip(t *testing.T) {
+	tests := []struct {
+		addr *UDPAddr
+		ap   netip.AddrPort
+	}{
+		{&UDPAddr{IP: IPv4(192, 0, 2, 1).To4(), Port: 53}, netip.MustParseAddrPort("192.0.2.1:53")},
+		{&UDPAddr{IP: ParseIP("2001:db8::1"), Port: 443}, netip.MustParseAddrPort("[2001:db8::1]:443")},
+		{&UDPAddr{IP: ParseIP("fe80::1"), Port: 80, Zone: "eth0"}, netip.MustParseAddrPort("[fe80::1%eth0]:80")},
+	}
+	for _, tt := range tests {
+		if got := tt.addr.AddrPort(); got != tt.ap {
+			t.Errorf("(%v).AddrPort() = %v; want %v", tt.addr, got, tt.ap)
+		}
+		if got := UDPAddrFromAddrPort(tt.ap); !reflect.DeepEqual(got, tt.addr) {
+			t.Errorf("UDPAddrFromAddrPort(%v) = %#v; want %#v", tt.ap, got, tt.addr)
+		}
+		ta := &TCPAddr{IP: tt.addr.IP, Port: tt.addr.Port, Zone: tt.addr.Zone}
+		if got := ta.AddrPort(); got != tt.ap {
+			t.Errorf("(%v).AddrPort() = %v; want %v", ta, got, tt.ap)
+		}
+		if got := TCPAddrFromAddrPort(tt.ap); !reflect.DeepEqual(got, ta) {
+			t.Errorf("TCPAddrFromAddrPort(%v) = %#v; want %#v", tt.ap, got, ta)
+		}
+	}
+	if got := (*UDPAddr)(nil).AddrPort(); got.IsValid() {
+		t.Errorf("nil UDPAddr AddrPort = %v; want zero", got)
+	}
+}
+
+func TestUDPConnAddrPort(t *testing.T) {
+	switch runtime.GOOS {
+	case "js":
+		t.Skipf("not supported on %s", runtime.GOOS)
+	}
+
+	c, err := ListenUDP("udp4", &UDPAddr{IP: IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	addr := c.LocalAddr().(*UDPAddr).AddrPort()
+
+	b := []byte("UDP ADDRPORT TEST")
+	if n, err := c.WriteToUDPAddrPort(b, addr); err != nil || n != len(b) {
+		t.Fatalf("WriteToUDPAddrPort = %d, %v; want %d, nil", n, err, len(b))
+	}
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 64)
+	n, from, err := c.ReadFromUDPAddrPort(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf[:n]) != string(b) {
+		t.Errorf("ReadFromUDPAddrPort read %q; want %q", buf[:n], b)
+	}
+	if from != addr {
+		t.Errorf("ReadFromUDPAddrPort from %v; want %v", from, addr)
+	}
+
+	if runtime.GOOS == "plan9" || runtime.GOOS == "windows" {
+		return
+	}
+	if _, _, err := c.WriteMsgUDPAddrPort(b, nil, addr); err != nil {
+		t.Fatal(err)
+	}
+	n, _, _, from, err = c.ReadMsgUDPAddrPort(buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf[:n]) != string(b) || from != addr {
+		t.Errorf("ReadMsgUDPAddrPort = %q, %v; want %q, %v", buf[:n], from, b, addr)
+	}
+
+	if _, err := c.WriteToUDPAddrPort(b, netip.AddrPort{}); err == nil {
+		t.Error("WriteToUDPAddrPort with invalid address succeeded")
+	}
+}
+
+func TestAllocs(t *testing.T) {
+	switch runtime.GOOS {
+	case "plan9", "js", "windows":
+		t.Skipf("not supported on %s", runtime.GOOS)
+	}
+	conn, err := ListenUDP("udp4", &UDPAddr{IP: IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	addr := conn.LocalAddr()
+	addrPort := addr.(*UDPAddr).AddrPort()
+	buf := make([]byte, 8)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		_, err := conn.WriteToUDPAddrPort(buf, addrPort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = conn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if got := int(allocs); got != 0 {
+		t.Errorf("WriteToUDPAddrPort/ReadFromUDPAddrPort allocated %d objects", got)
+	}
+
+	allocs = testing.AllocsPerRun(1000, func() {
+		_, err := conn.WriteTo(buf, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = conn.ReadFromUDP(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if got := int(allocs); got != 1 {
+		t.Errorf("WriteTo/ReadFromUDP allocated %d objects", got)
+	}
+}
+
+func BenchmarkWriteToReadFromUDPAddrPort(b *testing.B) {
+	conn, err := ListenUDP("udp4", &UDPAddr{IP: IPv4(127, 0, 0, 1)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	addr := conn.LocalAddr().(*UDPAddr).AddrPort()
+	buf := make([]byte, 8)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := conn.WriteToUDPAddrPort(buf, addr)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _, err = conn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
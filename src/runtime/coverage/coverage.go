@@ -0,0 +1,208 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package coverage records the coverage counters of programs built
+// with "go build -cover" and writes them out as coverage data files.
+//
+// An instrumented program writes its coverage data to the directory
+// named by the GOCOVERDIR environment variable when it exits, either
+// by returning from main.main or by calling os.Exit. Programs that
+// exit by other means, such as an unrecovered panic, write no data.
+// Long-running programs, such as servers, that do not exit normally can
+// use WriteMetaDir and WriteCountersDir to write the data explicitly.
+//
+// The files can be merged, subtracted, and converted to the textual
+// profile format read by "go tool cover" using "go tool covdata".
+package coverage
+
+import (
+	"errors"
+	"fmt"
+	"internal/coverage"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A file records the counters of one instrumented source file.
+type file struct {
+	name     string
+	counter  []uint32
+	pos      []uint32
+	numStmts []uint16
+}
+
+var state struct {
+	mu    sync.Mutex
+	mode  string
+	files []file
+	names map[string]bool
+}
+
+// RegisterFile records the coverage counters of one source file of a
+// program built with "go build -cover". The counters and block positions
+// are those declared by "go tool cover" for the file, and mode is the
+// coverage mode of the build.
+//
+// RegisterFile is called by code generated by the go command during
+// program initialization; it is not intended to be called directly.
+func RegisterFile(mode, fileName string, counter, pos []uint32, numStmts []uint16) {
+	if 3*len(counter) != len(pos) || len(counter) != len(numStmts) {
+		panic("coverage: mismatched sizes")
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.mode != "" && state.mode != mode {
+		panic("coverage: inconsistent coverage modes " + state.mode + " and " + mode)
+	}
+	if state.names[fileName] {
+		// Already registered.
+		return
+	}
+	if state.names == nil {
+		state.names = make(map[string]bool)
+		runtime_addExitHook(emitOnExit, true)
+	}
+	state.mode = mode
+	state.names[fileName] = true
+	state.files = append(state.files, file{fileName, counter, pos, numStmts})
+}
+
+// errNoCover is returned by the Write functions when
+// no coverage counters have been registered.
+var errNoCover = errors.New("coverage: program not built with -cover")
+
+// WriteMetaDir writes the coverage meta-data file of the running
+// program to the directory dir, unless the file already exists there.
+// It returns an error if the program was not built with -cover.
+func WriteMetaDir(dir string) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.names == nil {
+		return errNoCover
+	}
+	data, h := metaData()
+	name := coverage.MetaFileName(h)
+	if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+		return nil
+	}
+	return writeFile(dir, name, data)
+}
+
+// WriteCountersDir writes a new coverage counter data file with
+// the current values of the counters of the running program to the
+// directory dir. The data is only usable together with the program's
+// meta-data file, which WriteMetaDir writes.
+// It returns an error if the program was not built with -cover.
+func WriteCountersDir(dir string) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.names == nil {
+		return errNoCover
+	}
+	_, h := metaData()
+	c := &coverage.CounterData{MetaHash: h}
+	for _, f := range state.files {
+		for i := range f.counter {
+			var v uint32
+			if state.mode == "atomic" {
+				v = atomic.LoadUint32(&f.counter[i])
+			} else {
+				v = f.counter[i]
+			}
+			c.Counters = append(c.Counters, v)
+		}
+	}
+	name := coverage.CounterFileName(h, os.Getpid(), time.Now().UnixNano())
+	return writeFile(dir, name, c.Marshal())
+}
+
+// ClearCounters resets all the coverage counters of the running program
+// to zero. It returns an error if the program was not built with -cover.
+//
+// ClearCounters does not stop other goroutines from updating the
+// counters while it runs. Unless the program was built with
+// -covermode=atomic, it must not be called while they may be running.
+func ClearCounters() error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.names == nil {
+		return errNoCover
+	}
+	for _, f := range state.files {
+		for i := range f.counter {
+			if state.mode == "atomic" {
+				atomic.StoreUint32(&f.counter[i], 0)
+			} else {
+				f.counter[i] = 0
+			}
+		}
+	}
+	return nil
+}
+
+// metaData returns the meta-data file of the running program and its hash.
+// state.mu must be held.
+func metaData() ([]byte, coverage.Hash) {
+	m := &coverage.MetaData{Mode: state.mode}
+	for _, f := range state.files {
+		cf := coverage.File{Name: f.name, Blocks: make([]coverage.Block, len(f.counter))}
+		for i := range cf.Blocks {
+			cf.Blocks[i] = coverage.Block{
+				StartLine: f.pos[3*i+0],
+				StartCol:  f.pos[3*i+2] & 0xFFFF,
+				EndLine:   f.pos[3*i+1],
+				EndCol:    f.pos[3*i+2] >> 16 & 0xFFFF,
+				NumStmt:   uint32(f.numStmts[i]),
+			}
+		}
+		m.Files = append(m.Files, cf)
+	}
+	data := m.Marshal()
+	return data, coverage.HashMetaData(data)
+}
+
+// writeFile writes data to the file name in dir. It writes to a
+// temporary file first, so that readers never see a partial file.
+func writeFile(dir, name string, data []byte) error {
+	f, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// emitOnExit writes the coverage data of the program to $GOCOVERDIR.
+// It is run by the runtime when the program exits.
+func emitOnExit() {
+	dir := os.Getenv("GOCOVERDIR")
+	if dir == "" {
+		fmt.Fprintf(os.Stderr, "warning: GOCOVERDIR not set, no coverage data emitted\n")
+		return
+	}
+	if err := WriteMetaDir(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "error: coverage meta-data emit failed: %v\n", err)
+		return
+	}
+	if err := WriteCountersDir(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "error: coverage counter data emit failed: %v\n", err)
+	}
+}
+
+// runtime_addExitHook registers f to be run when the program exits.
+// Implemented in the runtime.
+func runtime_addExitHook(f func(), runOnNonZeroExit bool)
@@ -0,0 +1,235 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"internal/coverage"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testFiles returns the counters of a program with two source files.
+// The position of each block is encoded as by "go tool cover": start
+// line, end line, then start and end column packed into one word.
+func testFiles() []file {
+	return []file{
+		{
+			name:     "example.com/m/a.go",
+			counter:  []uint32{3, 0},
+			pos:      []uint32{1, 2, 5 | 10<<16, 4, 6, 2 | 1<<16},
+			numStmts: []uint16{2, 1},
+		},
+		{
+			name:     "example.com/m/b.go",
+			counter:  []uint32{1},
+			pos:      []uint32{7, 7, 1 | 30<<16},
+			numStmts: []uint16{1},
+		},
+	}
+}
+
+var testMeta = &coverage.MetaData{
+	Mode: "count",
+	Files: []coverage.File{
+		{
+			Name: "example.com/m/a.go",
+			Blocks: []coverage.Block{
+				{StartLine: 1, StartCol: 5, EndLine: 2, EndCol: 10, NumStmt: 2},
+				{StartLine: 4, StartCol: 2, EndLine: 6, EndCol: 1, NumStmt: 1},
+			},
+		},
+		{
+			Name: "example.com/m/b.go",
+			Blocks: []coverage.Block{
+				{StartLine: 7, StartCol: 1, EndLine: 7, EndCol: 30, NumStmt: 1},
+			},
+		},
+	},
+}
+
+// setState makes files the registered counters for the rest of the
+// test. Unlike RegisterFile, it does not install an exit hook, which
+// would write coverage data when the test binary exits.
+func setState(t *testing.T, mode string, files []file) {
+	state.mu.Lock()
+	oldMode, oldFiles, oldNames := state.mode, state.files, state.names
+	state.mode, state.files, state.names = mode, files, nil
+	if files != nil {
+		state.names = make(map[string]bool)
+		for _, f := range files {
+			state.names[f.name] = true
+		}
+	}
+	state.mu.Unlock()
+	t.Cleanup(func() {
+		state.mu.Lock()
+		state.mode, state.files, state.names = oldMode, oldFiles, oldNames
+		state.mu.Unlock()
+	})
+}
+
+// readDir returns the meta-data and counter data files in dir.
+func readDir(t *testing.T, dir string) (metas map[string]*coverage.MetaData, counters map[string]*coverage.CounterData) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metas = make(map[string]*coverage.MetaData)
+	counters = make(map[string]*coverage.CounterData)
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h, ok := coverage.ParseMetaFileName(e.Name()); ok {
+			if coverage.HashMetaData(data) != h {
+				t.Errorf("%s: contents do not match file name", e.Name())
+			}
+			m, err := coverage.UnmarshalMetaData(data)
+			if err != nil {
+				t.Fatalf("%s: %v", e.Name(), err)
+			}
+			metas[e.Name()] = m
+		} else if _, ok := coverage.ParseCounterFileName(e.Name()); ok {
+			c, err := coverage.UnmarshalCounterData(data)
+			if err != nil {
+				t.Fatalf("%s: %v", e.Name(), err)
+			}
+			counters[e.Name()] = c
+		} else {
+			t.Errorf("unexpected file %s", e.Name())
+		}
+	}
+	return metas, counters
+}
+
+func TestWriteMetaDir(t *testing.T) {
+	setState(t, "count", testFiles())
+	dir := t.TempDir()
+	if err := WriteMetaDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	metas, counters := readDir(t, dir)
+	if len(metas) != 1 || len(counters) != 0 {
+		t.Fatalf("got %d meta-data and %d counter files, want 1 and 0", len(metas), len(counters))
+	}
+	for name, m := range metas {
+		if !reflect.DeepEqual(m, testMeta) {
+			t.Errorf("%s = %+v, want %+v", name, m, testMeta)
+		}
+	}
+
+	// All runs of a program share one meta-data file,
+	// so writing it again leaves the existing file alone.
+	if err := WriteMetaDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if metas, _ := readDir(t, dir); len(metas) != 1 {
+		t.Errorf("after second WriteMetaDir, got %d meta-data files, want 1", len(metas))
+	}
+}
+
+func TestWriteCountersDir(t *testing.T) {
+	files := testFiles()
+	setState(t, "count", files)
+	dir := t.TempDir()
+	if err := WriteCountersDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	metas, counters := readDir(t, dir)
+	if len(metas) != 0 || len(counters) != 1 {
+		t.Fatalf("got %d meta-data and %d counter files, want 0 and 1", len(metas), len(counters))
+	}
+	want := &coverage.CounterData{
+		MetaHash: coverage.HashMetaData(testMeta.Marshal()),
+		Counters: []uint32{3, 0, 1},
+	}
+	for name, c := range counters {
+		if !reflect.DeepEqual(c, want) {
+			t.Errorf("%s = %+v, want %+v", name, c, want)
+		}
+		if !strings.Contains(name, want.MetaHash.String()) {
+			t.Errorf("counter file name %s does not contain meta-data hash %v", name, want.MetaHash)
+		}
+	}
+}
+
+func TestClearCounters(t *testing.T) {
+	for _, mode := range []string{"set", "count", "atomic"} {
+		t.Run(mode, func(t *testing.T) {
+			files := testFiles()
+			setState(t, mode, files)
+			if err := ClearCounters(); err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range files {
+				for i, v := range f.counter {
+					if v != 0 {
+						t.Errorf("%s: counter %d = %d after ClearCounters, want 0", f.name, i, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNotBuiltWithCover(t *testing.T) {
+	setState(t, "", nil)
+	dir := t.TempDir()
+	if err := WriteMetaDir(dir); err != errNoCover {
+		t.Errorf("WriteMetaDir = %v, want %v", err, errNoCover)
+	}
+	if err := WriteCountersDir(dir); err != errNoCover {
+		t.Errorf("WriteCountersDir = %v, want %v", err, errNoCover)
+	}
+	if err := ClearCounters(); err != errNoCover {
+		t.Errorf("ClearCounters = %v, want %v", err, errNoCover)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("found %d files in %s, want none", len(entries), dir)
+	}
+}
+
+func TestEmitOnExit(t *testing.T) {
+	setState(t, "count", testFiles())
+	dir := t.TempDir()
+	t.Setenv("GOCOVERDIR", dir)
+	emitOnExit()
+	metas, counters := readDir(t, dir)
+	if len(metas) != 1 || len(counters) != 1 {
+		t.Fatalf("got %d meta-data and %d counter files, want 1 and 1", len(metas), len(counters))
+	}
+	for _, c := range counters {
+		if name := coverage.MetaFileName(c.MetaHash); metas[name] == nil {
+			t.Errorf("counter file refers to missing meta-data file %s", name)
+		}
+	}
+}
+
+func TestRegisterFileErrors(t *testing.T) {
+	setState(t, "count", testFiles())
+	for _, tt := range []struct {
+		name string
+		mode string
+		f    file
+		want string
+	}{
+		{"sizes", "count", file{"x.go", []uint32{0}, []uint32{1, 1}, []uint16{1}}, "coverage: mismatched sizes"},
+		{"mode", "set", file{"x.go", []uint32{0}, []uint32{1, 1, 1}, []uint16{1}}, "coverage: inconsistent coverage modes count and set"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("RegisterFile panicked with %v, want %q", r, tt.want)
+				}
+			}()
+			RegisterFile(tt.mode, tt.f.name, tt.f.counter, tt.f.pos, tt.f.numStmts)
+		})
+	}
+}
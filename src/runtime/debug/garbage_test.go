@@ -6,6 +6,7 @@ package debug_test
 
 import (
 	"internal/testenv"
+	"math"
 	"runtime"
 	. "runtime/debug"
 	"testing"
@@ -172,6 +173,24 @@ func TestSetGCPercent(t *testing.T) {
 	}
 }
 
+func TestSetMemoryLimit(t *testing.T) {
+	// Test that the limit is being set and returned correctly.
+	old := SetMemoryLimit(-1)
+	defer SetMemoryLimit(old)
+	if prev := SetMemoryLimit(1 << 40); prev != old {
+		t.Errorf("SetMemoryLimit(1<<40) = %d, want %d", prev, old)
+	}
+	if got := SetMemoryLimit(-1); got != 1<<40 {
+		t.Errorf("SetMemoryLimit(-1) = %d, want %d", got, int64(1<<40))
+	}
+	if got := SetMemoryLimit(math.MaxInt64); got != 1<<40 {
+		t.Errorf("SetMemoryLimit(math.MaxInt64) = %d, want %d", got, int64(1<<40))
+	}
+	if got := SetMemoryLimit(-1); got != math.MaxInt64 {
+		t.Errorf("SetMemoryLimit(-1) = %d, want math.MaxInt64", got)
+	}
+}
+
 func abs64(a int64) int64 {
 	if a < 0 {
 		return -a
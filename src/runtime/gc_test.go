@@ -31,6 +31,17 @@ func TestGcSys(t *testing.T) {
 	}
 }
 
+func TestGCMemoryLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	got := runTestProg(t, "testprog", "GCMemoryLimit", "GOGC=off", "GOMEMLIMIT=64MiB")
+	want := "OK\n"
+	if got != want {
+		t.Fatalf("expected %q, but got %q", want, got)
+	}
+}
+
 func TestGcDeepNesting(t *testing.T) {
 	type T [2][2][2][2][2][2][2][2][2][2]*int
 	a := new(T)
@@ -5,8 +5,8 @@
 // Hashing algorithm inspired by
 // wyhash: https://github.com/wangyi-fudan/wyhash
 
-//go:build amd64 || arm64 || mips64 || mips64le || ppc64 || ppc64le || riscv64 || s390x || wasm
-// +build amd64 arm64 mips64 mips64le ppc64 ppc64le riscv64 s390x wasm
+//go:build amd64 || arm64 || loong64 || mips64 || mips64le || ppc64 || ppc64le || riscv64 || s390x || wasm
+// +build amd64 arm64 loong64 mips64 mips64le ppc64 ppc64le riscv64 s390x wasm
 
 package runtime
 
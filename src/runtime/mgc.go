@@ -158,7 +158,8 @@ func gcinit() {
 
 	// Initialize GC pacer state.
 	// Use the environment variable GOGC for the initial gcPercent value.
-	gcController.init(readGOGC())
+	// Use the environment variable GOMEMLIMIT for the initial memoryLimit value.
+	gcController.init(readGOGC(), readGOMEMLIMIT())
 
 	work.startSema = 1
 	work.markDoneSema = 1
@@ -723,6 +724,7 @@ func gcStart(trigger gcTrigger) {
 		work.pauseNS += now - work.pauseStart
 		work.tMark = now
 		memstats.gcPauseDist.record(now - work.pauseStart)
+		gcCPULimiter.addGCTime((now - work.pauseStart) * int64(gomaxprocs))
 	})
 
 	// Release the world sema before Gosched() in STW mode
@@ -871,6 +873,7 @@ top:
 			now := startTheWorldWithSema(true)
 			work.pauseNS += now - work.pauseStart
 			memstats.gcPauseDist.record(now - work.pauseStart)
+			gcCPULimiter.addGCTime((now - work.pauseStart) * int64(gomaxprocs))
 		})
 		semrelease(&worldsema)
 		goto top
@@ -984,6 +987,7 @@ func gcMarkTermination(nextTriggerRatio float64) {
 	work.pauseNS += now - work.pauseStart
 	work.tEnd = now
 	memstats.gcPauseDist.record(now - work.pauseStart)
+	gcCPULimiter.addGCTime((now - work.pauseStart) * int64(gomaxprocs))
 	atomic.Store64(&memstats.last_gc_unix, uint64(unixNow)) // must be Unix time to make sense to user
 	atomic.Store64(&memstats.last_gc_nanotime, uint64(now)) // monotonic time for us
 	memstats.pause_ns[memstats.numgc%uint32(len(memstats.pause_ns))] = uint64(work.pauseNS)
@@ -1295,9 +1299,11 @@ func gcBgMarkWorker() {
 		switch pp.gcMarkWorkerMode {
 		case gcMarkWorkerDedicatedMode:
 			atomic.Xaddint64(&gcController.dedicatedMarkTime, duration)
+			gcCPULimiter.addGCTime(duration)
 			atomic.Xaddint64(&gcController.dedicatedMarkWorkersNeeded, 1)
 		case gcMarkWorkerFractionalMode:
 			atomic.Xaddint64(&gcController.fractionalMarkTime, duration)
+			gcCPULimiter.addGCTime(duration)
 			atomic.Xaddint64(&pp.gcFractionalMarkTime, duration)
 		case gcMarkWorkerIdleMode:
 			atomic.Xaddint64(&gcController.idleMarkTime, duration)
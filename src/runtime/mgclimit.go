@@ -0,0 +1,132 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "runtime/internal/atomic"
+
+// gcCPULimiter is a mechanism to limit GC CPU utilization in situations
+// where it might become excessive and inhibit application progress (e.g.
+// a death spiral).
+//
+// The core of the limiter is a leaky bucket mechanism that fills with GC
+// CPU time and drains with mutator time. Because the bucket fills and
+// drains with time directly (i.e. without any weighting), this effectively
+// sets a very conservative limit of 50%. This limit could be enforced directly,
+// however, but the purpose of the bucket is to accommodate spikes in GC CPU
+// utilization without hurting throughput.
+//
+// Note that the bucket in the leaky bucket mechanism can never go negative,
+// so the GC never gets credit for a lot of CPU time spent without the GC
+// running. This is intentional, as an application that stays idle for, say,
+// an entire day, could build up enough credit to fail to keep memory usage
+// under control if the GC suddenly needs a lot of CPU time.
+//
+// The limiter is only active when a memory limit is set, since that's the
+// only situation in which the GC may be forced to run continuously.
+var gcCPULimiter gcCPULimiterState
+
+type gcCPULimiterState struct {
+	// gcTime is the total GC CPU time in nanoseconds since the program
+	// started, including mark assists, dedicated and fractional mark
+	// workers, and stop-the-world pauses (which count for all Ps).
+	// Accessed atomically.
+	gcTime int64
+
+	// fill is the current level of the bucket in CPU-nanoseconds.
+	// Accessed only while lock is held.
+	fill uint64
+
+	// lastUpdate is the nanotime timestamp of the last update.
+	// Accessed only while lock is held.
+	lastUpdate int64
+
+	// lastGCTime is the value of gcTime at the last update.
+	// Accessed only while lock is held.
+	lastGCTime int64
+
+	// lock is a simple try-lock on the limiter's state. The limiter
+	// is only ever updated opportunistically, so if the lock is
+	// already held there's no need to wait for it.
+	lock uint32
+
+	// enabled is non-zero if the limiter is currently limiting
+	// the GC's CPU use. Accessed atomically.
+	enabled uint32
+
+	// lastEnabledCycle is the GC cycle that last had the limiter
+	// enabled, plus one. Accessed atomically.
+	lastEnabledCycle uint32
+}
+
+// capacityPerProc is the limiter's bucket capacity for each P in GOMAXPROCS.
+const capacityPerProc = 1e9 // 1 second in nanoseconds
+
+// limiting returns true if the CPU limiter is currently enabled, meaning
+// the GC should not be given any more CPU time than it already has.
+//
+// This method is nosplit because it's called from the allocation path.
+//
+//go:nosplit
+func (l *gcCPULimiterState) limiting() bool {
+	return atomic.Load(&l.enabled) != 0
+}
+
+// addGCTime records d nanoseconds of GC CPU time.
+func (l *gcCPULimiterState) addGCTime(d int64) {
+	atomic.Xaddint64(&l.gcTime, d)
+}
+
+// update refills and drains the bucket given the current time and the
+// GC CPU time accumulated since the last update, then decides whether
+// the limiter should be enabled. If the limiter is already being updated
+// elsewhere, update does nothing.
+//
+// now must be a nanotime timestamp.
+func (l *gcCPULimiterState) update(now int64) {
+	if !atomic.Cas(&l.lock, 0, 1) {
+		return
+	}
+	gcTime := atomic.Loadint64(&l.gcTime)
+	gcDelta := gcTime - l.lastGCTime
+	l.lastGCTime = gcTime
+	var windowTime int64
+	if l.lastUpdate != 0 && now > l.lastUpdate {
+		windowTime = now - l.lastUpdate
+	}
+	l.lastUpdate = now
+
+	if atomic.Loadint64(&gcController.memoryLimit) == maxInt64 {
+		// Without a memory limit the GC pacer already bounds
+		// GC CPU use, so the limiter never has to kick in.
+		l.fill = 0
+		atomic.Store(&l.enabled, 0)
+		atomic.Store(&l.lock, 0)
+		return
+	}
+
+	procs := int64(gomaxprocs)
+	capacity := uint64(procs) * capacityPerProc
+	mutatorTime := windowTime*procs - gcDelta
+	if mutatorTime < 0 {
+		mutatorTime = 0
+	}
+
+	// Fill with GC time and drain with mutator time, clamping
+	// the bucket to [0, capacity].
+	l.fill += uint64(gcDelta)
+	if uint64(mutatorTime) >= l.fill {
+		l.fill = 0
+	} else {
+		l.fill -= uint64(mutatorTime)
+	}
+	if l.fill >= capacity {
+		l.fill = capacity
+		atomic.Store(&l.enabled, 1)
+		atomic.Store(&l.lastEnabledCycle, atomic.Load(&work.cycles)+1)
+	} else {
+		atomic.Store(&l.enabled, 0)
+	}
+	atomic.Store(&l.lock, 0)
+}
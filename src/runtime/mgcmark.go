@@ -393,6 +393,14 @@ func gcAssistAlloc(gp *g) {
 		return
 	}
 
+	// Don't assist if the GC CPU limiter is on. This is the death
+	// spiral guard: when near the memory limit the GC might otherwise
+	// consume all available CPU time. Letting the heap grow past its
+	// goal is preferable to the application making no progress.
+	if gcCPULimiter.limiting() {
+		return
+	}
+
 	traced := false
 retry:
 	// Compute the amount of scan work we need to do to make the
@@ -568,6 +576,7 @@ func gcAssistAlloc1(gp *g, scanWork int64) {
 	_p_.gcAssistTime += duration
 	if _p_.gcAssistTime > gcAssistTimeSlack {
 		atomic.Xaddint64(&gcController.assistTime, _p_.gcAssistTime)
+		gcCPULimiter.addGCTime(_p_.gcAssistTime)
 		_p_.gcAssistTime = 0
 	}
 }
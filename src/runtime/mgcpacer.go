@@ -47,6 +47,19 @@ const (
 
 	// defaultHeapMinimum is the value of heapMinimum for GOGC==100.
 	defaultHeapMinimum = 4 << 20
+
+	// memoryLimitHeadroomPercent is the percentage of the memory limit
+	// that the heap goal leaves unused as headroom, to absorb
+	// fragmentation and memory the pacer can't see coming (e.g. new
+	// goroutine stacks) before the next GC cycle adjusts.
+	memoryLimitHeadroomPercent = 3
+
+	// memoryLimitTriggerFraction is the fraction of the runway between
+	// the marked heap and a memory-limit-based heap goal at which the
+	// next GC is triggered. It is deliberately conservative since
+	// overshooting the memory limit is more costly than a few extra
+	// GC cycles.
+	memoryLimitTriggerFraction = 0.7
 )
 
 func init() {
@@ -77,6 +90,24 @@ type gcControllerState struct {
 
 	_ uint32 // padding so following 64-bit values are 8-byte aligned
 
+	// memoryLimit is the soft memory limit in bytes.
+	//
+	// Initialized from GOMEMLIMIT. GOMEMLIMIT=off is equivalent to
+	// maxInt64 which means in practice this limit is impossible to hit.
+	//
+	// Read atomically; written with the world stopped or with
+	// mheap_.lock held.
+	memoryLimit int64
+
+	// memoryLimitGoal is the heap goal implied by memoryLimit as of
+	// the last call to commit, or ^uint64(0) if there is no limit.
+	// The heap goal never exceeds it, except for the minimum runway
+	// that startCycle enforces.
+	//
+	// Read atomically; written with the world stopped or with
+	// mheap_.lock held.
+	memoryLimitGoal uint64
+
 	// heapMinimum is the minimum heap size at which to trigger GC.
 	// For small heaps, this overrides the usual GOGC*live set rule.
 	//
@@ -256,8 +287,9 @@ type gcControllerState struct {
 	_ cpu.CacheLinePad
 }
 
-func (c *gcControllerState) init(gcPercent int32) {
+func (c *gcControllerState) init(gcPercent int32, memoryLimit int64) {
 	c.heapMinimum = defaultHeapMinimum
+	c.memoryLimit = memoryLimit
 
 	// Set a reasonable initial GC trigger.
 	c.triggerRatio = 7 / 8.0
@@ -395,7 +427,17 @@ func (c *gcControllerState) revise() {
 		// work than we expected. Pace GC so that in the worst case it
 		// will complete by the hard goal.
 		const maxOvershoot = 1.1
-		heapGoal = int64(float64(heapGoal) * maxOvershoot)
+		hardGoal := int64(float64(heapGoal) * maxOvershoot)
+
+		// The memory limit is already a hard limit of sorts, so
+		// don't let the hard goal overshoot it.
+		if limitGoal := atomic.Load64(&c.memoryLimitGoal); uint64(hardGoal) > limitGoal {
+			hardGoal = int64(limitGoal)
+			if hardGoal < heapGoal {
+				hardGoal = heapGoal
+			}
+		}
+		heapGoal = hardGoal
 
 		// Compute the upper bound on the scan work remaining.
 		scanWorkExpected = int64(scan)
@@ -658,6 +700,12 @@ func (c *gcControllerState) commit(triggerRatio float64) {
 		goal = c.heapMarked + c.heapMarked*uint64(c.gcPercent)/100
 	}
 
+	// Compute the goal implied by the memory limit. It's applied
+	// after the GOGC-based trigger below, since that may push the goal
+	// up, but the memory limit always wins.
+	memoryLimitGoal := c.memoryLimitHeapGoal()
+	atomic.Store64(&c.memoryLimitGoal, memoryLimitGoal)
+
 	// Set the trigger ratio, capped to reasonable bounds.
 	if c.gcPercent >= 0 {
 		scalingFactor := float64(c.gcPercent) / 100
@@ -730,6 +778,23 @@ func (c *gcControllerState) commit(triggerRatio float64) {
 		}
 	}
 
+	// Apply the memory limit. This works whether or not GOGC is
+	// off: the limit caps the goal, and the trigger is placed far
+	// enough below it to give the mark phase some runway. If the
+	// limit leaves no room over the marked heap at all, trigger
+	// immediately; the GC CPU limiter keeps that from turning into
+	// a death spiral.
+	if memoryLimitGoal < goal {
+		goal = memoryLimitGoal
+		limitTrigger := goal
+		if goal > c.heapMarked {
+			limitTrigger = c.heapMarked + uint64(float64(goal-c.heapMarked)*memoryLimitTriggerFraction)
+		}
+		if limitTrigger < trigger {
+			trigger = limitTrigger
+		}
+	}
+
 	// Commit to the trigger and goal.
 	c.trigger = trigger
 	atomic.Store64(&c.heapGoal, goal)
@@ -799,6 +864,27 @@ func (c *gcControllerState) effectiveGrowthRatio() float64 {
 	return egogc
 }
 
+// memoryLimitHeapGoal returns the heap goal implied by the memory
+// limit, or ^uint64(0) if there's no limit.
+//
+// The memory limit applies to all memory mapped by the runtime, so
+// everything that isn't the heap is treated as overhead and
+// subtracted from it, along with some headroom.
+func (c *gcControllerState) memoryLimitHeapGoal() uint64 {
+	limit := atomic.Loadint64(&c.memoryLimit)
+	if limit == maxInt64 {
+		return ^uint64(0)
+	}
+	nonHeap := memstats.mappedReady() - heapRetained()
+	headroom := uint64(limit) / 100 * memoryLimitHeadroomPercent
+	if uint64(limit) <= nonHeap+headroom {
+		// The limit has already been hit by memory that isn't the
+		// heap. Aim for as small a heap as possible.
+		return 0
+	}
+	return uint64(limit) - nonHeap - headroom
+}
+
 // setGCPercent updates gcPercent and all related pacer state.
 // Returns the old value of gcPercent.
 //
@@ -836,6 +922,45 @@ func setGCPercent(in int32) (out int32) {
 	return out
 }
 
+// setMemoryLimit updates memoryLimit and all related pacer state.
+// Returns the old value of memoryLimit.
+//
+// The world must be stopped, or mheap_.lock must be held.
+func (c *gcControllerState) setMemoryLimit(in int64) int64 {
+	assertWorldStoppedOrLockHeld(&mheap_.lock)
+
+	out := c.memoryLimit
+	if in >= 0 {
+		atomic.Storeint64(&c.memoryLimit, in)
+	}
+	// Update pacing in response to memoryLimit change. This also
+	// updates the scavenger's goal, via gcPaceScavenger.
+	c.commit(c.triggerRatio)
+	return out
+}
+
+//go:linkname setMemoryLimit runtime/debug.setMemoryLimit
+func setMemoryLimit(in int64) (out int64) {
+	// Run on the system stack since we grab the heap lock.
+	systemstack(func() {
+		lock(&mheap_.lock)
+		out = gcController.setMemoryLimit(in)
+		unlock(&mheap_.lock)
+	})
+	if in >= 0 {
+		// A lower limit may give the scavenger work to do right away.
+		// Don't leave it parked until the next GC cycle.
+		wakeScavenger()
+
+		// The new limit may already be exceeded; start a GC if the new
+		// trigger says so.
+		if t := (gcTrigger{kind: gcTriggerHeap}); t.test() {
+			gcStart(t)
+		}
+	}
+	return out
+}
+
 func readGOGC() int32 {
 	p := gogetenv("GOGC")
 	if p == "off" {
@@ -846,3 +971,18 @@ func readGOGC() int32 {
 	}
 	return 100
 }
+
+// readGOMEMLIMIT reads the initial memory limit from the GOMEMLIMIT
+// environment variable.
+func readGOMEMLIMIT() int64 {
+	p := gogetenv("GOMEMLIMIT")
+	if p == "" || p == "off" {
+		return maxInt64
+	}
+	n, ok := parseByteCount(p)
+	if !ok {
+		print("GOMEMLIMIT=", p, "\n")
+		throw("malformed GOMEMLIMIT; see `go doc runtime/debug.SetMemoryLimit`")
+	}
+	return n
+}
@@ -52,6 +52,11 @@
 // the application had to grow the heap because existing fragments were
 // not sufficiently large to satisfy a page-level memory allocation, so we
 // scavenge those fragments eagerly to offset the growth in RSS that results.
+//
+// If a memory limit is set (see debug.SetMemoryLimit), the scavenger has a
+// second goal: keeping the total memory mapped by the runtime a little below
+// that limit (see memoryLimitScavengeGoal). Whichever goal is lower wins, both
+// for background and synchronous scavenging.
 
 package runtime
 
@@ -96,6 +101,12 @@ const (
 	// should reserve for scavenging at a time. Specifically, the amount of
 	// memory reserved is (heap size in bytes) / scavengeReservationShards.
 	scavengeReservationShards = 64
+
+	// memoryLimitScavengePercent is the percentage of the memory limit
+	// the scavenger aims to stay under, leaving the rest as a buffer so
+	// that the runtime doesn't end up scavenging synchronously all the
+	// time when it's close to the limit.
+	memoryLimitScavengePercent = 95
 )
 
 // heapRetained returns an estimate of the current heap RSS.
@@ -103,33 +114,43 @@ func heapRetained() uint64 {
 	return memstats.heap_sys.load() - atomic.Load64(&memstats.heap_released)
 }
 
+// memoryLimitScavengeGoal returns the retained heap size the scavenger
+// should aim for to keep the total memory mapped by the runtime under
+// memoryLimitScavengePercent of the memory limit, or ^uint64(0) if
+// there is no memory limit.
+func memoryLimitScavengeGoal() uint64 {
+	limit := atomic.Loadint64(&gcController.memoryLimit)
+	if limit == maxInt64 {
+		return ^uint64(0)
+	}
+	target := uint64(limit) / 100 * memoryLimitScavengePercent
+	nonHeap := memstats.mappedReady() - heapRetained()
+	if target <= nonHeap {
+		return 0
+	}
+	return target - nonHeap
+}
+
 // gcPaceScavenger updates the scavenger's pacing, particularly
 // its rate and RSS goal.
 //
 // The RSS goal is based on the current heap goal with a small overhead
-// to accommodate non-determinism in the allocator.
+// to accommodate non-determinism in the allocator, or the memory limit,
+// whichever is lower.
 //
 // The pacing is based on scavengePageRate, which applies to both regular and
 // huge pages. See that constant for more information.
 //
 // mheap_.lock must be held or the world must be stopped.
 func gcPaceScavenger() {
-	// If we're called before the first GC completed, disable scavenging.
-	// We never scavenge before the 2nd GC cycle anyway (we don't have enough
-	// information about the heap yet) so this is fine, and avoids a fault
-	// or garbage data later.
-	if gcController.lastHeapGoal == 0 {
+	retainedGoal := gcPercentScavengeGoal()
+	if limitGoal := memoryLimitScavengeGoal(); limitGoal < retainedGoal {
+		retainedGoal = limitGoal
+	}
+	if retainedGoal == ^uint64(0) {
 		mheap_.scavengeGoal = ^uint64(0)
 		return
 	}
-	// Compute our scavenging goal.
-	goalRatio := float64(atomic.Load64(&gcController.heapGoal)) / float64(gcController.lastHeapGoal)
-	retainedGoal := uint64(float64(memstats.last_heap_inuse) * goalRatio)
-	// Add retainExtraPercent overhead to retainedGoal. This calculation
-	// looks strange but the purpose is to arrive at an integer division
-	// (e.g. if retainExtraPercent = 12.5, then we get a divisor of 8)
-	// that also avoids the overflow from a multiplication.
-	retainedGoal += retainedGoal / (1.0 / (retainExtraPercent / 100.0))
 	// Align it to a physical page boundary to make the following calculations
 	// a bit more exact.
 	retainedGoal = (retainedGoal + uint64(physPageSize) - 1) &^ (uint64(physPageSize) - 1)
@@ -157,6 +178,30 @@ func gcPaceScavenger() {
 	mheap_.scavengeGoal = retainedGoal
 }
 
+// gcPercentScavengeGoal returns the retained heap size the scavenger
+// should aim for based on the heap goal, or ^uint64(0) if there isn't
+// enough information to compute one yet.
+//
+// mheap_.lock must be held or the world must be stopped.
+func gcPercentScavengeGoal() uint64 {
+	// If we're called before the first GC completed, disable scavenging.
+	// We never scavenge before the 2nd GC cycle anyway (we don't have enough
+	// information about the heap yet) so this is fine, and avoids a fault
+	// or garbage data later.
+	if gcController.lastHeapGoal == 0 {
+		return ^uint64(0)
+	}
+	// Compute our scavenging goal.
+	goalRatio := float64(atomic.Load64(&gcController.heapGoal)) / float64(gcController.lastHeapGoal)
+	retainedGoal := uint64(float64(memstats.last_heap_inuse) * goalRatio)
+	// Add retainExtraPercent overhead to retainedGoal. This calculation
+	// looks strange but the purpose is to arrive at an integer division
+	// (e.g. if retainExtraPercent = 12.5, then we get a divisor of 8)
+	// that also avoids the overflow from a multiplication.
+	retainedGoal += retainedGoal / (1.0 / (retainExtraPercent / 100.0))
+	return retainedGoal
+}
+
 // Sleep/wait state of the background scavenger.
 var scavenge struct {
 	lock       mutex
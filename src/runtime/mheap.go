@@ -1275,6 +1275,7 @@ HaveSpan:
 	if typ.manual() {
 		// Manually managed memory doesn't count toward heap_sys.
 		memstats.heap_sys.add(-int64(nbytes))
+		atomic.Xadd64(&memstats.manual_inuse, int64(nbytes))
 	}
 	// Update consistent stats.
 	stats := memstats.heapStats.acquire()
@@ -1412,9 +1413,17 @@ func (h *mheap) grow(npage uintptr) bool {
 	// By scavenging inline we deal with the failure to allocate out of
 	// memory fragments by scavenging the memory fragments that are least
 	// likely to be re-used.
-	if retained := heapRetained(); retained+uint64(totalGrowth) > h.scavengeGoal {
+	//
+	// The background scavenger's goal is only updated once per GC cycle, so
+	// consult the memory limit directly too: heap growth is exactly when we
+	// might be about to exceed it.
+	goal := h.scavengeGoal
+	if limitGoal := memoryLimitScavengeGoal(); limitGoal < goal {
+		goal = limitGoal
+	}
+	if retained := heapRetained(); retained+uint64(totalGrowth) > goal {
 		todo := totalGrowth
-		if overage := uintptr(retained + uint64(totalGrowth) - h.scavengeGoal); todo > overage {
+		if overage := uintptr(retained + uint64(totalGrowth) - goal); todo > overage {
 			todo = overage
 		}
 		h.pages.scavenge(todo, false)
@@ -1487,6 +1496,7 @@ func (h *mheap) freeSpanLocked(s *mspan, typ spanAllocType) {
 	if typ.manual() {
 		// Manually managed memory doesn't count toward heap_sys, so add it back.
 		memstats.heap_sys.add(int64(nbytes))
+		atomic.Xadd64(&memstats.manual_inuse, -int64(nbytes))
 	}
 	// Update consistent stats.
 	stats := memstats.heapStats.acquire()
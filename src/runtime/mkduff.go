@@ -40,6 +40,7 @@ func main() {
 	gen("ppc64x", tagsPPC64x, zeroPPC64x, copyPPC64x)
 	gen("mips64x", tagsMIPS64x, zeroMIPS64x, copyMIPS64x)
 	gen("riscv64", notags, zeroRISCV64, copyRISCV64)
+	gen("loong64", notags, zeroLOONG64, copyLOONG64)
 }
 
 func gen(arch string, tags, zero, copy func(io.Writer)) {
@@ -232,6 +233,33 @@ func copyMIPS64x(w io.Writer) {
 	fmt.Fprintln(w, "\tRET")
 }
 
+func zeroLOONG64(w io.Writer) {
+	// R0: always zero
+	// R20 (aka REGRT1): ptr to memory to be zeroed - 8
+	// On return, R20 points to the last zeroed dword.
+	fmt.Fprintln(w, "TEXT runtime·duffzero(SB), NOSPLIT|NOFRAME, $0-0")
+	for i := 0; i < 128; i++ {
+		fmt.Fprintln(w, "\tMOVV\tR0, 8(R20)")
+		fmt.Fprintln(w, "\tADDV\t$8, R20")
+	}
+	fmt.Fprintln(w, "\tRET")
+}
+
+func copyLOONG64(w io.Writer) {
+	// R20 (aka REGRT1): ptr to source memory
+	// R21 (aka REGRT2): ptr to destination memory
+	// R20 and R21 are updated as a side effect
+	fmt.Fprintln(w, "TEXT runtime·duffcopy(SB), NOSPLIT|NOFRAME, $0-0")
+	for i := 0; i < 128; i++ {
+		fmt.Fprintln(w, "\tMOVV\t(R20), R30")
+		fmt.Fprintln(w, "\tADDV\t$8, R20")
+		fmt.Fprintln(w, "\tMOVV\tR30, (R21)")
+		fmt.Fprintln(w, "\tADDV\t$8, R21")
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "\tRET")
+}
+
 func zeroRISCV64(w io.Writer) {
 	// ZERO: always zero
 	// X10: ptr to memory to be zeroed
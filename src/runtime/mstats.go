@@ -37,6 +37,11 @@ type mstats struct {
 	heap_inuse    uint64     // bytes in mSpanInUse spans
 	heap_released uint64     // bytes released to the os
 
+	// manual_inuse is the number of bytes in manually-managed spans
+	// (stacks, work bufs, and GC program bits). It is carved out of
+	// heap_sys, and is updated atomically.
+	manual_inuse uint64
+
 	// heap_objects is not used by the runtime directly and instead
 	// computed on the fly by updatememstats.
 	heap_objects uint64 // total number of allocated objects
@@ -662,6 +667,18 @@ func flushallmcaches() {
 	}
 }
 
+// mappedReady returns an estimate of the total memory mapped by the
+// runtime and not released back to the OS. This is the quantity the
+// memory limit (see debug.SetMemoryLimit) applies to.
+//
+// It does not take any locks and may be called concurrently with
+// updates, so the result is approximate.
+func (s *mstats) mappedReady() uint64 {
+	return heapRetained() + atomic.Load64(&s.manual_inuse) +
+		s.stacks_sys.load() + s.mspan_sys.load() + s.mcache_sys.load() +
+		s.buckhash_sys.load() + s.gcMiscSys.load() + s.other_sys.load()
+}
+
 // sysMemStat represents a global system statistic that is managed atomically.
 //
 // This type must structurally be a uint64 so that mstats aligns with MemStats.
@@ -9,6 +9,7 @@ import (
 	"os"
 	"runtime"
 	"runtime/debug"
+	"runtime/metrics"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -21,6 +22,7 @@ func init() {
 	register("GCPhys", GCPhys)
 	register("DeferLiveness", DeferLiveness)
 	register("GCZombie", GCZombie)
+	register("GCMemoryLimit", GCMemoryLimit)
 }
 
 func GCSys() {
@@ -300,3 +302,82 @@ func GCZombie() {
 	runtime.KeepAlive(keep)
 	runtime.KeepAlive(zombies)
 }
+
+// GCMemoryLimit must be run with GOGC=off and GOMEMLIMIT=64MiB.
+func GCMemoryLimit() {
+	const (
+		limit      = 64 << 20
+		liveHeap   = 16 << 20
+		allocSize  = 64 << 10
+		sampleSize = 16 << 20 // bytes allocated between samples
+	)
+	if got := debug.SetMemoryLimit(-1); got != limit {
+		fmt.Printf("GOMEMLIMIT not applied: memory limit is %d, want %d\n", got, limit)
+		return
+	}
+	if got := debug.SetGCPercent(-1); got != -1 {
+		fmt.Printf("GOGC not off: GC percent is %d\n", got)
+		return
+	}
+	// The GC CPU limiter measures GC time against GOMAXPROCS worth of
+	// CPU time, so make sure the program actually gets that much.
+	runtime.GOMAXPROCS(1)
+	limiterCycle := []metrics.Sample{{Name: "/gc/limiter/last-enabled:gc-cycle"}}
+
+	// Keep some of the heap live and churn through many times the
+	// memory limit in garbage. With GOGC=off, only the memory limit
+	// makes the GC run, and it must keep the heap under the limit.
+	live := make([][]byte, liveHeap/allocSize)
+	for i := range live {
+		live[i] = make([]byte, allocSize)
+	}
+	var stats runtime.MemStats
+	var peak uint64
+	for i := 0; i < 16*limit/allocSize; i++ {
+		sink = make([]byte, allocSize)
+		if i%(sampleSize/allocSize) == 0 {
+			runtime.ReadMemStats(&stats)
+			if stats.HeapAlloc > peak {
+				peak = stats.HeapAlloc
+			}
+		}
+	}
+	runtime.ReadMemStats(&stats)
+	if stats.NumGC == 0 {
+		fmt.Println("no GC ran under the memory limit")
+		return
+	}
+	// Leave 10% of the limit for pacing overshoot.
+	if peak > limit*11/10 {
+		fmt.Printf("heap reached %d bytes with a memory limit of %d\n", peak, limit)
+		return
+	}
+	// The limit is comfortably above the live heap, so the GC never
+	// needs more than its usual share of CPU.
+	metrics.Read(limiterCycle)
+	if c := limiterCycle[0].Value.Uint64(); c != 0 {
+		fmt.Printf("GC CPU limiter enabled in cycle %d with the heap under the limit\n", c-1)
+		return
+	}
+
+	// Drop the limit below the live heap. The GC cannot meet it, and
+	// would run back to back and starve the program without the GC
+	// CPU limiter, which must kick in.
+	debug.SetMemoryLimit(liveHeap / 2)
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		for i := 0; i < sampleSize/allocSize; i++ {
+			sink = make([]byte, allocSize)
+		}
+		metrics.Read(limiterCycle)
+		if limiterCycle[0].Value.Uint64() != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			fmt.Println("GC CPU limiter did not enable with the live heap over the memory limit")
+			return
+		}
+	}
+	runtime.KeepAlive(live)
+	fmt.Println("OK")
+}
@@ -28,6 +28,10 @@ import (
 
 var fset = token.NewFileSet()
 
+// funcs records the names of the funcs being rewritten,
+// so that calls to other helpers are left alone.
+var funcs = map[string]bool{}
+
 func main() {
 	af, err := parser.ParseFile(fset, "sort.go", nil, 0)
 	if err != nil {
@@ -57,6 +61,7 @@ func main() {
 			continue
 		}
 		arg0Type.Name = "lessSwap"
+		funcs[fd.Name.Name] = true
 
 		newDecl = append(newDecl, fd)
 	}
@@ -116,11 +121,8 @@ func rewriteCall(ce *ast.CallExpr) {
 		// e.g. skip SelectorExpr (data.Less(..) calls)
 		return
 	}
-	// skip casts
-	if ident.Name == "int" || ident.Name == "uint" {
-		return
-	}
-	if len(ce.Args) < 1 {
+	// skip casts and calls to helpers that do not take data
+	if !funcs[ident.Name] {
 		return
 	}
 	ident.Name += "_func"
@@ -37,6 +37,10 @@
 // functions, are the atomic equivalents of "return *addr" and
 // "*addr = val".
 //
+// In addition to the functions, the package provides the types Bool, Int32,
+// Int64, Uint32, Uint64, and Uintptr, whose methods perform the same
+// operations on a value that cannot be accessed non-atomically by mistake.
+//
 package atomic
 
 import (
@@ -47,8 +51,9 @@ import (
 //
 // On non-Linux ARM, the 64-bit functions use instructions unavailable before the ARMv6k core.
 //
-// On ARM, 386, and 32-bit MIPS, it is the caller's responsibility
-// to arrange for 64-bit alignment of 64-bit words accessed atomically.
+// On ARM, 386, and 32-bit MIPS, it is the caller's responsibility to arrange
+// for 64-bit alignment of 64-bit words accessed atomically via the primitive
+// atomic functions (types Int64 and Uint64 are automatically aligned).
 // The first word in a variable or in an allocated struct, array, or slice can
 // be relied upon to be 64-bit aligned.
 
@@ -0,0 +1,144 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atomic_test
+
+import (
+	. "sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+func TestBoolMethods(t *testing.T) {
+	var x Bool
+	if x.Load() {
+		t.Fatalf("zero Bool is true")
+	}
+	x.Store(true)
+	if !x.Load() {
+		t.Fatalf("Load after Store(true) = false")
+	}
+	if old := x.Swap(false); !old || x.Load() {
+		t.Fatalf("Swap(false) = %v, Load() = %v; want true, false", old, x.Load())
+	}
+	if x.CompareAndSwap(true, true) {
+		t.Fatalf("CompareAndSwap(true, true) succeeded on false value")
+	}
+	if !x.CompareAndSwap(false, true) || !x.Load() {
+		t.Fatalf("CompareAndSwap(false, true) failed")
+	}
+}
+
+func TestInt32Methods(t *testing.T) {
+	var x struct {
+		before int32
+		i      Int32
+		after  int32
+	}
+	x.before = magic32
+	x.after = magic32
+	var j int32
+	for delta := int32(1); delta+delta > delta; delta += delta {
+		k := x.i.Add(delta)
+		j += delta
+		if x.i.Load() != j || k != j {
+			t.Fatalf("delta=%d i=%d j=%d k=%d", delta, x.i.Load(), j, k)
+		}
+		if old := x.i.Swap(delta); old != j {
+			t.Fatalf("Swap(%d) = %d, want %d", delta, old, j)
+		}
+		if x.i.CompareAndSwap(j, 0) && j != delta {
+			t.Fatalf("CompareAndSwap(%d, 0) succeeded on %d", j, delta)
+		}
+		x.i.Store(j)
+	}
+	if x.before != magic32 || x.after != magic32 {
+		t.Fatalf("wrong magic: %#x _ %#x != %#x _ %#x", x.before, x.after, magic32, magic32)
+	}
+}
+
+func TestUint32Methods(t *testing.T) {
+	var x Uint32
+	if x.Add(3) != 3 || x.Swap(7) != 3 || !x.CompareAndSwap(7, 9) || x.CompareAndSwap(7, 1) {
+		t.Fatalf("unexpected result; value %d", x.Load())
+	}
+	x.Store(1 << 31)
+	if x.Load() != 1<<31 {
+		t.Fatalf("Load() = %d, want %d", x.Load(), uint32(1<<31))
+	}
+}
+
+func TestUintptrMethods(t *testing.T) {
+	var x Uintptr
+	if x.Add(3) != 3 || x.Swap(7) != 3 || !x.CompareAndSwap(7, 9) || x.CompareAndSwap(7, 1) {
+		t.Fatalf("unexpected result; value %d", x.Load())
+	}
+	x.Store(^uintptr(0))
+	if x.Load() != ^uintptr(0) {
+		t.Fatalf("Load() = %#x, want %#x", x.Load(), ^uintptr(0))
+	}
+}
+
+func TestInt64Methods(t *testing.T) {
+	if test64err != nil {
+		t.Skipf("Skipping 64-bit tests: %v", test64err)
+	}
+	var x Int64
+	if x.Add(-3) != -3 || x.Swap(magic64) != -3 || !x.CompareAndSwap(magic64, 9) || x.CompareAndSwap(magic64, 1) {
+		t.Fatalf("unexpected result; value %d", x.Load())
+	}
+	x.Store(-1 << 62)
+	if x.Load() != -1<<62 {
+		t.Fatalf("Load() = %d, want %d", x.Load(), int64(-1<<62))
+	}
+}
+
+func TestUint64Methods(t *testing.T) {
+	if test64err != nil {
+		t.Skipf("Skipping 64-bit tests: %v", test64err)
+	}
+	var x Uint64
+	if x.Add(3) != 3 || x.Swap(magic64) != 3 || !x.CompareAndSwap(magic64, 9) || x.CompareAndSwap(magic64, 1) {
+		t.Fatalf("unexpected result; value %d", x.Load())
+	}
+	x.Store(1 << 63)
+	if x.Load() != 1<<63 {
+		t.Fatalf("Load() = %#x, want %#x", x.Load(), uint64(1<<63))
+	}
+}
+
+// The 64-bit types must be 64-bit aligned even on 32-bit systems,
+// regardless of where they appear in a struct.
+func TestAlign64(t *testing.T) {
+	var x struct {
+		a int32
+		b Int64
+		c int32
+		d Uint64
+	}
+	if off := unsafe.Offsetof(x.b); off%8 != 0 {
+		t.Errorf("Offsetof(Int64 field) = %d, not 64-bit aligned", off)
+	}
+	if off := unsafe.Offsetof(x.d); off%8 != 0 {
+		t.Errorf("Offsetof(Uint64 field) = %d, not 64-bit aligned", off)
+	}
+	if a := unsafe.Alignof(x.b); a != 8 {
+		t.Errorf("Alignof(Int64) = %d, want 8", a)
+	}
+	if a := unsafe.Alignof(x.d); a != 8 {
+		t.Errorf("Alignof(Uint64) = %d, want 8", a)
+	}
+
+	// Each element of a slice or array must also be aligned.
+	s := make([]struct {
+		a int32
+		b Uint64
+	}, 3)
+	for i := range s {
+		if p := uintptr(unsafe.Pointer(&s[i].b)); p%8 != 0 {
+			t.Errorf("&s[%d].b = %#x, not 64-bit aligned", i, p)
+		}
+		s[i].b.Add(1)
+	}
+}